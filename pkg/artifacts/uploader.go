@@ -0,0 +1,239 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+const (
+	// DefaultRegion is used when no region is configured
+	DefaultRegion = "us-east-1"
+	// gcsEndpoint is the S3 compatible XML API of Google Cloud Storage
+	gcsEndpoint = "https://storage.googleapis.com"
+)
+
+// Config configures where artifacts are uploaded
+type Config struct {
+	// URL of the destination, s3://bucket/prefix or gs://bucket/prefix
+	URL string
+	// Endpoint of an S3 compatible service such as MinIO, AWS S3 when empty
+	Endpoint string
+	// Region of the bucket, DefaultRegion when empty
+	Region string
+	// Credentials used to sign requests
+	Credentials Credentials
+}
+
+// ConfigFromEnv returns a configuration for url with credentials, region and endpoint from the
+// standard AWS environment variables, which MinIO and GCS HMAC keys use as well
+func ConfigFromEnv(rawURL string) Config {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	return Config{
+		URL:      rawURL,
+		Endpoint: os.Getenv("AWS_ENDPOINT_URL_S3"),
+		Region:   region,
+		Credentials: Credentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+	}
+}
+
+// ErrNotFound is returned when an object doesn't exist
+var ErrNotFound = errors.New("object not found")
+
+// Uploader puts files into an S3 compatible bucket and reads them back
+type Uploader struct {
+	endpoint  *url.URL
+	pathStyle bool
+	bucket    string
+	prefix    string
+	region    string
+	creds     Credentials
+	client    *http.Client
+}
+
+// NewUploader creates an uploader for the destination in cfg
+func NewUploader(cfg Config) (*Uploader, error) {
+	dest, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifacts URL %s: %w", cfg.URL, err)
+	}
+	if dest.Host == "" {
+		return nil, fmt.Errorf("artifacts URL %s has no bucket", cfg.URL)
+	}
+	if cfg.Credentials.AccessKeyID == "" || cfg.Credentials.SecretAccessKey == "" {
+		return nil, fmt.Errorf("artifact upload requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	endpoint := cfg.Endpoint
+	switch dest.Scheme {
+	case "s3":
+	case "gs":
+		if endpoint == "" {
+			endpoint = gcsEndpoint
+		}
+		if cfg.Region == "" {
+			region = "auto"
+		}
+	default:
+		return nil, fmt.Errorf("unsupported artifacts URL scheme %q, expected s3:// or gs://", dest.Scheme)
+	}
+
+	u := &Uploader{
+		bucket: dest.Host,
+		prefix: strings.Trim(dest.Path, "/"),
+		region: region,
+		creds:  cfg.Credentials,
+		client: &http.Client{Timeout: 5 * time.Minute},
+	}
+
+	// Custom endpoints use path style addressing, which MinIO and GCS support
+	if endpoint != "" {
+		u.endpoint, err = url.Parse(endpoint)
+		if err != nil || u.endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid artifacts endpoint %s", endpoint)
+		}
+		u.pathStyle = true
+	} else {
+		u.endpoint = &url.URL{Scheme: "https", Host: fmt.Sprintf("%s.s3.%s.amazonaws.com", u.bucket, region)}
+	}
+
+	return u, nil
+}
+
+// Location returns the URL of a key below the uploader's prefix
+func (u *Uploader) Location(key string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(u.endpoint.String(), "/"), u.objectPath(key))
+}
+
+// objectPath returns the request path of a key below the uploader's prefix
+func (u *Uploader) objectPath(key string) string {
+	key = path.Join(u.prefix, key)
+	if u.pathStyle {
+		return path.Join(u.bucket, key)
+	}
+	return key
+}
+
+// objectURL returns the request URL of a key below the uploader's prefix
+func (u *Uploader) objectURL(key string) string {
+	target := *u.endpoint
+	target.Path = strings.TrimSuffix(u.endpoint.Path, "/") + "/" + u.objectPath(key)
+	return target.String()
+}
+
+// Get downloads key below the uploader's prefix, returning ErrNotFound if it doesn't exist
+func (u *Uploader) Get(ctx context.Context, key string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	signRequest(req, u.creds, u.region, time.Now())
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%s: %w", key, ErrNotFound)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to download %s: %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// Put uploads data to key below the uploader's prefix
+func (u *Uploader) Put(ctx context.Context, key string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Content-Sha256", hashHex(data))
+	signRequest(req, u.creds, u.region, time.Now())
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: %s: %s", key, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// UploadDir uploads every file in dir below keyPrefix, keeping their relative paths
+// It returns the number of files uploaded
+func (u *Uploader) UploadDir(ctx context.Context, dir, keyPrefix string) (int, error) {
+	log := util.GetLogger()
+
+	count := 0
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Symlinks such as cloned dependencies are skipped
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		key := path.Join(keyPrefix, filepath.ToSlash(rel))
+		log.V(1).Info("Uploading artifact", "file", file, "key", key)
+		if err := u.Put(ctx, key, data); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
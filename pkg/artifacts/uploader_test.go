@@ -0,0 +1,157 @@
+package artifacts
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var testCredentials = Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"}
+
+func TestNewUploader(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          Config
+		wantLocation string
+		wantErr      string
+	}{
+		{
+			name:         "aws s3",
+			cfg:          Config{URL: "s3://nightly/koncur", Region: "eu-west-1", Credentials: testCredentials},
+			wantLocation: "https://nightly.s3.eu-west-1.amazonaws.com/koncur/run-1/run.json",
+		},
+		{
+			name:         "minio endpoint",
+			cfg:          Config{URL: "s3://nightly/", Endpoint: "http://localhost:9000", Credentials: testCredentials},
+			wantLocation: "http://localhost:9000/nightly/run-1/run.json",
+		},
+		{
+			name:         "google cloud storage",
+			cfg:          Config{URL: "gs://nightly/ci", Credentials: testCredentials},
+			wantLocation: "https://storage.googleapis.com/nightly/ci/run-1/run.json",
+		},
+		{name: "unknown scheme", cfg: Config{URL: "ftp://nightly", Credentials: testCredentials}, wantErr: "unsupported"},
+		{name: "missing bucket", cfg: Config{URL: "s3:///koncur", Credentials: testCredentials}, wantErr: "no bucket"},
+		{name: "missing credentials", cfg: Config{URL: "s3://nightly"}, wantErr: "AWS_ACCESS_KEY_ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploader, err := NewUploader(tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NewUploader() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewUploader() unexpected error: %v", err)
+			}
+			if got := uploader.Location("run-1/run.json"); got != tt.wantLocation {
+				t.Errorf("Location() = %s, want %s", got, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestUploader_UploadDir(t *testing.T) {
+	var mu sync.Mutex
+	uploaded := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hashHex(body) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		uploaded[r.URL.Path] = string(body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "output"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "output", "output.yaml"), []byte("- name: rules\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "analysis.log"), []byte("done\n"), 0644)
+	os.Symlink(filepath.Join(dir, "analysis.log"), filepath.Join(dir, "link.log"))
+
+	uploader, err := NewUploader(Config{URL: "s3://nightly/koncur", Endpoint: server.URL, Credentials: testCredentials})
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := uploader.UploadDir(context.Background(), dir, "run-1/test")
+	if err != nil {
+		t.Fatalf("UploadDir() unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("UploadDir() uploaded %d files, want 2", count)
+	}
+
+	expected := map[string]string{
+		"/nightly/koncur/run-1/test/output/output.yaml": "- name: rules\n",
+		"/nightly/koncur/run-1/test/analysis.log":       "done\n",
+	}
+	for key, content := range expected {
+		if uploaded[key] != content {
+			t.Errorf("uploaded[%s] = %q, want %q", key, uploaded[key], content)
+		}
+	}
+}
+
+func TestUploader_PutError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}))
+	defer server.Close()
+
+	uploader, err := NewUploader(Config{URL: "s3://nightly", Endpoint: server.URL, Credentials: testCredentials})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = uploader.Put(context.Background(), "run.json", []byte("{}"))
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put() error = %v, want AccessDenied", err)
+	}
+}
+
+func TestUploader_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/nightly/koncur/run-1/result.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"target":"kantra"}`))
+	}))
+	defer server.Close()
+
+	uploader, err := NewUploader(Config{URL: "s3://nightly/koncur", Endpoint: server.URL, Credentials: testCredentials})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := uploader.Get(context.Background(), "run-1/result.json")
+	if err != nil || string(data) != `{"target":"kantra"}` {
+		t.Errorf("Get() = %s, %v", data, err)
+	}
+	if _, err := uploader.Get(context.Background(), "run-2/result.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/konveyor/test-harness/pkg/artifacts"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// uploadArtifacts uploads the work directories of a run's tests and its summary to object storage
+// Keys are prefixed with the run ID, e.g. <prefix>/<run-id>/<work-dir>/output/output.yaml.
+// It returns the location of the uploaded run
+func uploadArtifacts(run *history.Run, artifactDirs map[string][]string) (string, error) {
+	log := util.GetLogger()
+
+	cfg := artifacts.ConfigFromEnv(runArtifactsURL)
+	if runArtifactsEndpoint != "" {
+		cfg.Endpoint = runArtifactsEndpoint
+	}
+	uploader, err := artifacts.NewUploader(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	id := runID
+	if id == "" {
+		id = run.Timestamp.Format("20060102-150405")
+	}
+
+	ctx := context.Background()
+	total := 0
+	for _, test := range slices.Sorted(maps.Keys(artifactDirs)) {
+		for _, dir := range artifactDirs[test] {
+			log.Info("Uploading artifacts", "test", test, "directory", dir)
+			count, err := uploader.UploadDir(ctx, dir, path.Join(id, filepath.Base(dir)))
+			if err != nil {
+				return "", fmt.Errorf("test %s: %w", test, err)
+			}
+			total += count
+		}
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal run: %w", err)
+	}
+	if err := uploader.Put(ctx, path.Join(id, "run.json"), data); err != nil {
+		return "", err
+	}
+
+	location := uploader.Location(id)
+	fmt.Printf("Uploaded %d artifact(s) to %s\n", total+1, location)
+	return location, nil
+}
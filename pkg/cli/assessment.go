@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// runAssessmentTest answers the questionnaire of an assessment test and validates the risk the target
+// computed. Targets that can't assess applications skip the test
+func runAssessmentTest(ctx context.Context, target targets.Target, test *config.TestDefinition) (*targets.ExecutionResult, []validator.ValidationError, error) {
+	assessor, ok := target.(targets.Assessor)
+	if !ok {
+		progress.Stop(ctx)
+		return nil, nil, &skipError{reasons: []string{fmt.Sprintf("assessment tests aren't supported by %s", target.Name())}}
+	}
+
+	result, err := assessor.Assess(ctx, test)
+	progress.Stop(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execution failed: %w", err)
+	}
+
+	failures := assessmentFailures(test.Expect.Assessment, result.Assessment)
+	if len(failures) == 0 {
+		color.Green("  ✓ Assessment risk %s, confidence %d", result.Assessment.Risk, result.Assessment.Confidence)
+		return result, nil, nil
+	}
+	for _, failure := range failures {
+		color.Red("  ✗ %s", failure.Message)
+	}
+	return result, failures, nil
+}
+
+// assessmentFailures compares the risk and confidence of an assessment with the expected ones
+func assessmentFailures(expected *config.ExpectedAssessment, actual *targets.AssessmentResult) []validator.ValidationError {
+	var failures []validator.ValidationError
+	if actual.Risk != expected.Risk {
+		failures = append(failures, validator.ValidationError{
+			Path:     "assessment.risk",
+			Message:  fmt.Sprintf("Assessment risk is %s, expected %s", actual.Risk, expected.Risk),
+			Expected: expected.Risk,
+			Actual:   actual.Risk,
+		})
+	}
+	if expected.Confidence != nil && actual.Confidence != *expected.Confidence {
+		failures = append(failures, validator.ValidationError{
+			Path:     "assessment.confidence",
+			Message:  fmt.Sprintf("Assessment confidence is %d, expected %d", actual.Confidence, *expected.Confidence),
+			Expected: strconv.Itoa(*expected.Confidence),
+			Actual:   strconv.Itoa(actual.Confidence),
+		})
+	}
+	return failures
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// runCancelTest runs the analysis of a cancellation test and validates that it was canceled cleanly
+// Targets that can't cancel analyses skip the test
+func runCancelTest(ctx context.Context, target targets.Target, test *config.TestDefinition) (*targets.ExecutionResult, []validator.ValidationError, error) {
+	canceler, ok := target.(targets.Canceler)
+	if !ok {
+		progress.Stop(ctx)
+		return nil, nil, &skipError{reasons: []string{fmt.Sprintf("cancellation tests aren't supported by %s", target.Name())}}
+	}
+
+	result, err := canceler.ExecuteCanceled(ctx, test)
+	progress.Stop(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execution failed: %w", err)
+	}
+
+	failures := cancelFailures(result)
+	if len(failures) == 0 {
+		color.Green("  ✓ Canceled cleanly after %s", test.Cancel.After.Duration)
+		return result, nil, nil
+	}
+	for _, failure := range failures {
+		color.Red("  ✗ %s", failure.Message)
+	}
+	return result, failures, nil
+}
+
+// cancelFailures reports an analysis that finished before it was canceled and what a canceled analysis
+// left behind
+func cancelFailures(result *targets.ExecutionResult) []validator.ValidationError {
+	if !result.Canceled {
+		return []validator.ValidationError{{
+			Path:     "cancel",
+			Message:  "Analysis finished before it was canceled, cancel it earlier",
+			Expected: "canceled",
+			Actual:   "finished",
+		}}
+	}
+	var failures []validator.ValidationError
+	for _, leftover := range result.Leftovers {
+		failures = append(failures, validator.ValidationError{
+			Path:    "cancel",
+			Message: fmt.Sprintf("Canceled analysis left behind: %s", leftover),
+			Actual:  leftover,
+		})
+	}
+	return failures
+}
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// targetCapabilities holds the version and capabilities detected once per run
+type targetCapabilities struct {
+	target       string
+	version      string
+	capabilities targets.Capabilities
+}
+
+// detectCapabilities detects a target's version and capabilities
+// Detection failures are reported as warnings and every test is run
+func detectCapabilities(ctx context.Context, target targets.Target) *targetCapabilities {
+	log := util.GetLogger()
+	detected := &targetCapabilities{target: target.Name()}
+
+	version, err := target.Version(ctx)
+	if err != nil {
+		color.Yellow("Warning: failed to detect %s version, running all tests: %v", target.Name(), err)
+		return detected
+	}
+	detected.version = version
+
+	capabilities, err := target.Capabilities(ctx)
+	if err != nil {
+		color.Yellow("Warning: failed to detect %s capabilities, running all tests: %v", target.Name(), err)
+		return detected
+	}
+	detected.capabilities = capabilities
+
+	if version != "" {
+		log.Info("Detected target version", "target", target.Name(), "version", version)
+	}
+	return detected
+}
+
+// unsupported returns why the target can't run a test, empty when it can
+func (c *targetCapabilities) unsupported(test *config.TestDefinition) []string {
+	if c == nil {
+		return nil
+	}
+	return targets.Unsupported(test, c.target, c.version, c.capabilities)
+}
+
+// skipError is returned for tests that aren't run: tests with a skip reason and tests needing
+// features the target lacks
+type skipError struct {
+	reasons []string
+}
+
+func (e *skipError) Error() string {
+	return strings.Join(e.reasons, "; ")
+}
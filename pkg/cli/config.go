@@ -47,12 +47,14 @@ Supported target types:
   - tackle-hub: Tackle Hub API execution
   - tackle-ui: Tackle UI browser automation (not implemented)
   - kai-rpc: Kai analyzer RPC (not implemented)
-  - vscode: VSCode extension execution (not implemented)`,
+  - vscode: VSCode extension execution (not implemented)
+  - asset-gen: Asset generation with kantra discover and generate
+  - mock: Canned outputs for testing koncur itself`,
 		RunE: runConfigTarget,
 	}
 
 	cmd.Flags().StringVarP(&configOutputFile, "output", "o", "", "Output file path (default: .koncur/config/target-<type>.yaml)")
-	cmd.Flags().StringVarP(&configType, "type", "t", "", "Target type (kantra, tackle-hub, tackle-ui, kai-rpc, vscode)")
+	cmd.Flags().StringVarP(&configType, "type", "t", "", "Target type (kantra, tackle-hub, tackle-ui, kai-rpc, vscode, asset-gen, mock)")
 
 	return cmd
 }
@@ -84,7 +86,7 @@ func runConfigTarget(cmd *cobra.Command, args []string) error {
 	if targetType == "" {
 		prompt := promptui.Select{
 			Label: "Select target type",
-			Items: []string{"kantra", "tackle-hub", "tackle-ui", "kai-rpc", "vscode"},
+			Items: []string{"kantra", "tackle-hub", "tackle-ui", "kai-rpc", "vscode", "asset-gen", "mock"},
 		}
 		_, result, err := prompt.Run()
 		if err != nil {
@@ -108,6 +110,10 @@ func runConfigTarget(cmd *cobra.Command, args []string) error {
 		targetConfig, err = createKaiRPCConfig()
 	case "vscode":
 		targetConfig, err = createVSCodeConfig()
+	case "asset-gen":
+		targetConfig, err = createAssetGenConfig()
+	case "mock":
+		targetConfig, err = createMockConfig()
 	default:
 		return fmt.Errorf("unsupported target type: %s", targetType)
 	}
@@ -221,6 +227,63 @@ func createKantraConfig() (*config.TargetConfig, error) {
 	}, nil
 }
 
+// createAssetGenConfig creates an asset generation target configuration interactively
+func createAssetGenConfig() (*config.TargetConfig, error) {
+	assetGenConfig := &config.AssetGenConfig{}
+
+	// Prompt for binary path (optional)
+	prompt := promptui.Prompt{
+		Label:   "Kantra binary path (optional, press Enter to use PATH)",
+		Default: "",
+	}
+	binaryPath, err := prompt.Run()
+	if err != nil && err != promptui.ErrInterrupt {
+		return nil, err
+	}
+	if binaryPath != "" {
+		assetGenConfig.BinaryPath = binaryPath
+	}
+
+	return &config.TargetConfig{
+		Type:     "asset-gen",
+		AssetGen: assetGenConfig,
+	}, nil
+}
+
+// createMockConfig creates a mock target configuration interactively
+func createMockConfig() (*config.TargetConfig, error) {
+	mockConfig := &config.MockConfig{}
+
+	// Prompt for fixtures directory (optional)
+	prompt := promptui.Prompt{
+		Label:   "Fixtures directory (optional, press Enter to return expected outputs)",
+		Default: "",
+	}
+	fixturesDir, err := prompt.Run()
+	if err != nil && err != promptui.ErrInterrupt {
+		return nil, err
+	}
+	mockConfig.FixturesDir = fixturesDir
+
+	// Select failure mode
+	selectPrompt := promptui.Select{
+		Label: "Simulated failure",
+		Items: []string{"none", config.MockFailureTimeout, config.MockFailureExitCode, config.MockFailureMalformedOutput},
+	}
+	_, failure, err := selectPrompt.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select failure: %w", err)
+	}
+	if failure != "none" {
+		mockConfig.Failure = failure
+	}
+
+	return &config.TargetConfig{
+		Type: "mock",
+		Mock: mockConfig,
+	}, nil
+}
+
 // createTackleHubConfig creates a Tackle Hub target configuration interactively
 func createTackleHubConfig() (*config.TargetConfig, error) {
 	tackleHubConfig := &config.TackleHubConfig{}
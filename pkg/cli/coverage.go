@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/rules"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// checkExpectedCoverage compares the expected output of a test with the rules of its local rules paths
+// that its label selector selects, so stale expected files are caught before the analysis runs
+// Rules from Git repositories and tests without expected output files aren't checked
+func checkExpectedCoverage(test *config.TestDefinition) ([]string, error) {
+	if test.IsAssetTest() || test.IsFixTest() {
+		return nil, nil
+	}
+
+	rulesets, err := loadLocalRules(test)
+	if err != nil {
+		return nil, err
+	}
+	if len(rulesets) == 0 {
+		return nil, nil
+	}
+
+	selector, err := targets.ParseLabelExpression(analysisLabelSelector(test.Analysis))
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	check := func(prefix string, expected []konveyor.RuleSet) {
+		for _, w := range rules.CheckCoverage(rulesets, selector, validator.SelectRuleSets(expected, test.Expect.ValidateOnly)) {
+			warnings = append(warnings, prefix+w.String())
+		}
+	}
+	if test.IsMultiApplication() {
+		for _, app := range test.Analysis.Applications {
+			if app.Expect.File != "" {
+				check(app.Name+": ", app.Expect.Result)
+			}
+		}
+	} else if test.Expect.Output.File != "" {
+		check("", test.Expect.Output.Result)
+	}
+	return warnings, nil
+}
+
+// printCoverageWarnings prints the expected coverage warnings of a test
+func printCoverageWarnings(test *config.TestDefinition) {
+	warnings, err := checkExpectedCoverage(test)
+	if err != nil {
+		color.Yellow("  ⚠ Failed to check expected coverage: %v", err)
+		return
+	}
+	prefix := ""
+	if test.Variant != nil {
+		prefix = test.Variant.Name() + ": "
+	}
+	for _, w := range warnings {
+		color.Yellow("  ⚠ %s%s", prefix, w)
+	}
+}
+
+// loadLocalRules loads the rulesets of a test's local rules paths, rules from Git repositories aren't
+// loaded. Rulesets ignored by validateOnly are left out
+func loadLocalRules(test *config.TestDefinition) ([]*rules.RuleSet, error) {
+	var rulesets []*rules.RuleSet
+	for i, path := range test.Analysis.Rules {
+		if i < len(test.Analysis.RulesGitComponents) && test.Analysis.RulesGitComponents[i] != nil {
+			continue
+		}
+		loaded, err := rules.Load(test.ResolvePath(path))
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range loaded {
+			if len(test.Expect.ValidateOnly) == 0 || slices.Contains(test.Expect.ValidateOnly, rs.Name) {
+				rulesets = append(rulesets, rs)
+			}
+		}
+	}
+	return rulesets, nil
+}
+
+// includeError reports a rule whose konveyor.io/include label the analyzer didn't honor
+func includeError(failure rules.IncludeFailure) validator.ValidationError {
+	err := validator.ValidationError{
+		Path:     fmt.Sprintf("%s/%s", failure.RuleSet, failure.RuleID),
+		Message:  fmt.Sprintf("Rule %s", failure.Message),
+		Expected: fmt.Sprintf("%s=%s", rules.IncludeLabel, failure.Include),
+	}
+	if failure.Found != "" {
+		err.Actual = failure.Found
+	}
+	return err
+}
+
+// analysisLabelSelector returns the label selector of an analysis. Without one, the analysis selects
+// rules by its targets and sources like kantra does
+func analysisLabelSelector(analysis config.AnalysisConfig) string {
+	if analysis.LabelSelector != "" || (len(analysis.Target) == 0 && len(analysis.Source) == 0) {
+		return analysis.LabelSelector
+	}
+	var groups []string
+	for _, g := range []struct {
+		label  string
+		values []string
+	}{
+		{konveyor.TargetTechnologyLabel, analysis.Target},
+		{konveyor.SourceTechnologyLabel, analysis.Source},
+	} {
+		if len(g.values) == 0 {
+			continue
+		}
+		labels := make([]string, len(g.values))
+		for i, v := range g.values {
+			labels[i] = g.label + "=" + v
+		}
+		groups = append(groups, "("+strings.Join(labels, " || ")+")")
+	}
+	return strings.Join(groups, " && ")
+}
+
+// recordTriggeredRules records the rules a passed test's expected output shows firing, its output matched them
+func recordTriggeredRules(triggered *rules.Triggered, test *config.TestDefinition) {
+	if test == nil {
+		return
+	}
+	for _, rs := range test.Expect.Output.Result {
+		triggered.Add(rs)
+	}
+	for _, app := range test.Analysis.Applications {
+		for _, rs := range app.Expect.Result {
+			triggered.Add(rs)
+		}
+	}
+}
+
+// printRuleCoverage prints the share of each ruleset's rules that fired and the total
+func printRuleCoverage(coverage []rules.RuleSetCoverage) {
+	nameWidth := len("RULESET")
+	for _, c := range coverage {
+		nameWidth = max(nameWidth, len(c.Name))
+	}
+
+	fmt.Println("\nRule coverage:")
+	fmt.Printf("%-*s  %7s  %5s  %8s\n", nameWidth, "RULESET", "COVERED", "RULES", "COVERAGE")
+	covered, total := 0, 0
+	for _, c := range coverage {
+		fmt.Printf("%-*s  %7d  %5d  %7.1f%%\n", nameWidth, c.Name, len(c.Covered), c.Rules, c.Percent())
+		covered += len(c.Covered)
+		total += c.Rules
+	}
+	percent := 0.0
+	if total > 0 {
+		percent = float64(covered) * 100 / float64(total)
+	}
+	fmt.Printf("%-*s  %7d  %5d  %7.1f%%\n", nameWidth, "TOTAL", covered, total, percent)
+}
+
+// writeRuleCoverage writes the rule coverage report as JSON, creating the file's directory
+func writeRuleCoverage(path string, coverage []rules.RuleSetCoverage) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create rule coverage directory: %w", err)
+	}
+	data, err := json.MarshalIndent(coverage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal rule coverage: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write rule coverage file: %w", err)
+	}
+	return nil
+}
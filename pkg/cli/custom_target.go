@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/output"
+	"github.com/konveyor/test-harness/pkg/rules"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// customTargetFailures checks that the rules of a test's custom target were applied. At least one of them
+// must be reported in the outputs, otherwise the analysis didn't select the target
+func customTargetFailures(test *config.TestDefinition, result *targets.ExecutionResult) ([]validator.ValidationError, error) {
+	custom := test.Analysis.CustomTarget
+	if custom == nil {
+		return nil, nil
+	}
+
+	ruleIDs := map[string]bool{}
+	for _, path := range custom.Rules {
+		loaded, err := rules.Load(test.ResolvePath(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the rules of custom target %s: %w", custom.Name, err)
+		}
+		for _, rs := range loaded {
+			for _, rule := range rs.Rules {
+				ruleIDs[rule.RuleID] = true
+			}
+		}
+	}
+
+	outputs := []string{result.OutputFile()}
+	if test.IsMultiApplication() {
+		outputs = nil
+		for _, app := range test.Analysis.Applications {
+			if file, ok := result.ApplicationOutputFile(app.Name); ok {
+				outputs = append(outputs, file)
+			}
+		}
+	}
+	for _, file := range outputs {
+		rulesets, err := output.Load(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse output: %w", err)
+		}
+		for _, rs := range rulesets {
+			for id := range rs.Violations {
+				if ruleIDs[id] {
+					return nil, nil
+				}
+			}
+			for id := range rs.Insights {
+				if ruleIDs[id] {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return []validator.ValidationError{{
+		Path:     "customTarget",
+		Message:  fmt.Sprintf("No rule of custom target %s was reported, the analysis didn't apply its rules", custom.Name),
+		Expected: slices.Sorted(maps.Keys(ruleIDs)),
+	}}, nil
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/artifacts"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/kube"
+	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dispatchImage          string
+	dispatchNamespace      string
+	dispatchKubeconfig     string
+	dispatchTargetConfig   string
+	dispatchTarget         string
+	dispatchFilter         []string
+	dispatchResultsPVC     string
+	dispatchResultsDir     string
+	dispatchArtifactsURL   string
+	dispatchEndpoint       string
+	dispatchEnvSecret      string
+	dispatchServiceAccount string
+	dispatchParallelism    int
+	dispatchJobTimeout     time.Duration
+	dispatchKeepJobs       bool
+	dispatchRunID          string
+	dispatchNoHistory      bool
+)
+
+// NewDispatchCmd creates the dispatch command
+func NewDispatchCmd() *cobra.Command {
+	dispatchCmd := &cobra.Command{
+		Use:   "dispatch <test-directory>",
+		Short: "Run tests as Kubernetes Jobs",
+		Long: `Schedule each test as a Kubernetes Job running the harness image and aggregate the results.
+
+The image must contain koncur and the test suite at the same relative paths as
+the local directory. Each Job runs a single test and writes its outcome either to
+a shared PersistentVolumeClaim, which must also be mounted locally with
+--results-dir, or to object storage with --artifacts-url.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := util.GetLogger()
+
+			if dispatchImage == "" {
+				return fmt.Errorf("--image is required")
+			}
+			if (dispatchResultsPVC == "") == (dispatchArtifactsURL == "") {
+				return fmt.Errorf("exactly one of --results-pvc or --artifacts-url is required to collect results")
+			}
+			if dispatchResultsPVC != "" && dispatchResultsDir == "" {
+				return fmt.Errorf("--results-dir is required with --results-pvc, mount the claim locally to read results")
+			}
+
+			filters, err := discovery.ParseFilters(dispatchFilter)
+			if err != nil {
+				return err
+			}
+			found, err := discovery.Discover(args[0], filters)
+			if err != nil {
+				return fmt.Errorf("failed to find test files: %w", err)
+			}
+			var tests []kube.TestJob
+			// Skipped tests are recorded without starting a Job
+			var skipped []history.TestResult
+			for _, test := range found {
+				if test.Skipped {
+					skipped = append(skipped, history.TestResult{Name: test.Name, File: test.File, Outcome: history.OutcomeSkipped})
+					continue
+				}
+				if test.Definition != nil && test.Definition.Skip != "" {
+					skipped = append(skipped, history.TestResult{Name: test.Name, File: test.File, Outcome: history.OutcomeSkipped, Reason: test.Definition.Skip})
+					continue
+				}
+				tests = append(tests, kube.TestJob{Name: test.Name, File: filepath.ToSlash(test.File)})
+			}
+			if len(found) == 0 {
+				return fmt.Errorf("no test files found in %s", args[0])
+			}
+
+			opts := kube.JobOptions{
+				Namespace:      dispatchNamespace,
+				Image:          dispatchImage,
+				RunID:          dispatchRunID,
+				TargetType:     dispatchTarget,
+				ResultsPVC:     dispatchResultsPVC,
+				ArtifactsURL:   dispatchArtifactsURL,
+				EnvSecret:      dispatchEnvSecret,
+				ServiceAccount: dispatchServiceAccount,
+				Timeout:        dispatchJobTimeout,
+			}
+			if opts.RunID == "" {
+				opts.RunID = time.Now().Format("20060102-150405")
+			}
+
+			var targetConfig []byte
+			if dispatchTargetConfig != "" {
+				// Validate locally before any Job is created
+				cfg, err := config.LoadTargetConfig(dispatchTargetConfig)
+				if err != nil {
+					return fmt.Errorf("failed to load target config: %w", err)
+				}
+				targetConfig, err = os.ReadFile(dispatchTargetConfig)
+				if err != nil {
+					return fmt.Errorf("failed to read target config: %w", err)
+				}
+				opts.TargetType = cfg.Type
+			}
+
+			cluster, namespace, err := kube.NewCluster(dispatchKubeconfig)
+			if err != nil {
+				return err
+			}
+			if opts.Namespace == "" {
+				opts.Namespace = namespace
+			}
+
+			var results kube.ResultStore = &kube.DirResultStore{Dir: dispatchResultsDir}
+			if dispatchArtifactsURL != "" {
+				cfg := artifacts.ConfigFromEnv(dispatchArtifactsURL)
+				if dispatchEndpoint != "" {
+					cfg.Endpoint = dispatchEndpoint
+				}
+				uploader, err := artifacts.NewUploader(cfg)
+				if err != nil {
+					return err
+				}
+				results = &kube.ObjectResultStore{Uploader: uploader}
+			}
+
+			dispatcher := &kube.Dispatcher{
+				Cluster:      cluster,
+				Results:      results,
+				Options:      opts,
+				TargetConfig: targetConfig,
+				Parallelism:  dispatchParallelism,
+				KeepJobs:     dispatchKeepJobs,
+			}
+
+			// Jobs are still cleaned up when interrupted
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			log.Info("Dispatching tests", "count", len(tests), "namespace", opts.Namespace, "run", opts.RunID)
+			run, err := dispatcher.Dispatch(ctx, tests)
+			if err != nil {
+				return err
+			}
+			run.Results = append(run.Results, skipped...)
+
+			if !dispatchNoHistory {
+				if err := history.NewStore(history.DefaultDir).Append(run); err != nil {
+					log.Info("Warning: failed to record run history", "error", err.Error())
+				}
+			}
+
+			printDispatchSummary(run)
+			return nil
+		},
+	}
+
+	dispatchCmd.Flags().StringVar(&dispatchImage, "image", "", "Harness image containing koncur and the test suite (required)")
+	dispatchCmd.Flags().StringVarP(&dispatchNamespace, "namespace", "n", "", "Namespace to create Jobs in (default: kubeconfig namespace)")
+	dispatchCmd.Flags().StringVar(&dispatchKubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: $KUBECONFIG, ~/.kube/config or in-cluster)")
+	dispatchCmd.Flags().StringVarP(&dispatchTargetConfig, "target-config", "c", "", "Path to target configuration file, mounted into the Jobs from a ConfigMap")
+	dispatchCmd.Flags().StringVarP(&dispatchTarget, "target", "t", "", "Target type when no target configuration is given")
+	dispatchCmd.Flags().StringArrayVarP(&dispatchFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable)")
+	dispatchCmd.Flags().StringVar(&dispatchResultsPVC, "results-pvc", "", "PersistentVolumeClaim the Jobs write results to")
+	dispatchCmd.Flags().StringVar(&dispatchResultsDir, "results-dir", "", "Local directory where the results PVC is mounted")
+	dispatchCmd.Flags().StringVar(&dispatchArtifactsURL, "artifacts-url", "", "Upload each Job's artifacts and results to s3://bucket/prefix or gs://bucket/prefix and collect results from there")
+	dispatchCmd.Flags().StringVar(&dispatchEndpoint, "artifacts-endpoint", "", "S3 compatible endpoint to collect results from (default: $AWS_ENDPOINT_URL_S3 or AWS S3)")
+	dispatchCmd.Flags().StringVar(&dispatchEnvSecret, "env-secret", "", "Secret added to the Jobs' environment, e.g. holding AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	dispatchCmd.Flags().StringVar(&dispatchServiceAccount, "service-account", "", "Service account running the Jobs")
+	dispatchCmd.Flags().IntVarP(&dispatchParallelism, "parallelism", "p", 0, "Maximum number of Jobs running at once (default: all)")
+	dispatchCmd.Flags().DurationVar(&dispatchJobTimeout, "job-timeout", 0, "Maximum duration of each Job (default: no limit)")
+	dispatchCmd.Flags().BoolVar(&dispatchKeepJobs, "keep-jobs", false, "Don't delete Jobs after they finish")
+	dispatchCmd.Flags().StringVar(&dispatchRunID, "run-id", "", "Run ID naming Jobs and results (default: run timestamp)")
+	dispatchCmd.Flags().BoolVar(&dispatchNoHistory, "no-history", false, "Don't record test outcomes in the run history")
+
+	return dispatchCmd
+}
+
+// printDispatchSummary prints every test's outcome followed by the totals
+func printDispatchSummary(run *history.Run) {
+	passed, failed, skipped, xfailed := 0, 0, 0, 0
+	for _, result := range run.Results {
+		switch result.Outcome {
+		case history.OutcomePassed:
+			passed++
+			color.Green("  ✓ %s (%s)", result.Name, result.Duration.Round(time.Second))
+		case history.OutcomeSkipped:
+			skipped++
+			color.Yellow("  ⊘ %s", result.Name)
+		case history.OutcomeExpectedFailure:
+			xfailed++
+			color.Yellow("  ⚠ %s: expected failure (%s)", result.Name, result.Reason)
+		case history.OutcomeUnexpectedPass:
+			failed++
+			color.Red("  ✗ %s: unexpected pass (%s)", result.Name, result.Reason)
+		case history.OutcomeError:
+			failed++
+			color.Red("  ✗ %s: %s", result.Name, result.Error)
+		default:
+			failed++
+			color.Red("  ✗ %s", result.Name)
+		}
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Printf("Summary: %d total\n", len(run.Results))
+	if passed > 0 {
+		color.Green("  ✓ Passed: %d", passed)
+	}
+	if skipped > 0 {
+		color.Yellow("  ⊘ Skipped: %d", skipped)
+	}
+	if xfailed > 0 {
+		color.Yellow("  ⚠ Expected failures: %d", xfailed)
+	}
+	if failed > 0 {
+		color.Red("  ✗ Failed: %d", failed)
+	}
+}
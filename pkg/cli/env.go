@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/env"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envConfigFile      string
+	envOperatorVersion string
+)
+
+// NewEnvCmd creates the env command
+func NewEnvCmd() *cobra.Command {
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Manage the Konveyor instance under test",
+		Long: `Install a Konveyor operator version into a cluster, or a new kind cluster,
+and tear it down again, as configured in an environment file.`,
+	}
+
+	envCmd.PersistentFlags().StringVarP(&envConfigFile, "config", "c", "", "Path to environment configuration file (required)")
+	envCmd.PersistentFlags().StringVar(&envOperatorVersion, "operator-version", "", "Override the operator version of the configuration")
+	_ = envCmd.MarkPersistentFlagRequired("config")
+
+	// Subcommands
+	envCmd.AddCommand(NewEnvUpCmd())
+	envCmd.AddCommand(NewEnvDownCmd())
+
+	return envCmd
+}
+
+// NewEnvUpCmd creates the env up command
+func NewEnvUpCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Install Konveyor and wait until the Hub is ready",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadEnvConfig(envConfigFile, envOperatorVersion)
+			if err != nil {
+				return err
+			}
+			if err := env.New(cfg).Up(context.Background()); err != nil {
+				return err
+			}
+			color.Green("✓ Konveyor %s is ready", cfg.Operator.Version)
+			return nil
+		},
+	}
+}
+
+// NewEnvDownCmd creates the env down command
+func NewEnvDownCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "down",
+		Short: "Tear down the Konveyor instance or kind cluster",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadEnvConfig(envConfigFile, envOperatorVersion)
+			if err != nil {
+				return err
+			}
+			if err := env.New(cfg).Down(context.Background()); err != nil {
+				return err
+			}
+			color.Green("✓ Environment torn down")
+			return nil
+		},
+	}
+}
+
+// setUpEnvironment brings up the environment of a run and returns the function tearing it down
+// once the run's outcome is known
+func setUpEnvironment(path string) (func(failed bool), error) {
+	cfg, err := config.LoadEnvConfig(path, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load env config: %w", err)
+	}
+
+	environment := env.New(cfg)
+	teardown := func(failed bool) {
+		if !cfg.ShouldTeardown(failed) {
+			color.Yellow("Keeping environment (teardown: %s), run 'koncur env down -c %s' to remove it", cfg.GetTeardown(), path)
+			return
+		}
+		if err := environment.Down(context.Background()); err != nil {
+			color.Yellow("Warning: failed to tear down environment: %v", err)
+		}
+	}
+
+	if err := environment.Up(context.Background()); err != nil {
+		teardown(true)
+		return nil, fmt.Errorf("failed to set up environment: %w", err)
+	}
+	return teardown, nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes of koncur run, so CI can tell why a run failed
+const (
+	// exitValidationFailure is returned when tests failed validation beyond the exit policy
+	exitValidationFailure = 1
+	// exitExecutionError is returned when tests couldn't run, and failures exceed the exit policy
+	exitExecutionError = 2
+	// exitConfigError is returned when no test ran because of invalid flags, settings or test files
+	exitConfigError = 3
+)
+
+// exitError ends koncur with an exit code
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// exitCode returns the exit code of an error returned by a command
+func exitCode(err error) int {
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return 1
+}
+
+// withConfigErrors exits with exitConfigError when a command fails without an exit code of its own
+func withConfigErrors(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		err := run(cmd, args)
+		var exit *exitError
+		if err != nil && !errors.As(err, &exit) {
+			return &exitError{code: exitConfigError, err: err}
+		}
+		return err
+	}
+}
+
+// checkExitPolicy returns an error when the failures of a run exceed the exit policy
+// Execution errors take precedence over validation failures in the exit code
+func checkExitPolicy(run *history.Run, policy config.ExitPolicy) error {
+	failed, errored, ran := 0, 0, 0
+	for _, result := range run.Results {
+		switch result.Outcome {
+		case history.OutcomeSkipped:
+			continue
+		case history.OutcomeFailed, history.OutcomeUnexpectedPass:
+			failed++
+		case history.OutcomeError:
+			errored++
+		}
+		ran++
+	}
+	if !policy.Exceeded(failed, errored, ran) {
+		return nil
+	}
+
+	code := exitValidationFailure
+	if errored > 0 {
+		code = exitExecutionError
+	}
+	return &exitError{
+		code: code,
+		err:  fmt.Errorf("%d of %d tests failed validation and %d couldn't run, exceeding the exit policy", failed, ran, errored),
+	}
+}
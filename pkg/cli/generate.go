@@ -10,28 +10,38 @@ import (
 	"github.com/fatih/color"
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/expected"
+	"github.com/konveyor/test-harness/pkg/output"
 	"github.com/konveyor/test-harness/pkg/parser"
+	"github.com/konveyor/test-harness/pkg/progress"
 	"github.com/konveyor/test-harness/pkg/targets"
 	"github.com/konveyor/test-harness/pkg/util"
 	"github.com/spf13/cobra"
-	yaml2 "gopkg.in/yaml.v2"
 	"gopkg.in/yaml.v3"
 )
 
 var (
 	testDir             string
 	outputDir           string
-	generateFilter      string
+	generateFilter      []string
 	dryRun              bool
 	targetTypeGen       string
 	targetConfigFileGen string
 )
 
+// generateSettingFlags maps the generate flags overriding settings to their settings
+var generateSettingFlags = map[string]string{
+	"target":        config.SettingTarget,
+	"target-config": config.SettingTargetConfig,
+}
+
 // NewGenerateCmd creates the generate command
 func NewGenerateCmd() *cobra.Command {
 	generateCmd := &cobra.Command{
-		Use:   "generate",
-		Short: "Generate expected outputs for tests",
+		Use:     "generate",
+		Aliases: []string{"record"},
+		Short:   "Generate expected outputs for tests",
 		Long: `Generate expected outputs by running tests and capturing their actual results.
 This command will:
   1. Find all test.yaml files in the specified directory
@@ -45,34 +55,39 @@ This is useful when:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log := util.GetLogger()
 
-			// Find all test.yaml files
+			filters, err := discovery.ParseFilters(generateFilter)
+			if err != nil {
+				return err
+			}
+
+			// Find all test.yaml files matching the filters
 			log.Info("Searching for test files", "directory", testDir)
-			testFiles, err := findTestFiles(testDir)
+			tests, err := discovery.Discover(testDir, filters)
 			if err != nil {
 				return fmt.Errorf("failed to find test files: %w", err)
 			}
 
-			if len(testFiles) == 0 {
+			if len(tests) == 0 {
+				if len(filters) > 0 {
+					return fmt.Errorf("no test files matched filter: %s", strings.Join(generateFilter, ", "))
+				}
 				return fmt.Errorf("no test files found in %s", testDir)
 			}
 
+			testFiles := make([]string, 0, len(tests))
+			for _, test := range tests {
+				testFiles = append(testFiles, test.File)
+			}
 			log.Info("Found test files", "count", len(testFiles))
 
-			// Filter tests if pattern provided
-			if generateFilter != "" {
-				filtered := []string{}
-				for _, tf := range testFiles {
-					testName := filepath.Base(filepath.Dir(tf))
-					if strings.Contains(testName, generateFilter) {
-						filtered = append(filtered, tf)
-					}
-				}
-				testFiles = filtered
-				log.Info("Filtered test files", "count", len(testFiles), "pattern", generateFilter)
+			// Resolve the settings and target config once for all tests
+			settings, err := resolveSettings(cmd, generateSettingFlags)
+			if err != nil {
+				return err
 			}
-
-			if len(testFiles) == 0 {
-				return fmt.Errorf("no test files matched filter: %s", generateFilter)
+			targetConfig, err := loadTargetConfig(settings)
+			if err != nil {
+				return fmt.Errorf("failed to load target config: %w", err)
 			}
 
 			// Process each test
@@ -80,6 +95,10 @@ This is useful when:
 			failCount := 0
 			skippedCount := 0
 
+			display, stopProgress := startProgress(len(testFiles))
+			defer stopProgress()
+			durations := expectedDurations()
+
 			for i, testFile := range testFiles {
 				testName := filepath.Base(filepath.Dir(testFile))
 				fmt.Printf("\n[%d/%d] Processing: %s\n", i+1, len(testFiles), testName)
@@ -91,9 +110,13 @@ This is useful when:
 					failCount++
 					continue
 				}
+				// The settings only apply to the execution, the saved definition keeps the test's own
+				timeout, workDir := test.Timeout, test.WorkDir
+				settings.Apply(test)
+				restoreSettings := func() { test.Timeout, test.WorkDir = timeout, workDir }
 
 				// Check if test is marked as skipped
-				if isTestSkipped(testFile) {
+				if discovery.IsSkipped(testFile) {
 					color.Yellow("  ⊘ Skipped (marked as SKIPPED in file)")
 					skippedCount++
 					continue
@@ -106,48 +129,6 @@ This is useful when:
 					continue
 				}
 
-				// Load or create target config
-				var targetConfig *config.TargetConfig
-				if targetConfigFileGen != "" {
-					log.Info("Loading target configuration", "file", targetConfigFileGen)
-					targetConfig, err = config.LoadTargetConfig(targetConfigFileGen)
-					if err != nil {
-						color.Red("  ✗ Failed to load target config: %v", err)
-						failCount++
-						continue
-					}
-				} else if targetTypeGen != "" {
-					// Try to auto-discover config file for the specified target type
-					discoveredPath := fmt.Sprintf(".koncur/config/target-%s.yaml", targetTypeGen)
-					if _, err := os.Stat(discoveredPath); err == nil {
-						log.Info("Auto-discovered target configuration", "file", discoveredPath)
-						targetConfig, err = config.LoadTargetConfig(discoveredPath)
-						if err != nil {
-							color.Red("  ✗ Failed to load auto-discovered target config: %v", err)
-							failCount++
-							continue
-						}
-					} else {
-						// Create default config for specified type
-						targetConfig = &config.TargetConfig{Type: targetTypeGen}
-					}
-				} else {
-					// Default to kantra, try to auto-discover first
-					discoveredPath := ".koncur/config/target-kantra.yaml"
-					if _, err := os.Stat(discoveredPath); err == nil {
-						log.Info("Auto-discovered target configuration", "file", discoveredPath)
-						targetConfig, err = config.LoadTargetConfig(discoveredPath)
-						if err != nil {
-							color.Red("  ✗ Failed to load auto-discovered target config: %v", err)
-							failCount++
-							continue
-						}
-					} else {
-						// Create default kantra config
-						targetConfig = &config.TargetConfig{Type: "kantra"}
-					}
-				}
-
 				// Check if test requires maven settings but target doesn't have it
 				if test.RequireMavenSettings {
 					hasSettings := false
@@ -188,56 +169,94 @@ This is useful when:
 					continue
 				}
 
+				display.Start(i+1, testName, durations[testName])
+				ctx := progress.WithReporter(context.Background(), display)
+
+				// Matrix tests save one expected output per variant
+				if test.IsMatrix() {
+					err := generateMatrixOutputs(ctx, target, test)
+					display.Stop()
+					if err != nil {
+						color.Red("  ✗ %v", err)
+						failCount++
+						continue
+					}
+					restoreSettings()
+					if err := saveSimpleTestDefinition(testFile, test); err != nil {
+						color.Red("  ✗ Failed to save: %v", err)
+						failCount++
+						continue
+					}
+					color.Green("  ✓ Generated and saved expected output (%d variants)", len(test.Matrix.Variants()))
+					successCount++
+					continue
+				}
+
 				// Execute the test
 				log.Info("Executing analysis", "test", testName, "target", target.Name())
-				result, err := target.Execute(context.Background(), test)
+				result, err := target.Execute(ctx, test)
+				display.Stop()
 				if err != nil {
 					color.Red("  ✗ Execution failed: %v", err)
 					failCount++
 					continue
 				}
-
-				color.Blue("  ⟳ Analysis completed (exit code: %d, duration: %s)", result.ExitCode, result.Duration)
-
-				// Parse the output
-				actualOutput, err := parser.ParseOutput(result.OutputFile)
-				if err != nil {
-					color.Red("  ✗ Failed to parse output: %v", err)
+				if result.OOMKilled {
+					color.Red("  ✗ Analysis ran out of memory, raise resources.memory")
 					failCount++
 					continue
 				}
 
-				log.Info("Output parsed", "rulesets", len(actualOutput))
-
-				// Filter rulesets to only include those with violations, insights, or tags
-				filteredOutput := parser.FilterRuleSets(actualOutput)
-				log.Info("Filtered output", "original", len(actualOutput), "filtered", len(filteredOutput))
+				color.Blue("  ⟳ Analysis completed (exit code: %d, duration: %s)", result.ExitCode, result.Duration)
 
 				// Update test to use file-based expectation
 				test.Expect.ExitCode = result.ExitCode
 				test.Expect.Output.Result = nil // Clear inline expectation
 
-				// Save the filtered output.yaml file to the test directory
 				testDirPath := test.GetTestDir() // Use the absolute path stored in test
-				expectedOutputFile := filepath.Join(testDirPath, "expected-output.yaml")
 
-				// Save the filtered output as YAML with path normalization
-				if err := saveFilteredOutput(filteredOutput, expectedOutputFile, testDirPath); err != nil {
-					color.Red("  ✗ Failed to save filtered output: %v", err)
-					failCount++
-					continue
+				var summary string
+				if test.IsMultiApplication() {
+					// Save one expected output file per application
+					failed := false
+					for i := range test.Analysis.Applications {
+						app := &test.Analysis.Applications[i]
+						expectedFileName := fmt.Sprintf("expected-output-%s.yaml", testDirName(app.Name))
+						kept, total, err := generateExpectedOutput(result.ApplicationArtifacts[app.Name].Output(), filepath.Join(testDirPath, expectedFileName), testDirPath)
+						if err != nil {
+							color.Red("  ✗ Application %s: %v", app.Name, err)
+							failed = true
+							break
+						}
+						app.Expect = config.ExpectedOutput{File: expectedFileName}
+						color.Blue("  ⟳ Application %s: %d rulesets, %d filtered", app.Name, kept, total-kept)
+					}
+					if failed {
+						failCount++
+						continue
+					}
+					summary = fmt.Sprintf("%d applications", len(test.Analysis.Applications))
+				} else {
+					// Save the filtered output.yaml file to the test directory
+					kept, total, err := generateExpectedOutput(result.OutputFile(), filepath.Join(testDirPath, "expected-output.yaml"), testDirPath)
+					if err != nil {
+						color.Red("  ✗ Failed to generate expected output: %v", err)
+						failCount++
+						continue
+					}
+					test.Expect.Output.File = "expected-output.yaml"
+					summary = fmt.Sprintf("%d rulesets, %d filtered", kept, total-kept)
 				}
 
-				test.Expect.Output.File = "expected-output.yaml"
-
 				// Save updated test definition
+				restoreSettings()
 				if err := saveSimpleTestDefinition(testFile, test); err != nil {
 					color.Red("  ✗ Failed to save: %v", err)
 					failCount++
 					continue
 				}
 
-				color.Green("  ✓ Generated and saved expected output (%d rulesets, %d filtered)", len(filteredOutput), len(actualOutput)-len(filteredOutput))
+				color.Green("  ✓ Generated and saved expected output (%s)", summary)
 				successCount++
 			}
 
@@ -261,45 +280,40 @@ This is useful when:
 
 	// Flags
 	generateCmd.Flags().StringVarP(&testDir, "test-dir", "d", "./tests", "Directory containing test definitions")
-	generateCmd.Flags().StringVarP(&generateFilter, "filter", "f", "", "Filter tests by name pattern")
+	generateCmd.Flags().StringArrayVarP(&generateFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable)")
 	generateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be done without executing")
-	generateCmd.Flags().StringVarP(&targetTypeGen, "target", "t", "kantra", "Target type to use (kantra, tackle-hub, tackle-ui, kai-rpc, vscode)")
+	generateCmd.Flags().StringVarP(&targetTypeGen, "target", "t", "kantra", "Target type to use (kantra, tackle-hub, tackle-ui, kai-rpc, vscode, asset-gen, mock)")
 	generateCmd.Flags().StringVarP(&targetConfigFileGen, "target-config", "c", "", "Path to target configuration file")
 
+	// Subcommands
+	generateCmd.AddCommand(NewGenerateRulesCmd())
+
 	return generateCmd
 }
 
-// findTestFiles recursively finds all test.yaml files in the given directory
-func findTestFiles(dir string) ([]string, error) {
-	var testFiles []string
+// generateExpectedOutput parses an analysis output, filters it and saves it as an expected output file
+// Returns the number of rulesets kept and the total number of rulesets in the output
+func generateExpectedOutput(outputFile, expectedOutputFile, testDirPath string) (int, int, error) {
+	log := util.GetLogger()
 
-	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() && filepath.Base(path) == "test.yaml" {
-			testFiles = append(testFiles, path)
-		}
-		return nil
-	})
-
-	return testFiles, err
-}
-
-// isTestSkipped checks if the test file contains a SKIPPED marker in the first few lines
-func isTestSkipped(testFile string) bool {
-	content, err := os.ReadFile(testFile)
+	// Parse the output
+	actualOutput, err := output.Load(outputFile)
 	if err != nil {
-		return false
+		return 0, 0, fmt.Errorf("failed to parse output: %w", err)
 	}
 
-	// Check first 500 bytes for SKIPPED marker
-	searchContent := string(content)
-	if len(searchContent) > 500 {
-		searchContent = searchContent[:500]
+	log.Info("Output parsed", "rulesets", len(actualOutput))
+
+	// Filter rulesets to only include those with violations, insights, or tags
+	filteredOutput := parser.FilterRuleSets(actualOutput)
+	log.Info("Filtered output", "original", len(actualOutput), "filtered", len(filteredOutput))
+
+	// Save the filtered output as YAML with path normalization
+	if err := saveFilteredOutput(filteredOutput, expectedOutputFile, testDirPath); err != nil {
+		return 0, 0, fmt.Errorf("failed to save filtered output: %w", err)
 	}
 
-	return strings.Contains(searchContent, "SKIPPED:") || strings.Contains(searchContent, "# SKIPPED")
+	return len(filteredOutput), len(actualOutput), nil
 }
 
 // validateTestForGeneration validates a test but skips expected output validation
@@ -309,15 +323,68 @@ func validateTestForGeneration(test *config.TestDefinition) error {
 	if test.Name == "" {
 		return fmt.Errorf("test name is required")
 	}
-	if test.Analysis.Application == "" {
+	if test.Analysis.Application == "" && !test.IsMultiApplication() {
 		return fmt.Errorf("analysis application is required")
 	}
-	if test.Analysis.AnalysisMode == "" {
+	for _, app := range test.Analysis.Applications {
+		if app.Name == "" || app.Application == "" {
+			return fmt.Errorf("each analysis application requires a name and application")
+		}
+	}
+	if test.Analysis.AnalysisMode == "" && (!test.IsMatrix() || len(test.Matrix.Modes) == 0) {
 		return fmt.Errorf("analysis mode is required")
 	}
 	return nil
 }
 
+// generateMatrixOutputs runs every variant of a matrix test and saves its expected output, then points
+// the test at the files with a pattern such as expected-output-{mode}-{target}.yaml
+func generateMatrixOutputs(ctx context.Context, target targets.Target, test *config.TestDefinition) error {
+	log := util.GetLogger()
+
+	pattern := test.Expect.Output.File
+	if pattern == "" {
+		pattern = "expected-output"
+		if len(test.Matrix.Modes) > 0 {
+			pattern += "-" + config.MatrixModePlaceholder
+		}
+		if len(test.Matrix.Targets) > 0 {
+			pattern += "-" + config.MatrixTargetPlaceholder
+		}
+		pattern += ".yaml"
+	}
+	test.Expect.Output = config.ExpectedOutput{File: pattern}
+
+	testDirPath := test.GetTestDir()
+	for i, variant := range test.MatrixVariants() {
+		log.Info("Executing analysis", "test", variant.Name, "target", target.Name())
+		progress.Update(ctx, "variant "+variant.Variant.Name())
+		result, err := target.Execute(ctx, variant)
+		if err != nil {
+			return fmt.Errorf("variant %s: execution failed: %w", variant.Variant.Name(), err)
+		}
+		if result.OOMKilled {
+			return fmt.Errorf("variant %s: analysis ran out of memory, raise resources.memory", variant.Variant.Name())
+		}
+		// Variants share the expected exit code
+		if i > 0 && result.ExitCode != test.Expect.ExitCode {
+			return fmt.Errorf("variant %s exited with %d, other variants with %d", variant.Variant.Name(), result.ExitCode, test.Expect.ExitCode)
+		}
+		test.Expect.ExitCode = result.ExitCode
+
+		expectedFile := variant.ResolvePath(variant.Expect.Output.File)
+		if err := os.MkdirAll(filepath.Dir(expectedFile), 0755); err != nil {
+			return fmt.Errorf("variant %s: failed to create expected output directory: %w", variant.Variant.Name(), err)
+		}
+		kept, total, err := generateExpectedOutput(result.OutputFile(), expectedFile, testDirPath)
+		if err != nil {
+			return fmt.Errorf("variant %s: %w", variant.Variant.Name(), err)
+		}
+		color.Blue("  ⟳ Variant %s: %d rulesets, %d filtered", variant.Variant.Name(), kept, total-kept)
+	}
+	return nil
+}
+
 // saveSimpleTestDefinition saves a simplified test definition
 // This avoids the circular reference issue in RuleSet.MarshalYAML
 func saveSimpleTestDefinition(testFile string, test *config.TestDefinition) error {
@@ -327,14 +394,16 @@ func saveSimpleTestDefinition(testFile string, test *config.TestDefinition) erro
 	}
 
 	type SimpleExpectConfig struct {
-		ExitCode int                  `yaml:"exitCode"`
-		Output   SimpleExpectedOutput `yaml:"output"`
+		ExitCode  int                     `yaml:"exitCode"`
+		Output    SimpleExpectedOutput    `yaml:"output"`
+		Tolerance *config.ToleranceConfig `yaml:"tolerance,omitempty"`
 	}
 
 	type SimpleTestDefinition struct {
 		Name                 string                `yaml:"name"`
 		Description          string                `yaml:"description,omitempty"`
 		Analysis             config.AnalysisConfig `yaml:"analysis"`
+		Matrix               *config.MatrixConfig  `yaml:"matrix,omitempty"`
 		Timeout              *config.Duration      `yaml:"timeout,omitempty"`
 		WorkDir              string                `yaml:"workDir,omitempty"`
 		RequireMavenSettings bool                  `yaml:"requireMavenSettings,omitempty"`
@@ -345,6 +414,7 @@ func saveSimpleTestDefinition(testFile string, test *config.TestDefinition) erro
 		Name:                 test.Name,
 		Description:          test.Description,
 		Analysis:             test.Analysis,
+		Matrix:               test.Matrix,
 		Timeout:              test.Timeout,
 		WorkDir:              test.WorkDir,
 		RequireMavenSettings: test.RequireMavenSettings,
@@ -353,6 +423,7 @@ func saveSimpleTestDefinition(testFile string, test *config.TestDefinition) erro
 			Output: SimpleExpectedOutput{
 				File: test.Expect.Output.File,
 			},
+			Tolerance: test.Expect.Tolerance,
 		},
 	}
 
@@ -388,9 +459,8 @@ func copyFile(src, dst string) error {
 // saveFilteredOutput saves the filtered rulesets to a YAML file with path normalization
 // Uses yaml.v2 to match analyzer-lsp's marshalling behavior and avoid circular reference issues
 func saveFilteredOutput(rulesets []konveyor.RuleSet, path string, testDir string) error {
-	// Use yaml.v2 because konveyor types were designed for v2
-	// v3 has different MarshalYAML behavior that causes infinite recursion
-	data, err := yaml2.Marshal(rulesets)
+	// Expected outputs are written in the current schema version
+	data, err := expected.Marshal(rulesets, false)
 	if err != nil {
 		return fmt.Errorf("failed to marshal rulesets: %w", err)
 	}
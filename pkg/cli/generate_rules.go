@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/rules"
+	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rulesOutputDir    string
+	rulesApplication  string
+	rulesAnalysisMode string
+	rulesForce        bool
+)
+
+// NewGenerateRulesCmd creates the generate rules command
+func NewGenerateRulesCmd() *cobra.Command {
+	rulesCmd := &cobra.Command{
+		Use:   "rules <rules-file-or-directory>",
+		Short: "Scaffold tests from analyzer rule files",
+		Long: `Scaffold test definitions and expected output skeletons from Konveyor rule files.
+
+One test is created per ruleset. For each ruleset this command writes:
+  - test.yaml with the label selector and rules pre-filled
+  - expected-output.yaml with an entry for every rule and no incidents
+
+The skeletons are a starting point; run 'koncur generate' against a real
+application to capture the actual incidents.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log := util.GetLogger()
+
+			log.Info("Loading rules", "path", args[0])
+			rulesets, err := rules.Load(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to load rules: %w", err)
+			}
+
+			if len(rulesets) == 0 {
+				return fmt.Errorf("no rules found in %s", args[0])
+			}
+
+			createdCount := 0
+			skippedCount := 0
+			for _, rs := range rulesets {
+				testDirPath := filepath.Join(rulesOutputDir, testDirName(rs.Name))
+				testFile := filepath.Join(testDirPath, "test.yaml")
+
+				if _, err := os.Stat(testFile); err == nil && !rulesForce {
+					color.Yellow("  ⊘ Skipped %s (%s already exists, use --force to overwrite)", rs.Name, testFile)
+					skippedCount++
+					continue
+				}
+
+				if err := scaffoldRuleSetTest(rs, testDirPath); err != nil {
+					return fmt.Errorf("failed to scaffold test for ruleset %s: %w", rs.Name, err)
+				}
+
+				color.Green("  ✓ Created %s (%d rules)", testFile, len(rs.Rules))
+				createdCount++
+			}
+
+			fmt.Printf("\nScaffolded %d test(s), skipped %d\n", createdCount, skippedCount)
+			if rulesApplication == "" && createdCount > 0 {
+				fmt.Println("  Note: Set 'analysis.application' in each test before running it")
+			}
+
+			return nil
+		},
+	}
+
+	rulesCmd.Flags().StringVarP(&rulesOutputDir, "output-dir", "o", "./tests", "Directory to create the test directories in")
+	rulesCmd.Flags().StringVarP(&rulesApplication, "application", "a", "", "Application path or git URL to pre-fill in the tests")
+	rulesCmd.Flags().StringVarP(&rulesAnalysisMode, "mode", "m", string(provider.SourceOnlyAnalysisMode), "Analysis mode for the tests (source-only, full)")
+	rulesCmd.Flags().BoolVar(&rulesForce, "force", false, "Overwrite existing test definitions")
+
+	return rulesCmd
+}
+
+// scaffoldRuleSetTest writes the test definition and expected output skeleton for a ruleset
+func scaffoldRuleSetTest(rs *rules.RuleSet, testDirPath string) error {
+	if err := os.MkdirAll(testDirPath, 0755); err != nil {
+		return fmt.Errorf("failed to create test directory: %w", err)
+	}
+
+	rulesPath, err := filepath.Abs(rs.Path)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute rules path: %w", err)
+	}
+
+	application := rulesApplication
+	if application == "" {
+		application = "TODO"
+	}
+
+	description := rs.Description
+	if description == "" {
+		description = fmt.Sprintf("Generated from rules in %s", rs.Path)
+	}
+
+	test := &config.TestDefinition{
+		Name:        rs.Name,
+		Description: description,
+		Analysis: config.AnalysisConfig{
+			Application:   application,
+			LabelSelector: rs.LabelSelector(),
+			Rules:         []string{rulesPath},
+			AnalysisMode:  provider.AnalysisMode(rulesAnalysisMode),
+		},
+		Expect: config.ExpectConfig{
+			ExitCode: 0,
+			Output: config.ExpectedOutput{
+				File: "expected-output.yaml",
+			},
+		},
+	}
+
+	expectedOutputFile := filepath.Join(testDirPath, test.Expect.Output.File)
+	if err := saveFilteredOutput([]konveyor.RuleSet{expectedRuleSetSkeleton(rs)}, expectedOutputFile, ""); err != nil {
+		return err
+	}
+
+	return saveSimpleTestDefinition(filepath.Join(testDirPath, "test.yaml"), test)
+}
+
+// expectedRuleSetSkeleton builds an expected ruleset with one entry per rule and no incidents
+// Tag-only rules are left out since their tags depend on the analyzed application
+func expectedRuleSetSkeleton(rs *rules.RuleSet) konveyor.RuleSet {
+	expected := konveyor.RuleSet{
+		Name:        rs.Name,
+		Description: rs.Description,
+		Violations:  map[string]konveyor.Violation{},
+		Insights:    map[string]konveyor.Violation{},
+	}
+
+	for _, r := range rs.Rules {
+		if r.IsTagOnly() {
+			continue
+		}
+
+		description := r.Description
+		if description == "" {
+			description = r.Message
+		}
+
+		v := konveyor.Violation{
+			Description: description,
+			Category:    r.Category,
+			Labels:      r.Labels,
+			Links:       r.Links,
+			Incidents:   []konveyor.Incident{},
+			Effort:      r.Effort,
+		}
+
+		if r.IsInsight() {
+			expected.Insights[r.RuleID] = v
+		} else {
+			expected.Violations[r.RuleID] = v
+		}
+	}
+
+	return expected
+}
+
+// testDirName converts a ruleset name into a directory name for its test
+func testDirName(name string) string {
+	return strings.Map(func(ch rune) rune {
+		if (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '-' || ch == '_' {
+			return ch
+		}
+		if ch >= 'A' && ch <= 'Z' {
+			return ch - 'A' + 'a'
+		}
+		return '-'
+	}, name)
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/konveyor/test-harness/pkg/github"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// reportToGitHub posts the run results to a commit as a check run or status
+// Repository, commit and token default to the GitHub Actions environment
+func reportToGitHub(run *history.Run, failures map[string][]validator.ValidationError, reportURL string) error {
+	repo := runGitHubRepo
+	if repo == "" {
+		repo = os.Getenv("GITHUB_REPOSITORY")
+	}
+	sha := runGitHubSHA
+	if sha == "" {
+		sha = os.Getenv("GITHUB_SHA")
+	}
+	if sha == "" {
+		return fmt.Errorf("commit to report on is unknown, use --github-sha")
+	}
+
+	client, err := github.NewClient(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_TOKEN"), repo)
+	if err != nil {
+		return err
+	}
+
+	// Without uploaded artifacts, link the workflow run
+	detailsURL := reportURL
+	if detailsURL == "" && os.Getenv("GITHUB_RUN_ID") != "" {
+		detailsURL = fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID"))
+	}
+
+	baseDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	reporter := &github.Reporter{
+		Client:     client,
+		SHA:        sha,
+		Name:       runGitHubName,
+		DetailsURL: detailsURL,
+		BaseDir:    baseDir,
+	}
+	if err := reporter.Report(context.Background(), runGitHubReport, run, failures); err != nil {
+		return err
+	}
+
+	fmt.Printf("Reported results to %s@%s as a %s\n", repo, sha, runGitHubReport)
+	return nil
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listFilter []string
+)
+
+// NewListCmd creates the list command
+func NewListCmd() *cobra.Command {
+	listCmd := &cobra.Command{
+		Use:   "list [directory]",
+		Short: "List discovered tests",
+		Long: `Discover test definitions in a directory (default: ./tests) and list them
+with their application, analysis mode and status.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "./tests"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+
+			filters, err := discovery.ParseFilters(listFilter)
+			if err != nil {
+				return err
+			}
+
+			tests, err := discovery.Discover(dir, filters)
+			if err != nil {
+				return fmt.Errorf("failed to find test files: %w", err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tAPPLICATION\tMODE\tTAGS\tSTATUS")
+
+			for _, test := range tests {
+				switch {
+				case test.Skipped:
+					fmt.Fprintf(w, "%s\t-\t-\t-\tskipped\n", test.Name)
+				case test.Err == nil && test.Definition.Skip != "":
+					fmt.Fprintf(w, "%s\t-\t-\t-\tskipped: %s\n", test.Definition.Name, test.Definition.Skip)
+				case test.Err != nil:
+					fmt.Fprintf(w, "%s\t-\t-\t-\tinvalid\n", test.Name)
+				default:
+					def := test.Definition
+					tags := "-"
+					if len(def.Tags) > 0 {
+						tags = strings.Join(def.Tags, ",")
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\tready\n", def.Name, describeApplication(def), describeMode(def), tags)
+				}
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Printf("\n%d test(s) found\n", len(tests))
+			return nil
+		},
+	}
+
+	listCmd.Flags().StringArrayVarP(&listFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable)")
+
+	return listCmd
+}
+
+// describeMode returns the analysis mode of a test, and the number of variants of matrix tests
+func describeMode(test *config.TestDefinition) string {
+	if !test.IsMatrix() {
+		return string(test.Analysis.AnalysisMode)
+	}
+	return fmt.Sprintf("matrix (%d)", len(test.Matrix.Variants()))
+}
+
+// describeApplication returns a short description of what a test analyzes
+func describeApplication(test *config.TestDefinition) string {
+	if !test.IsMultiApplication() {
+		return test.Analysis.Application
+	}
+
+	names := make([]string, 0, len(test.Analysis.Applications))
+	for _, app := range test.Analysis.Applications {
+		names = append(names, app.Name)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/expected"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateExpectedFilter []string
+	migrateExpectedDryRun bool
+)
+
+// NewMigrateExpectedCmd creates the migrate-expected command
+func NewMigrateExpectedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-expected [directory]",
+		Short: "Upgrade expected output files to the current schema version",
+		Long: fmt.Sprintf(`Upgrade the expected output files of the tests in a directory (default: ./tests)
+to the current schema version (%d), so a change of the expected output schema doesn't
+require editing every file by hand.
+
+Files of older versions are loaded, migrated and rewritten in the format koncur generate
+writes. Files already at the current version are left as is. Template files can't be
+rewritten and are reported, upgrade them by hand.`, expected.CurrentVersion),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "./tests"
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			filters, err := discovery.ParseFilters(migrateExpectedFilter)
+			if err != nil {
+				return err
+			}
+			tests, err := discovery.Discover(dir, filters)
+			if err != nil {
+				return fmt.Errorf("failed to find test files: %w", err)
+			}
+
+			migrated, total, failed := 0, 0, 0
+			for _, test := range tests {
+				// Expected outputs aren't loaded, unknown versions are reported per file. Skipped tests
+				// are migrated too
+				def, err := config.LoadWithOptions(test.File, true)
+				if err != nil {
+					color.Yellow("⚠ %s: %v", test.Name, err)
+					continue
+				}
+				for _, file := range expectedOutputFiles(def) {
+					path := def.ResolvePath(file.File)
+					if file.Template {
+						color.Yellow("⚠ %s is a template, upgrade it by hand", path)
+						continue
+					}
+					total++
+					from, err := migrateExpectedFile(path, migrateExpectedDryRun)
+					switch {
+					case err != nil:
+						failed++
+						color.Red("✗ %s: %v", path, err)
+					case from < expected.CurrentVersion:
+						migrated++
+						color.Green("✓ %s: version %d -> %d", path, from, expected.CurrentVersion)
+					}
+				}
+			}
+
+			action := "Migrated"
+			if migrateExpectedDryRun {
+				action = "Would migrate"
+			}
+			fmt.Printf("\n%s %d of %d expected output file(s)\n", action, migrated, total)
+			if failed > 0 {
+				return fmt.Errorf("%d expected output file(s) could not be migrated", failed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&migrateExpectedFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable)")
+	cmd.Flags().BoolVar(&migrateExpectedDryRun, "dry-run", false, "Report the files to migrate without rewriting them")
+
+	return cmd
+}
+
+// expectedOutputFiles returns the expected outputs of a test kept in files, for every matrix variant and
+// application. Outputs shared by several variants are returned once
+func expectedOutputFiles(test *config.TestDefinition) []config.ExpectedOutput {
+	var outputs []config.ExpectedOutput
+	add := func(file config.ExpectedOutput) {
+		if file.File != "" && !slices.ContainsFunc(outputs, func(o config.ExpectedOutput) bool { return o.File == file.File }) {
+			outputs = append(outputs, file)
+		}
+	}
+	for _, variant := range test.MatrixVariants() {
+		add(variant.Expect.Output)
+		for _, app := range variant.Analysis.Applications {
+			add(app.Expect)
+		}
+	}
+	return outputs
+}
+
+// migrateExpectedFile rewrites an expected output file in the current schema version unless it already
+// is, and returns the version it had. With dryRun the file isn't written
+func migrateExpectedFile(path string, dryRun bool) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read expected output file: %w", err)
+	}
+	rulesets, version, err := expected.Parse(data)
+	if err != nil {
+		return 0, err
+	}
+	if version == expected.CurrentVersion || dryRun {
+		return version, nil
+	}
+
+	migrated, err := expected.Marshal(rulesets, strings.EqualFold(filepath.Ext(path), ".json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal expected output: %w", err)
+	}
+	if err := os.WriteFile(path, migrated, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write expected output file: %w", err)
+	}
+	return version, nil
+}
+
+// checkExpectedVersion fails on expected output files of older schema versions the migration changes,
+// whose rulesets differ from the ones compared with the output. Other older files are edited as they are
+func checkExpectedVersion(path string, data []byte) error {
+	rulesets, version, err := expected.Decode(data)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(expected.Migrate(rulesets, version), rulesets) {
+		return fmt.Errorf("%s has expected output version %d, upgrade it with 'koncur migrate-expected' first", path, version)
+	}
+	return nil
+}
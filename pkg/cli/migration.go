@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// runMigrationTest exports the application of a migration test to its tracker and validates the created
+// ticket. Targets that can't export applications skip the test
+func runMigrationTest(ctx context.Context, target targets.Target, test *config.TestDefinition) (*targets.ExecutionResult, []validator.ValidationError, error) {
+	migrator, ok := target.(targets.Migrator)
+	if !ok {
+		progress.Stop(ctx)
+		return nil, nil, &skipError{reasons: []string{fmt.Sprintf("migration tests aren't supported by %s", target.Name())}}
+	}
+
+	result, err := migrator.Migrate(ctx, test)
+	progress.Stop(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("execution failed: %w", err)
+	}
+
+	failures := ticketFailures(test, result.Ticket)
+	if len(failures) == 0 {
+		color.Green("  ✓ Ticket %s created %s", result.Ticket.Reference, result.Ticket.Link)
+		return result, nil, nil
+	}
+	for _, failure := range failures {
+		color.Red("  ✗ %s", failure.Message)
+	}
+	return result, failures, nil
+}
+
+// ticketFailures compares the ticket created by a migration test with the expected one. The summary and
+// issue type are only known for tickets created in the mock Jira
+func ticketFailures(test *config.TestDefinition, ticket *targets.TicketResult) []validator.ValidationError {
+	if ticket.Error != "" {
+		return []validator.ValidationError{{
+			Path:    "ticket",
+			Message: fmt.Sprintf("Ticket wasn't created: %s", ticket.Error),
+			Actual:  ticket.Error,
+		}}
+	}
+
+	expected := test.Expect.Ticket
+	tracker := test.Migration.Tracker
+	var failures []validator.ValidationError
+	if tracker.IsMock() {
+		if ticket.IssueType != tracker.IssueType {
+			failures = append(failures, validator.ValidationError{
+				Path:     "ticket.issueType",
+				Message:  fmt.Sprintf("Ticket is a %s, expected a %s", ticket.IssueType, tracker.IssueType),
+				Expected: tracker.IssueType,
+				Actual:   ticket.IssueType,
+			})
+		}
+		if !strings.Contains(ticket.Summary, expected.Summary) {
+			failures = append(failures, validator.ValidationError{
+				Path:     "ticket.summary",
+				Message:  fmt.Sprintf("Ticket summary %q doesn't contain %q", ticket.Summary, expected.Summary),
+				Expected: expected.Summary,
+				Actual:   ticket.Summary,
+			})
+		}
+	} else if expected.Summary != "" {
+		color.Yellow("  ⚠ Ticket summary not checked, only the mock Jira records it")
+	}
+	if expected.Status != "" && ticket.Status != expected.Status {
+		failures = append(failures, validator.ValidationError{
+			Path:     "ticket.status",
+			Message:  fmt.Sprintf("Ticket status is %s, expected %s", ticket.Status, expected.Status),
+			Expected: expected.Status,
+			Actual:   ticket.Status,
+		})
+	}
+	return failures
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/notify"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// defaultNotifyConfig is auto-discovered when no notification configuration is given
+const defaultNotifyConfig = ".koncur/config/notify.yaml"
+
+// sendNotifications sends the run summary to the configured notifiers
+// Nothing is sent without a notification configuration
+func sendNotifications(run *history.Run, reportURL string) error {
+	log := util.GetLogger()
+
+	path := runNotifyConfig
+	if path == "" {
+		if _, err := os.Stat(defaultNotifyConfig); err != nil {
+			return nil
+		}
+		path = defaultNotifyConfig
+		log.Info("Auto-discovered notification configuration", "file", path)
+	}
+
+	cfg, err := config.LoadNotifyConfig(path)
+	if err != nil {
+		return err
+	}
+
+	summary := notify.SummaryFromRun(run, reportURL)
+	sent, err := notify.Notify(context.Background(), cfg, summary)
+	if err != nil {
+		return err
+	}
+	if !sent {
+		log.Info("Skipping notifications", "when", cfg.GetWhen(), "failures", summary.Failures())
+		return nil
+	}
+
+	fmt.Printf("Sent run summary to %d notifier(s)\n", len(cfg.Notifiers))
+	return nil
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// startProgress shows the progress of running tests on stderr, logs are printed above the status line
+// It returns a nil display with --quiet, and a function restoring the log output
+func startProgress(total int) (*progress.Display, func()) {
+	if quiet {
+		return nil, func() {}
+	}
+	display := progress.NewDisplay(os.Stderr, total)
+	util.SetLogOutput(display)
+	return display, func() {
+		display.Stop()
+		util.SetLogOutput(nil)
+	}
+}
+
+// expectedDurations returns how long each test took when it last passed, to estimate its progress
+func expectedDurations() map[string]time.Duration {
+	runs, err := history.NewStore(history.DefaultDir).Load(10)
+	if err != nil {
+		return nil
+	}
+	return history.LastDurations(runs)
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportRuns       int
+	reportHistoryDir string
+	reportFlakyOnly  bool
+)
+
+// NewReportCmd creates the report command
+func NewReportCmd() *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report on test results",
+		Long:  `Report on test results recorded by previous runs.`,
+	}
+
+	// Subcommands
+	reportCmd.AddCommand(NewReportHistoryCmd())
+
+	return reportCmd
+}
+
+// NewReportHistoryCmd creates the report history command
+func NewReportHistoryCmd() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show pass rates and flaky tests across runs",
+		Long: `Show per-test pass rates over the last N runs recorded by 'koncur run'.
+
+Tests whose results alternate between passing and failing are flagged as flaky.
+Skipped runs don't count towards the pass rate.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runs, err := history.NewStore(reportHistoryDir).Load(reportRuns)
+			if err != nil {
+				return fmt.Errorf("failed to load run history: %w", err)
+			}
+
+			if len(runs) == 0 {
+				fmt.Printf("No run history found in %s\n", reportHistoryDir)
+				return nil
+			}
+
+			printHistoryReport(runs, history.Summarize(runs))
+			return nil
+		},
+	}
+
+	historyCmd.Flags().IntVarP(&reportRuns, "runs", "n", 10, "Number of most recent runs to include (0 for all)")
+	historyCmd.Flags().StringVar(&reportHistoryDir, "history-dir", history.DefaultDir, "Directory containing the run history")
+	historyCmd.Flags().BoolVar(&reportFlakyOnly, "flaky", false, "Only show flaky tests")
+
+	return historyCmd
+}
+
+// printHistoryReport prints a pass rate table and a flaky test summary
+func printHistoryReport(runs []history.Run, stats []history.TestStats) {
+	fmt.Printf("Run history: %d run(s) from %s to %s\n\n",
+		len(runs),
+		runs[0].Timestamp.Format("2006-01-02 15:04"),
+		runs[len(runs)-1].Timestamp.Format("2006-01-02 15:04"))
+
+	nameWidth := len("TEST")
+	for _, s := range stats {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+
+	fmt.Printf("%-*s  %5s  %9s  %s\n", nameWidth, "TEST", "RUNS", "PASS RATE", "RESULTS (oldest first)")
+
+	flaky := 0
+	for _, s := range stats {
+		if s.Flaky() {
+			flaky++
+		} else if reportFlakyOnly {
+			continue
+		}
+
+		rate := "-"
+		if s.Passed+s.Failed > 0 {
+			rate = fmt.Sprintf("%.0f%%", s.PassRate()*100)
+		}
+		fmt.Printf("%-*s  %5d  %9s  %s", nameWidth, s.Name, s.Runs, rate, outcomeTrend(s.Outcomes))
+		if s.Flaky() {
+			color.New(color.FgYellow, color.Bold).Print("  FLAKY")
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	if flaky > 0 {
+		color.Yellow("  ⚠ Flaky: %d of %d test(s)", flaky, len(stats))
+	} else {
+		color.Green("  ✓ No flaky tests in %d test(s)", len(stats))
+	}
+}
+
+// outcomeTrend renders outcomes as a compact sequence of symbols
+func outcomeTrend(outcomes []history.Outcome) string {
+	var b strings.Builder
+	for _, o := range outcomes {
+		switch o {
+		case history.OutcomePassed:
+			b.WriteString(color.GreenString("✓"))
+		case history.OutcomeSkipped:
+			b.WriteString(color.YellowString("⊘"))
+		case history.OutcomeExpectedFailure:
+			b.WriteString(color.YellowString("⚠"))
+		default:
+			b.WriteString(color.RedString("✗"))
+		}
+	}
+	return b.String()
+}
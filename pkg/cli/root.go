@@ -10,6 +10,7 @@ import (
 
 var (
 	verbose bool
+	quiet   bool
 )
 
 // NewRootCmd creates the root command
@@ -22,19 +23,29 @@ for Konveyor tools (Kantra, Tackle, Kai).
 
 Koncur concurs with your expected results!`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			util.InitLogger(verbose)
+			util.InitLogger(verbose, quiet)
 		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Only log errors and don't show the progress of running tests")
+	rootCmd.MarkFlagsMutuallyExclusive("verbose", "quiet")
 
 	// Add subcommands
 	rootCmd.AddCommand(NewRunCmd())
 	rootCmd.AddCommand(NewValidateCmd())
+	rootCmd.AddCommand(NewListCmd())
 	rootCmd.AddCommand(NewGenerateCmd())
+	rootCmd.AddCommand(NewMigrateExpectedCmd())
 	rootCmd.AddCommand(NewCleanCmd())
 	rootCmd.AddCommand(NewConfigCmd())
+	rootCmd.AddCommand(NewReportCmd())
+	rootCmd.AddCommand(NewDispatchCmd())
+	rootCmd.AddCommand(NewEnvCmd())
+	rootCmd.AddCommand(NewWatchCmd())
+	rootCmd.AddCommand(NewStressCmd())
+	rootCmd.AddCommand(NewSoakCmd())
 
 	return rootCmd
 }
@@ -44,6 +55,6 @@ func Execute() {
 	rootCmd := NewRootCmd()
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
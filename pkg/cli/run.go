@@ -2,15 +2,26 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/github"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/output"
 	"github.com/konveyor/test-harness/pkg/parser"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/rules"
 	"github.com/konveyor/test-harness/pkg/targets"
 	"github.com/konveyor/test-harness/pkg/util"
 	"github.com/konveyor/test-harness/pkg/validator"
@@ -19,11 +30,54 @@ import (
 )
 
 var (
-	targetConfigFile string
-	targetType       string
-	runFilter        string
+	targetConfigFile     string
+	targetType           string
+	runFilter            []string
+	runNoHistory         bool
+	runResultFile        string
+	runArtifactsURL      string
+	runArtifactsEndpoint string
+	runID                string
+	runEnvConfig         string
+	runNotifyConfig      string
+	runGitHubReport      string
+	runGitHubRepo        string
+	runGitHubSHA         string
+	runGitHubName        string
+	runTimeout           time.Duration
+	runWorkDir           string
+	runMaxFailures       int
+	runMaxFailureRate    float64
+	runValidationFailure string
+	runOffline           bool
+	runSourceCache       string
+	runStreamThreshold   int
+	runRuleCoverage      string
+	runRuleCoverageFile  string
+	runSeed              string
+
+	// outputStreamThreshold is the output size in bytes above which output is validated while it is read
+	outputStreamThreshold int64 = config.DefaultStreamThreshold << 20
 )
 
+// runSettingFlags maps the run flags overriding settings to their settings
+var runSettingFlags = map[string]string{
+	"target":        config.SettingTarget,
+	"target-config": config.SettingTargetConfig,
+	"artifacts-url": config.SettingArtifactsURL,
+	"notify-config": config.SettingNotifyConfig,
+	"timeout":       config.SettingTimeout,
+	"work-dir":      config.SettingWorkDir,
+	"offline":       config.SettingOffline,
+	"source-cache":  config.SettingSourceCache,
+
+	"stream-threshold": config.SettingStreamThreshold,
+
+	"max-failures":        config.SettingMaxFailures,
+	"max-failure-rate":    config.SettingMaxFailureRate,
+	"validation-failures": config.SettingValidationFailures,
+}
+
 // NewRunCmd creates the run command
 func NewRunCmd() *cobra.Command {
 	runCmd := &cobra.Command{
@@ -35,10 +89,18 @@ You can provide either:
   - A specific test file (test.yaml)
   - A directory containing test files (will search recursively)`,
 		Args: cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: withConfigErrors(func(cmd *cobra.Command, args []string) error {
 			path := args[0]
 			log := util.GetLogger()
 
+			if runGitHubReport != "" && runGitHubReport != github.ModeCheck && runGitHubReport != github.ModeStatus {
+				return fmt.Errorf("invalid --github-report %q, expected %s or %s", runGitHubReport, github.ModeCheck, github.ModeStatus)
+			}
+
+			if err := checkUpdateExpected(); err != nil {
+				return err
+			}
+
 			// Check if path is a file or directory
 			info, err := os.Stat(path)
 			if err != nil {
@@ -47,210 +109,600 @@ You can provide either:
 
 			var testFiles []string
 			if info.IsDir() {
-				// Find all test.yaml files in directory
-				log.Info("Searching for test files", "directory", path)
-				testFiles, err = findTestFiles(path)
+				// Discover test files in directory, applying any filters
+				filters, err := discovery.ParseFilters(runFilter)
 				if err != nil {
-					return fmt.Errorf("failed to find test files: %w", err)
+					return err
 				}
 
-				if len(testFiles) == 0 {
-					return fmt.Errorf("no test files found in %s", path)
+				log.Info("Searching for test files", "directory", path)
+				tests, err := discovery.Discover(path, filters)
+				if err != nil {
+					return fmt.Errorf("failed to find test files: %w", err)
 				}
 
-				log.Info("Found test files", "count", len(testFiles))
-
-				// Filter tests if pattern provided
-				if runFilter != "" {
-					filtered := []string{}
-					for _, tf := range testFiles {
-						testName := filepath.Base(filepath.Dir(tf))
-						if strings.Contains(testName, runFilter) {
-							filtered = append(filtered, tf)
-						}
+				if len(tests) == 0 {
+					if len(filters) > 0 {
+						return fmt.Errorf("no test files matched filter: %s", strings.Join(runFilter, ", "))
 					}
-					testFiles = filtered
-					log.Info("Filtered test files", "count", len(testFiles), "pattern", runFilter)
+					return fmt.Errorf("no test files found in %s", path)
 				}
 
-				if len(testFiles) == 0 {
-					return fmt.Errorf("no test files matched filter: %s", runFilter)
+				for _, test := range tests {
+					testFiles = append(testFiles, test.File)
 				}
+				log.Info("Found test files", "count", len(testFiles))
 			} else {
 				// Single test file
 				testFiles = []string{path}
 			}
 
-			// Load or create target config once for all tests
-			var targetConfig *config.TargetConfig
-			if targetConfigFile != "" {
-				log.Info("Loading target configuration", "file", targetConfigFile)
-				targetConfig, err = config.LoadTargetConfig(targetConfigFile)
-				if err != nil {
-					return fmt.Errorf("failed to load target config: %w", err)
-				}
-			} else if targetType != "" {
-				// Try to auto-discover config file for the specified target type
-				discoveredPath := fmt.Sprintf(".koncur/config/target-%s.yaml", targetType)
-				if _, err := os.Stat(discoveredPath); err == nil {
-					log.Info("Auto-discovered target configuration", "file", discoveredPath)
-					targetConfig, err = config.LoadTargetConfig(discoveredPath)
-					if err != nil {
-						return fmt.Errorf("failed to load auto-discovered target config: %w", err)
-					}
-				} else {
-					// Create default config for specified type
-					targetConfig = &config.TargetConfig{Type: targetType}
-				}
-			} else {
-				// Default to kantra, try to auto-discover first
-				discoveredPath := ".koncur/config/target-kantra.yaml"
-				if _, err := os.Stat(discoveredPath); err == nil {
-					log.Info("Auto-discovered target configuration", "file", discoveredPath)
-					targetConfig, err = config.LoadTargetConfig(discoveredPath)
-					if err != nil {
-						return fmt.Errorf("failed to load auto-discovered target config: %w", err)
-					}
-				} else {
-					// Create default kantra config
-					targetConfig = &config.TargetConfig{Type: "kantra"}
-				}
+			// Resolve the settings and target config once for all tests
+			settings, err := resolveSettings(cmd, runSettingFlags)
+			if err != nil {
+				return err
+			}
+			runArtifactsURL, runNotifyConfig = settings.ArtifactsURL, settings.NotifyConfig
+			outputStreamThreshold = settings.GetStreamThreshold()
+			if err := checkOffline(settings); err != nil {
+				return err
+			}
+			targetConfig, err := loadTargetConfig(settings)
+			if err != nil {
+				return fmt.Errorf("failed to load target config: %w", err)
 			}
 
 			log.Info("Using target", "type", targetConfig.Type)
 
+			// Bring up the Konveyor instance under test, torn down once the outcome is known
+			failCount := 0
+			if runEnvConfig != "" {
+				teardown, err := setUpEnvironment(runEnvConfig)
+				if err != nil {
+					return err
+				}
+				defer func() { teardown(failCount > 0) }()
+			}
+
 			// Create target from config
 			target, err := targets.NewTarget(targetConfig)
 			if err != nil {
 				return fmt.Errorf("failed to create target: %w", err)
 			}
 
+			// Seeded resources are removed once the tests ran
+			if runSeed != "" {
+				unseed, err := seedHub(target, runSeed)
+				if err != nil {
+					return err
+				}
+				defer unseed()
+			}
+
+			// Rules of the rules repository whose coverage is reported, loaded before any test runs
+			var coverageRules []*rules.RuleSet
+			triggered := rules.NewTriggered()
+			if runRuleCoverage != "" {
+				if coverageRules, err = rules.Load(runRuleCoverage); err != nil {
+					return fmt.Errorf("failed to load rules for coverage: %w", err)
+				}
+			}
+
+			// Tests needing features the target's version lacks are skipped
+			capabilities := detectCapabilities(context.Background(), target)
+
 			// Run all tests
 			successCount := 0
-			failCount := 0
 			skippedCount := 0
+			xfailCount := 0
 
-			for i, testFile := range testFiles {
-				testName := filepath.Base(filepath.Dir(testFile))
-				if len(testFiles) > 1 {
-					fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(testFiles), testName)
+			// Record every test outcome for the run history
+			run := &history.Run{
+				Timestamp: time.Now(),
+				Target:    targetConfig.Type,
+			}
+			// Directories holding each test's outputs and logs, uploaded after the run
+			artifactDirs := map[string][]string{}
+			// Validation errors of failed tests, reported to GitHub
+			testFailures := map[string][]validator.ValidationError{}
+
+			// Matrix tests run once per variant
+			testCases := expandTestCases(testFiles)
+
+			// Show the progress of long analyses, estimated from the previous durations of the tests
+			display, stopProgress := startProgress(len(testCases))
+			defer stopProgress()
+			durations := expectedDurations()
+
+			for i, testCase := range testCases {
+				testFile, testName := testCase.File, testCase.Name
+				if len(testCases) > 1 {
+					fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(testCases), testName)
 				}
 
 				// Check if test is marked as skipped
-				if isTestSkipped(testFile) {
+				if discovery.IsSkipped(testFile) {
 					color.Yellow("  ⊘ Skipped (marked as SKIPPED in file)")
 					skippedCount++
+					run.Results = append(run.Results, history.TestResult{
+						Name:    testName,
+						File:    testFile,
+						Outcome: history.OutcomeSkipped,
+						Reason:  "marked as SKIPPED in file",
+					})
 					continue
 				}
 
 				// Run single test
-				passed, err := runSingleTest(testFile, target, targetConfig)
-				if err != nil {
-					color.Red("  ✗ Error: %v", err)
-					failCount++
-					continue
+				display.Start(i+1, testName, durations[testName])
+				ctx := progress.WithReporter(context.Background(), display)
+				testResult, test, result, failures := runTestCase(ctx, testCase, target, targetConfig, settings, capabilities)
+				if result != nil {
+					artifactDirs[testName] = result.ArtifactDirs()
 				}
-
-				if passed {
+				switch testResult.Outcome {
+				case history.OutcomeSkipped:
+					skippedCount++
+				case history.OutcomeExpectedFailure:
+					xfailCount++
+					testFailures[testName] = failures
+				case history.OutcomePassed:
 					successCount++
-				} else {
+					recordTriggeredRules(triggered, test)
+				case history.OutcomeFailed:
+					failCount++
+					testFailures[testName] = failures
+				default:
 					failCount++
 				}
+				run.Results = append(run.Results, testResult)
+			}
+
+			if !runNoHistory {
+				if err := history.NewStore(history.DefaultDir).Append(run); err != nil {
+					log.Info("Warning: failed to record run history", "error", err.Error())
+				}
+			}
+
+			if runResultFile != "" {
+				if err := writeRunResult(runResultFile, run); err != nil {
+					return err
+				}
+			}
+
+			reportURL := ""
+			if runArtifactsURL != "" {
+				reportURL, err = uploadArtifacts(run, artifactDirs)
+				if err != nil {
+					color.Yellow("Warning: failed to upload artifacts: %v", err)
+				}
+			}
+
+			// Notifications are configured per suite, offline runs skip them rather than failing
+			if !settings.Offline {
+				if err := sendNotifications(run, reportURL); err != nil {
+					color.Yellow("Warning: failed to send notifications: %v", err)
+				}
+			}
+
+			if runGitHubReport != "" {
+				if err := reportToGitHub(run, testFailures, reportURL); err != nil {
+					color.Yellow("Warning: failed to report to GitHub: %v", err)
+				}
 			}
 
 			// Print summary if multiple tests
-			if len(testFiles) > 1 {
+			if len(testCases) > 1 {
 				fmt.Println("\n" + strings.Repeat("=", 60))
-				fmt.Printf("Summary: %d total\n", len(testFiles))
+				fmt.Printf("Summary: %d total\n", len(testCases))
 				if successCount > 0 {
 					color.Green("  ✓ Passed: %d", successCount)
 				}
 				if skippedCount > 0 {
 					color.Yellow("  ⊘ Skipped: %d", skippedCount)
 				}
+				if xfailCount > 0 {
+					color.Yellow("  ⚠ Expected failures: %d", xfailCount)
+				}
 				if failCount > 0 {
 					color.Red("  ✗ Failed: %d", failCount)
-					return nil
 				}
-			} else if failCount > 0 {
-				return nil
 			}
 
+			if runRuleCoverage != "" {
+				coverage := rules.Coverage(coverageRules, triggered)
+				printRuleCoverage(coverage)
+				if runRuleCoverageFile != "" {
+					if err := writeRuleCoverage(runRuleCoverageFile, coverage); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Failures beyond the exit policy fail the process, the results were already reported
+			if err := checkExitPolicy(run, settings.ExitPolicy); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
 			return nil
-		},
+		}),
 	}
 
+	// Invalid flags are configuration errors too
+	runCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &exitError{code: exitConfigError, err: err}
+	})
+
 	// Flags
 	runCmd.Flags().StringVarP(&targetConfigFile, "target-config", "c", "", "Path to target configuration file")
-	runCmd.Flags().StringVarP(&targetType, "target", "t", "", "Target type (kantra, tackle-hub, tackle-ui, kai-rpc, vscode)")
-	runCmd.Flags().StringVarP(&runFilter, "filter", "f", "", "Filter tests by name pattern (only applies when running a directory)")
+	runCmd.Flags().StringVarP(&targetType, "target", "t", "", "Target type (kantra, tackle-hub, tackle-ui, kai-rpc, vscode, asset-gen, mock)")
+	runCmd.Flags().StringArrayVarP(&runFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable, only applies when running a directory)")
+	runCmd.Flags().BoolVar(&runNoHistory, "no-history", false, "Don't record test outcomes in the run history")
+	runCmd.Flags().StringVar(&runResultFile, "result-file", "", "Write the test outcomes of the run as JSON to this file")
+	runCmd.Flags().StringVar(&runArtifactsURL, "artifacts-url", "", "Upload each test's work directory to s3://bucket/prefix or gs://bucket/prefix after the run")
+	runCmd.Flags().StringVar(&runArtifactsEndpoint, "artifacts-endpoint", "", "S3 compatible endpoint for artifact upload, e.g. MinIO (default: $AWS_ENDPOINT_URL_S3 or AWS S3)")
+	runCmd.Flags().StringVar(&runNotifyConfig, "notify-config", "", "Path to notification configuration file (default: .koncur/config/notify.yaml if present)")
+	runCmd.Flags().StringVar(&runGitHubReport, "github-report", "", "Report results to a GitHub commit as a check run with annotations (check) or a commit status (status)")
+	runCmd.Flags().StringVar(&runGitHubRepo, "github-repo", "", "GitHub repository to report to as owner/name (default: $GITHUB_REPOSITORY)")
+	runCmd.Flags().StringVar(&runGitHubSHA, "github-sha", "", "Commit to report on (default: $GITHUB_SHA)")
+	runCmd.Flags().StringVar(&runGitHubName, "github-name", "koncur", "Name of the check run or status context")
+	runCmd.Flags().StringVar(&runEnvConfig, "env-config", "", "Install Konveyor as configured in this file before the run and tear it down afterwards")
+	runCmd.Flags().DurationVar(&runTimeout, "timeout", 0, "Timeout of every test, overriding test definitions")
+	runCmd.Flags().StringVar(&runWorkDir, "work-dir", "", "Work directory of every test, overriding test definitions")
+	runCmd.Flags().IntVar(&runMaxFailures, "max-failures", 0, "Fail the run only when more tests fail (default: any failure fails it)")
+	runCmd.Flags().Float64Var(&runMaxFailureRate, "max-failure-rate", 0, "Fail the run only when a larger percentage of the tests run fail")
+	runCmd.Flags().StringVar(&runValidationFailure, "validation-failures", "", "fail to count validation failures against the thresholds, or warn to only count execution errors (default: fail)")
+	runCmd.Flags().BoolVar(&runOffline, "offline", false, "Forbid network access: kantra runs locally with pre-pulled images and repositories come from the source cache")
+	runCmd.Flags().StringVar(&runSourceCache, "source-cache", "", "Directory of pre-seeded repository checkouts used by offline runs, <host>/<path>/<ref>")
+	runCmd.Flags().IntVar(&runStreamThreshold, "stream-threshold", config.DefaultStreamThreshold, "Output size in MiB above which output is validated ruleset by ruleset while it is read")
+	runCmd.Flags().StringVar(&updateExpected, "update-expected", "", "Accept the outputs of failed tests into their expected output files: prompt for each change, or all")
+	runCmd.Flags().StringVar(&runRuleCoverage, "rule-coverage", "", "Report which rules of this rules file or directory fired in the outputs of passed tests")
+	runCmd.Flags().StringVar(&runRuleCoverageFile, "rule-coverage-file", "", "Write the rule coverage report as JSON to this file")
+	runCmd.Flags().StringVar(&runSeed, "seed", "", "Create the tag categories, stakeholders, business services, archetypes and migration waves of this seed file in the Hub before the tests, and remove them after")
+	runCmd.Flags().StringVar(&runID, "run-id", "", "Run ID prefixing uploaded artifacts (default: run timestamp)")
 
 	return runCmd
 }
 
-// runSingleTest executes a single test and returns whether it passed
-func runSingleTest(testFile string, target targets.Target, targetConfig *config.TargetConfig) (bool, error) {
+// runTestCase runs a test case and prints its outcome, returned as the test's record in the run history
+// The progress of the test in the context is stopped before the outcome is printed
+func runTestCase(ctx context.Context, testCase testCase, target targets.Target, targetConfig *config.TargetConfig, settings *config.Settings, capabilities *targetCapabilities) (history.TestResult, *config.TestDefinition, *targets.ExecutionResult, []validator.ValidationError) {
+	testResult := history.TestResult{Name: testCase.Name, File: testCase.File}
+
+	start := time.Now()
+	test, result, failures, err := runSingleTest(ctx, testCase, target, targetConfig, settings, capabilities)
+	progress.Stop(ctx)
+	testResult.Duration = time.Since(start)
+
+	// Known bugs are tracked without failing the run until they're fixed
+	expectedFailure := ""
+	if test != nil {
+		expectedFailure = test.ExpectedFailure
+	}
+	var skip *skipError
+	switch {
+	case errors.As(err, &skip):
+		color.Yellow("  ⊘ Skipped (%s)", skip.Error())
+		testResult.Outcome = history.OutcomeSkipped
+		testResult.Reason = skip.Error()
+	case expectedFailure != "" && (err != nil || len(failures) > 0):
+		color.Yellow("  ⚠ Expected failure (%s)", expectedFailure)
+		testResult.Outcome = history.OutcomeExpectedFailure
+		testResult.Reason = expectedFailure
+		if err != nil {
+			testResult.Error = err.Error()
+		}
+	case expectedFailure != "":
+		color.Red("  ✗ Unexpected pass, remove expectedFailure if %s is fixed", expectedFailure)
+		testResult.Outcome = history.OutcomeUnexpectedPass
+		testResult.Reason = expectedFailure
+	case err != nil:
+		color.Red("  ✗ Error: %v", err)
+		testResult.Outcome = history.OutcomeError
+		testResult.Error = err.Error()
+	case len(failures) == 0:
+		testResult.Outcome = history.OutcomePassed
+	default:
+		testResult.Outcome = history.OutcomeFailed
+	}
+	// Known failure signatures in the output explain failed executions
+	if testResult.Outcome == history.OutcomeError || testResult.Outcome == history.OutcomeFailed ||
+		testResult.Outcome == history.OutcomeExpectedFailure {
+		if diagnosis := diagnoseFailure(result, failures, err); diagnosis != nil {
+			color.Yellow("    Probable cause: %s", diagnosis.Cause)
+			color.Yellow("    Hint: %s", diagnosis.Hint)
+			testResult.Diagnosis = diagnosis.String()
+		}
+	}
+	return testResult, test, result, failures
+}
+
+// writeRunResult writes the outcomes of a run as JSON, creating the file's directory
+func writeRunResult(path string, run *history.Run) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create result directory: %w", err)
+	}
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write result file: %w", err)
+	}
+	return nil
+}
+
+// testCase is one execution of a test file, matrix tests have one per variant
+type testCase struct {
+	File string
+	// Name is the directory containing the test file, followed by the variant for matrix tests
+	Name string
+	// Variant is the name of the matrix variant to run, empty without a matrix
+	Variant string
+}
+
+// expandTestCases returns the test cases of test files, expanding matrix tests into their variants
+// Files that can't be loaded are kept as a single case, the error is reported when it runs
+func expandTestCases(testFiles []string) []testCase {
+	var cases []testCase
+	for _, testFile := range testFiles {
+		name := filepath.Base(filepath.Dir(testFile))
+		test, err := config.LoadWithOptions(testFile, true)
+		if err != nil || !test.IsMatrix() {
+			cases = append(cases, testCase{File: testFile, Name: name})
+			continue
+		}
+		for _, v := range test.Matrix.Variants() {
+			cases = append(cases, testCase{File: testFile, Name: fmt.Sprintf("%s-%s", name, v.Name()), Variant: v.Name()})
+		}
+	}
+	return cases
+}
+
+// checkOffline fails offline runs using options that need network access, before any test runs
+func checkOffline(settings *config.Settings) error {
+	if !settings.Offline {
+		return nil
+	}
+	switch {
+	case settings.ArtifactsURL != "":
+		return fmt.Errorf("offline runs can't upload artifacts to %s", settings.ArtifactsURL)
+	case runGitHubReport != "":
+		return fmt.Errorf("offline runs can't report to GitHub")
+	case runEnvConfig != "":
+		return fmt.Errorf("offline runs can't install Konveyor with --env-config")
+	}
+	return nil
+}
+
+// runSingleTest executes a single test and returns its definition, execution result and validation failures
+// The test passed when there are no failures, the result is nil when the test couldn't be executed and
+// the definition is nil when it couldn't be loaded or is invalid
+// Skipped tests and tests the target can't run return a *skipError
+func runSingleTest(ctx context.Context, testCase testCase, target targets.Target, targetConfig *config.TargetConfig, settings *config.Settings, capabilities *targetCapabilities) (*config.TestDefinition, *targets.ExecutionResult, []validator.ValidationError, error) {
 	// Load test definition
-	test, err := config.Load(testFile)
+	test, err := config.Load(testCase.File)
 	if err != nil {
-		return false, fmt.Errorf("failed to load test: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to load test: %w", err)
 	}
+	settings.Apply(test)
 
 	// Validate test definition
 	if err := config.Validate(test); err != nil {
-		return false, fmt.Errorf("invalid test definition: %w", err)
+		return nil, nil, nil, fmt.Errorf("invalid test definition: %w", err)
+	}
+
+	// Select the matrix variant and load its expected output
+	if testCase.Variant != "" {
+		if test, err = test.FindVariant(testCase.Variant); err != nil {
+			return nil, nil, nil, err
+		}
+		if err := config.LoadExpectedOutputs(test); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to load test: %w", err)
+		}
+	}
+
+	if test.Skip != "" {
+		return test, nil, nil, &skipError{reasons: []string{test.Skip}}
 	}
+	if reasons := capabilities.unsupported(test); len(reasons) > 0 {
+		return test, nil, nil, &skipError{reasons: reasons}
+	}
+
+	// Cancellation tests validate the cancellation instead of the output
+	if test.IsCancelTest() {
+		result, failures, err := runCancelTest(ctx, target, test)
+		return test, result, failures, err
+	}
+	// Assessment tests validate the risk of an assessment, they don't analyze
+	if test.IsAssessmentTest() {
+		result, failures, err := runAssessmentTest(ctx, target, test)
+		return test, result, failures, err
+	}
+	// Migration tests validate the ticket an application was exported to, they don't analyze
+	if test.IsMigrationTest() {
+		result, failures, err := runMigrationTest(ctx, target, test)
+		return test, result, failures, err
+	}
+
+	// Stale expected outputs are reported before the analysis runs, the test still runs
+	printCoverageWarnings(test)
 
 	// Execute the test
-	result, err := target.Execute(context.Background(), test)
+	result, err := target.Execute(ctx, test)
+	progress.Stop(ctx)
 	if err != nil {
-		return false, fmt.Errorf("execution failed: %w", err)
+		return test, nil, nil, fmt.Errorf("execution failed: %w", err)
+	}
+
+	// Tests expecting the analysis to run out of memory pass once it does, its output is incomplete
+	if result.OOMKilled != test.Expect.OOMKilled {
+		message := "Analysis ran out of memory"
+		if test.Expect.OOMKilled {
+			message = "Expected the analysis to run out of memory"
+		}
+		color.Red("  ✗ %s", message)
+		return test, result, []validator.ValidationError{{
+			Path:     "oomKilled",
+			Message:  message,
+			Expected: test.Expect.OOMKilled,
+			Actual:   result.OOMKilled,
+		}}, nil
+	}
+	if result.OOMKilled {
+		return test, result, nil, nil
 	}
 
 	// Check exit code
 	if result.ExitCode != test.Expect.ExitCode {
-		color.Red("  ✗ Exit code mismatch: expected %d, got %d", test.Expect.ExitCode, result.ExitCode)
-		return false, nil
+		message := fmt.Sprintf("Exit code mismatch: expected %d, got %d", test.Expect.ExitCode, result.ExitCode)
+		// Point at the analyzer log when the target kept one, or else at the command's stderr
+		if log := result.Artifacts[targets.ArtifactAnalysisLog]; log != "" {
+			message += fmt.Sprintf(", see %s", log)
+		} else if log := result.Artifacts[targets.ArtifactStderr]; log != "" {
+			message += fmt.Sprintf(", see %s", log)
+		}
+		color.Red("  ✗ %s", message)
+		return test, result, []validator.ValidationError{{
+			Path:     "exitCode",
+			Message:  message,
+			Expected: test.Expect.ExitCode,
+			Actual:   result.ExitCode,
+		}}, nil
 	}
 
-	// Parse the output
-	actualOutput, err := parser.ParseOutput(result.OutputFile)
+	// Get target type for validation
+	tgtType := ""
+	if targetConfig != nil {
+		tgtType = targetConfig.Type
+	}
+
+	failures, err := validateResult(test, result, tgtType)
+	if err == nil && len(failures) > 0 {
+		updateExpectedOutputs(test, result)
+	}
+	return test, result, failures, err
+}
+
+// diagnoseFailure returns the probable cause of a test that couldn't run or whose analysis failed, nil
+// when it is unknown. Outputs of analyses that succeeded aren't diagnosed, they may log recovered errors
+func diagnoseFailure(result *targets.ExecutionResult, failures []validator.ValidationError, err error) *targets.Diagnosis {
 	if err != nil {
-		return false, fmt.Errorf("failed to parse output: %w", err)
+		return targets.Diagnose(err.Error())
+	}
+	if result == nil || len(failures) == 0 {
+		return nil
 	}
+	switch failures[0].Path {
+	case "exitCode", "oomKilled":
+		return result.Diagnose()
+	}
+	return nil
+}
 
-	// Filter actual output to match how expected output is filtered during generation
-	filteredActual := parser.FilterRuleSets(actualOutput)
+// validateResult validates the outputs of an execution result against the test's expected output
+// The test passed when no validation errors are returned
+func validateResult(test *config.TestDefinition, result *targets.ExecutionResult, tgtType string) ([]validator.ValidationError, error) {
+	// Asset and fix tests compare generated files instead of analysis output
+	if test.IsAssetTest() {
+		return validateAssets(test.ResolvePath(test.Expect.Assets), result)
+	}
+	if test.IsFixTest() {
+		return validatePatches(test.ResolvePath(test.Expect.Patches), test.Expect.GetPatchSimilarity(), result)
+	}
 
-	// Normalize paths in actual output to match expected output format
-	normalizedActual, err := normalizeRuleSetPaths(filteredActual, test.GetTestDir())
+	// Tolerance options were checked when the test was validated
+	opts, err := test.Expect.Tolerance.ValidatorOptions()
 	if err != nil {
-		return false, fmt.Errorf("failed to normalize paths: %w", err)
+		return nil, fmt.Errorf("invalid tolerance: %w", err)
 	}
+	// Incident paths through symlinks in the work directory match the linked sources
+	opts.SourceRoot = result.WorkDir
+	opts.RuleSets = test.Expect.ValidateOnly
+	opts.ExcludedPaths = test.Analysis.ExcludedPaths
 
-	// Get target type for validation
-	tgtType := ""
-	if targetConfig != nil {
-		tgtType = targetConfig.Type
+	// Rules labeled konveyor.io/include are checked whatever the expected output lists
+	includes, err := loadLocalRules(test)
+	if err != nil {
+		color.Yellow("  ⚠ Include labels not checked: %v", err)
 	}
 
-	// Validate against expected output using the filtered file
-	validation, err := validator.ValidateFiles(test.GetTestDir(), tgtType, normalizedActual, test.Expect.Output.Result)
+	// Multi-application tests validate each application's output separately
+	if test.IsMultiApplication() {
+		var failures []validator.ValidationError
+		for _, app := range test.Analysis.Applications {
+			outputFile, ok := result.ApplicationOutputFile(app.Name)
+			if !ok {
+				return nil, fmt.Errorf("no output found for application %s", app.Name)
+			}
+
+			fmt.Printf("  Application: %s\n", app.Name)
+			appFailures, err := validateOutput(outputFile, app.Expect.Result, test.GetTestDir(), tgtType, opts, includes, result)
+			if err != nil {
+				return nil, fmt.Errorf("application %s: %w", app.Name, err)
+			}
+			for _, failure := range appFailures {
+				failure.Path = fmt.Sprintf("%s: %s", app.Name, failure.Path)
+				failures = append(failures, failure)
+			}
+		}
+		customFailures, err := customTargetFailures(test, result)
+		if err != nil {
+			return nil, err
+		}
+		return append(failures, customFailures...), nil
+	}
+
+	failures, err := validateOutput(result.OutputFile(), test.Expect.Output.Result, test.GetTestDir(), tgtType, opts, includes, result)
 	if err != nil {
-		return false, fmt.Errorf("validation error: %w", err)
+		return nil, err
 	}
+	customFailures, err := customTargetFailures(test, result)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, customFailures...)
+	artifactFailures, err := validateArtifacts(test, result)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, artifactFailures...)
+	return append(failures, validateArchetypes(test.Expect.Archetypes, result)...), nil
+}
+
+// validateOutput parses an output file, validates it against the expected rulesets and reports the result
+// Outputs larger than the stream threshold are validated ruleset by ruleset while they are read. The rules
+// of the includes rulesets labeled konveyor.io/include are checked separately from the expected rulesets
+func validateOutput(outputFile string, expected []konveyor.RuleSet, testDir, tgtType string, opts validator.Options, includes []*rules.RuleSet, result *targets.ExecutionResult) ([]validator.ValidationError, error) {
+	var validation *validator.ValidationResult
+	var filtered, total int
+	var err error
+	checker := rules.NewIncludeChecker(includes)
+	if info, statErr := os.Stat(outputFile); statErr == nil && info.Size() > outputStreamThreshold {
+		validation, filtered, total, err = streamValidateOutput(outputFile, expected, testDir, tgtType, opts, checker)
+	} else {
+		validation, filtered, total, err = loadValidateOutput(outputFile, expected, testDir, tgtType, opts, checker)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, failure := range checker.Failures() {
+		validation.Errors = append(validation.Errors, includeError(failure))
+	}
+	validation.Passed = len(validation.Errors) == 0
 
 	// Report results
 	if validation.Passed {
 		green := color.New(color.FgGreen, color.Bold)
 		green.Printf("  ✓ PASSED")
-		fmt.Printf(" - Duration: %s, RuleSets: %d (filtered from %d)\n", result.Duration, len(filteredActual), len(actualOutput))
-		return true, nil
+		fmt.Printf(" - Duration: %s, RuleSets: %d (filtered from %d)\n", result.Duration, filtered, total)
+		return nil, nil
 	}
 
 	// Test failed
 	red := color.New(color.FgRed, color.Bold)
 	red.Println("  ✗ FAILED")
+	fmt.Printf("    Matched %d of %d expected ruleset(s)\n", len(validation.Matched), len(validator.SelectRuleSets(expected, opts.RuleSets)))
 
 	// Print validation errors in a pretty format
 	if len(validation.Errors) > 0 {
@@ -267,7 +719,153 @@ func runSingleTest(testFile string, target targets.Target, targetConfig *config.
 		fmt.Println()
 	}
 
-	return false, nil
+	return validation.Errors, nil
+}
+
+// loadValidateOutput loads an output file and validates it, returning the number of rulesets left after
+// filtering and the total number of rulesets. The checker is given the rulesets before they are filtered
+func loadValidateOutput(outputFile string, expected []konveyor.RuleSet, testDir, tgtType string, opts validator.Options, checker *rules.IncludeChecker) (*validator.ValidationResult, int, int, error) {
+	// Parse the output
+	actualOutput, err := output.Load(outputFile)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to parse output: %w", err)
+	}
+	for _, rs := range actualOutput {
+		checker.Add(rs)
+	}
+
+	// Filter actual output to match how expected output is filtered during generation
+	filteredActual := parser.FilterRuleSets(actualOutput)
+
+	// Normalize paths in actual output to match expected output format
+	normalizedActual, err := normalizeRuleSetPaths(filteredActual, testDir)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to normalize paths: %w", err)
+	}
+
+	// Validate against expected output using the filtered file
+	validation, err := validator.ValidateWithOptions(testDir, tgtType, normalizedActual, expected, opts)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("validation error: %w", err)
+	}
+	return validation, len(filteredActual), len(actualOutput), nil
+}
+
+// streamValidateOutput validates an output file ruleset by ruleset while reading it, so only one actual
+// ruleset is held in memory. Rulesets are filtered and normalized like loaded output
+func streamValidateOutput(outputFile string, expected []konveyor.RuleSet, testDir, tgtType string, opts validator.Options, checker *rules.IncludeChecker) (*validator.ValidationResult, int, int, error) {
+	fmt.Printf("  Streaming large output: %s\n", outputFile)
+	v := validator.NewStreamValidator(testDir, tgtType, expected, opts)
+	filtered, total := 0, 0
+	err := output.Stream(outputFile, func(rs konveyor.RuleSet) error {
+		total++
+		checker.Add(rs)
+		kept := parser.FilterRuleSets([]konveyor.RuleSet{rs})
+		if len(kept) == 0 {
+			return nil
+		}
+		normalized, err := normalizeRuleSetPaths(kept, testDir)
+		if err != nil {
+			return fmt.Errorf("failed to normalize paths: %w", err)
+		}
+		for _, n := range normalized {
+			filtered++
+			v.Add(n)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to parse output: %w", err)
+	}
+	return v.Result(), filtered, total, nil
+}
+
+// validateAssets validates generated assets against the expected assets directory and reports the result
+func validateAssets(expectedDir string, result *targets.ExecutionResult) ([]validator.ValidationError, error) {
+	if result.AssetsDir == "" {
+		return nil, fmt.Errorf("target did not generate assets")
+	}
+
+	validation, err := validator.ValidateAssets(expectedDir, result.AssetsDir)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	return reportFileValidation(validation, fmt.Sprintf("Assets: %s", result.AssetsDir), result), nil
+}
+
+// validateArtifacts compares the artifacts of expect.artifacts with their expected files, using the
+// validator registered for each artifact kind
+func validateArtifacts(test *config.TestDefinition, result *targets.ExecutionResult) ([]validator.ValidationError, error) {
+	var failures []validator.ValidationError
+	for _, kind := range slices.Sorted(maps.Keys(test.Expect.Artifacts)) {
+		// Kinds without a validator were rejected when the test was validated
+		v, _ := validator.ArtifactValidatorFor(kind)
+		actual := result.Artifacts[targets.ArtifactKind(kind)]
+		if actual == "" {
+			color.Red("  ✗ Artifact %s: not produced by the target", kind)
+			failures = append(failures, validator.ValidationError{
+				Path:    fmt.Sprintf("artifacts/%s", kind),
+				Message: fmt.Sprintf("Target did not produce the %s artifact", kind),
+			})
+			continue
+		}
+
+		diffs, err := v.Validate(test.ResolvePath(test.Expect.Artifacts[kind]), actual)
+		if err != nil {
+			return nil, fmt.Errorf("artifact %s: %w", kind, err)
+		}
+		for i := range diffs {
+			diffs[i].Path = fmt.Sprintf("artifacts/%s%s", kind, strings.TrimSuffix(diffs[i].Path, "/"))
+		}
+		if len(diffs) == 0 {
+			color.Green("  ✓ Artifact %s matches %s", kind, test.Expect.Artifacts[kind])
+			continue
+		}
+		color.Red("  ✗ Artifact %s: %d difference(s) from %s", kind, len(diffs), test.Expect.Artifacts[kind])
+		for i, diff := range diffs {
+			diff.Print(i + 1)
+		}
+		failures = append(failures, diffs...)
+	}
+	return failures, nil
+}
+
+// validatePatches validates Kai patches against the expected patches directory and reports the result
+func validatePatches(expectedDir string, minSimilarity float64, result *targets.ExecutionResult) ([]validator.ValidationError, error) {
+	if result.PatchesDir == "" {
+		return nil, fmt.Errorf("target did not return patches")
+	}
+
+	validation, err := validator.ValidatePatches(expectedDir, result.PatchesDir, minSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	return reportFileValidation(validation, fmt.Sprintf("Patches: %s", result.PatchesDir), result), nil
+}
+
+// reportFileValidation prints the result of validating generated files and returns its errors
+func reportFileValidation(validation *validator.ValidationResult, summary string, result *targets.ExecutionResult) []validator.ValidationError {
+	if validation.Passed {
+		green := color.New(color.FgGreen, color.Bold)
+		green.Printf("  ✓ PASSED")
+		fmt.Printf(" - Duration: %s, %s\n", result.Duration, summary)
+		return nil
+	}
+
+	red := color.New(color.FgRed, color.Bold)
+	red.Println("  ✗ FAILED")
+	fmt.Printf("\n    Found %d validation error(s):\n\n", len(validation.Errors))
+	for i, err := range validation.Errors {
+		err.Print(i + 1)
+		if i < len(validation.Errors)-1 {
+			fmt.Println()
+		}
+	}
+	fmt.Println()
+
+	return validation.Errors
 }
 
 // normalizeRuleSetPaths normalizes file paths in rulesets to match the expected output format
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/seed"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+// seedHub creates the resources of a seed file in the Hub of the target before the tests, it returns a
+// function removing them
+func seedHub(target targets.Target, path string) (func(), error) {
+	hub, ok := target.(*targets.TackleHubTarget)
+	if !ok {
+		return nil, fmt.Errorf("--seed fills a Hub, the %s target isn't supported", target.Name())
+	}
+	s, err := seed.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	seeded, err := seed.Apply(hub.Client(), s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed the Hub: %w", err)
+	}
+	return func() {
+		if err := seeded.Remove(); err != nil {
+			color.Yellow("Warning: failed to remove the seeded resources: %v", err)
+		}
+	}, nil
+}
+
+// validateArchetypes checks that the analyzed application belongs to exactly the expected archetypes
+// Targets without archetypes aren't checked
+func validateArchetypes(expected []string, result *targets.ExecutionResult) []validator.ValidationError {
+	if len(expected) == 0 {
+		return nil
+	}
+	if result.Archetypes == nil {
+		color.Yellow("  ⚠ Archetypes not checked, the target doesn't associate applications with archetypes")
+		return nil
+	}
+
+	var failures []validator.ValidationError
+	for _, name := range expected {
+		if !slices.Contains(result.Archetypes, name) {
+			failures = append(failures, validator.ValidationError{
+				Path:    "archetypes",
+				Message: fmt.Sprintf("Application doesn't belong to archetype %s", name),
+			})
+		}
+	}
+	for _, name := range result.Archetypes {
+		if !slices.Contains(expected, name) {
+			failures = append(failures, validator.ValidationError{
+				Path:    "archetypes",
+				Message: fmt.Sprintf("Application unexpectedly belongs to archetype %s", name),
+			})
+		}
+	}
+	return failures
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// resolveSettings resolves the harness settings of a command from the global and suite configuration files,
+// KONCUR_* environment variables and flags. flags maps flag names to the settings they set, a flag only
+// overrides the other layers when it is given
+func resolveSettings(cmd *cobra.Command, flags map[string]string) (*config.Settings, error) {
+	given := map[string]string{}
+	for flag, setting := range flags {
+		if cmd.Flags().Changed(flag) {
+			given[setting] = cmd.Flags().Lookup(flag).Value.String()
+		}
+	}
+	return config.ResolveSettings(config.SettingSources{
+		GlobalFile: config.DefaultGlobalConfigFile(),
+		SuiteFile:  config.SuiteConfigFile,
+		Flags:      given,
+	})
+}
+
+// loadTargetConfig resolves the target configuration of the settings
+func loadTargetConfig(settings *config.Settings) (*config.TargetConfig, error) {
+	if file := settings.TargetConfigFile(); file != "" {
+		util.GetLogger().Info("Loading target configuration", "file", file)
+	}
+	return settings.ResolveTargetConfig()
+}
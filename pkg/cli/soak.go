@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/soak"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	soakTargetConfig   string
+	soakFilter         []string
+	soakInterval       time.Duration
+	soakDuration       time.Duration
+	soakMinSuccessRate float64
+	soakHubMetricsURL  string
+	soakReport         string
+)
+
+// soakDriftWarning is the growth of a measure between the first and the last iteration that is highlighted
+const soakDriftWarning = 0.2
+
+// soakSettingFlags maps the soak flags overriding settings to their settings
+var soakSettingFlags = map[string]string{
+	"target-config": config.SettingTargetConfig,
+}
+
+// NewSoakCmd creates the soak command
+func NewSoakCmd() *cobra.Command {
+	soakCmd := &cobra.Command{
+		Use:   "soak <test-file-or-directory>",
+		Short: "Repeat tests over hours to check longevity",
+		Long: `Run tests every --interval until --duration has passed, e.g. every 30 minutes for
+12 hours before a release.
+
+Each iteration records the duration and outcome of every test, the peak memory
+of the analyses run locally and, with --hub-metrics-url, the memory of the Hub.
+The drift of these measures between the first and the last iteration is
+reported, and the soak fails when the share of successful tests over all
+iterations is below --min-success-rate.`,
+		Args: cobra.ExactArgs(1),
+		RunE: withConfigErrors(func(cmd *cobra.Command, args []string) error {
+			log := util.GetLogger()
+
+			if soakInterval <= 0 || soakDuration <= 0 {
+				return fmt.Errorf("--interval and --duration must be positive")
+			}
+			if soakMinSuccessRate < 0 || soakMinSuccessRate > 1 {
+				return fmt.Errorf("--min-success-rate must be between 0 and 1")
+			}
+
+			path := args[0]
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("failed to stat path: %w", err)
+			}
+			testFiles := []string{path}
+			if info.IsDir() {
+				filters, err := discovery.ParseFilters(soakFilter)
+				if err != nil {
+					return err
+				}
+				tests, err := discovery.Discover(path, filters)
+				if err != nil {
+					return fmt.Errorf("failed to find test files: %w", err)
+				}
+				if len(tests) == 0 {
+					return fmt.Errorf("no test files found in %s", path)
+				}
+				testFiles = testFiles[:0]
+				for _, test := range tests {
+					testFiles = append(testFiles, test.File)
+				}
+			}
+
+			settings, err := resolveSettings(cmd, soakSettingFlags)
+			if err != nil {
+				return err
+			}
+			outputStreamThreshold = settings.GetStreamThreshold()
+			targetConfig, err := loadTargetConfig(settings)
+			if err != nil {
+				return fmt.Errorf("failed to load target config: %w", err)
+			}
+			target, err := targets.NewTarget(targetConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create target: %w", err)
+			}
+			capabilities := detectCapabilities(context.Background(), target)
+			testCases := expandTestCases(testFiles)
+
+			// An interrupted soak still reports the iterations that finished
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			schedule := soak.Schedule{Interval: soakInterval, Duration: soakDuration}
+			report := &soak.Report{Start: time.Now()}
+			end := schedule.End(report.Start)
+			log.Info("Starting soak", "tests", len(testCases), "interval", soakInterval, "duration", soakDuration)
+
+			for next := report.Start; next.Before(end); next = schedule.Next(next, time.Now()) {
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Until(next)):
+				}
+				if ctx.Err() != nil {
+					break
+				}
+				iteration := runSoakIteration(ctx, len(report.Iterations)+1, testCases, target, targetConfig, settings, capabilities)
+				if ctx.Err() != nil {
+					break
+				}
+				report.Iterations = append(report.Iterations, iteration)
+			}
+
+			printSoakSummary(report)
+			if soakReport != "" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(soakReport, data, 0644); err != nil {
+					return fmt.Errorf("failed to write soak report: %w", err)
+				}
+				fmt.Printf("\nSoak report written to %s\n", soakReport)
+			}
+
+			if rate := report.SuccessRate(); rate < soakMinSuccessRate {
+				cmd.SilenceUsage = true
+				return &exitError{
+					code: exitValidationFailure,
+					err:  fmt.Errorf("%.1f%% of the tests succeeded, below the minimum success rate of %.1f%%", rate*100, soakMinSuccessRate*100),
+				}
+			}
+			return nil
+		}),
+	}
+
+	soakCmd.Flags().StringVarP(&soakTargetConfig, "target-config", "c", "", "Path to target configuration file")
+	soakCmd.Flags().StringArrayVarP(&soakFilter, "filter", "f", nil, "Filter tests by name pattern, name=, name~, tag= or target= expression (repeatable, only applies when running a directory)")
+	soakCmd.Flags().DurationVar(&soakInterval, "interval", 30*time.Minute, "Time between the starts of two iterations, iterations taking longer are followed right away")
+	soakCmd.Flags().DurationVar(&soakDuration, "duration", 12*time.Hour, "How long iterations are started for")
+	soakCmd.Flags().Float64Var(&soakMinSuccessRate, "min-success-rate", 1, "Share of test runs over all iterations that must succeed, between 0 and 1")
+	soakCmd.Flags().StringVar(&soakHubMetricsURL, "hub-metrics-url", "", "Prometheus metrics endpoint of the Hub whose memory is recorded after each iteration")
+	soakCmd.Flags().StringVar(&soakReport, "report", "", "Write the iterations and drifts to a JSON file")
+
+	return soakCmd
+}
+
+// runSoakIteration runs every test case once and records their outcomes and the memory used
+func runSoakIteration(ctx context.Context, index int, testCases []testCase, target targets.Target, targetConfig *config.TargetConfig, settings *config.Settings, capabilities *targetCapabilities) soak.Iteration {
+	iteration := soak.Iteration{Index: index, Start: time.Now()}
+	fmt.Printf("\n%s\nIteration %d started at %s\n", strings.Repeat("=", 60), index, iteration.Start.Format("15:04:05"))
+
+	display, stopProgress := startProgress(len(testCases))
+	defer stopProgress()
+	for i, testCase := range testCases {
+		if ctx.Err() != nil {
+			break
+		}
+		fmt.Printf("\n[%d/%d] Running: %s\n", i+1, len(testCases), testCase.Name)
+		if discovery.IsSkipped(testCase.File) {
+			color.Yellow("  ⊘ Skipped (marked as SKIPPED in file)")
+			iteration.Results = append(iteration.Results, history.TestResult{
+				Name:    testCase.Name,
+				File:    testCase.File,
+				Outcome: history.OutcomeSkipped,
+				Reason:  "marked as SKIPPED in file",
+			})
+			continue
+		}
+		display.Start(i+1, testCase.Name, 0)
+		testResult, _, result, _ := runTestCase(progress.WithReporter(ctx, display), testCase, target, targetConfig, settings, capabilities)
+		if result != nil {
+			iteration.PeakMemory = max(iteration.PeakMemory, result.PeakMemory)
+		}
+		iteration.Results = append(iteration.Results, testResult)
+	}
+	iteration.Duration = time.Since(iteration.Start)
+
+	if soakHubMetricsURL != "" {
+		memory, err := soak.ScrapeMemory(ctx, soakHubMetricsURL)
+		if err != nil {
+			color.Yellow("  ⚠ Failed to record the Hub memory: %v", err)
+		}
+		iteration.HubMemory = memory
+	}
+	fmt.Printf("\nIteration %d took %s, %.0f%% of the tests succeeded\n", index, iteration.Duration.Round(time.Second), iteration.SuccessRate()*100)
+	return iteration
+}
+
+// printSoakSummary prints the success rate of every iteration followed by the drifts of the soak
+func printSoakSummary(report *soak.Report) {
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Printf("Soak: %d iterations in %s\n", len(report.Iterations), time.Since(report.Start).Round(time.Second))
+	for _, it := range report.Iterations {
+		line := fmt.Sprintf("  Iteration %d at %s: %.0f%% succeeded in %s", it.Index, it.Start.Format("15:04:05"), it.SuccessRate()*100, it.Duration.Round(time.Second))
+		if it.SuccessRate() < 1 {
+			color.Red("%s", line)
+		} else {
+			color.Green("%s", line)
+		}
+	}
+
+	if drifts := report.Drifts(); len(drifts) > 0 {
+		fmt.Println("\nDrift from the first to the last iteration:")
+		for _, d := range drifts {
+			if d.Change() > soakDriftWarning {
+				color.Yellow("  ⚠ %s", d)
+			} else {
+				fmt.Printf("  %s\n", d)
+			}
+		}
+	}
+	fmt.Printf("\nSuccess rate: %.1f%%\n", report.SuccessRate()*100)
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stressTargetConfig   string
+	stressTasks          int
+	stressDistinctApps   bool
+	stressTimeout        time.Duration
+	stressMaxFailureRate float64
+	stressReport         string
+)
+
+// stressSettingFlags maps the stress flags overriding settings to their settings
+var stressSettingFlags = map[string]string{
+	"target-config": config.SettingTargetConfig,
+}
+
+// NewStressCmd creates the stress command
+func NewStressCmd() *cobra.Command {
+	stressCmd := &cobra.Command{
+		Use:   "stress <test-file>",
+		Short: "Submit concurrent analyses of a test to one Hub",
+		Long: `Submit several analysis tasks of a test to one Tackle Hub at once and check that
+all of them complete.
+
+The tasks analyze the test's application, or one copy of the application per
+task with --distinct-applications. The time each task was queued, how many
+tasks ran at once and which tasks the scheduler postponed are reported with
+the failure rate.`,
+		Args: cobra.ExactArgs(1),
+		RunE: withConfigErrors(func(cmd *cobra.Command, args []string) error {
+			log := util.GetLogger()
+
+			if stressTasks < 1 {
+				return fmt.Errorf("--tasks must be at least 1")
+			}
+			if stressMaxFailureRate < 0 || stressMaxFailureRate > 1 {
+				return fmt.Errorf("--max-failure-rate must be between 0 and 1")
+			}
+
+			settings, err := resolveSettings(cmd, stressSettingFlags)
+			if err != nil {
+				return err
+			}
+			test, err := config.Load(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to load test: %w", err)
+			}
+			settings.Apply(test)
+			if err := config.Validate(test); err != nil {
+				return fmt.Errorf("invalid test definition: %w", err)
+			}
+
+			targetConfig, err := loadTargetConfig(settings)
+			if err != nil {
+				return fmt.Errorf("failed to load target config: %w", err)
+			}
+			if targetConfig.Type != "tackle-hub" {
+				return fmt.Errorf("stress submits tasks to a Hub, the %s target isn't supported", targetConfig.Type)
+			}
+			target, err := targets.NewTarget(targetConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create target: %w", err)
+			}
+			hub, ok := target.(*targets.TackleHubTarget)
+			if !ok {
+				return fmt.Errorf("stress submits tasks to a Hub, %s isn't one", target.Name())
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			timeout := stressTimeout
+			if timeout == 0 {
+				timeout = test.GetTimeout()
+			}
+			log.Info("Starting stress run", "test", test.Name, "tasks", stressTasks, "distinctApplications", stressDistinctApps)
+			result, err := hub.Stress(ctx, test, targets.StressOptions{
+				Tasks:                stressTasks,
+				DistinctApplications: stressDistinctApps,
+				Timeout:              timeout,
+			})
+			if err != nil {
+				return &exitError{code: exitExecutionError, err: err}
+			}
+
+			printStressSummary(result)
+			if stressReport != "" {
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(stressReport, data, 0644); err != nil {
+					return fmt.Errorf("failed to write stress report: %w", err)
+				}
+				fmt.Printf("\nStress report written to %s\n", stressReport)
+			}
+
+			if result.FailureRate() > stressMaxFailureRate {
+				return &exitError{
+					code: exitValidationFailure,
+					err:  fmt.Errorf("%d of %d tasks didn't succeed, exceeding the maximum failure rate of %.0f%%", result.Failed(), len(result.Tasks), stressMaxFailureRate*100),
+				}
+			}
+			return nil
+		}),
+	}
+
+	stressCmd.Flags().StringVarP(&stressTargetConfig, "target-config", "c", "", "Path to Tackle Hub target configuration file")
+	stressCmd.Flags().IntVarP(&stressTasks, "tasks", "n", 5, "Number of analysis tasks submitted at once")
+	stressCmd.Flags().BoolVar(&stressDistinctApps, "distinct-applications", false, "Analyze one copy of the application per task instead of a shared application")
+	stressCmd.Flags().DurationVar(&stressTimeout, "timeout", 0, "How long all tasks may take to finish (default: the test's timeout)")
+	stressCmd.Flags().Float64Var(&stressMaxFailureRate, "max-failure-rate", 0, "Share of tasks allowed to fail, between 0 and 1")
+	stressCmd.Flags().StringVar(&stressReport, "report", "", "Write the tasks and their queue and run times to a JSON file")
+
+	return stressCmd
+}
+
+// printStressSummary prints every task's outcome followed by the scheduler behavior
+func printStressSummary(result *targets.StressResult) {
+	for _, task := range result.Tasks {
+		timing := fmt.Sprintf("queued %s, ran %s", task.QueueTime().Round(time.Second), task.RunTime().Round(time.Second))
+		if task.Postponed {
+			timing += ", postponed"
+		}
+		switch {
+		case task.Succeeded():
+			color.Green("  ✓ Task %d of %s (%s)", task.ID, task.Application, timing)
+		case task.Error != "":
+			color.Red("  ✗ Task %d of %s %s: %s (%s)", task.ID, task.Application, task.State, task.Error, timing)
+		default:
+			color.Red("  ✗ Task %d of %s %s (%s)", task.ID, task.Application, task.State, timing)
+		}
+	}
+
+	shortest, average, longest := result.QueueTimes()
+	fmt.Println("\n" + strings.Repeat("=", 60))
+	fmt.Printf("Stress: %d tasks in %s\n", len(result.Tasks), result.Duration.Round(time.Second))
+	fmt.Printf("  Queue time: %s min, %s avg, %s max\n", shortest.Round(time.Second), average.Round(time.Second), longest.Round(time.Second))
+	fmt.Printf("  Running at once: %d at most\n", result.MaxRunning)
+	if postponed := result.Postponed(); postponed > 0 {
+		color.Yellow("  ⚠ Postponed: %d", postponed)
+	}
+	if failed := result.Failed(); failed > 0 {
+		color.Red("  ✗ Failed: %d (%.0f%%)", failed, result.FailureRate()*100)
+	} else {
+		color.Green("  ✓ All tasks succeeded")
+	}
+}
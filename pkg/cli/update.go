@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/output"
+	"github.com/konveyor/test-harness/pkg/parser"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/update"
+	"github.com/konveyor/test-harness/pkg/validator"
+	"github.com/manifoldco/promptui"
+)
+
+// Modes of --update-expected
+const (
+	// updateExpectedPrompt shows each change and asks whether to accept it
+	updateExpectedPrompt = "prompt"
+	// updateExpectedAll accepts every change
+	updateExpectedAll = "all"
+)
+
+// updateExpected is the --update-expected mode of run and validate, empty to leave expected outputs as is
+var updateExpected string
+
+// checkUpdateExpected fails on an unknown --update-expected mode
+func checkUpdateExpected() error {
+	switch updateExpected {
+	case "", updateExpectedPrompt, updateExpectedAll:
+		return nil
+	}
+	return fmt.Errorf("invalid --update-expected %q, expected %s or %s", updateExpected, updateExpectedPrompt, updateExpectedAll)
+}
+
+// updateExpectedOutputs accepts the actual outputs of a failed test into its expected output files
+// Failures are printed as warnings, the test result doesn't change
+func updateExpectedOutputs(test *config.TestDefinition, result *targets.ExecutionResult) {
+	if updateExpected == "" || test.IsAssetTest() || test.IsFixTest() {
+		return
+	}
+	if !test.IsMultiApplication() {
+		if err := updateExpectedOutput(&test.Expect.Output, result.OutputFile(), test.GetTestDir(), test.Expect.ValidateOnly); err != nil {
+			color.Yellow("  ⚠ Failed to update the expected output: %v", err)
+		}
+		return
+	}
+	for i := range test.Analysis.Applications {
+		app := &test.Analysis.Applications[i]
+		outputFile, ok := result.ApplicationOutputFile(app.Name)
+		if !ok {
+			continue
+		}
+		if err := updateExpectedOutput(&app.Expect, outputFile, test.GetTestDir(), test.Expect.ValidateOnly); err != nil {
+			color.Yellow("  ⚠ Failed to update the expected output of %s: %v", app.Name, err)
+		}
+	}
+}
+
+// updateExpectedOutput edits an expected output file with the accepted differences from an output file
+// Only the changed rules and fields are rewritten, the rest of the file is kept as is. With validateOnly,
+// only the listed rulesets are updated
+func updateExpectedOutput(expected *config.ExpectedOutput, outputFile, testDir string, validateOnly []string) error {
+	if expected.ResolvedFilePath == "" {
+		return fmt.Errorf("the expected output is inline in the test definition")
+	}
+	if expected.Template {
+		return fmt.Errorf("%s is a template, update it by hand", expected.ResolvedFilePath)
+	}
+	data, err := os.ReadFile(expected.ResolvedFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read expected output file: %w", err)
+	}
+	if err := checkExpectedVersion(expected.ResolvedFilePath, data); err != nil {
+		return err
+	}
+
+	// The output is filtered and normalized like generated expected outputs
+	actual, err := output.Load(outputFile)
+	if err != nil {
+		return err
+	}
+	normalized, err := normalizeRuleSetPaths(parser.FilterRuleSets(actual), testDir)
+	if err != nil {
+		return fmt.Errorf("failed to normalize paths: %w", err)
+	}
+	// Rules are compared in canonical order, changes that only reorder them aren't offered
+	changes, err := update.Changes(
+		output.Canonicalize(validator.SelectRuleSets(expected.Result, validateOnly)),
+		output.Canonicalize(validator.SelectRuleSets(normalized, validateOnly)),
+	)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	accepted := changes
+	if updateExpected == updateExpectedPrompt {
+		fmt.Printf("\n  Updating %s\n", expected.ResolvedFilePath)
+		if accepted, err = promptChanges(changes); err != nil {
+			return err
+		}
+	}
+	if len(accepted) == 0 {
+		return nil
+	}
+
+	edited, err := update.Apply(data, accepted)
+	if errors.Is(err, update.ErrNotEditable) {
+		return fmt.Errorf("%s isn't block style YAML, regenerate it with 'koncur generate'", expected.ResolvedFilePath)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(expected.ResolvedFilePath, edited, 0644); err != nil {
+		return fmt.Errorf("failed to write expected output file: %w", err)
+	}
+	color.Cyan("  Updated %s with %d of %d change(s), run the test again to validate it", expected.ResolvedFilePath, len(accepted), len(changes))
+	return nil
+}
+
+// Answers to the prompt of each change
+const (
+	answerAccept    = "Accept"
+	answerSkip      = "Skip"
+	answerAcceptAll = "Accept all remaining"
+	answerSkipAll   = "Skip all remaining"
+)
+
+// promptChanges shows the diff of each change and returns the changes accepted
+// Interrupting the prompt accepts none of them
+func promptChanges(changes []update.Change) ([]update.Change, error) {
+	var accepted []update.Change
+	for i, c := range changes {
+		fmt.Printf("\n  [%d/%d] %s\n", i+1, len(changes), c)
+		for _, line := range c.Diff() {
+			switch line[0] {
+			case '+':
+				color.Green("    %s", line)
+			case '-':
+				color.Red("    %s", line)
+			default:
+				fmt.Printf("    %s\n", line)
+			}
+		}
+
+		prompt := promptui.Select{
+			Label: "Accept this change into the expected output",
+			Items: []string{answerAccept, answerSkip, answerAcceptAll, answerSkipAll},
+		}
+		_, answer, err := prompt.Run()
+		if err != nil {
+			if err == promptui.ErrInterrupt {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to prompt: %w", err)
+		}
+		switch answer {
+		case answerAccept:
+			accepted = append(accepted, c)
+		case answerAcceptAll:
+			return append(accepted, changes[i:]...), nil
+		case answerSkipAll:
+			return accepted, nil
+		}
+	}
+	return accepted, nil
+}
@@ -2,40 +2,207 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/fatih/color"
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/targets"
 	"github.com/konveyor/test-harness/pkg/util"
+	"github.com/konveyor/test-harness/pkg/validator"
 	"github.com/spf13/cobra"
 )
 
+var (
+	validateOutputs      bool
+	validateTargetType   string
+	validateOutputFile   string
+	validateExpectedFile string
+	validateTestDir      string
+)
+
 // NewValidateCmd creates the validate command
 func NewValidateCmd() *cobra.Command {
 	validateCmd := &cobra.Command{
-		Use:   "validate <test-file>",
-		Short: "Validate a test definition",
-		Long:  `Check if a test definition is valid without running it.`,
-		Args:  cobra.ExactArgs(1),
+		Use:   "validate [test-file-or-directory]",
+		Short: "Validate test definitions or their existing outputs",
+		Long: `Check if a test definition is valid without running it.
+
+With --outputs, the output of the most recent run of each test is validated
+again against its expected results without re-running the analysis.
+
+With --output and --expected, an analyzer output produced elsewhere (for
+example a CI artifact) is validated against an expected output file without
+any test definition or target.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			testFile := args[0]
-			log := util.GetLogger()
+			// Validation failures are reported through the exit code, not usage
+			cmd.SilenceUsage = true
+			if err := checkUpdateExpected(); err != nil {
+				return err
+			}
 
-			log.Info("Validating test definition", "file", testFile)
+			if validateOutputFile != "" || validateExpectedFile != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("a test path cannot be combined with --output and --expected")
+				}
+				return validateOutputFiles()
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("a test file or directory is required")
+			}
+
+			path := args[0]
+			log := util.GetLogger()
 
-			// Load test definition
-			test, err := config.Load(testFile)
+			info, err := os.Stat(path)
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to stat path: %w", err)
 			}
 
-			// Validate test definition
-			if err := config.Validate(test); err != nil {
-				return err
+			testFiles := []string{path}
+			if info.IsDir() {
+				testFiles, err = discovery.FindTestFiles(path)
+				if err != nil {
+					return fmt.Errorf("failed to find test files: %w", err)
+				}
+				if len(testFiles) == 0 {
+					return fmt.Errorf("no test files found in %s", path)
+				}
+			}
+
+			// Outputs are found where the settings put them when the tests ran
+			settings := &config.Settings{}
+			if validateOutputs {
+				if settings, err = resolveSettings(cmd, map[string]string{"target": config.SettingTarget}); err != nil {
+					return err
+				}
+				validateTargetType = settings.TargetType()
+			}
+
+			failCount := 0
+			for _, testFile := range testFiles {
+				log.Info("Validating test definition", "file", testFile)
+
+				// Load test definition
+				test, err := config.Load(testFile)
+				if err != nil {
+					return err
+				}
+
+				// Validate test definition
+				if err := config.Validate(test); err != nil {
+					return err
+				}
+
+				if !validateOutputs {
+					fmt.Printf("✓ Test definition is valid: %s\n", test.Name)
+					// Expected outputs are checked against the rules their analysis selects
+					variants, err := config.LoadVariants(test)
+					if err != nil {
+						color.Yellow("  ⚠ Failed to check expected coverage: %v", err)
+						continue
+					}
+					for _, variant := range variants {
+						printCoverageWarnings(variant)
+					}
+					continue
+				}
+
+				// Matrix tests are validated per variant
+				settings.Apply(test)
+				variants, err := config.LoadVariants(test)
+				if err != nil {
+					return err
+				}
+				for _, variant := range variants {
+					name := filepath.Base(filepath.Dir(testFile))
+					if variant.Variant != nil {
+						name = fmt.Sprintf("%s-%s", name, variant.Variant.Name())
+					}
+					fmt.Printf("Validating outputs: %s\n", name)
+					passed, err := validateExistingOutputs(variant)
+					if err != nil {
+						color.Red("  ✗ Error: %v", err)
+						failCount++
+					} else if !passed {
+						failCount++
+					}
+				}
 			}
 
-			fmt.Printf("✓ Test definition is valid: %s\n", test.Name)
+			if failCount > 0 {
+				return fmt.Errorf("%d of %d test(s) failed validation", failCount, len(testFiles))
+			}
 			return nil
 		},
 	}
 
+	validateCmd.Flags().BoolVar(&validateOutputs, "outputs", false, "Validate the outputs of the most recent run instead of only the test definition")
+	validateCmd.Flags().StringVarP(&validateTargetType, "target", "t", "kantra", "Target type that produced the outputs")
+	validateCmd.Flags().StringVar(&updateExpected, "update-expected", "", "With --outputs, accept the outputs that failed validation into the expected output files: prompt for each change, or all")
+	validateCmd.Flags().StringVar(&validateOutputFile, "output", "", "Existing analyzer output file to validate")
+	validateCmd.Flags().StringVar(&validateExpectedFile, "expected", "", "Expected output file to validate against")
+	validateCmd.Flags().StringVar(&validateTestDir, "test-dir", "", "Path prefix removed from output file paths (default: directory of the expected file)")
+
 	return validateCmd
 }
+
+// validateExistingOutputs validates the outputs of the most recent run of a test
+func validateExistingOutputs(test *config.TestDefinition) (bool, error) {
+	if test.IsCancelTest() {
+		color.Yellow("  ⚠ Cancellation tests have no outputs to validate")
+		return true, nil
+	}
+	if test.IsAssessmentTest() {
+		color.Yellow("  ⚠ Assessment tests have no outputs to validate")
+		return true, nil
+	}
+	if test.IsMigrationTest() {
+		color.Yellow("  ⚠ Migration tests have no outputs to validate")
+		return true, nil
+	}
+	result, err := targets.LatestResult(test)
+	if err != nil {
+		return false, err
+	}
+
+	failures, err := validateResult(test, result, validateTargetType)
+	if err == nil && len(failures) > 0 {
+		updateExpectedOutputs(test, result)
+	}
+	return len(failures) == 0, err
+}
+
+// validateOutputFiles validates an existing output file against an expected output file
+func validateOutputFiles() error {
+	if validateOutputFile == "" || validateExpectedFile == "" {
+		return fmt.Errorf("--output and --expected must be used together")
+	}
+
+	expected, err := config.LoadExpectedOutput(validateExpectedFile)
+	if err != nil {
+		return err
+	}
+
+	testDir := validateTestDir
+	if testDir == "" {
+		testDir = filepath.Dir(validateExpectedFile)
+	}
+	testDir, err = filepath.Abs(testDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve test directory: %w", err)
+	}
+
+	fmt.Printf("Validating %s against %s\n", validateOutputFile, validateExpectedFile)
+	result := &targets.ExecutionResult{Artifacts: targets.OutputArtifacts{targets.ArtifactOutput: validateOutputFile}}
+	failures, err := validateOutput(validateOutputFile, expected, testDir, validateTargetType, validator.Options{}, nil, result)
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("output does not match expected output")
+	}
+	return nil
+}
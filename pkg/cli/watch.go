@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/discovery"
+	"github.com/konveyor/test-harness/pkg/output"
+	"github.com/konveyor/test-harness/pkg/targets"
+	"github.com/konveyor/test-harness/pkg/watch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchRules        string
+	watchTest         string
+	watchTargetConfig string
+	watchDebounce     time.Duration
+	watchInterval     time.Duration
+)
+
+// watchSettingFlags maps the watch flags overriding settings to their settings
+var watchSettingFlags = map[string]string{
+	"target-config": config.SettingTargetConfig,
+}
+
+// NewWatchCmd creates the watch command
+func NewWatchCmd() *cobra.Command {
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Re-run tests whenever rules change",
+		Long: `Run tests with a local rules directory and run them again whenever a rule file,
+test definition or expected output changes, until interrupted.
+
+The tests' rules are replaced by --rules and kantra analyzes them locally
+(run-local). After each run the output is validated and the rules whose
+incidents changed since the previous run are listed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if watchRules == "" || watchTest == "" {
+				return fmt.Errorf("--rules and --test are required")
+			}
+			rulesPath, err := filepath.Abs(watchRules)
+			if err != nil {
+				return fmt.Errorf("failed to resolve rules path: %w", err)
+			}
+			if _, err := os.Stat(rulesPath); err != nil {
+				return fmt.Errorf("failed to stat rules path: %w", err)
+			}
+
+			info, err := os.Stat(watchTest)
+			if err != nil {
+				return fmt.Errorf("failed to stat path: %w", err)
+			}
+			testFiles := []string{watchTest}
+			if info.IsDir() {
+				if testFiles, err = discovery.FindTestFiles(watchTest); err != nil {
+					return fmt.Errorf("failed to find test files: %w", err)
+				}
+				if len(testFiles) == 0 {
+					return fmt.Errorf("no test files found in %s", watchTest)
+				}
+			}
+
+			settings, err := resolveSettings(cmd, watchSettingFlags)
+			if err != nil {
+				return err
+			}
+			targetConfig, err := loadTargetConfig(settings)
+			if err != nil {
+				return fmt.Errorf("failed to load target config: %w", err)
+			}
+			if targetConfig.Type != "kantra" {
+				return fmt.Errorf("watch runs kantra locally, the %s target isn't supported", targetConfig.Type)
+			}
+			if targetConfig.Kantra == nil {
+				targetConfig.Kantra = &config.KantraConfig{}
+			}
+			targetConfig.Kantra.RunLocal = true
+			target, err := targets.NewTarget(targetConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create target: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			// Test directories hold the expected outputs, editing them validates again
+			paths := []string{rulesPath}
+			for _, testFile := range testFiles {
+				paths = append(paths, filepath.Dir(testFile))
+			}
+
+			w := &watchSession{target: target, settings: settings, rulesPath: rulesPath, previous: map[string][]konveyor.RuleSet{}}
+			w.run(ctx, testFiles)
+			fmt.Printf("\nWatching %s for changes, press Ctrl+C to stop\n", strings.Join(paths, ", "))
+
+			watcher := &watch.Watcher{Paths: paths, Interval: watchInterval, Debounce: watchDebounce}
+			return watcher.Run(ctx, func(changed []string) {
+				fmt.Printf("\n%s changed:\n", time.Now().Format("15:04:05"))
+				for _, path := range changed {
+					fmt.Printf("  %s\n", path)
+				}
+				w.run(ctx, testFiles)
+				if ctx.Err() == nil {
+					fmt.Println("\nWatching for changes, press Ctrl+C to stop")
+				}
+			})
+		},
+	}
+
+	watchCmd.Flags().StringVar(&watchRules, "rules", "", "Rules file or directory analyzed instead of the tests' rules (required)")
+	watchCmd.Flags().StringVar(&watchTest, "test", "", "Test file or directory of tests to run (required)")
+	watchCmd.Flags().StringVarP(&watchTargetConfig, "target-config", "c", "", "Path to kantra target configuration file")
+	watchCmd.Flags().DurationVar(&watchDebounce, "debounce", watch.DefaultDebounce, "How long files must stay unchanged before the tests run again")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", watch.DefaultInterval, "How often files are checked for changes")
+
+	return watchCmd
+}
+
+// watchSession runs the watched tests and keeps their last outputs to show what changed
+type watchSession struct {
+	target    targets.Target
+	settings  *config.Settings
+	rulesPath string
+	// previous holds the last output of each test and application
+	previous map[string][]konveyor.RuleSet
+}
+
+// run runs every test file, errors are printed and the session goes on
+func (w *watchSession) run(ctx context.Context, testFiles []string) {
+	for _, testFile := range testFiles {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := w.runTest(ctx, testFile); err != nil {
+			color.Red("  ✗ Error: %v", err)
+		}
+	}
+}
+
+// runTest runs the variants of a test with the watched rules, validates their outputs and prints the
+// changes since the previous run
+func (w *watchSession) runTest(ctx context.Context, testFile string) error {
+	test, err := config.Load(testFile)
+	if err != nil {
+		return fmt.Errorf("failed to load test: %w", err)
+	}
+	w.settings.Apply(test)
+	if err := config.Validate(test); err != nil {
+		return fmt.Errorf("invalid test definition: %w", err)
+	}
+	variants, err := config.LoadVariants(test)
+	if err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		name := filepath.Base(filepath.Dir(testFile))
+		if variant.Variant != nil {
+			name = fmt.Sprintf("%s-%s", name, variant.Variant.Name())
+		}
+		fmt.Printf("\nRunning: %s\n", name)
+
+		variant.Analysis.Rules = []string{w.rulesPath}
+		variant.Analysis.RulesGitComponents = []*config.GitURLComponents{nil}
+
+		result, err := w.target.Execute(ctx, variant)
+		if err != nil {
+			return fmt.Errorf("execution failed: %w", err)
+		}
+		if result.ExitCode != variant.Expect.ExitCode {
+			color.Red("  ✗ Exit code mismatch: expected %d, got %d", variant.Expect.ExitCode, result.ExitCode)
+			continue
+		}
+		if _, err := validateResult(variant, result, "kantra"); err != nil {
+			return err
+		}
+
+		// Multi-application tests have one output per application
+		if !variant.IsMultiApplication() {
+			w.printChanges(name, result.OutputFile())
+		}
+		for _, app := range variant.Analysis.Applications {
+			if file, ok := result.ApplicationOutputFile(app.Name); ok {
+				w.printChanges(fmt.Sprintf("%s: %s", name, app.Name), file)
+			}
+		}
+	}
+	return nil
+}
+
+// printChanges prints the rules whose incidents changed since the previous output of the same key
+func (w *watchSession) printChanges(key, file string) {
+	current, err := output.Load(file)
+	if err != nil {
+		color.Yellow("  ⚠ Failed to compare outputs: %v", err)
+		return
+	}
+	previous, ok := w.previous[key]
+	w.previous[key] = current
+	if !ok {
+		return
+	}
+
+	changes := output.Diff(previous, current)
+	if len(changes) == 0 {
+		fmt.Printf("  No changes since the previous run of %s\n", key)
+		return
+	}
+	fmt.Printf("  Changes since the previous run of %s:\n", key)
+	for _, c := range changes {
+		switch {
+		case c.Previous < 0:
+			color.Green("    %s", c)
+		case c.Current < 0:
+			color.Red("    %s", c)
+		default:
+			color.Yellow("    %s", c)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// When the environment is torn down after a run
+const (
+	// TeardownAlways tears the environment down after every run
+	TeardownAlways = "always"
+	// TeardownOnSuccess keeps the environment for debugging when tests failed
+	TeardownOnSuccess = "success"
+	// TeardownNever keeps the environment, e.g. to reuse it for several runs
+	TeardownNever = "never"
+)
+
+// Environment defaults
+const (
+	// DefaultKonveyorNamespace is where the operator installs Konveyor
+	DefaultKonveyorNamespace = "konveyor-tackle"
+	// DefaultOLMVersion is the Operator Lifecycle Manager release installed when missing
+	DefaultOLMVersion = "v0.38.0"
+	// DefaultKindClusterName is the name of created kind clusters
+	DefaultKindClusterName = "koncur-test"
+	// DefaultReadyTimeout is how long to wait for the Hub to become ready
+	DefaultReadyTimeout = 15 * time.Minute
+)
+
+// EnvConfig defines a Konveyor installation the tests run against
+type EnvConfig struct {
+	// Kind creates a kind cluster for the installation, an existing cluster is used when nil
+	Kind *KindConfig `yaml:"kind,omitempty"`
+
+	// Kubeconfig of the cluster, kind writes to it when creating a cluster (default: $KUBECONFIG or ~/.kube/config)
+	Kubeconfig string `yaml:"kubeconfig,omitempty"`
+
+	// Operator to install
+	Operator OperatorConfig `yaml:"operator" validate:"required"`
+
+	// Tackle is the spec of the Tackle custom resource (default: feature_auth_required "false")
+	Tackle map[string]any `yaml:"tackle,omitempty"`
+
+	// HubURL is polled until the Hub answers, when set
+	HubURL string `yaml:"hubURL,omitempty" validate:"omitempty,url"`
+
+	// ReadyTimeout limits how long to wait for the operator and Hub (default: 15m)
+	ReadyTimeout *Duration `yaml:"readyTimeout,omitempty"`
+
+	// Teardown after a run: always, success or never (default: always)
+	Teardown string `yaml:"teardown,omitempty" validate:"omitempty,oneof=always success never"`
+}
+
+// KindConfig defines the kind cluster to create
+type KindConfig struct {
+	// Name of the cluster (default: koncur-test)
+	Name string `yaml:"name,omitempty"`
+
+	// NodeImage selects the Kubernetes version, e.g. kindest/node:v1.30.0
+	NodeImage string `yaml:"nodeImage,omitempty"`
+
+	// Ingress installs ingress-nginx, reachable on host ports 8080 and 8443
+	Ingress bool `yaml:"ingress,omitempty"`
+}
+
+// OperatorConfig defines the Konveyor operator to install
+type OperatorConfig struct {
+	// Version of the operator, a release tag such as v0.7.0 or a branch such as main
+	Version string `yaml:"version" validate:"required"`
+
+	// ManifestURL overrides the operator manifest, which defaults to tackle-k8s.yaml of the version
+	ManifestURL string `yaml:"manifestURL,omitempty" validate:"omitempty,url"`
+
+	// Namespace the operator installs Konveyor in (default: konveyor-tackle)
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// OLMVersion is installed when OLM is missing from the cluster (default: v0.38.0)
+	OLMVersion string `yaml:"olmVersion,omitempty"`
+}
+
+// GetManifestURL returns the operator manifest for the configured version
+func (oc *OperatorConfig) GetManifestURL() string {
+	if oc.ManifestURL != "" {
+		return oc.ManifestURL
+	}
+	return fmt.Sprintf("https://raw.githubusercontent.com/konveyor/tackle2-operator/%s/tackle-k8s.yaml", oc.Version)
+}
+
+// GetNamespace returns the Konveyor namespace with a default
+func (oc *OperatorConfig) GetNamespace() string {
+	if oc.Namespace == "" {
+		return DefaultKonveyorNamespace
+	}
+	return oc.Namespace
+}
+
+// GetOLMVersion returns the OLM version with a default
+func (oc *OperatorConfig) GetOLMVersion() string {
+	if oc.OLMVersion == "" {
+		return DefaultOLMVersion
+	}
+	return oc.OLMVersion
+}
+
+// GetName returns the cluster name with a default
+func (kc *KindConfig) GetName() string {
+	if kc.Name == "" {
+		return DefaultKindClusterName
+	}
+	return kc.Name
+}
+
+// GetReadyTimeout returns the readiness timeout with a default
+func (ec *EnvConfig) GetReadyTimeout() time.Duration {
+	if ec.ReadyTimeout == nil || ec.ReadyTimeout.Duration <= 0 {
+		return DefaultReadyTimeout
+	}
+	return ec.ReadyTimeout.Duration
+}
+
+// GetTeardown returns when to tear down with a default
+func (ec *EnvConfig) GetTeardown() string {
+	if ec.Teardown == "" {
+		return TeardownAlways
+	}
+	return ec.Teardown
+}
+
+// ShouldTeardown reports whether the environment is torn down after a run
+func (ec *EnvConfig) ShouldTeardown(failed bool) bool {
+	switch ec.GetTeardown() {
+	case TeardownNever:
+		return false
+	case TeardownOnSuccess:
+		return !failed
+	default:
+		return true
+	}
+}
+
+// GetTackleSpec returns the Tackle custom resource spec, disabling auth unless configured
+func (ec *EnvConfig) GetTackleSpec() map[string]any {
+	spec := map[string]any{}
+	for k, v := range ec.Tackle {
+		spec[k] = v
+	}
+	if _, ok := spec["feature_auth_required"]; !ok {
+		spec["feature_auth_required"] = "false"
+	}
+	return spec
+}
+
+// LoadEnvConfig loads and validates environment configuration from a file, operatorVersion overrides
+// operator.version when set
+// Environment variables are expanded, e.g. operator.version: ${HUB_VERSION} to test several versions nightly
+func LoadEnvConfig(path, operatorVersion string) (*EnvConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env config file %s: %w", path, err)
+	}
+
+	var envConfig EnvConfig
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &envConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse env config YAML: %w", err)
+	}
+	if operatorVersion != "" {
+		envConfig.Operator.Version = operatorVersion
+	}
+
+	if err := validate.Struct(&envConfig); err != nil {
+		return nil, fmt.Errorf("invalid env config: %w", err)
+	}
+
+	return &envConfig, nil
+}
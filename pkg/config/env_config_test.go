@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadEnvConfig(t *testing.T) {
+	t.Setenv("TEST_HUB_VERSION", "v0.7.0")
+
+	tests := []struct {
+		name    string
+		content string
+		version string
+		wantErr string
+	}{
+		{
+			name: "kind and operator",
+			content: `kind:
+  name: nightly
+  ingress: true
+operator:
+  version: ${TEST_HUB_VERSION}
+tackle:
+  cache_data_volume_size: 10Gi
+hubURL: http://localhost:8080/hub
+readyTimeout: 20m
+teardown: success
+`,
+		},
+		{name: "missing version", content: "operator:\n  namespace: konveyor\n", wantErr: "Version"},
+		{name: "version override", content: "kind:\n  name: nightly\n  ingress: true\noperator:\n  version: ${UNSET_HUB_VERSION}\nreadyTimeout: 20m\n", version: "v0.7.0"},
+		{name: "unknown teardown", content: "operator:\n  version: main\nteardown: sometimes\n", wantErr: "Teardown"},
+		{name: "invalid manifest", content: "operator:\n  version: main\n  manifestURL: not a url\n", wantErr: "ManifestURL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "env.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadEnvConfig(path, tt.version)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("LoadEnvConfig() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadEnvConfig() unexpected error: %v", err)
+			}
+			if cfg.Operator.Version != "v0.7.0" {
+				t.Errorf("Version = %q, want expanded v0.7.0", cfg.Operator.Version)
+			}
+			if cfg.GetReadyTimeout() != 20*time.Minute {
+				t.Errorf("GetReadyTimeout() = %v, want 20m", cfg.GetReadyTimeout())
+			}
+			if cfg.Kind.GetName() != "nightly" || !cfg.Kind.Ingress {
+				t.Errorf("unexpected kind config: %+v", cfg.Kind)
+			}
+		})
+	}
+}
+
+func TestEnvConfig_Defaults(t *testing.T) {
+	cfg := &EnvConfig{Operator: OperatorConfig{Version: "main"}, Tackle: map[string]any{"feature_auth_required": "true"}}
+
+	if got := cfg.Operator.GetManifestURL(); got != "https://raw.githubusercontent.com/konveyor/tackle2-operator/main/tackle-k8s.yaml" {
+		t.Errorf("GetManifestURL() = %q", got)
+	}
+	if cfg.Operator.GetNamespace() != DefaultKonveyorNamespace || cfg.Operator.GetOLMVersion() != DefaultOLMVersion {
+		t.Errorf("unexpected operator defaults")
+	}
+	if cfg.GetReadyTimeout() != DefaultReadyTimeout {
+		t.Errorf("GetReadyTimeout() = %v, want %v", cfg.GetReadyTimeout(), DefaultReadyTimeout)
+	}
+	if spec := cfg.GetTackleSpec(); spec["feature_auth_required"] != "true" {
+		t.Errorf("configured auth setting overridden: %v", spec)
+	}
+	if (&KindConfig{}).GetName() != DefaultKindClusterName {
+		t.Errorf("unexpected kind cluster default")
+	}
+}
+
+func TestEnvConfig_ShouldTeardown(t *testing.T) {
+	tests := []struct {
+		teardown string
+		failed   bool
+		want     bool
+	}{
+		{teardown: "", failed: true, want: true},
+		{teardown: TeardownAlways, failed: true, want: true},
+		{teardown: TeardownOnSuccess, failed: false, want: true},
+		{teardown: TeardownOnSuccess, failed: true, want: false},
+		{teardown: TeardownNever, failed: false, want: false},
+	}
+
+	for _, tt := range tests {
+		cfg := &EnvConfig{Teardown: tt.teardown}
+		if got := cfg.ShouldTeardown(tt.failed); got != tt.want {
+			t.Errorf("ShouldTeardown(%v) with teardown %q = %v, want %v", tt.failed, tt.teardown, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/konveyor/test-harness/pkg/fixtures"
+)
+
+// FixtureScheme is the application prefix for the applications embedded in koncur
+const FixtureScheme = "fixture:"
+
+// IsFixtureURL checks if the given string is an embedded fixture application reference
+// Format: fixture:java-minimal
+func IsFixtureURL(str string) bool {
+	return strings.HasPrefix(str, FixtureScheme)
+}
+
+// FixtureName returns the name of the fixture an application reference points to
+func FixtureName(str string) string {
+	return strings.TrimPrefix(str, FixtureScheme)
+}
+
+// isKnownFixture reports whether an application is no fixture reference or references a known fixture
+func isKnownFixture(str string) bool {
+	return !IsFixtureURL(str) || fixtures.Exists(FixtureName(str))
+}
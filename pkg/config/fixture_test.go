@@ -0,0 +1,32 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsFixtureURL(t *testing.T) {
+	if !IsFixtureURL("fixture:java-minimal") || IsFixtureURL("https://github.com/konveyor/tackle-testapp") {
+		t.Error("IsFixtureURL() should only match the fixture scheme")
+	}
+	if got := FixtureName("fixture:legacy.jar"); got != "legacy.jar" {
+		t.Errorf("FixtureName() = %q, want legacy.jar", got)
+	}
+}
+
+func TestValidate_Fixture(t *testing.T) {
+	test := &TestDefinition{
+		Name:     "fixture",
+		Analysis: AnalysisConfig{Application: "fixture:spring-boot", AnalysisMode: "source-only"},
+		Expect:   ExpectConfig{Output: ExpectedOutput{File: "expected-output.yaml"}},
+	}
+	if err := Validate(test); err != nil {
+		t.Errorf("Validate() of a known fixture error = %v", err)
+	}
+
+	test.Analysis.Application = "fixture:tackle-testapp"
+	err := Validate(test)
+	if err == nil || !strings.Contains(err.Error(), "java-minimal") {
+		t.Errorf("Validate() of an unknown fixture error = %v, want the available fixtures", err)
+	}
+}
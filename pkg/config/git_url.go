@@ -5,10 +5,36 @@ import (
 )
 
 // GitURLComponents represents a parsed Git URL with optional branch/tag and path
+// Subversion URLs use the same components, with Kind set to RepositoryKindSubversion
 type GitURLComponents struct {
 	URL  string // Base Git URL
 	Ref  string // Branch or tag (optional)
 	Path string // Path within repository (optional)
+	Kind string // Repository kind, empty means git (optional)
+
+	Options *GitOptions // Clone options (optional)
+}
+
+// GitOptions controls how a test application repository is cloned
+// The zero value is a shallow clone of the default branch or ref
+type GitOptions struct {
+	// FullClone clones the full history instead of using --depth 1
+	FullClone bool `json:"full_clone,omitempty" yaml:"fullClone,omitempty"`
+
+	// Submodules initializes and updates submodules recursively
+	Submodules bool `json:"submodules,omitempty" yaml:"submodules,omitempty"`
+
+	// Commit checks out a specific commit SHA after cloning
+	Commit string `json:"commit,omitempty" yaml:"commit,omitempty" validate:"omitempty,hexadecimal,min=7,max=40"`
+
+	// SparsePaths limits the checkout to the given directories
+	SparsePaths []string `json:"sparse_paths,omitempty" yaml:"sparsePaths,omitempty" validate:"dive,required"`
+}
+
+// IsShallow returns true if the repository can be cloned with --depth 1
+// A pinned commit needs history, since it may not be the tip of the ref
+func (o *GitOptions) IsShallow() bool {
+	return o == nil || (!o.FullClone && o.Commit == "")
 }
 
 // ParseGitURLWithPath parses a Git URL that may contain a reference and path
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ImageScheme is the application prefix for applications shipped in container images
+const ImageScheme = "image:"
+
+// ImageComponents represents a parsed container image application reference
+type ImageComponents struct {
+	Image string // Image reference (registry/repository:tag or @digest)
+	Path  string // Path of the application inside the image (optional)
+}
+
+// ParseImageURL parses an image application reference
+// Format: image:quay.io/org/app:tag#/path/in/image/app.war
+func ParseImageURL(imageURL string) *ImageComponents {
+	ref := strings.TrimPrefix(imageURL, ImageScheme)
+	components := &ImageComponents{}
+
+	// The path is separated with "#" since ":" is used by tags
+	if strings.Contains(ref, "#") {
+		parts := strings.SplitN(ref, "#", 2)
+		components.Image = parts[0]
+		components.Path = parts[1]
+	} else {
+		components.Image = ref
+	}
+
+	return components
+}
+
+// IsImageURL checks if the given string is a container image application reference
+func IsImageURL(str string) bool {
+	return strings.HasPrefix(str, ImageScheme)
+}
+
+// imageRefPattern matches image references, [registry[:port]/]repository[:tag][@sha256:digest]
+var imageRefPattern = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-]+[a-z0-9]+)*)*(:\w[\w.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+// IsImageRef checks if the given string is a valid image reference
+func IsImageRef(ref string) bool {
+	return imageRefPattern.MatchString(ref)
+}
+
+// IsPinnedImage checks if an image reference is pinned to a digest, so every run uses the same image
+func IsPinnedImage(ref string) bool {
+	return IsImageRef(ref) && strings.Contains(ref, "@sha256:")
+}
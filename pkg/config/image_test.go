@@ -0,0 +1,119 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestParseImageURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		imageURL  string
+		wantImage string
+		wantPath  string
+	}{
+		{
+			name:      "image with tag",
+			imageURL:  "image:quay.io/konveyor/tackle-testapp:latest",
+			wantImage: "quay.io/konveyor/tackle-testapp:latest",
+			wantPath:  "",
+		},
+		{
+			name:      "image with tag and path",
+			imageURL:  "image:quay.io/konveyor/tackle-testapp:v1.0#/deployments/app.war",
+			wantImage: "quay.io/konveyor/tackle-testapp:v1.0",
+			wantPath:  "/deployments/app.war",
+		},
+		{
+			name:      "image with digest and path",
+			imageURL:  "image:quay.io/org/app@sha256:abc123#/opt/app-root/src",
+			wantImage: "quay.io/org/app@sha256:abc123",
+			wantPath:  "/opt/app-root/src",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			components := ParseImageURL(tt.imageURL)
+			if components.Image != tt.wantImage {
+				t.Errorf("ParseImageURL() Image = %v, want %v", components.Image, tt.wantImage)
+			}
+			if components.Path != tt.wantPath {
+				t.Errorf("ParseImageURL() Path = %v, want %v", components.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestAnalysisConfig_ParseImageURL(t *testing.T) {
+	ac := AnalysisConfig{
+		Application: "image:quay.io/org/app:tag#/deployments/app.jar",
+	}
+	ac.ParseGitURLs()
+
+	if ac.ApplicationGitComponents != nil {
+		t.Error("Expected image application not to be parsed as a Git URL")
+	}
+	if ac.ApplicationImageComponents == nil {
+		t.Fatal("Expected ApplicationImageComponents to be set")
+	}
+	if ac.ApplicationImageComponents.Image != "quay.io/org/app:tag" {
+		t.Errorf("ApplicationImageComponents.Image = %v", ac.ApplicationImageComponents.Image)
+	}
+}
+
+func TestIsImageRef(t *testing.T) {
+	digest := "@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		ref        string
+		wantValid  bool
+		wantPinned bool
+	}{
+		{ref: "quay.io/konveyor/kantra:latest", wantValid: true},
+		{ref: "localhost:5000/kantra", wantValid: true},
+		{ref: "quay.io/konveyor/kantra" + digest, wantValid: true, wantPinned: true},
+		{ref: "quay.io/konveyor/kantra:v0.8.0" + digest, wantValid: true, wantPinned: true},
+		{ref: "quay.io/konveyor/kantra@sha256:abc123", wantValid: false},
+		{ref: "Quay.io/Konveyor/Kantra", wantValid: false},
+		{ref: "", wantValid: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsImageRef(tt.ref); got != tt.wantValid {
+			t.Errorf("IsImageRef(%q) = %v, want %v", tt.ref, got, tt.wantValid)
+		}
+		if got := IsPinnedImage(tt.ref); got != tt.wantPinned {
+			t.Errorf("IsPinnedImage(%q) = %v, want %v", tt.ref, got, tt.wantPinned)
+		}
+	}
+}
+
+func TestKantraConfig_Validate(t *testing.T) {
+	pinned := "quay.io/konveyor/kantra@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	tests := []struct {
+		name    string
+		cfg     *KantraConfig
+		wantErr bool
+	}{
+		{name: "no images", cfg: &KantraConfig{}},
+		{name: "invalid provider image", cfg: &KantraConfig{ProviderImages: map[string]string{"java": "not an image"}}, wantErr: true},
+		{name: "digests required without runner image", cfg: &KantraConfig{RequireDigests: true}, wantErr: true},
+		{name: "digests required with tagged provider image", cfg: &KantraConfig{
+			RequireDigests: true,
+			RunnerImage:    pinned,
+			ProviderImages: map[string]string{"java": "quay.io/konveyor/java-external-provider:latest"},
+		}, wantErr: true},
+		{name: "pinned images", cfg: &KantraConfig{
+			RequireDigests: true,
+			RunnerImage:    pinned,
+			ProviderImages: map[string]string{"java": "quay.io/konveyor/java-external-provider@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
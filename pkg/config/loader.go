@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
-	"gopkg.in/yaml.v3"
+	"github.com/konveyor/test-harness/pkg/expected"
 )
 
 // Load reads and parses a test definition from a YAML file
@@ -22,9 +22,10 @@ func LoadWithOptions(path string, skipExpectedOutput bool) (*TestDefinition, err
 		return nil, fmt.Errorf("failed to read test file %s: %w", path, err)
 	}
 
-	var test TestDefinition
-	if err := yaml.Unmarshal(data, &test); err != nil {
-		return nil, fmt.Errorf("failed to parse test YAML: %w", err)
+	// Unknown fields are rejected, so typos don't silently leave settings empty
+	test, err := decodeTestDefinition(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse test YAML %s: %w", path, err)
 	}
 
 	// Store the absolute path to the test file
@@ -37,43 +38,101 @@ func LoadWithOptions(path string, skipExpectedOutput bool) (*TestDefinition, err
 	// Parse Git URLs in the analysis configuration
 	test.Analysis.ParseGitURLs()
 
-	// If the expected output specifies a file, load it (unless skipped)
-	if test.Expect.Output.File != "" && !skipExpectedOutput {
-		// Resolve the expected output file path relative to the test file's directory
-		expectedOutputPath := test.Expect.Output.File
-		if !filepath.IsAbs(expectedOutputPath) {
-			testDir := filepath.Dir(path)
-			expectedOutputPath = filepath.Join(testDir, expectedOutputPath)
+	// If the expected outputs specify a file, load them (unless skipped)
+	// Matrix tests load them per variant since their file names contain placeholders
+	if !skipExpectedOutput && !test.IsMatrix() {
+		if err := LoadExpectedOutputs(test); err != nil {
+			return nil, err
 		}
+	}
+
+	return test, nil
+}
 
-		// Store the resolved absolute path
-		absExpectedPath, err := filepath.Abs(expectedOutputPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get absolute path for expected output: %w", err)
+// LoadExpectedOutputs loads the expected output files of a test and its applications
+func LoadExpectedOutputs(test *TestDefinition) error {
+	testDir := test.GetTestDir()
+	vars := test.TemplateVars(ApplicationName(test.Analysis.Application))
+	if err := resolveExpectedOutput(&test.Expect.Output, testDir, vars); err != nil {
+		return err
+	}
+	for i := range test.Analysis.Applications {
+		app := &test.Analysis.Applications[i]
+		if err := resolveExpectedOutput(&app.Expect, testDir, test.TemplateVars(app.Name)); err != nil {
+			return fmt.Errorf("application %s: %w", app.Name, err)
 		}
-		test.Expect.Output.ResolvedFilePath = absExpectedPath
+	}
+	return nil
+}
 
-		rulesets, err := LoadExpectedOutput(expectedOutputPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load expected output from %s: %w", test.Expect.Output.File, err)
+// LoadVariants returns the variants of a matrix test with their expected outputs loaded, or the test itself
+func LoadVariants(test *TestDefinition) ([]*TestDefinition, error) {
+	if !test.IsMatrix() {
+		return []*TestDefinition{test}, nil
+	}
+	variants := test.MatrixVariants()
+	for _, variant := range variants {
+		if err := LoadExpectedOutputs(variant); err != nil {
+			return nil, fmt.Errorf("variant %s: %w", variant.Variant.Name(), err)
 		}
+	}
+	return variants, nil
+}
+
+// resolveExpectedOutput loads the expected output file, if one is specified,
+// resolving it relative to the test file's directory. Template files are rendered with vars
+func resolveExpectedOutput(output *ExpectedOutput, testDir string, vars map[string]any) error {
+	if output.File == "" {
+		return nil
+	}
+	if !output.Template {
+		vars = nil
+	}
 
-		test.Expect.Output.Result = rulesets
+	expectedOutputPath := output.File
+	if !filepath.IsAbs(expectedOutputPath) {
+		expectedOutputPath = filepath.Join(testDir, expectedOutputPath)
 	}
 
-	return &test, nil
+	// Store the resolved absolute path
+	absExpectedPath, err := filepath.Abs(expectedOutputPath)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path for expected output: %w", err)
+	}
+	output.ResolvedFilePath = absExpectedPath
+
+	rulesets, err := LoadExpectedOutputWithVars(expectedOutputPath, vars)
+	if err != nil {
+		return fmt.Errorf("failed to load expected output from %s: %w", output.File, err)
+	}
+	output.Result = rulesets
+
+	return nil
 }
 
-// LoadExpectedOutput reads and parses expected RuleSets from a YAML file
+// LoadExpectedOutput reads and parses expected RuleSets from a YAML or JSON file
 func LoadExpectedOutput(path string) ([]konveyor.RuleSet, error) {
+	return LoadExpectedOutputWithVars(path, nil)
+}
+
+// LoadExpectedOutputWithVars reads expected RuleSets from a YAML or JSON file, rendering it as a Go
+// template with vars first unless vars are nil
+func LoadExpectedOutputWithVars(path string, vars map[string]any) ([]konveyor.RuleSet, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read expected output file: %w", err)
 	}
 
-	var rulesets []konveyor.RuleSet
-	if err := yaml.Unmarshal(data, &rulesets); err != nil {
-		return nil, fmt.Errorf("failed to parse expected output YAML: %w", err)
+	if vars != nil {
+		if data, err = renderExpectedOutput(data, vars); err != nil {
+			return nil, fmt.Errorf("failed to render expected output: %w", err)
+		}
+	}
+
+	// Files of older schema versions are upgraded in memory, migrate-expected rewrites them
+	rulesets, _, err := expected.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse expected output: %w", err)
 	}
 
 	return rulesets, nil
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+// Placeholders replaced in the expected output files of matrix tests
+const (
+	// MatrixModePlaceholder is replaced with the analysis mode of a variant
+	MatrixModePlaceholder = "{mode}"
+	// MatrixTargetPlaceholder is replaced with the migration target of a variant
+	MatrixTargetPlaceholder = "{target}"
+)
+
+// MatrixConfig expands a test into one variant per combination of parameters
+// Parameters left empty keep the value of the test's analysis configuration
+type MatrixConfig struct {
+	// Modes are the analysis modes to run the test in
+	Modes []provider.AnalysisMode `yaml:"modes,omitempty" validate:"unique,dive,oneof=full source-only"`
+
+	// Targets are the migration targets to analyze for, one per variant
+	Targets []string `yaml:"targets,omitempty" validate:"unique,dive,required"`
+}
+
+// MatrixVariant is one combination of matrix parameters, empty parameters aren't expanded
+type MatrixVariant struct {
+	Mode   provider.AnalysisMode
+	Target string
+}
+
+// Name returns the parameters of the variant joined with dashes, e.g. source-only-quarkus
+func (v MatrixVariant) Name() string {
+	var parts []string
+	if v.Mode != "" {
+		parts = append(parts, string(v.Mode))
+	}
+	if v.Target != "" {
+		parts = append(parts, v.Target)
+	}
+	return strings.Join(parts, "-")
+}
+
+// Expand replaces the matrix placeholders in s with the variant's parameters
+func (v MatrixVariant) Expand(s string) string {
+	return strings.NewReplacer(MatrixModePlaceholder, string(v.Mode), MatrixTargetPlaceholder, v.Target).Replace(s)
+}
+
+// Variants returns every combination of the matrix parameters, modes first
+func (m *MatrixConfig) Variants() []MatrixVariant {
+	modes := m.Modes
+	if len(modes) == 0 {
+		modes = []provider.AnalysisMode{""}
+	}
+	targets := m.Targets
+	if len(targets) == 0 {
+		targets = []string{""}
+	}
+
+	variants := make([]MatrixVariant, 0, len(modes)*len(targets))
+	for _, mode := range modes {
+		for _, target := range targets {
+			variants = append(variants, MatrixVariant{Mode: mode, Target: target})
+		}
+	}
+	return variants
+}
+
+// IsMatrix returns true if the test expands into several variants
+func (td *TestDefinition) IsMatrix() bool {
+	return td.Matrix != nil
+}
+
+// ForVariant returns a copy of the test with the variant's parameters applied and the placeholders of its
+// expected output file replaced. Expected outputs are loaded separately with LoadExpectedOutputs
+// The copy keeps the test file path so relative paths resolve the same way
+func (td *TestDefinition) ForVariant(v MatrixVariant) *TestDefinition {
+	variant := *td
+	variant.Name = fmt.Sprintf("%s-%s", td.Name, v.Name())
+	variant.Matrix = nil
+	variant.Variant = &v
+	if v.Mode != "" {
+		variant.Analysis.AnalysisMode = v.Mode
+	}
+	if v.Target != "" {
+		variant.Analysis.Target = []string{v.Target}
+	}
+	variant.Expect.Output = ExpectedOutput{File: v.Expand(td.Expect.Output.File), Template: td.Expect.Output.Template}
+	if td.Expect.Output.File == "" {
+		variant.Expect.Output.Result = td.Expect.Output.Result
+	}
+	return &variant
+}
+
+// MatrixVariants returns the variants of a matrix test, or the test itself without a matrix
+func (td *TestDefinition) MatrixVariants() []*TestDefinition {
+	if !td.IsMatrix() {
+		return []*TestDefinition{td}
+	}
+	var variants []*TestDefinition
+	for _, v := range td.Matrix.Variants() {
+		variants = append(variants, td.ForVariant(v))
+	}
+	return variants
+}
+
+// FindVariant returns the variant of a matrix test with the given name, see MatrixVariant.Name
+func (td *TestDefinition) FindVariant(name string) (*TestDefinition, error) {
+	for _, variant := range td.MatrixVariants() {
+		if variant.Variant != nil && variant.Variant.Name() == name {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("test %s has no matrix variant %s", td.Name, name)
+}
+
+// validateMatrix checks the matrix parameters and every variant of a matrix test
+func validateMatrix(test *TestDefinition) error {
+	if err := validate.Struct(test.Matrix); err != nil {
+		return fmt.Errorf("invalid matrix: %w", err)
+	}
+	if len(test.Matrix.Modes) == 0 && len(test.Matrix.Targets) == 0 {
+		return fmt.Errorf("matrix must specify modes or targets")
+	}
+	if test.IsMultiApplication() || test.IsAssetTest() || test.IsFixTest() {
+		return fmt.Errorf("matrix tests must analyze a single application")
+	}
+	// Variants share inline results, but each needs its own expected output file
+	if file := test.Expect.Output.File; file != "" {
+		if len(test.Matrix.Modes) > 1 && !strings.Contains(file, MatrixModePlaceholder) {
+			return fmt.Errorf("'expect.output.file' must contain %s to expand matrix modes", MatrixModePlaceholder)
+		}
+		if len(test.Matrix.Targets) > 1 && !strings.Contains(file, MatrixTargetPlaceholder) {
+			return fmt.Errorf("'expect.output.file' must contain %s to expand matrix targets", MatrixTargetPlaceholder)
+		}
+	}
+
+	for _, variant := range test.MatrixVariants() {
+		if err := Validate(variant); err != nil {
+			return fmt.Errorf("variant %s: %w", variant.Variant.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+func TestMatrixConfig_Variants(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix MatrixConfig
+		want   []string
+	}{
+		{
+			name:   "modes and targets",
+			matrix: MatrixConfig{Modes: []provider.AnalysisMode{"source-only", "full"}, Targets: []string{"cloud-readiness", "quarkus"}},
+			want:   []string{"source-only-cloud-readiness", "source-only-quarkus", "full-cloud-readiness", "full-quarkus"},
+		},
+		{
+			name:   "modes only",
+			matrix: MatrixConfig{Modes: []provider.AnalysisMode{"source-only", "full"}},
+			want:   []string{"source-only", "full"},
+		},
+		{
+			name:   "targets only",
+			matrix: MatrixConfig{Targets: []string{"quarkus"}},
+			want:   []string{"quarkus"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range tt.matrix.Variants() {
+				got = append(got, v.Name())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Variants() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTestDefinition_ForVariant(t *testing.T) {
+	test := &TestDefinition{
+		Name:     "daytrader",
+		Analysis: AnalysisConfig{Application: "/apps/daytrader", AnalysisMode: "full", Target: []string{"eap8"}},
+		Matrix:   &MatrixConfig{Modes: []provider.AnalysisMode{"source-only"}, Targets: []string{"quarkus"}},
+		Expect:   ExpectConfig{Output: ExpectedOutput{File: "expected/{mode}-{target}.yaml"}},
+	}
+	test.SetTestFilePath("/tests/daytrader/test.yaml")
+
+	variant := test.ForVariant(MatrixVariant{Mode: "source-only", Target: "quarkus"})
+	if variant.Name != "daytrader-source-only-quarkus" {
+		t.Errorf("Name = %s", variant.Name)
+	}
+	if variant.Analysis.AnalysisMode != "source-only" || !slices.Equal(variant.Analysis.Target, []string{"quarkus"}) {
+		t.Errorf("Analysis = %+v", variant.Analysis)
+	}
+	if variant.Expect.Output.File != "expected/source-only-quarkus.yaml" {
+		t.Errorf("Expect.Output.File = %s", variant.Expect.Output.File)
+	}
+	if variant.IsMatrix() || variant.GetTestDir() != "/tests/daytrader" {
+		t.Errorf("variant should be a plain test in the same directory: %+v", variant)
+	}
+	if test.Analysis.AnalysisMode != "full" || test.Analysis.Target[0] != "eap8" {
+		t.Errorf("original test was modified: %+v", test.Analysis)
+	}
+}
+
+func TestValidate_Matrix(t *testing.T) {
+	tests := []struct {
+		name    string
+		matrix  *MatrixConfig
+		mode    provider.AnalysisMode
+		file    string
+		wantErr string
+	}{
+		{
+			name:   "modes replace the analysis mode",
+			matrix: &MatrixConfig{Modes: []provider.AnalysisMode{"source-only", "full"}},
+			file:   "expected-{mode}.yaml",
+		},
+		{
+			name:   "targets keep the analysis mode",
+			matrix: &MatrixConfig{Targets: []string{"quarkus", "cloud-readiness"}},
+			mode:   "full",
+			file:   "expected-{target}.yaml",
+		},
+		{
+			name:    "missing analysis mode",
+			matrix:  &MatrixConfig{Targets: []string{"quarkus"}},
+			file:    "expected.yaml",
+			wantErr: "variant quarkus",
+		},
+		{
+			name:    "shared expected file",
+			matrix:  &MatrixConfig{Targets: []string{"quarkus", "cloud-readiness"}},
+			mode:    "full",
+			file:    "expected.yaml",
+			wantErr: "{target}",
+		},
+		{
+			name:    "unknown mode",
+			matrix:  &MatrixConfig{Modes: []provider.AnalysisMode{"binary"}},
+			file:    "expected.yaml",
+			wantErr: "invalid matrix",
+		},
+		{
+			name:    "empty matrix",
+			matrix:  &MatrixConfig{},
+			mode:    "full",
+			file:    "expected.yaml",
+			wantErr: "modes or targets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name:     "daytrader",
+				Analysis: AnalysisConfig{Application: "/apps/daytrader", AnalysisMode: tt.mode},
+				Matrix:   tt.matrix,
+				Expect:   ExpectConfig{Output: ExpectedOutput{File: tt.file}},
+			}
+			err := Validate(test)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadVariants(t *testing.T) {
+	dir := t.TempDir()
+	testYAML := `name: daytrader
+analysis:
+  application: /apps/daytrader
+  analysisMode: full
+matrix:
+  targets: [quarkus, cloud-readiness]
+expect:
+  output:
+    file: expected-{target}.yaml
+`
+	files := map[string]string{
+		"test.yaml":                     testYAML,
+		"expected-quarkus.yaml":         "- name: quarkus\n",
+		"expected-cloud-readiness.yaml": "- name: cloud-readiness\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The expected files contain placeholders until the test is expanded
+	test, err := Load(filepath.Join(dir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := Validate(test); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	variants, err := LoadVariants(test)
+	if err != nil {
+		t.Fatalf("LoadVariants() error = %v", err)
+	}
+	if len(variants) != 2 {
+		t.Fatalf("got %d variants, want 2", len(variants))
+	}
+	for _, variant := range variants {
+		result := variant.Expect.Output.Result
+		if len(result) != 1 || result[0].Name != variant.Variant.Target {
+			t.Errorf("variant %s loaded %+v", variant.Name, result)
+		}
+	}
+
+	if _, err := test.FindVariant("eap8"); err == nil {
+		t.Error("FindVariant() expected error for an unknown variant")
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// When notifications are sent
+const (
+	// NotifyAlways notifies after every run
+	NotifyAlways = "always"
+	// NotifyOnFailure notifies only when tests failed or couldn't be executed
+	NotifyOnFailure = "failure"
+)
+
+// NotifyConfig defines who is notified when a run completes
+type NotifyConfig struct {
+	// When to notify: always or failure (default: failure)
+	When string `yaml:"when,omitempty" validate:"omitempty,oneof=always failure"`
+
+	// MinFailures is the number of failed or errored tests needed to notify on failure (default: 1)
+	MinFailures int `yaml:"minFailures,omitempty" validate:"gte=0"`
+
+	// ReportURL links to the run's report, uploaded artifacts are linked when empty
+	ReportURL string `yaml:"reportURL,omitempty"`
+
+	// Notifiers receiving the run summary
+	Notifiers []NotifierConfig `yaml:"notifiers" validate:"required,min=1,dive"`
+}
+
+// NotifierConfig configures a single notification sender
+type NotifierConfig struct {
+	// Type of the sender: slack, webhook or email
+	Type string `yaml:"type" validate:"required,oneof=slack webhook email"`
+
+	// Slack incoming webhook configuration
+	Slack *SlackNotifierConfig `yaml:"slack,omitempty" validate:"required_if=Type slack,omitempty"`
+
+	// Generic JSON webhook configuration
+	Webhook *WebhookNotifierConfig `yaml:"webhook,omitempty" validate:"required_if=Type webhook,omitempty"`
+
+	// Email configuration
+	Email *EmailNotifierConfig `yaml:"email,omitempty" validate:"required_if=Type email,omitempty"`
+}
+
+// SlackNotifierConfig for posting to a Slack incoming webhook
+type SlackNotifierConfig struct {
+	WebhookURL string `yaml:"webhookURL" validate:"required,url"`
+
+	// WebhookURLFrom reads the webhook URL from a secret instead
+	WebhookURLFrom *SecretRef `yaml:"webhookURLFrom,omitempty"`
+}
+
+// WebhookNotifierConfig for posting the run summary as JSON
+type WebhookNotifierConfig struct {
+	URL     string            `yaml:"url" validate:"required,url"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// EmailNotifierConfig for sending the run summary over SMTP
+type EmailNotifierConfig struct {
+	Host     string   `yaml:"host" validate:"required"`
+	Port     int      `yaml:"port,omitempty"` // Default: 587
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from" validate:"required,email"`
+	To       []string `yaml:"to" validate:"required,min=1,dive,email"`
+
+	// PasswordFrom reads the password from a secret instead
+	PasswordFrom *SecretRef `yaml:"passwordFrom,omitempty"`
+}
+
+// GetWhen returns when to notify with a default
+func (nc *NotifyConfig) GetWhen() string {
+	if nc.When == "" {
+		return NotifyOnFailure
+	}
+	return nc.When
+}
+
+// GetMinFailures returns the failures needed to notify with a default
+func (nc *NotifyConfig) GetMinFailures() int {
+	if nc.MinFailures <= 0 {
+		return 1
+	}
+	return nc.MinFailures
+}
+
+// ShouldNotify reports whether a run with the given number of failed or errored tests is notified
+func (nc *NotifyConfig) ShouldNotify(failures int) bool {
+	if nc.GetWhen() == NotifyAlways {
+		return true
+	}
+	return failures >= nc.GetMinFailures()
+}
+
+// LoadNotifyConfig loads and validates notification configuration from a file
+// Environment variables such as ${SLACK_WEBHOOK_URL} are expanded so secrets can stay out of the file
+func LoadNotifyConfig(path string) (*NotifyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notify config file %s: %w", path, err)
+	}
+
+	var notifyConfig NotifyConfig
+	if err := yaml.Unmarshal([]byte(os.ExpandEnv(string(data))), &notifyConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse notify config YAML: %w", err)
+	}
+
+	if err := notifyConfig.ResolveSecrets(); err != nil {
+		return nil, fmt.Errorf("invalid notify config: %w", err)
+	}
+
+	if err := validate.Struct(&notifyConfig); err != nil {
+		return nil, fmt.Errorf("invalid notify config: %w", err)
+	}
+
+	return &notifyConfig, nil
+}
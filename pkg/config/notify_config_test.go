@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadNotifyConfig(t *testing.T) {
+	t.Setenv("TEST_SLACK_WEBHOOK", "https://hooks.slack.com/services/T0/B0/secret")
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "all notifiers",
+			content: `when: always
+notifiers:
+  - type: slack
+    slack:
+      webhookURL: ${TEST_SLACK_WEBHOOK}
+  - type: webhook
+    webhook:
+      url: https://ci.example.com/hooks/koncur
+  - type: email
+    email:
+      host: smtp.example.com
+      from: koncur@example.com
+      to: [team@example.com]
+`,
+		},
+		{name: "no notifiers", content: "when: failure\n", wantErr: "Notifiers"},
+		{name: "unknown when", content: "when: sometimes\nnotifiers:\n  - type: webhook\n    webhook:\n      url: https://ci.example.com\n", wantErr: "When"},
+		{name: "missing sender config", content: "notifiers:\n  - type: slack\n", wantErr: "Slack"},
+		{name: "invalid recipient", content: "notifiers:\n  - type: email\n    email:\n      host: smtp\n      from: koncur@example.com\n      to: [team]\n", wantErr: "To"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notify.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := LoadNotifyConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadNotifyConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadNotifyConfig() unexpected error: %v", err)
+			}
+			if got := cfg.Notifiers[0].Slack.WebhookURL; got != "https://hooks.slack.com/services/T0/B0/secret" {
+				t.Errorf("webhook URL not expanded from the environment: %s", got)
+			}
+		})
+	}
+}
+
+func TestNotifyConfig_ShouldNotify(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      NotifyConfig
+		failures int
+		expected bool
+	}{
+		{name: "default skips passing runs", failures: 0, expected: false},
+		{name: "default notifies failures", failures: 1, expected: true},
+		{name: "always", cfg: NotifyConfig{When: NotifyAlways}, failures: 0, expected: true},
+		{name: "below threshold", cfg: NotifyConfig{MinFailures: 3}, failures: 2, expected: false},
+		{name: "at threshold", cfg: NotifyConfig{MinFailures: 3}, failures: 3, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.ShouldNotify(tt.failures); got != tt.expected {
+				t.Errorf("ShouldNotify(%d) = %v, want %v", tt.failures, got, tt.expected)
+			}
+		})
+	}
+}
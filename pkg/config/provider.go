@@ -0,0 +1,24 @@
+package config
+
+// Analysis providers that can be selected in a test
+const (
+	ProviderJava   = "java"
+	ProviderGo     = "go"
+	ProviderPython = "python"
+	ProviderNodeJS = "nodejs"
+	ProviderDotnet = "dotnet"
+)
+
+// GenericProvider is the external provider serving go, python and nodejs analysis
+const GenericProvider = "generic"
+
+// ProviderImplementation returns the provider that runs the analysis for a language
+// The go, python and nodejs providers are all served by the generic external provider
+func ProviderImplementation(name string) string {
+	switch name {
+	case ProviderGo, ProviderPython, ProviderNodeJS:
+		return GenericProvider
+	default:
+		return name
+	}
+}
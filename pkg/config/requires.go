@@ -0,0 +1,57 @@
+package config
+
+import "slices"
+
+// Capability is a target feature a test depends on
+type Capability string
+
+// Capabilities known to the harness
+const (
+	// CapabilityIncidentSelector filters incidents with analysis.incident_selector
+	CapabilityIncidentSelector Capability = "incident-selector"
+	// CapabilityProviderSelection runs the providers in analysis.providers instead of detecting them
+	CapabilityProviderSelection Capability = "provider-selection"
+	// CapabilityProviderSettings overrides provider settings with analysis.providerSettings
+	CapabilityProviderSettings Capability = "provider-settings"
+	// CapabilityCustomTarget creates the custom migration target of analysis.customTarget
+	CapabilityCustomTarget Capability = "custom-target"
+)
+
+// KnownCapabilities lists every capability tests may require
+var KnownCapabilities = []Capability{
+	CapabilityIncidentSelector,
+	CapabilityProviderSelection,
+	CapabilityProviderSettings,
+	CapabilityCustomTarget,
+}
+
+// RequiresConfig declares what a test needs from the target, tests are skipped on targets lacking it
+type RequiresConfig struct {
+	// Capabilities needed in addition to those the test definition implies
+	Capabilities []Capability `yaml:"capabilities,omitempty" validate:"dive,oneof=incident-selector provider-selection provider-settings custom-target"`
+
+	// Versions maps target types to their minimum version, e.g. kantra: v0.7.0
+	Versions map[string]string `yaml:"versions,omitempty" validate:"dive,keys,required,endkeys,required"`
+}
+
+// RequiredCapabilities returns the capabilities the test uses or declares, sorted
+func (td *TestDefinition) RequiredCapabilities() []Capability {
+	var capabilities []Capability
+	if td.Analysis.IncidentSelector != "" {
+		capabilities = append(capabilities, CapabilityIncidentSelector)
+	}
+	if len(td.Analysis.Providers) > 0 {
+		capabilities = append(capabilities, CapabilityProviderSelection)
+	}
+	if len(td.Analysis.ProviderSettings) > 0 {
+		capabilities = append(capabilities, CapabilityProviderSettings)
+	}
+	if td.Analysis.CustomTarget != nil {
+		capabilities = append(capabilities, CapabilityCustomTarget)
+	}
+	if td.Requires != nil {
+		capabilities = append(capabilities, td.Requires.Capabilities...)
+	}
+	slices.Sort(capabilities)
+	return slices.Compact(capabilities)
+}
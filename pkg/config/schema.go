@@ -0,0 +1,274 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/konveyor/test-harness/pkg/fixtures"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaError is a problem with a field of a test definition file
+type schemaError struct {
+	// line of the field, or of its parent when the field is missing, zero when unknown
+	line    int
+	path    string
+	message string
+}
+
+func (e *schemaError) Error() string {
+	msg := e.message
+	if e.path != "" {
+		msg = fmt.Sprintf("%s %s", e.path, e.message)
+	}
+	if e.line > 0 {
+		return fmt.Sprintf("line %d: %s", e.line, msg)
+	}
+	return msg
+}
+
+// unknownFieldPattern matches the errors yaml.v3 reports for fields missing from the target struct
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (\S+) not found in type (\S+)$`)
+
+// decodeTestDefinition strictly decodes a test definition, rejecting fields the definition doesn't have
+// so typos fail loading instead of silently producing an empty setting. The parsed document is kept
+// to report the lines of fields failing validation
+func decodeTestDefinition(data []byte) (*TestDefinition, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var test TestDefinition
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&test); err != nil && !errors.Is(err, io.EOF) {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+		errs := make([]error, 0, len(typeErr.Errors))
+		for _, msg := range typeErr.Errors {
+			errs = append(errs, unknownFieldError(msg))
+		}
+		return nil, errors.Join(errs...)
+	}
+	test.document = &doc
+	return &test, nil
+}
+
+// unknownFieldError rewrites a yaml.v3 unknown field error, suggesting the field that was probably meant
+// Other decoding errors are returned unchanged
+func unknownFieldError(msg string) error {
+	match := unknownFieldPattern.FindStringSubmatch(msg)
+	if match == nil {
+		return errors.New(msg)
+	}
+	line, _ := strconv.Atoi(match[1])
+	err := &schemaError{line: line, message: fmt.Sprintf("unknown field %q", match[2])}
+	if suggestion := closestField(match[2], knownFields[match[3]]); suggestion != "" {
+		err.message += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return err
+}
+
+// knownFields maps the types of a test definition, named like in yaml.v3 errors, to their YAML fields
+var knownFields = map[string][]string{}
+
+func init() {
+	collectKnownFields(reflect.TypeOf(TestDefinition{}))
+}
+
+// collectKnownFields records the YAML fields of a struct type and the struct types it contains
+func collectKnownFields(t reflect.Type) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.PkgPath() != reflect.TypeOf(TestDefinition{}).PkgPath() {
+		return
+	}
+	if _, ok := knownFields[t.String()]; ok {
+		return
+	}
+	knownFields[t.String()] = nil
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name := yamlName(field)
+		if name == "" {
+			continue
+		}
+		knownFields[t.String()] = append(knownFields[t.String()], name)
+		collectKnownFields(field.Type)
+	}
+}
+
+// yamlName returns the YAML name of a struct field, empty for fields that aren't decoded
+func yamlName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// closestField returns the known field closest to a misspelled one, empty when none is close
+func closestField(name string, fields []string) string {
+	best, bestDistance := "", len(name)/2+1
+	for _, field := range fields {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(field)); d < bestDistance {
+			best, bestDistance = field, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings, treating a swap of adjacent
+// characters as a single edit
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// validationErrors converts struct validation errors into errors naming the YAML fields and their lines
+func validationErrors(test *TestDefinition, err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	errs := make([]error, 0, len(fieldErrs))
+	for _, fe := range fieldErrs {
+		path := yamlPath(reflect.TypeOf(*test), fe.StructNamespace())
+		errs = append(errs, &schemaError{
+			line:    fieldLine(test.document, path),
+			path:    strings.Join(path, "."),
+			message: validationMessage(fe),
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// validationMessage describes why a field failed validation
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_without":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(strings.Fields(fe.Param()), ", "), fmt.Sprint(fe.Value()))
+	case "excluded_with":
+		return "cannot be combined with " + strings.ToLower(fe.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "fixture":
+		return fmt.Sprintf("must be one of the fixtures %s, got %q", strings.Join(fixtures.Names(), ", "), fmt.Sprint(fe.Value()))
+	default:
+		if fe.Param() != "" {
+			return fmt.Sprintf("failed %s=%s validation", fe.Tag(), fe.Param())
+		}
+		return fmt.Sprintf("failed %s validation", fe.Tag())
+	}
+}
+
+// indexPattern matches the slice and map indexes of a validator namespace, e.g. applications[0]
+var indexPattern = regexp.MustCompile(`^(\w+)\[(.+)\]$`)
+
+// yamlPath converts a validator struct namespace such as TestDefinition.Analysis.Applications[0].Name
+// into the path of YAML keys, e.g. analysis, applications, 0, name
+func yamlPath(t reflect.Type, namespace string) []string {
+	parts := strings.Split(namespace, ".")
+	var path []string
+	for _, part := range parts[1:] {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		name, index := part, ""
+		if match := indexPattern.FindStringSubmatch(part); match != nil {
+			name, index = match[1], match[2]
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			path = append(path, strings.ToLower(name))
+			break
+		}
+		path = append(path, yamlName(field))
+		t = field.Type
+		if index != "" {
+			path = append(path, index)
+			for t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			t = t.Elem()
+		}
+	}
+	return path
+}
+
+// fieldLine returns the line of a YAML path in a document, or of the deepest parent present when the
+// path is missing. It is zero without a document
+func fieldLine(doc *yaml.Node, path []string) int {
+	if doc == nil {
+		return 0
+	}
+	node := doc
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+	for _, key := range path {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(key); err == nil && i < len(node.Content) {
+				next = node.Content[i]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
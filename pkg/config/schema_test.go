@@ -0,0 +1,174 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_Schema(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string
+	}{
+		{
+			name: "unknown top-level field",
+			content: `name: typo
+lables: [smoke]
+analysis:
+  application: /apps/a
+  analysisMode: full
+expect:
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: []string{`line 2: unknown field "lables"`},
+		},
+		{
+			name: "misspelled nested field",
+			content: `name: typo
+analysis:
+  application: /apps/a
+  analysisMdoe: full
+expect:
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: []string{`line 4: unknown field "analysisMdoe", did you mean "analysisMode"?`},
+		},
+		{
+			name: "several unknown fields",
+			content: `name: typo
+analysis:
+  application: /apps/a
+  analysisMode: full
+  labelselector: konveyor.io/target=quarkus
+expect:
+  exitcode: 0
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: []string{`line 5: unknown field "labelselector", did you mean "labelSelector"?`, `line 7: unknown field "exitcode", did you mean "exitCode"?`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatal("Load() expected error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %v, want %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_SchemaLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "missing analysis mode",
+			content: `name: missing
+analysis:
+  application: /apps/a
+expect:
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: "line 2: analysis.analysisMode is required",
+		},
+		{
+			name: "unknown analysis mode",
+			content: `name: unknown-mode
+analysis:
+  application: /apps/a
+  analysisMode: binary
+expect:
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: `line 4: analysis.analysisMode must be one of full, source-only, got "binary"`,
+		},
+		{
+			name: "missing application name",
+			content: `name: apps
+analysis:
+  analysisMode: full
+  applications:
+  - application: /apps/a
+    expect:
+      result:
+      - name: rules
+`,
+			wantErr: "line 5: analysis.applications.0.name is required",
+		},
+		{
+			name: "missing name",
+			content: `analysis:
+  application: /apps/a
+  analysisMode: full
+expect:
+  output:
+    result:
+    - name: rules
+`,
+			wantErr: "line 1: name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			test, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			err = Validate(test)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"analysisMode", "analysisMode", 0},
+		{"analysisMdoe", "analysisMode", 1},
+		{"exitcode", "exitcode", 0},
+		{"lables", "labels", 1},
+		{"tags", "target", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
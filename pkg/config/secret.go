@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// SecretRef references a secret that is resolved when the configuration is loaded, so credentials never
+// have to be written in plaintext into configuration files committed to a repository
+type SecretRef struct {
+	// File containing the secret, e.g. a mounted Kubernetes Secret. Trailing newlines are removed
+	File string `yaml:"file,omitempty"`
+
+	// Env is the environment variable holding the secret
+	Env string `yaml:"env,omitempty"`
+
+	// VaultPath reads the secret from HashiCorp Vault as <path>#<key>, e.g. secret/data/koncur#hubPassword.
+	// The server and token come from VAULT_ADDR and VAULT_TOKEN
+	VaultPath string `yaml:"vaultPath,omitempty"`
+}
+
+// vaultClient reads Vault secrets
+var vaultClient = &http.Client{Timeout: 30 * time.Second}
+
+// Resolve returns the secret the reference points to
+func (r *SecretRef) Resolve() (string, error) {
+	set := 0
+	for _, source := range []string{r.File, r.Env, r.VaultPath} {
+		if source != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return "", fmt.Errorf("exactly one of file, env or vaultPath is required")
+	}
+
+	switch {
+	case r.File != "":
+		data, err := os.ReadFile(r.File)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case r.Env != "":
+		value, ok := os.LookupEnv(r.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", r.Env)
+		}
+		return value, nil
+	default:
+		return readVaultSecret(r.VaultPath)
+	}
+}
+
+// readVaultSecret reads a key of a Vault secret, from KV version 2 or version 1 secrets engines
+func readVaultSecret(vaultPath string) (string, error) {
+	path, key, ok := strings.Cut(vaultPath, "#")
+	if !ok || path == "" || key == "" {
+		return "", fmt.Errorf("invalid vaultPath %q, expected <path>#<key>", vaultPath)
+	}
+	addr, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+	if addr == "" || token == "" {
+		return "", fmt.Errorf("VAULT_ADDR and VAULT_TOKEN are required to read %s", vaultPath)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := vaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to read Vault secret %s: %s", path, resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("failed to parse Vault secret %s: %w", path, err)
+	}
+	// KV version 2 nests the secret's keys under data.data
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, metadata := data["metadata"]; metadata {
+			data = nested
+		}
+	}
+	value, ok := data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("Vault secret %s has no key %s", path, key)
+	}
+	return value, nil
+}
+
+// resolveSecret sets a credential from its secret reference, when it has one
+// The reference is removed once resolved, so configurations can be resolved again
+func resolveSecret(name string, value *string, ref **SecretRef) error {
+	if *ref == nil {
+		return nil
+	}
+	if *value != "" {
+		return fmt.Errorf("%s and %sFrom cannot both be set", name, name)
+	}
+	secret, err := (*ref).Resolve()
+	if err != nil {
+		return fmt.Errorf("failed to resolve %sFrom: %w", name, err)
+	}
+	*value, *ref = secret, nil
+	return nil
+}
+
+// ResolveSecrets replaces the secret references of the selected target with their secrets
+// Other targets keep their references, so credentials of targets that don't run aren't needed
+func (tc *TargetConfig) ResolveSecrets() error {
+	switch tc.Type {
+	case "kantra":
+		if tc.Kantra != nil {
+			if err := tc.Kantra.Git.resolveSecrets(); err != nil {
+				return fmt.Errorf("kantra.git: %w", err)
+			}
+		}
+	case "tackle-hub":
+		if hub := tc.TackleHub; hub != nil {
+			if err := resolveSecret("password", &hub.Password, &hub.PasswordFrom); err != nil {
+				return fmt.Errorf("tackleHub: %w", err)
+			}
+			if err := resolveSecret("token", &hub.Token, &hub.TokenFrom); err != nil {
+				return fmt.Errorf("tackleHub: %w", err)
+			}
+		}
+	case "tackle-ui":
+		if ui := tc.TackleUI; ui != nil {
+			if err := resolveSecret("password", &ui.Password, &ui.PasswordFrom); err != nil {
+				return fmt.Errorf("tackleUI: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveSecrets replaces the secret references of Git credentials with their secrets
+func (g *GitAuthConfig) resolveSecrets() error {
+	if g == nil {
+		return nil
+	}
+	if err := resolveSecret("token", &g.Token, &g.TokenFrom); err != nil {
+		return err
+	}
+	return resolveSecret("sshKeyPassword", &g.SSHKeyPassword, &g.SSHKeyPasswordFrom)
+}
+
+// ResolveSecrets replaces the secret references of a notification configuration with their secrets
+func (nc *NotifyConfig) ResolveSecrets() error {
+	for i, notifier := range nc.Notifiers {
+		if slack := notifier.Slack; slack != nil {
+			if err := resolveSecret("webhookURL", &slack.WebhookURL, &slack.WebhookURLFrom); err != nil {
+				return fmt.Errorf("notifiers[%d].slack: %w", i, err)
+			}
+		}
+		if email := notifier.Email; email != nil {
+			if err := resolveSecret("password", &email.Password, &email.PasswordFrom); err != nil {
+				return fmt.Errorf("notifiers[%d].email: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
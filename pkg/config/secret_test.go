@@ -0,0 +1,131 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSecretRef_Resolve(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/koncur":
+			w.Write([]byte(`{"data":{"data":{"hubPassword":"kv2-secret"},"metadata":{"version":1}}}`))
+		case "/v1/kv/koncur":
+			w.Write([]byte(`{"data":{"hubPassword":"kv1-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer vault.Close()
+	t.Setenv("VAULT_ADDR", vault.URL)
+	t.Setenv("VAULT_TOKEN", "root")
+	t.Setenv("TEST_HUB_PASSWORD", "env-secret")
+
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("file-secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		ref     SecretRef
+		want    string
+		wantErr string
+	}{
+		{name: "file", ref: SecretRef{File: file}, want: "file-secret"},
+		{name: "env", ref: SecretRef{Env: "TEST_HUB_PASSWORD"}, want: "env-secret"},
+		{name: "vault kv v2", ref: SecretRef{VaultPath: "secret/data/koncur#hubPassword"}, want: "kv2-secret"},
+		{name: "vault kv v1", ref: SecretRef{VaultPath: "kv/koncur#hubPassword"}, want: "kv1-secret"},
+		{name: "missing vault key", ref: SecretRef{VaultPath: "kv/koncur#token"}, wantErr: "no key token"},
+		{name: "missing vault secret", ref: SecretRef{VaultPath: "kv/other#token"}, wantErr: "404"},
+		{name: "vault path without key", ref: SecretRef{VaultPath: "kv/koncur"}, wantErr: "<path>#<key>"},
+		{name: "unset env", ref: SecretRef{Env: "TEST_UNSET_PASSWORD"}, wantErr: "not set"},
+		{name: "several sources", ref: SecretRef{File: file, Env: "TEST_HUB_PASSWORD"}, wantErr: "exactly one"},
+		{name: "no source", ref: SecretRef{}, wantErr: "exactly one"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ref.Resolve()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Resolve() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTargetConfig_ResolveSecrets(t *testing.T) {
+	t.Setenv("TEST_HUB_TOKEN", "hub-token")
+	t.Setenv("TEST_GIT_TOKEN", "git-token")
+
+	cfg := &TargetConfig{
+		Type:      "tackle-hub",
+		Kantra:    &KantraConfig{Git: &GitAuthConfig{TokenFrom: &SecretRef{Env: "TEST_UNSET_TOKEN"}}},
+		TackleHub: &TackleHubConfig{URL: "https://hub", TokenFrom: &SecretRef{Env: "TEST_HUB_TOKEN"}},
+	}
+	if err := cfg.ResolveSecrets(); err != nil {
+		t.Fatalf("ResolveSecrets() error = %v", err)
+	}
+	if cfg.TackleHub.Token != "hub-token" || cfg.TackleHub.TokenFrom != nil {
+		t.Errorf("ResolveSecrets() = hub token %q, reference %+v", cfg.TackleHub.Token, cfg.TackleHub.TokenFrom)
+	}
+	// Only the selected target's secrets are resolved
+	if cfg.Kantra.Git.Token != "" {
+		t.Errorf("ResolveSecrets() resolved the kantra git token %q", cfg.Kantra.Git.Token)
+	}
+
+	// A plaintext credential and a reference to it are ambiguous
+	cfg.TackleHub.TokenFrom = &SecretRef{Env: "TEST_HUB_TOKEN"}
+	if err := cfg.ResolveSecrets(); err == nil || !strings.Contains(err.Error(), "tackleHub: token and tokenFrom") {
+		t.Errorf("ResolveSecrets() error = %v", err)
+	}
+
+	cfg.Type = "kantra"
+	cfg.Kantra.Git.TokenFrom = &SecretRef{Env: "TEST_GIT_TOKEN"}
+	if err := cfg.ResolveSecrets(); err != nil || cfg.Kantra.Git.Token != "git-token" {
+		t.Errorf("ResolveSecrets() = git token %q, error %v", cfg.Kantra.Git.Token, err)
+	}
+}
+
+func TestLoadNotifyConfig_Secrets(t *testing.T) {
+	t.Setenv("TEST_SMTP_PASSWORD", "smtp-secret")
+	path := filepath.Join(t.TempDir(), "notify.yaml")
+	content := `notifiers:
+  - type: email
+    email:
+      host: smtp.example.com
+      username: koncur
+      passwordFrom:
+        env: TEST_SMTP_PASSWORD
+      from: koncur@example.com
+      to: [team@example.com]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadNotifyConfig(path)
+	if err != nil {
+		t.Fatalf("LoadNotifyConfig() error = %v", err)
+	}
+	if got := cfg.Notifiers[0].Email.Password; got != "smtp-secret" {
+		t.Errorf("Password = %q, want smtp-secret", got)
+	}
+}
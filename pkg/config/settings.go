@@ -0,0 +1,444 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration files holding settings
+const (
+	// GlobalConfigEnv names the harness-wide configuration file, replacing <user config dir>/koncur/config.yaml
+	GlobalConfigEnv = "KONCUR_CONFIG"
+	// SuiteConfigFile holds the settings of the test suite in the directory koncur runs from
+	SuiteConfigFile = ".koncur/config.yaml"
+)
+
+// Settings that environment variables and flags override
+const (
+	SettingTarget       = "target"
+	SettingTargetConfig = "targetConfig"
+	SettingArtifactsURL = "artifactsURL"
+	SettingNotifyConfig = "notifyConfig"
+	SettingTimeout      = "timeout"
+	SettingWorkDir      = "workDir"
+	SettingOffline      = "offline"
+	SettingSourceCache  = "sourceCache"
+
+	SettingStreamThreshold = "streamThreshold"
+
+	SettingMaxFailures        = "exitPolicy.maxFailures"
+	SettingMaxFailureRate     = "exitPolicy.maxFailureRate"
+	SettingValidationFailures = "exitPolicy.validationFailures"
+)
+
+// SettingEnv maps settings to the KONCUR_* environment variables overriding them
+var SettingEnv = map[string]string{
+	SettingTarget:       "KONCUR_TARGET",
+	SettingTargetConfig: "KONCUR_TARGET_CONFIG",
+	SettingArtifactsURL: "KONCUR_ARTIFACTS_URL",
+	SettingNotifyConfig: "KONCUR_NOTIFY_CONFIG",
+	SettingTimeout:      "KONCUR_TIMEOUT",
+	SettingWorkDir:      "KONCUR_WORK_DIR",
+	SettingOffline:      "KONCUR_OFFLINE",
+	SettingSourceCache:  "KONCUR_SOURCE_CACHE",
+
+	SettingStreamThreshold: "KONCUR_STREAM_THRESHOLD",
+
+	SettingMaxFailures:        "KONCUR_MAX_FAILURES",
+	SettingMaxFailureRate:     "KONCUR_MAX_FAILURE_RATE",
+	SettingValidationFailures: "KONCUR_VALIDATION_FAILURES",
+}
+
+// settingOrder applies environment variables and flags in a stable order, so errors are reproducible
+var settingOrder = []string{
+	SettingTarget, SettingTargetConfig, SettingArtifactsURL, SettingNotifyConfig, SettingTimeout, SettingWorkDir,
+	SettingOffline, SettingSourceCache, SettingStreamThreshold,
+	SettingMaxFailures, SettingMaxFailureRate, SettingValidationFailures,
+}
+
+// Settings are the harness options resolved from its configuration layers, from lowest to highest
+// precedence: the global config, the suite config, KONCUR_* environment variables and flags.
+// Test definitions sit between the suite config and the environment variables: they override the
+// suite's test defaults and are overridden by environment variables and flags
+type Settings struct {
+	// Target configures the targets, e.g. binaries and credentials. Its type selects the target to run
+	Target *TargetConfig `yaml:"target,omitempty"`
+
+	// TargetConfig is a target configuration file overlaying Target
+	// (default: .koncur/config/target-<type>.yaml when it exists)
+	TargetConfig string `yaml:"targetConfig,omitempty"`
+
+	// ArtifactsURL uploads the artifacts of each run, e.g. s3://bucket/prefix
+	ArtifactsURL string `yaml:"artifactsURL,omitempty"`
+
+	// NotifyConfig is the notification configuration file (default: .koncur/config/notify.yaml)
+	NotifyConfig string `yaml:"notifyConfig,omitempty"`
+
+	// ExitPolicy decides when failing tests fail the run
+	ExitPolicy ExitPolicy `yaml:"exitPolicy,omitempty"`
+
+	// Offline forbids network access, for disconnected environments. Only the kantra and mock targets run
+	Offline bool `yaml:"offline,omitempty"`
+
+	// SourceCache holds pre-seeded checkouts of the repositories tests clone, used when offline
+	SourceCache string `yaml:"sourceCache,omitempty"`
+
+	// StreamThreshold is the size in MiB above which analysis output is validated ruleset by ruleset
+	// while it is read, instead of being loaded at once (default: 256)
+	StreamThreshold *int `yaml:"streamThreshold,omitempty"`
+
+	// Defaults apply to the tests that don't set them
+	Defaults TestSettings `yaml:"defaults,omitempty"`
+
+	// Overrides replace the settings of every test, set by environment variables and flags
+	Overrides TestSettings `yaml:"-"`
+
+	// targetType overrides the type of the target configuration, set by environment variables and flags
+	targetType string
+}
+
+// TestSettings are execution settings shared by the tests of a suite
+type TestSettings struct {
+	Timeout *Duration `yaml:"timeout,omitempty"`
+	WorkDir string    `yaml:"workDir,omitempty"`
+
+	// Env is merged into the environment of each test, the test's own variables win
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// Ways validation failures are handled by the exit policy
+const (
+	// ValidationFailuresFail counts validation failures like execution errors
+	ValidationFailuresFail = "fail"
+	// ValidationFailuresWarn reports validation failures without counting them, only execution errors fail the run
+	ValidationFailuresWarn = "warn"
+)
+
+// ExitPolicy decides when failing tests fail the run. Without thresholds any failing test fails it
+type ExitPolicy struct {
+	// MaxFailures fails the run when more tests fail
+	MaxFailures *int `yaml:"maxFailures,omitempty"`
+
+	// MaxFailureRate fails the run when a larger percentage of the tests run fail
+	MaxFailureRate *float64 `yaml:"maxFailureRate,omitempty"`
+
+	// ValidationFailures is fail or warn (default: fail)
+	ValidationFailures string `yaml:"validationFailures,omitempty"`
+}
+
+// Exceeded reports whether the failures of a run fail it. failed counts the tests whose output didn't
+// match their expectations, errored the tests that couldn't run, and run the tests that weren't skipped
+func (p ExitPolicy) Exceeded(failed, errored, run int) bool {
+	counted := errored
+	if p.ValidationFailures != ValidationFailuresWarn {
+		counted += failed
+	}
+	if p.MaxFailures == nil && p.MaxFailureRate == nil {
+		return counted > 0
+	}
+	if p.MaxFailures != nil && counted > *p.MaxFailures {
+		return true
+	}
+	return p.MaxFailureRate != nil && run > 0 && float64(counted)*100/float64(run) > *p.MaxFailureRate
+}
+
+// validate checks the values of the exit policy
+func (p ExitPolicy) validate() error {
+	if p.MaxFailures != nil && *p.MaxFailures < 0 {
+		return fmt.Errorf("exitPolicy.maxFailures must not be negative")
+	}
+	if p.MaxFailureRate != nil && (*p.MaxFailureRate < 0 || *p.MaxFailureRate > 100) {
+		return fmt.Errorf("exitPolicy.maxFailureRate must be a percentage between 0 and 100")
+	}
+	switch p.ValidationFailures {
+	case "", ValidationFailuresFail, ValidationFailuresWarn:
+		return nil
+	}
+	return fmt.Errorf("exitPolicy.validationFailures must be %s or %s, got %q", ValidationFailuresFail, ValidationFailuresWarn, p.ValidationFailures)
+}
+
+// SettingSources locates the configuration layers of the settings
+type SettingSources struct {
+	// GlobalFile is the harness-wide configuration, ignored when it doesn't exist
+	GlobalFile string
+	// SuiteFile is the configuration of the test suite, ignored when it doesn't exist
+	SuiteFile string
+	// Flags are settings given on the command line, by setting name
+	Flags map[string]string
+}
+
+// DefaultGlobalConfigFile returns the harness-wide configuration file: $KONCUR_CONFIG, or else
+// koncur/config.yaml in the user configuration directory. It is empty when neither is known
+func DefaultGlobalConfigFile() string {
+	if file := os.Getenv(GlobalConfigEnv); file != "" {
+		return file
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "koncur", "config.yaml")
+}
+
+// ResolveSettings merges the configuration layers into settings
+// Mappings of the configuration files are merged key by key, so a suite config can set the URL of a target
+// whose credentials are in the global config. Other values, including lists, are replaced
+func ResolveSettings(sources SettingSources) (*Settings, error) {
+	merged := map[string]any{}
+	for _, file := range []string{sources.GlobalFile, sources.SuiteFile} {
+		if file == "" {
+			continue
+		}
+		layer, err := loadSettingsFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		mergeSettings(merged, layer)
+	}
+
+	var settings Settings
+	if err := remarshal(merged, &settings); err != nil {
+		return nil, fmt.Errorf("failed to merge settings: %w", err)
+	}
+
+	for _, name := range settingOrder {
+		if value, ok := os.LookupEnv(SettingEnv[name]); ok && value != "" {
+			if err := settings.set(name, value); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", SettingEnv[name], err)
+			}
+		}
+	}
+	for _, name := range settingOrder {
+		if value, ok := sources.Flags[name]; ok {
+			if err := settings.set(name, value); err != nil {
+				return nil, fmt.Errorf("invalid %s flag: %w", name, err)
+			}
+		}
+	}
+	if err := settings.ExitPolicy.validate(); err != nil {
+		return nil, err
+	}
+	if settings.StreamThreshold != nil && *settings.StreamThreshold < 0 {
+		return nil, fmt.Errorf("streamThreshold must not be negative")
+	}
+	return &settings, nil
+}
+
+// loadSettingsFile strictly decodes a configuration file, returning its raw mapping for merging
+func loadSettingsFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode into the settings first so misspelled settings fail instead of being ignored
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&Settings{}); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	layer := map[string]any{}
+	if err := yaml.Unmarshal(data, &layer); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return layer, nil
+}
+
+// set overrides a setting from an environment variable or flag
+func (s *Settings) set(name, value string) error {
+	switch name {
+	case SettingTarget:
+		s.targetType = value
+	case SettingTargetConfig:
+		s.TargetConfig = value
+	case SettingArtifactsURL:
+		s.ArtifactsURL = value
+	case SettingNotifyConfig:
+		s.NotifyConfig = value
+	case SettingTimeout:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		s.Overrides.Timeout = &Duration{Duration: d}
+	case SettingWorkDir:
+		s.Overrides.WorkDir = value
+	case SettingOffline:
+		offline, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		s.Offline = offline
+	case SettingSourceCache:
+		s.SourceCache = value
+	case SettingStreamThreshold:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		s.StreamThreshold = &n
+	case SettingMaxFailures:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		s.ExitPolicy.MaxFailures = &n
+	case SettingMaxFailureRate:
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		s.ExitPolicy.MaxFailureRate = &rate
+	case SettingValidationFailures:
+		s.ExitPolicy.ValidationFailures = value
+	default:
+		return fmt.Errorf("unknown setting %q", name)
+	}
+	return nil
+}
+
+// DefaultStreamThreshold is the size in MiB above which analysis output is streamed
+const DefaultStreamThreshold = 256
+
+// GetStreamThreshold returns the size in bytes above which analysis output is streamed
+func (s *Settings) GetStreamThreshold() int64 {
+	threshold := DefaultStreamThreshold
+	if s.StreamThreshold != nil {
+		threshold = *s.StreamThreshold
+	}
+	return int64(threshold) << 20
+}
+
+// TargetType returns the type of target selected before loading a target configuration file:
+// the environment variable or flag, else the type of the target settings (default: kantra)
+func (s *Settings) TargetType() string {
+	switch {
+	case s.targetType != "":
+		return s.targetType
+	case s.Target != nil && s.Target.Type != "":
+		return s.Target.Type
+	}
+	return "kantra"
+}
+
+// TargetConfigFile returns the target configuration file overlaying the target settings, empty when there is
+// none. Without a targetConfig setting, .koncur/config/target-<type>.yaml is used when it exists
+func (s *Settings) TargetConfigFile() string {
+	if s.TargetConfig != "" {
+		return s.TargetConfig
+	}
+	discovered := fmt.Sprintf(".koncur/config/target-%s.yaml", s.TargetType())
+	if _, err := os.Stat(discovered); err == nil {
+		return discovered
+	}
+	return ""
+}
+
+// ResolveTargetConfig returns the target configuration: the target settings overlaid by the target
+// configuration file, with its secret references resolved. A type set by an environment variable or
+// flag wins over both. Offline settings switch the target to run without network access
+func (s *Settings) ResolveTargetConfig() (*TargetConfig, error) {
+	merged := map[string]any{}
+	if s.Target != nil {
+		if err := remarshal(s.Target, &merged); err != nil {
+			return nil, fmt.Errorf("failed to merge target config: %w", err)
+		}
+	}
+
+	if file := s.TargetConfigFile(); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read target config file %s: %w", file, err)
+		}
+		layer := map[string]any{}
+		if err := yaml.Unmarshal(data, &layer); err != nil {
+			return nil, fmt.Errorf("failed to parse target config YAML: %w", err)
+		}
+		mergeSettings(merged, layer)
+	}
+
+	var targetConfig TargetConfig
+	if err := remarshal(merged, &targetConfig); err != nil {
+		return nil, fmt.Errorf("failed to merge target config: %w", err)
+	}
+	switch {
+	case s.targetType != "":
+		targetConfig.Type = s.targetType
+	case targetConfig.Type == "":
+		targetConfig.Type = "kantra"
+	}
+
+	if s.Offline {
+		if err := targetConfig.ApplyOffline(s.SourceCache); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := targetConfig.ResolveSecrets(); err != nil {
+		return nil, fmt.Errorf("invalid target config: %w", err)
+	}
+	return &targetConfig, nil
+}
+
+// Apply merges the test settings into a test definition: the suite's defaults fill in the settings the
+// test doesn't have, and the overrides replace them
+func (s *Settings) Apply(test *TestDefinition) {
+	if test.Timeout == nil {
+		test.Timeout = s.Defaults.Timeout
+	}
+	if test.WorkDir == "" {
+		test.WorkDir = s.Defaults.WorkDir
+	}
+	if s.Overrides.Timeout != nil {
+		test.Timeout = s.Overrides.Timeout
+	}
+	if s.Overrides.WorkDir != "" {
+		test.WorkDir = s.Overrides.WorkDir
+	}
+
+	// The environment is copied so the settings never modify a map the test shares
+	env := maps.Clone(s.Defaults.Env)
+	if env == nil {
+		env = map[string]string{}
+	}
+	maps.Copy(env, test.Env)
+	maps.Copy(env, s.Overrides.Env)
+	if len(env) > 0 {
+		test.Env = env
+	}
+}
+
+// mergeSettings merges an overlay into a base mapping, recursing into mappings both have
+func mergeSettings(base, overlay map[string]any) {
+	for key, value := range overlay {
+		baseMap, baseOK := base[key].(map[string]any)
+		overlayMap, overlayOK := value.(map[string]any)
+		if baseOK && overlayOK {
+			mergeSettings(baseMap, overlayMap)
+			continue
+		}
+		base[key] = value
+	}
+}
+
+// remarshal converts a value into another type through YAML
+func remarshal(in, out any) error {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
@@ -0,0 +1,301 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeSettingsFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolveSettings_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	global := writeSettingsFile(t, dir, "global.yaml", `target:
+  type: tackle-hub
+  tackleHub:
+    url: https://hub.global
+    token: secret
+artifactsURL: s3://global/runs
+defaults:
+  timeout: 10m
+  env:
+    LOG_LEVEL: debug
+    JAVA_OPTS: -Xmx1g
+`)
+	suite := writeSettingsFile(t, dir, "suite.yaml", `target:
+  tackleHub:
+    url: https://hub.suite
+notifyConfig: suite-notify.yaml
+defaults:
+  workDir: /work/suite
+  env:
+    JAVA_OPTS: -Xmx2g
+`)
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		flags     map[string]string
+		wantType  string
+		wantURL   string
+		wantNotif string
+		wantTime  time.Duration
+		wantWork  string
+	}{
+		{
+			name:      "files",
+			wantType:  "tackle-hub",
+			wantURL:   "s3://global/runs",
+			wantNotif: "suite-notify.yaml",
+			wantTime:  10 * time.Minute,
+			wantWork:  "/work/suite",
+		},
+		{
+			name:      "environment overrides files",
+			env:       map[string]string{"KONCUR_TARGET": "mock", "KONCUR_ARTIFACTS_URL": "s3://env/runs", "KONCUR_TIMEOUT": "2m"},
+			wantType:  "mock",
+			wantURL:   "s3://env/runs",
+			wantNotif: "suite-notify.yaml",
+			wantTime:  2 * time.Minute,
+			wantWork:  "/work/suite",
+		},
+		{
+			name:      "flags override environment",
+			env:       map[string]string{"KONCUR_TARGET": "mock", "KONCUR_WORK_DIR": "/work/env"},
+			flags:     map[string]string{SettingTarget: "kantra", SettingWorkDir: "/work/flag", SettingNotifyConfig: "flag-notify.yaml"},
+			wantType:  "kantra",
+			wantURL:   "s3://global/runs",
+			wantNotif: "flag-notify.yaml",
+			wantTime:  10 * time.Minute,
+			wantWork:  "/work/flag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range SettingEnv {
+				t.Setenv(name, "")
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			settings, err := ResolveSettings(SettingSources{GlobalFile: global, SuiteFile: suite, Flags: tt.flags})
+			if err != nil {
+				t.Fatalf("ResolveSettings() error = %v", err)
+			}
+			if got := settings.TargetType(); got != tt.wantType {
+				t.Errorf("TargetType() = %s, want %s", got, tt.wantType)
+			}
+			if settings.ArtifactsURL != tt.wantURL {
+				t.Errorf("ArtifactsURL = %s, want %s", settings.ArtifactsURL, tt.wantURL)
+			}
+			if settings.NotifyConfig != tt.wantNotif {
+				t.Errorf("NotifyConfig = %s, want %s", settings.NotifyConfig, tt.wantNotif)
+			}
+
+			test := &TestDefinition{Name: "daytrader"}
+			settings.Apply(test)
+			if test.GetTimeout() != tt.wantTime {
+				t.Errorf("GetTimeout() = %s, want %s", test.GetTimeout(), tt.wantTime)
+			}
+			if test.GetWorkDir() != tt.wantWork {
+				t.Errorf("GetWorkDir() = %s, want %s", test.GetWorkDir(), tt.wantWork)
+			}
+			if test.Env["LOG_LEVEL"] != "debug" || test.Env["JAVA_OPTS"] != "-Xmx2g" {
+				t.Errorf("Env = %v", test.Env)
+			}
+		})
+	}
+}
+
+func TestResolveSettings_Errors(t *testing.T) {
+	dir := t.TempDir()
+	typo := writeSettingsFile(t, dir, "typo.yaml", "artifactsUrl: s3://bucket\n")
+
+	if _, err := ResolveSettings(SettingSources{SuiteFile: typo}); err == nil || !strings.Contains(err.Error(), "typo.yaml") {
+		t.Errorf("ResolveSettings() with an unknown field error = %v", err)
+	}
+
+	t.Setenv("KONCUR_TIMEOUT", "soon")
+	if _, err := ResolveSettings(SettingSources{}); err == nil || !strings.Contains(err.Error(), "KONCUR_TIMEOUT") {
+		t.Errorf("ResolveSettings() with an invalid timeout error = %v", err)
+	}
+
+	// Missing configuration files are layers that aren't used
+	t.Setenv("KONCUR_TIMEOUT", "")
+	settings, err := ResolveSettings(SettingSources{GlobalFile: filepath.Join(dir, "missing.yaml")})
+	if err != nil {
+		t.Fatalf("ResolveSettings() with missing files error = %v", err)
+	}
+	if settings.TargetType() != "kantra" {
+		t.Errorf("TargetType() = %s, want kantra", settings.TargetType())
+	}
+}
+
+func TestResolveSettings_ExitPolicy(t *testing.T) {
+	dir := t.TempDir()
+	suite := writeSettingsFile(t, dir, "suite.yaml", `exitPolicy:
+  maxFailures: 2
+  validationFailures: warn
+`)
+
+	t.Setenv("KONCUR_MAX_FAILURE_RATE", "12.5")
+	settings, err := ResolveSettings(SettingSources{
+		SuiteFile: suite,
+		Flags:     map[string]string{SettingMaxFailures: "5"},
+	})
+	if err != nil {
+		t.Fatalf("ResolveSettings() error = %v", err)
+	}
+	policy := settings.ExitPolicy
+	if *policy.MaxFailures != 5 || *policy.MaxFailureRate != 12.5 || policy.ValidationFailures != ValidationFailuresWarn {
+		t.Errorf("ExitPolicy = %d, %v, %s", *policy.MaxFailures, *policy.MaxFailureRate, policy.ValidationFailures)
+	}
+
+	t.Setenv("KONCUR_MAX_FAILURE_RATE", "")
+	if _, err := ResolveSettings(SettingSources{Flags: map[string]string{SettingValidationFailures: "ignore"}}); err == nil {
+		t.Error("ResolveSettings() with an invalid validationFailures should fail")
+	}
+	if _, err := ResolveSettings(SettingSources{Flags: map[string]string{SettingMaxFailureRate: "150"}}); err == nil {
+		t.Error("ResolveSettings() with a rate above 100 should fail")
+	}
+}
+
+func TestExitPolicy_Exceeded(t *testing.T) {
+	two, tenPercent := 2, 10.0
+	tests := []struct {
+		name                 string
+		policy               ExitPolicy
+		failed, errored, run int
+		want                 bool
+	}{
+		{name: "default passes without failures", run: 5},
+		{name: "default fails on any failure", failed: 1, run: 5, want: true},
+		{name: "within max failures", policy: ExitPolicy{MaxFailures: &two}, failed: 1, errored: 1, run: 5},
+		{name: "beyond max failures", policy: ExitPolicy{MaxFailures: &two}, failed: 2, errored: 1, run: 5, want: true},
+		{name: "within failure rate", policy: ExitPolicy{MaxFailureRate: &tenPercent}, failed: 1, run: 10},
+		{name: "beyond failure rate", policy: ExitPolicy{MaxFailureRate: &tenPercent}, failed: 2, run: 10, want: true},
+		{name: "validation failures warned", policy: ExitPolicy{ValidationFailures: ValidationFailuresWarn}, failed: 3, run: 5},
+		{name: "errors fail with validation failures warned", policy: ExitPolicy{ValidationFailures: ValidationFailuresWarn}, errored: 1, run: 5, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Exceeded(tt.failed, tt.errored, tt.run); got != tt.want {
+				t.Errorf("Exceeded(%d, %d, %d) = %v, want %v", tt.failed, tt.errored, tt.run, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSettings_Apply(t *testing.T) {
+	settings := &Settings{
+		Defaults: TestSettings{Timeout: &Duration{Duration: 10 * time.Minute}, WorkDir: "/work/suite"},
+	}
+	test := &TestDefinition{Name: "slow", Timeout: &Duration{Duration: 30 * time.Minute}}
+	settings.Apply(test)
+
+	// Tests override the suite's defaults
+	if test.GetTimeout() != 30*time.Minute || test.GetWorkDir() != "/work/suite" {
+		t.Errorf("Apply() = timeout %s, work dir %s", test.GetTimeout(), test.GetWorkDir())
+	}
+
+	// Environment variables and flags override tests
+	settings.Overrides.Timeout = &Duration{Duration: time.Minute}
+	settings.Apply(test)
+	if test.GetTimeout() != time.Minute {
+		t.Errorf("Apply() with overrides = timeout %s", test.GetTimeout())
+	}
+}
+
+func TestSettings_ResolveTargetConfig(t *testing.T) {
+	dir := t.TempDir()
+	file := writeSettingsFile(t, dir, "target.yaml", `type: tackle-hub
+tackleHub:
+  url: https://hub.file
+`)
+
+	settings := &Settings{
+		Target: &TargetConfig{
+			Type:      "kantra",
+			Kantra:    &KantraConfig{BinaryPath: "/usr/bin/kantra"},
+			TackleHub: &TackleHubConfig{URL: "https://hub.global", Token: "secret"},
+		},
+		TargetConfig: file,
+	}
+
+	cfg, err := settings.ResolveTargetConfig()
+	if err != nil {
+		t.Fatalf("ResolveTargetConfig() error = %v", err)
+	}
+	if cfg.Type != "tackle-hub" || cfg.TackleHub.URL != "https://hub.file" || cfg.TackleHub.Token != "secret" {
+		t.Errorf("ResolveTargetConfig() = %+v, hub %+v", cfg, cfg.TackleHub)
+	}
+	if cfg.Kantra == nil || cfg.Kantra.BinaryPath != "/usr/bin/kantra" {
+		t.Errorf("ResolveTargetConfig() lost the kantra settings: %+v", cfg.Kantra)
+	}
+
+	// A type given by an environment variable or flag wins over the file
+	if err := settings.set(SettingTarget, "mock"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg, err = settings.ResolveTargetConfig(); err != nil || cfg.Type != "mock" {
+		t.Errorf("ResolveTargetConfig() with a type override = %+v, %v", cfg, err)
+	}
+}
+
+func TestSettings_ResolveTargetConfig_Offline(t *testing.T) {
+	t.Setenv("KONCUR_OFFLINE", "true")
+	settings, err := ResolveSettings(SettingSources{Flags: map[string]string{SettingSourceCache: "/srv/sources"}})
+	if err != nil {
+		t.Fatalf("ResolveSettings() error = %v", err)
+	}
+	if !settings.Offline || settings.SourceCache != "/srv/sources" {
+		t.Fatalf("offline settings = %v, %s", settings.Offline, settings.SourceCache)
+	}
+
+	cfg, err := settings.ResolveTargetConfig()
+	if err != nil {
+		t.Fatalf("ResolveTargetConfig() error = %v", err)
+	}
+	kantra := cfg.Kantra
+	if !kantra.Offline || !kantra.RunLocal || kantra.PullPolicy != "never" || kantra.SourceCache != "/srv/sources" {
+		t.Errorf("offline kantra config = %+v", kantra)
+	}
+
+	// Targets depending on a service can't run offline
+	if err := settings.set(SettingTarget, "tackle-hub"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := settings.ResolveTargetConfig(); err == nil || !strings.Contains(err.Error(), "network") {
+		t.Errorf("ResolveTargetConfig() of an offline tackle-hub target error = %v", err)
+	}
+}
+
+func TestSettings_StreamThreshold(t *testing.T) {
+	settings, err := ResolveSettings(SettingSources{})
+	if err != nil {
+		t.Fatalf("ResolveSettings() error = %v", err)
+	}
+	if got := settings.GetStreamThreshold(); got != DefaultStreamThreshold<<20 {
+		t.Errorf("GetStreamThreshold() = %d, want the default", got)
+	}
+
+	t.Setenv("KONCUR_STREAM_THRESHOLD", "64")
+	if settings, err = ResolveSettings(SettingSources{}); err != nil || settings.GetStreamThreshold() != 64<<20 {
+		t.Errorf("ResolveSettings() with KONCUR_STREAM_THRESHOLD = %v, %v", settings, err)
+	}
+	if _, err := ResolveSettings(SettingSources{Flags: map[string]string{SettingStreamThreshold: "-1"}}); err == nil {
+		t.Error("ResolveSettings() with a negative stream threshold succeeded")
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"strings"
+)
+
+const (
+	// RepositoryKindGit is the repository kind for Git repositories
+	RepositoryKindGit = "git"
+	// RepositoryKindSubversion is the repository kind for Subversion repositories
+	RepositoryKindSubversion = "subversion"
+)
+
+// ParseSVNURLWithPath parses a Subversion URL that may contain a branch and path
+// Format: svn://svn.example.com/repo#trunk/path/to/dir
+// The branch is appended to the repository URL, as Tackle Hub does for Subversion
+func ParseSVNURLWithPath(svnURL string) *GitURLComponents {
+	components := ParseGitURLWithPath(svnURL)
+	components.Kind = RepositoryKindSubversion
+	return components
+}
+
+// IsSVNURL checks if the given string is a Subversion URL
+func IsSVNURL(str string) bool {
+	return strings.HasPrefix(str, "svn://") ||
+		strings.HasPrefix(str, "svn+ssh://")
+}
+
+// RepositoryKind returns the kind of repository the components refer to
+func (c *GitURLComponents) RepositoryKind() string {
+	if c.Kind == "" {
+		return RepositoryKindGit
+	}
+	return c.Kind
+}
+
+// CheckoutURL returns the URL to check out
+// For Subversion the branch is part of the URL, Git clones the base URL and checks out the ref
+func (c *GitURLComponents) CheckoutURL() string {
+	if c.RepositoryKind() == RepositoryKindSubversion && c.Ref != "" {
+		return strings.TrimSuffix(c.URL, "/") + "/" + c.Ref
+	}
+	return c.URL
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestIsSVNURL(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want bool
+	}{
+		{"SVN URL", "svn://svn.example.com/repo", true},
+		{"SVN over SSH", "svn+ssh://svn.example.com/repo", true},
+		{"SVN URL with branch", "svn://svn.example.com/repo#trunk", true},
+		{"Git URL", "https://github.com/konveyor/rules.git", false},
+		{"Local path", "/path/to/app", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSVNURL(tt.str); got != tt.want {
+				t.Errorf("IsSVNURL(%q) = %v, want %v", tt.str, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSVNURLWithPath(t *testing.T) {
+	components := ParseSVNURLWithPath("svn://svn.example.com/repo#trunk/modules/web")
+
+	if components.URL != "svn://svn.example.com/repo" {
+		t.Errorf("URL = %v, want svn://svn.example.com/repo", components.URL)
+	}
+	if components.Ref != "trunk" {
+		t.Errorf("Ref = %v, want trunk", components.Ref)
+	}
+	if components.Path != "modules/web" {
+		t.Errorf("Path = %v, want modules/web", components.Path)
+	}
+	if components.RepositoryKind() != RepositoryKindSubversion {
+		t.Errorf("RepositoryKind() = %v, want %v", components.RepositoryKind(), RepositoryKindSubversion)
+	}
+	if components.CheckoutURL() != "svn://svn.example.com/repo/trunk" {
+		t.Errorf("CheckoutURL() = %v, want svn://svn.example.com/repo/trunk", components.CheckoutURL())
+	}
+}
+
+func TestGitURLComponents_RepositoryKind(t *testing.T) {
+	components := ParseGitURLWithPath("https://github.com/konveyor/rules.git#main/java")
+
+	if components.RepositoryKind() != RepositoryKindGit {
+		t.Errorf("RepositoryKind() = %v, want %v", components.RepositoryKind(), RepositoryKindGit)
+	}
+	if components.CheckoutURL() != "https://github.com/konveyor/rules.git" {
+		t.Errorf("CheckoutURL() = %v, want base URL", components.CheckoutURL())
+	}
+}
+
+func TestAnalysisConfig_ParseSVNURL(t *testing.T) {
+	ac := AnalysisConfig{
+		Application: "svn://svn.example.com/repo#trunk",
+	}
+	ac.ParseGitURLs()
+
+	if ac.ApplicationGitComponents == nil {
+		t.Fatal("Expected ApplicationGitComponents to be set")
+	}
+	if ac.ApplicationGitComponents.RepositoryKind() != RepositoryKindSubversion {
+		t.Errorf("RepositoryKind() = %v, want %v", ac.ApplicationGitComponents.RepositoryKind(), RepositoryKindSubversion)
+	}
+}
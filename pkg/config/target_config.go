@@ -2,15 +2,18 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
+	"slices"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // TargetConfig defines how to execute tests (separate from test definitions)
 type TargetConfig struct {
-	// Type specifies the target: kantra, tackle-hub, tackle-ui, kai-rpc, vscode
-	Type string `yaml:"type" validate:"required,oneof=kantra tackle-hub tackle-ui kai-rpc vscode"`
+	// Type specifies the target: kantra, tackle-hub, tackle-ui, kai-rpc, vscode, asset-gen, mock
+	Type string `yaml:"type" validate:"required,oneof=kantra tackle-hub tackle-ui kai-rpc vscode asset-gen mock"`
 
 	// Kantra-specific configuration
 	Kantra *KantraConfig `yaml:"kantra,omitempty"`
@@ -26,12 +29,148 @@ type TargetConfig struct {
 
 	// VSCode extension configuration
 	VSCode *VSCodeConfig `yaml:"vscode,omitempty"`
+
+	// Asset generation configuration
+	AssetGen *AssetGenConfig `yaml:"assetGen,omitempty"`
+
+	// Mock target configuration
+	Mock *MockConfig `yaml:"mock,omitempty"`
 }
 
 // KantraConfig for Kantra CLI execution
 type KantraConfig struct {
 	BinaryPath    string `yaml:"binaryPath,omitempty"`
 	MavenSettings string `yaml:"mavenSettings,omitempty"`
+
+	// Git credentials for cloning private application and rules repositories
+	Git *GitAuthConfig `yaml:"git,omitempty"`
+
+	// ProviderImages overrides provider container images by provider name, e.g. dotnet
+	ProviderImages map[string]string `yaml:"providerImages,omitempty" validate:"dive,image"`
+
+	// OutputDir is where kantra writes output.yaml and its other artifacts, relative to each test's work
+	// directory. Absolute directories get a subdirectory per run (default: output)
+	OutputDir string `yaml:"outputDir,omitempty"`
+
+	// Resources limits the containers kantra runs, tests may override them (optional)
+	Resources *ResourceLimits `yaml:"resources,omitempty"`
+
+	// ContainerTool is the podman or docker binary kantra runs containers with (default: CONTAINER_TOOL,
+	// or else podman or docker from PATH)
+	ContainerTool string `yaml:"containerTool,omitempty"`
+
+	// ContainerHost is the socket of the container engine, e.g. unix:///run/user/1000/podman/podman.sock
+	ContainerHost string `yaml:"containerHost,omitempty"`
+
+	// RunLocal runs the analysis without containers, for environments where none can run
+	RunLocal bool `yaml:"runLocal,omitempty"`
+
+	// PullPolicy decides when the images of kantra's containers are pulled (default: the container tool's)
+	PullPolicy string `yaml:"pullPolicy,omitempty" validate:"omitempty,oneof=always missing never newer"`
+
+	// RunnerImage overrides the analyzer image kantra runs
+	RunnerImage string `yaml:"runnerImage,omitempty" validate:"omitempty,image"`
+
+	// RequireDigests requires the runner image and provider images to be pinned to digests, e.g.
+	// quay.io/konveyor/kantra@sha256:..., so runs can be reproduced with the same images
+	RequireDigests bool `yaml:"requireDigests,omitempty"`
+
+	// Offline forbids network access: repositories come from SourceCache, applications from container
+	// images fail and images are never pulled
+	Offline bool `yaml:"offline,omitempty"`
+
+	// SourceCache holds pre-seeded checkouts of repositories at <sourceCache>/<host>/<path>/<ref>, e.g.
+	// github.com/konveyor/tackle-testapp/main. Checkouts of the default branch are in HEAD
+	SourceCache string `yaml:"sourceCache,omitempty"`
+}
+
+// Validate checks the kantra configuration
+func (c *KantraConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("invalid kantra config: %w", err)
+	}
+	if c.RequireDigests {
+		if !IsPinnedImage(c.RunnerImage) {
+			return fmt.Errorf("requireDigests: runnerImage %q is not pinned to a digest", c.RunnerImage)
+		}
+		for _, name := range slices.Sorted(maps.Keys(c.ProviderImages)) {
+			if !IsPinnedImage(c.ProviderImages[name]) {
+				return fmt.Errorf("requireDigests: providerImages.%s %q is not pinned to a digest", name, c.ProviderImages[name])
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceLimits limits the CPU and memory of each container kantra runs for an analysis
+type ResourceLimits struct {
+	// CPUs available to each container, e.g. 2 or 0.5
+	CPUs string `yaml:"cpus,omitempty" validate:"omitempty,numeric"`
+
+	// Memory of each container, e.g. 512m or 4g
+	Memory string `yaml:"memory,omitempty" validate:"omitempty,memory"`
+}
+
+// IsZero reports whether no limit is set
+func (r *ResourceLimits) IsZero() bool {
+	return r == nil || (r.CPUs == "" && r.Memory == "")
+}
+
+// Merge returns the limits with those set in override replacing them
+func (r *ResourceLimits) Merge(override *ResourceLimits) *ResourceLimits {
+	merged := &ResourceLimits{}
+	for _, limits := range []*ResourceLimits{r, override} {
+		if limits == nil {
+			continue
+		}
+		if limits.CPUs != "" {
+			merged.CPUs = limits.CPUs
+		}
+		if limits.Memory != "" {
+			merged.Memory = limits.Memory
+		}
+	}
+	return merged
+}
+
+// GitAuthConfig holds credentials for cloning Git repositories
+// Tokens are used for HTTP(S) URLs and SSH keys for git@ and ssh:// URLs
+type GitAuthConfig struct {
+	Username       string `yaml:"username,omitempty"`
+	Token          string `yaml:"token,omitempty"`
+	SSHKeyPath     string `yaml:"sshKeyPath,omitempty"`
+	SSHKeyPassword string `yaml:"sshKeyPassword,omitempty"`
+
+	// TokenFrom and SSHKeyPasswordFrom read the credentials from secrets instead
+	TokenFrom          *SecretRef `yaml:"tokenFrom,omitempty"`
+	SSHKeyPasswordFrom *SecretRef `yaml:"sshKeyPasswordFrom,omitempty"`
+}
+
+// offlineTargets are the target types that run without network access
+var offlineTargets = []string{"kantra", "mock"}
+
+// ApplyOffline switches the target to run without network access. Kantra runs locally with the
+// repositories of sourceCache and never pulls images, targets depending on a service fail
+func (c *TargetConfig) ApplyOffline(sourceCache string) error {
+	if !slices.Contains(offlineTargets, c.Type) {
+		return fmt.Errorf("the %s target needs network access, offline runs support %s", c.Type, strings.Join(offlineTargets, " and "))
+	}
+	if c.Type != "kantra" {
+		return nil
+	}
+	if c.Kantra == nil {
+		c.Kantra = &KantraConfig{}
+	}
+	c.Kantra.Offline = true
+	c.Kantra.RunLocal = true
+	c.Kantra.PullPolicy = "never"
+	if sourceCache != "" {
+		c.Kantra.SourceCache = sourceCache
+	}
+	return nil
 }
 
 // TackleHubConfig for Tackle Hub API execution
@@ -41,15 +180,184 @@ type TackleHubConfig struct {
 	Password      string `yaml:"password,omitempty"`
 	Token         string `yaml:"token,omitempty"`
 	MavenSettings string `yaml:"mavenSettings,omitempty"`
+
+	// PasswordFrom and TokenFrom read the credentials from secrets instead
+	PasswordFrom *SecretRef `yaml:"passwordFrom,omitempty"`
+	TokenFrom    *SecretRef `yaml:"tokenFrom,omitempty"`
+
+	// Addon runs the analysis tasks, e.g. an addon deployed with a pre-release image (default: analyzer)
+	Addon string `yaml:"addon,omitempty"`
+
+	// AddonImage is the image the addon must run, tests fail before any task is created when the Hub
+	// runs another one, e.g. after the operator updated it
+	AddonImage string `yaml:"addonImage,omitempty" validate:"omitempty,image"`
+
+	// ExtensionImages are the images the addon's extensions must run by extension name, e.g. java
+	ExtensionImages map[string]string `yaml:"extensionImages,omitempty" validate:"dive,image"`
+
+	// ReuseApplications reuses the application named after each test, updated with the test's repository,
+	// once its running tasks finished. Otherwise every test creates its application (default: true)
+	ReuseApplications *bool `yaml:"reuseApplications,omitempty"`
+
+	// Task sets the scheduling of the analysis tasks, tests may override it (optional)
+	Task *HubTaskConfig `yaml:"task,omitempty"`
+
+	// Results is how the analysis results are fetched, HubResultsInsights or HubResultsAnalysis
+	// (default: insights)
+	Results string `yaml:"results,omitempty" validate:"omitempty,oneof=insights analysis"`
+
+	// MockJira is the harness's mock Jira that migration tests without a tracker URL export to (optional)
+	MockJira *MockJiraConfig `yaml:"mockJira,omitempty"`
+}
+
+// MockJiraConfig configures the mock Jira started by migration tests, the Hub must reach it
+type MockJiraConfig struct {
+	// Listen is the address the mock listens on (default: :8089)
+	Listen string `yaml:"listen,omitempty"`
+
+	// URL is the URL the Hub reaches the mock at, e.g. http://host.containers.internal:8089
+	URL string `yaml:"url" validate:"required,url"`
+}
+
+// DefaultMockJiraListen is the address of the mock Jira when none is configured
+const DefaultMockJiraListen = ":8089"
+
+// GetListen returns the address the mock Jira listens on
+func (c *MockJiraConfig) GetListen() string {
+	if c.Listen == "" {
+		return DefaultMockJiraListen
+	}
+	return c.Listen
+}
+
+// DefaultHubAddon runs analysis tasks when no addon is configured
+const DefaultHubAddon = "analyzer"
+
+// Ways of fetching the results of Hub analyses
+const (
+	// HubResultsInsights fetches the insights of the application in one request
+	HubResultsInsights = "insights"
+	// HubResultsAnalysis fetches the issues and insights of the application's analysis page by page, like
+	// the UI does
+	HubResultsAnalysis = "analysis"
+)
+
+// GetAddon returns the addon running analysis tasks with a default
+func (c *TackleHubConfig) GetAddon() string {
+	if c.Addon != "" {
+		return c.Addon
+	}
+	return DefaultHubAddon
+}
+
+// GetReuseApplications returns whether existing applications are reused with a default
+func (c *TackleHubConfig) GetReuseApplications() bool {
+	return c.ReuseApplications == nil || *c.ReuseApplications
+}
+
+// GetResults returns how the analysis results are fetched with a default
+func (c *TackleHubConfig) GetResults() string {
+	if c.Results != "" {
+		return c.Results
+	}
+	return HubResultsInsights
+}
+
+// Validate checks the Tackle Hub configuration
+func (c *TackleHubConfig) Validate() error {
+	if err := validate.Struct(c); err != nil {
+		return fmt.Errorf("invalid tackleHub config: %w", err)
+	}
+	return nil
+}
+
+// HubTaskConfig sets how the Hub schedules the analysis tasks, e.g. to test preemption and priorities
+type HubTaskConfig struct {
+	// Kind of the tasks, the Hub selects the addon of newer kinds (default: analyzer)
+	Kind string `yaml:"kind,omitempty"`
+
+	// Priority of the tasks, higher priorities run first and may preempt lower ones (default: 0)
+	Priority *int `yaml:"priority,omitempty" validate:"omitempty,gte=0"`
+
+	// PreemptEnabled lets the tasks preempt running tasks of lower priority when blocked
+	PreemptEnabled *bool `yaml:"preemptEnabled,omitempty"`
+
+	// Upload is the bucket binaries are uploaded to, HubUploadTask or HubUploadApplication (default: task)
+	Upload string `yaml:"upload,omitempty" validate:"omitempty,oneof=task application"`
+
+	// VerifyUpload downloads uploaded binaries from the bucket and compares their size and checksum with
+	// the local file before the analysis starts
+	VerifyUpload *bool `yaml:"verifyUpload,omitempty"`
+}
+
+// DefaultHubTaskKind is the kind of analysis tasks when none is configured
+const DefaultHubTaskKind = "analyzer"
+
+// Buckets binaries are uploaded to
+const (
+	// HubUploadTask uploads binaries to the bucket of the analysis task
+	HubUploadTask = "task"
+	// HubUploadApplication uploads binaries to the bucket of the application
+	HubUploadApplication = "application"
+)
+
+// Merge returns the task settings with those set in override replacing them
+func (c *HubTaskConfig) Merge(override *HubTaskConfig) *HubTaskConfig {
+	merged := &HubTaskConfig{}
+	for _, task := range []*HubTaskConfig{c, override} {
+		if task == nil {
+			continue
+		}
+		if task.Kind != "" {
+			merged.Kind = task.Kind
+		}
+		if task.Priority != nil {
+			merged.Priority = task.Priority
+		}
+		if task.PreemptEnabled != nil {
+			merged.PreemptEnabled = task.PreemptEnabled
+		}
+		if task.Upload != "" {
+			merged.Upload = task.Upload
+		}
+		if task.VerifyUpload != nil {
+			merged.VerifyUpload = task.VerifyUpload
+		}
+	}
+	return merged
+}
+
+// GetKind returns the kind of the tasks with a default
+func (c *HubTaskConfig) GetKind() string {
+	if c != nil && c.Kind != "" {
+		return c.Kind
+	}
+	return DefaultHubTaskKind
+}
+
+// GetUpload returns the bucket binaries are uploaded to with a default
+func (c *HubTaskConfig) GetUpload() string {
+	if c != nil && c.Upload != "" {
+		return c.Upload
+	}
+	return HubUploadTask
+}
+
+// GetVerifyUpload returns whether uploaded binaries are downloaded and compared with the local file
+func (c *HubTaskConfig) GetVerifyUpload() bool {
+	return c != nil && c.VerifyUpload != nil && *c.VerifyUpload
 }
 
 // TackleUIConfig for Tackle UI browser automation
 type TackleUIConfig struct {
 	URL      string `yaml:"url" validate:"required"`
 	Username string `yaml:"username" validate:"required"`
-	Password string `yaml:"password" validate:"required"`
+	Password string `yaml:"password" validate:"required_without=PasswordFrom"`
 	Browser  string `yaml:"browser,omitempty"` // chrome, firefox
 	Headless bool   `yaml:"headless,omitempty"`
+
+	// PasswordFrom reads the password from a secret instead
+	PasswordFrom *SecretRef `yaml:"passwordFrom,omitempty"`
 }
 
 // KaiRPCConfig for Kai analyzer RPC
@@ -65,6 +373,40 @@ type VSCodeConfig struct {
 	WorkspaceDir string `yaml:"workspaceDir,omitempty"`
 }
 
+// AssetGenConfig for asset generation with kantra discover and generate
+type AssetGenConfig struct {
+	BinaryPath string `yaml:"binaryPath,omitempty"` // Path to 'kantra' binary
+}
+
+// Mock target failure modes
+const (
+	// MockFailureTimeout waits for the test timeout and fails the execution
+	MockFailureTimeout = "timeout"
+	// MockFailureExitCode returns the outputs with a non-zero exit code
+	MockFailureExitCode = "exit-code"
+	// MockFailureMalformedOutput writes an output file that isn't valid YAML
+	MockFailureMalformedOutput = "malformed-output"
+)
+
+// MockConfig for the mock target, which returns canned outputs without running any tool
+type MockConfig struct {
+	// FixturesDir holds a directory of outputs per test, named after the test.
+	// Tests without fixtures get their expected output back
+	FixturesDir string `yaml:"fixturesDir,omitempty"`
+
+	// Failure simulates a failure: timeout, exit-code or malformed-output
+	Failure string `yaml:"failure,omitempty"`
+
+	// ExitCode returned by the exit-code failure (default: 1)
+	ExitCode int `yaml:"exitCode,omitempty"`
+
+	// FailTests limits the failure to the tests with these names, all tests fail when empty
+	FailTests []string `yaml:"failTests,omitempty"`
+
+	// Version simulates a kantra version, capabilities are gated like kantra's when set
+	Version string `yaml:"version,omitempty"`
+}
+
 // LoadTargetConfig loads target configuration from a file
 func LoadTargetConfig(path string) (*TargetConfig, error) {
 	data, err := os.ReadFile(path)
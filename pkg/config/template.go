@@ -0,0 +1,69 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// DefaultSourceRoot is the directory holding the analyzed sources in outputs once their paths are normalized
+const DefaultSourceRoot = "/source"
+
+// Variables of expected output templates
+const (
+	// TemplateSourceRoot is the directory of the analyzed sources, DefaultSourceRoot unless set in expect.vars
+	TemplateSourceRoot = "SourceRoot"
+	// TemplateAppName is the name of the analyzed application
+	TemplateAppName = "AppName"
+	// TemplateTestName is the name of the test, including its matrix variant
+	TemplateTestName = "TestName"
+	// TemplateMode is the analysis mode
+	TemplateMode = "Mode"
+	// TemplateTarget is the comma separated list of analysis targets
+	TemplateTarget = "Target"
+)
+
+// TemplateVars returns the variables of the expected output templates of an application of the test
+// The test's expect.vars, e.g. JavaVersion, are added to and override the built-in variables
+func (td *TestDefinition) TemplateVars(appName string) map[string]any {
+	vars := map[string]any{
+		TemplateSourceRoot: DefaultSourceRoot,
+		TemplateAppName:    appName,
+		TemplateTestName:   td.Name,
+		TemplateMode:       string(td.Analysis.AnalysisMode),
+		TemplateTarget:     strings.Join(td.Analysis.Target, ","),
+	}
+	for name, value := range td.Expect.Vars {
+		vars[name] = value
+	}
+	return vars
+}
+
+// ApplicationName returns the name of an application from its path, URL or image reference, without its
+// archive extension, e.g. acmeair-webapp for acmeair-webapp.war
+func ApplicationName(application string) string {
+	application, _, _ = strings.Cut(application, "#")
+	application = strings.TrimSuffix(strings.TrimRight(application, "/"), ".git")
+	name := path.Base(strings.TrimPrefix(application, FixtureScheme))
+	switch ext := path.Ext(name); ext {
+	case ".war", ".jar", ".ear", ".zip":
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// renderExpectedOutput executes an expected output file as a Go template with vars, so paths and version
+// specific strings don't need a copy of the file per target. Unknown variables fail
+func renderExpectedOutput(data []byte, vars map[string]any) ([]byte, error) {
+	tmpl, err := template.New("expected-output").Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, vars); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	return rendered.Bytes(), nil
+}
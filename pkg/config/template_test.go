@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplicationName(t *testing.T) {
+	tests := []struct {
+		application string
+		want        string
+	}{
+		{application: "acmeair-webapp-1.0-SNAPSHOT.war", want: "acmeair-webapp-1.0-SNAPSHOT"},
+		{application: "/apps/daytrader/", want: "daytrader"},
+		{application: "https://github.com/konveyor/example-applications.git#main", want: "example-applications"},
+		{application: "fixture:java-minimal", want: "java-minimal"},
+	}
+	for _, tt := range tests {
+		if got := ApplicationName(tt.application); got != tt.want {
+			t.Errorf("ApplicationName(%q) = %q, want %q", tt.application, got, tt.want)
+		}
+	}
+}
+
+func TestLoad_ExpectedOutputTemplate(t *testing.T) {
+	dir := t.TempDir()
+	expected := `- name: eap8
+  violations:
+    ejb-00001:
+      description: EJB on Java {{ .JavaVersion }}
+      incidents:
+      - uri: file://{{ .SourceRoot }}/{{ .AppName }}/src/Bean.java
+        lineNumber: 3
+`
+	test := `name: template
+analysis:
+  application: /apps/daytrader
+  analysisMode: source-only
+expect:
+  vars:
+    JavaVersion: "17"
+  output:
+    file: expected-output.yaml
+    template: true
+`
+	for name, data := range map[string]string{"expected-output.yaml": expected, "test.yaml": test} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	td, err := Load(filepath.Join(dir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	v := td.Expect.Output.Result[0].Violations["ejb-00001"]
+	if v.Description != "EJB on Java 17" || v.Incidents[0].URI != "file:///source/daytrader/src/Bean.java" {
+		t.Errorf("rendered violation = %+v", v)
+	}
+
+	// Unknown variables fail instead of rendering as empty strings
+	test = strings.Replace(test, "JavaVersion", "JavaRelease", 1)
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(test), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(filepath.Join(dir, "test.yaml")); err == nil || !strings.Contains(err.Error(), "JavaVersion") {
+		t.Errorf("Load() with an unknown variable error = %v", err)
+	}
+}
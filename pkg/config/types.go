@@ -1,11 +1,17 @@
 package config
 
 import (
+	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
 	"github.com/konveyor/analyzer-lsp/provider"
+	"github.com/konveyor/test-harness/pkg/validator"
+	"gopkg.in/yaml.v3"
 )
 
 // TestDefinition represents a single test case
@@ -13,6 +19,9 @@ type TestDefinition struct {
 	Name        string `yaml:"name" validate:"required"`
 	Description string `yaml:"description,omitempty"`
 
+	// Tags categorize the test so it can be selected during discovery
+	Tags []string `yaml:"tags,omitempty"`
+
 	// Analysis configuration - what to analyze
 	Analysis AnalysisConfig `yaml:"analysis" validate:"required"`
 
@@ -21,11 +30,56 @@ type TestDefinition struct {
 	WorkDir              string    `yaml:"workDir,omitempty"`
 	RequireMavenSettings bool      `yaml:"requireMavenSettings,omitempty"`
 
+	// Env sets environment variables for the analysis, e.g. JAVA_OPTS or LOG_LEVEL
+	Env map[string]string `yaml:"env,omitempty" validate:"dive,keys,required,excludesall==,endkeys"`
+
+	// Resources overrides the container limits of the kantra target for this test (optional)
+	Resources *ResourceLimits `yaml:"resources,omitempty"`
+
+	// HubTask overrides the task scheduling and binary uploads of the tackle-hub target for this test (optional)
+	HubTask *HubTaskConfig `yaml:"hubTask,omitempty"`
+
+	// Assets configures asset generation, tests with assets run on the asset-gen target (optional)
+	Assets *AssetsConfig `yaml:"assets,omitempty"`
+
+	// Fixes asks Kai to fix incidents of the expected output, tests with fixes run on the kai-rpc target (optional)
+	Fixes *FixConfig `yaml:"fixes,omitempty"`
+
+	// Cancel cancels the analysis while it runs, the test validates the cancellation instead of the output (optional)
+	Cancel *CancelConfig `yaml:"cancel,omitempty"`
+
+	// Assessment assesses the application with a questionnaire instead of analyzing it, the test validates
+	// the risk and confidence of the assessment (optional)
+	Assessment *AssessmentConfig `yaml:"assessment,omitempty"`
+
+	// Migration adds the application to a migration wave and exports it to an issue tracker instead of
+	// analyzing it, the test validates the created ticket (optional)
+	Migration *MigrationConfig `yaml:"migration,omitempty"`
+
+	// Requires declares target capabilities and versions the test needs, it is skipped without them (optional)
+	Requires *RequiresConfig `yaml:"requires,omitempty"`
+
+	// Matrix expands the test into one variant per combination of analysis modes and targets (optional)
+	Matrix *MatrixConfig `yaml:"matrix,omitempty"`
+
+	// Variant holds the matrix parameters of an expanded test (not in YAML)
+	Variant *MatrixVariant `yaml:"-"`
+
+	// Skip disables the test, the reason is reported with the skipped outcome (optional)
+	Skip string `yaml:"skip,omitempty"`
+
+	// ExpectedFailure marks a known bug, e.g. a link to its issue. The test failing is reported as an
+	// expected failure, passing is reported as an unexpected pass so the marker gets removed (optional)
+	ExpectedFailure string `yaml:"expectedFailure,omitempty"`
+
 	// Validation configuration
 	Expect ExpectConfig `yaml:"expect" validate:"required"`
 
 	// Internal field - path to the test file (not in YAML)
 	testFilePath string `yaml:"-"`
+
+	// Internal field - parsed YAML document, used to report the lines of invalid fields
+	document *yaml.Node `yaml:"-"`
 }
 
 // SetTestFilePath sets the test file path
@@ -44,7 +98,7 @@ func (t *TestDefinition) GetTestDir() string {
 // AnalysisConfig defines what to analyze
 type AnalysisConfig struct {
 	// Application is either a file path or git repository URL
-	Application      string                `json:"application" yaml:"application" validate:"required" `
+	Application      string                `json:"application" yaml:"application,omitempty" validate:"required_without=Applications,fixture" `
 	LabelSelector    string                `json:"label_selector" yaml:"labelSelector,omitempty" `
 	KnownLibs        bool                  `json:"known_libs" yaml:"knownLibs,omitempty"`
 	ContextLines     int                   `json:"context_lines" yaml:"context_lines"`
@@ -52,17 +106,375 @@ type AnalysisConfig struct {
 	Source           []string              `json:"source" yaml:"source"`
 	Target           []string              `json:"target" yaml:"target"`
 	Rules            []string              `json:"rules" yaml:"rules"`
-	AnalysisMode     provider.AnalysisMode `json:"analysis_mode" yaml:"analysisMode" validate:"required" `
+	AnalysisMode     provider.AnalysisMode `json:"analysis_mode" yaml:"analysisMode" validate:"required,oneof=full source-only" `
+
+	// Providers selects the analysis providers to run: java, go, python, nodejs or dotnet (optional, detected by default)
+	Providers []string `json:"providers,omitempty" yaml:"providers,omitempty" validate:"dive,oneof=java go python nodejs dotnet"`
+
+	// ProviderSettings overrides the settings of analysis providers (optional)
+	ProviderSettings []ProviderSettings `json:"provider_settings,omitempty" yaml:"providerSettings,omitempty" validate:"omitempty,unique=Name,dive"`
+
+	// Applications analyzes several applications in one test, each with its own expected output
+	Applications []ApplicationConfig `json:"applications,omitempty" yaml:"applications,omitempty" validate:"excluded_with=Application,dive"`
+
+	// Git controls how application repositories are cloned
+	Git *GitOptions `json:"git,omitempty" yaml:"git,omitempty" validate:"omitempty"`
+
+	// ExcludedPaths are paths relative to the application that aren't analyzed, e.g. generated sources.
+	// Incidents in them fail the test (optional)
+	ExcludedPaths []string `json:"excluded_paths,omitempty" yaml:"excludedPaths,omitempty" validate:"unique,dive,required"`
+
+	// DependencyFolders are directories of the application holding its dependencies, e.g. lib, they are
+	// analyzed as dependencies instead of sources (optional)
+	DependencyFolders []string `json:"dependency_folders,omitempty" yaml:"dependencyFolders,omitempty" validate:"unique,dive,required"`
+
+	// Build describes the build of Java applications, e.g. the subprojects of a Gradle build (optional)
+	Build *BuildOptions `json:"build,omitempty" yaml:"build,omitempty" validate:"omitempty"`
+
+	// CustomTarget is a migration target created in Tackle Hub for the analysis, with the test's rules,
+	// e.g. to analyze for a target label no shipped target has (optional)
+	CustomTarget *CustomTargetConfig `json:"customTarget,omitempty" yaml:"customTarget,omitempty" validate:"omitempty"`
 
 	// Parsed Git components (not in YAML)
 	ApplicationGitComponents *GitURLComponents   `yaml:"-" json:"-"`
 	RulesGitComponents       []*GitURLComponents `yaml:"-" json:"-"`
+
+	// Parsed container image components (not in YAML)
+	ApplicationImageComponents *ImageComponents `yaml:"-" json:"-"`
+}
+
+// Java build tools
+const (
+	BuildToolMaven  = "maven"
+	BuildToolGradle = "gradle"
+)
+
+// BuildOptions describes the build of a Java application. Analyses assume Maven builds unless told otherwise
+type BuildOptions struct {
+	// Tool is maven or gradle (default: detected from the build files of the application)
+	Tool string `json:"tool,omitempty" yaml:"tool,omitempty" validate:"omitempty,oneof=maven gradle"`
+
+	// Subprojects are the Gradle subprojects or Maven modules the build must declare, e.g. app or
+	// services:api. A build missing one fails the test before the analysis (optional)
+	Subprojects []string `json:"subprojects,omitempty" yaml:"subprojects,omitempty" validate:"unique,dive,required"`
+
+	// BuildLess analyzes without resolving dependencies through the build, kantra neither searches Maven
+	// Central for dependencies nor runs dependency rules. Gradle builds the provider can't run need it
+	BuildLess bool `json:"buildLess,omitempty" yaml:"buildLess,omitempty"`
+}
+
+// CustomTargetConfig is a custom migration target, like one added on the Hub's custom migration targets page
+type CustomTargetConfig struct {
+	// Name of the target, it must not exist in the Hub yet
+	Name string `json:"name" yaml:"name" validate:"required"`
+
+	// Description of the target (optional)
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Rules are the rule files uploaded to the target, relative to the test file. The Hub derives the
+	// target's labels from their konveyor.io/target labels
+	Rules []string `json:"rules" yaml:"rules" validate:"required,unique,dive,required"`
+
+	// Image is the icon of the target, a PNG or SVG file relative to the test file (optional)
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// ApplicationConfig defines one application of a multi-application test
+type ApplicationConfig struct {
+	// Name identifies the application, results are keyed by it
+	Name string `json:"name" yaml:"name" validate:"required"`
+
+	// Application is either a file path or git repository URL
+	Application string `json:"application" yaml:"application" validate:"required,fixture"`
+
+	// Expect is the expected output for this application
+	Expect ExpectedOutput `json:"expect" yaml:"expect"`
+
+	// Parsed Git and container image components (not in YAML)
+	ApplicationGitComponents   *GitURLComponents `yaml:"-" json:"-"`
+	ApplicationImageComponents *ImageComponents  `yaml:"-" json:"-"`
+}
+
+// ProviderSettings overrides the settings of an analysis provider
+type ProviderSettings struct {
+	// Name of the provider, e.g. java
+	Name string `json:"name" yaml:"name" validate:"required"`
+
+	// Address of an already running provider (optional)
+	Address string `json:"address,omitempty" yaml:"address,omitempty"`
+
+	// BinaryPath of an alternative provider or LSP server binary (optional)
+	BinaryPath string `json:"binaryPath,omitempty" yaml:"binaryPath,omitempty"`
+
+	// ExcludedDirs are directories the provider doesn't analyze, added to its defaults such as
+	// node_modules and target (optional)
+	ExcludedDirs []string `json:"excludedDirs,omitempty" yaml:"excludedDirs,omitempty" validate:"dive,required"`
+
+	// ProviderSpecificConfig holds provider settings such as excludedPaths or jvmMaxMem
+	ProviderSpecificConfig map[string]interface{} `json:"providerSpecificConfig,omitempty" yaml:"providerSpecificConfig,omitempty"`
+}
+
+// ProviderConfig converts the settings to the analyzer provider configuration format
+func (ps ProviderSettings) ProviderConfig() provider.Config {
+	cfg := provider.Config{
+		Name:       ps.Name,
+		Address:    ps.Address,
+		BinaryPath: ps.BinaryPath,
+	}
+	specific := ps.ProviderSpecificConfig
+	if len(ps.ExcludedDirs) > 0 {
+		specific = maps.Clone(specific)
+		if specific == nil {
+			specific = map[string]interface{}{}
+		}
+		dirs := make([]interface{}, 0, len(ps.ExcludedDirs))
+		for _, dir := range ps.ExcludedDirs {
+			dirs = append(dirs, dir)
+		}
+		specific[provider.ExcludedDirsConfigKey] = dirs
+	}
+	if len(specific) > 0 {
+		cfg.InitConfig = []provider.InitConfig{{ProviderSpecificConfig: specific}}
+	}
+	return cfg
+}
+
+// ProviderConfigs returns the provider settings overrides in the analyzer provider configuration format
+func (ac *AnalysisConfig) ProviderConfigs() []provider.Config {
+	configs := make([]provider.Config, 0, len(ac.ProviderSettings))
+	for _, ps := range ac.ProviderSettings {
+		configs = append(configs, ps.ProviderConfig())
+	}
+	return configs
 }
 
 // ExpectConfig defines expected outcomes
 type ExpectConfig struct {
 	ExitCode int            `yaml:"exitCode"`
 	Output   ExpectedOutput `yaml:"output" validate:"required"`
+
+	// Tolerance relaxes effort and category comparisons (optional)
+	Tolerance *ToleranceConfig `yaml:"tolerance,omitempty"`
+
+	// ValidateOnly lists the only rulesets that are validated, the rest of the output is ignored (optional)
+	ValidateOnly []string `yaml:"validateOnly,omitempty" validate:"unique,dive,required"`
+
+	// Vars are variables of expected output file templates, e.g. {{ .JavaVersion }} (optional)
+	Vars map[string]string `yaml:"vars,omitempty" validate:"dive,keys,required,endkeys"`
+
+	// Assets is the directory holding the expected generated assets, relative to the test file
+	Assets string `yaml:"assets,omitempty"`
+
+	// Patches is the directory holding the expected fix patches, relative to the test file
+	Patches string `yaml:"patches,omitempty"`
+
+	// PatchSimilarity is the minimum similarity of changed lines for a patch to match (default: 0.8)
+	PatchSimilarity float64 `yaml:"patchSimilarity,omitempty" validate:"gte=0,lte=1"`
+
+	// OOMKilled expects the analysis to run out of memory, the output isn't validated then
+	OOMKilled bool `yaml:"oomKilled,omitempty"`
+
+	// Archetypes are the archetypes the application must belong to after the analysis, only Tackle Hub
+	// associates archetypes (optional)
+	Archetypes []string `yaml:"archetypes,omitempty" validate:"unique,dive,required"`
+
+	// Assessment is the expected outcome of an assessment test
+	Assessment *ExpectedAssessment `yaml:"assessment,omitempty"`
+
+	// Ticket is the expected ticket of a migration test
+	Ticket *ExpectedTicket `yaml:"ticket,omitempty"`
+
+	// Artifacts maps output artifacts other than the analysis output, e.g. dependencies or transactions,
+	// to their expected files relative to the test file (optional)
+	Artifacts map[string]string `yaml:"artifacts,omitempty" validate:"dive,keys,required,endkeys,required"`
+}
+
+// MigrationConfig configures a migration test
+type MigrationConfig struct {
+	// Wave is the name of the migration wave the application is added to (default: the test name)
+	Wave string `yaml:"wave,omitempty"`
+
+	// Tracker is the issue tracker the application is exported to
+	Tracker TrackerConfig `yaml:"tracker"`
+}
+
+// GetWave returns the name of the migration wave, defaulting to the test name
+func (m *MigrationConfig) GetWave(testName string) string {
+	if m.Wave == "" {
+		return testName
+	}
+	return m.Wave
+}
+
+// Kinds of Jira trackers
+const (
+	TrackerJiraCloud  = "jira-cloud"
+	TrackerJiraOnPrem = "jira-onprem"
+)
+
+// TrackerConfig is a Jira instance tickets are created in. Without a URL the harness's mock Jira is used
+type TrackerConfig struct {
+	// URL of the Jira instance, the mock Jira configured in the target is used when empty (optional)
+	URL string `yaml:"url,omitempty" validate:"omitempty,url"`
+
+	// Kind is jira-cloud or jira-onprem (default: jira-onprem)
+	Kind string `yaml:"kind,omitempty" validate:"omitempty,oneof=jira-cloud jira-onprem"`
+
+	// Identity is the name of the Hub identity holding the Jira credentials, required with a URL
+	Identity string `yaml:"identity,omitempty"`
+
+	// Project is the name of the Jira project tickets are created in, e.g. MIG
+	Project string `yaml:"project" validate:"required"`
+
+	// IssueType is the type of the created tickets, e.g. Story
+	IssueType string `yaml:"issueType" validate:"required"`
+}
+
+// GetKind returns the kind of the tracker, defaulting to Jira on premises
+func (t *TrackerConfig) GetKind() string {
+	if t.Kind == "" {
+		return TrackerJiraOnPrem
+	}
+	return t.Kind
+}
+
+// IsMock returns true when tickets are created in the harness's mock Jira
+func (t *TrackerConfig) IsMock() bool {
+	return t.URL == ""
+}
+
+// ExpectedTicket is the ticket a migration test must create
+type ExpectedTicket struct {
+	// Summary must be contained in the summary of the ticket, only the mock Jira records it (optional)
+	Summary string `yaml:"summary,omitempty"`
+
+	// Status is the status of the ticket in Jira, e.g. To Do (optional)
+	Status string `yaml:"status,omitempty"`
+}
+
+// AssessmentConfig configures an assessment test
+type AssessmentConfig struct {
+	// Questionnaire is a questionnaire YAML file in the format the Hub imports, relative to the test file
+	Questionnaire string `yaml:"questionnaire" validate:"required"`
+
+	// Answers are the answers selected for the questions of the questionnaire
+	Answers []AssessmentAnswer `yaml:"answers" validate:"required,dive"`
+}
+
+// AssessmentAnswer selects the answer of a question, found by the texts of its section, question and answer
+type AssessmentAnswer struct {
+	Section  string `yaml:"section" validate:"required"`
+	Question string `yaml:"question" validate:"required"`
+	Answer   string `yaml:"answer" validate:"required"`
+}
+
+// ExpectedAssessment is the outcome the Hub computes for an assessment
+type ExpectedAssessment struct {
+	// Risk is green, yellow, red or unknown
+	Risk string `yaml:"risk" validate:"required,oneof=green yellow red unknown"`
+
+	// Confidence is the confidence in the assessment from 0 to 100 (optional)
+	Confidence *int `yaml:"confidence,omitempty" validate:"omitempty,gte=0,lte=100"`
+}
+
+// CancelConfig configures a cancellation test
+type CancelConfig struct {
+	// After is how long the analysis runs before it is canceled
+	After Duration `yaml:"after"`
+
+	// GracePeriod is how long the analysis has to stop once canceled (default: 30s)
+	GracePeriod *Duration `yaml:"gracePeriod,omitempty"`
+}
+
+// DefaultCancelGracePeriod is how long a canceled analysis has to stop when no grace period is configured
+const DefaultCancelGracePeriod = 30 * time.Second
+
+// GetGracePeriod returns how long the canceled analysis has to stop with a default
+func (c *CancelConfig) GetGracePeriod() time.Duration {
+	if c.GracePeriod != nil {
+		return c.GracePeriod.Duration
+	}
+	return DefaultCancelGracePeriod
+}
+
+// DefaultPatchSimilarity is the minimum patch similarity when none is configured
+const DefaultPatchSimilarity = 0.8
+
+// GetPatchSimilarity returns the minimum patch similarity with a default
+func (ec *ExpectConfig) GetPatchSimilarity() float64 {
+	if ec.PatchSimilarity > 0 {
+		return ec.PatchSimilarity
+	}
+	return DefaultPatchSimilarity
+}
+
+// validateArtifacts checks that every expected artifact has a registered validator
+func (ec *ExpectConfig) validateArtifacts() error {
+	for _, kind := range slices.Sorted(maps.Keys(ec.Artifacts)) {
+		if _, ok := validator.ArtifactValidatorFor(kind); !ok {
+			return fmt.Errorf("'expect.artifacts' has no validator for %s, known artifacts: %s", kind, strings.Join(validator.ArtifactKinds(), ", "))
+		}
+	}
+	return nil
+}
+
+// FixConfig selects the incidents Kai is asked to fix
+type FixConfig struct {
+	// Rules selects incidents of these rule IDs from the expected output, all incidents when empty
+	Rules []string `yaml:"rules,omitempty"`
+
+	// MaxIterations limits the agent iterations of each fix request (optional)
+	MaxIterations int `yaml:"maxIterations,omitempty" validate:"gte=0"`
+}
+
+// AssetsConfig defines how assets are generated from an application's discovered configuration
+type AssetsConfig struct {
+	// Platform the application configuration is discovered from (default: cloud-foundry)
+	Platform string `yaml:"platform,omitempty" validate:"omitempty,oneof=cloud-foundry"`
+
+	// ChartDir is a Helm chart rendered with the discovered configuration, relative to the test file
+	// Without a chart only the discovery manifest is generated
+	ChartDir string `yaml:"chartDir,omitempty"`
+}
+
+// GetPlatform returns the discovery platform with a default
+func (ac *AssetsConfig) GetPlatform() string {
+	if ac.Platform != "" {
+		return ac.Platform
+	}
+	return "cloud-foundry"
+}
+
+// ToleranceConfig relaxes how violation metadata is compared against the expected output
+type ToleranceConfig struct {
+	// Effort maps rule IDs to accepted effort ranges such as "1-3", "*" applies to every rule
+	Effort map[string]string `yaml:"effort,omitempty" validate:"dive,keys,required,endkeys,required"`
+
+	// CategoryAtLeast accepts actual categories at least as severe as expected (mandatory > optional > potential)
+	CategoryAtLeast bool `yaml:"categoryAtLeast,omitempty"`
+
+	// ExactVariables requires incident variables to match exactly instead of as a subset
+	ExactVariables bool `yaml:"exactVariables,omitempty"`
+
+	// Findings normalizes where findings land before comparing them, so one expected file works across
+	// analyzer versions: by-effort makes findings without effort insights, merged compares insights and
+	// violations together (default: compared as reported)
+	Findings string `yaml:"findings,omitempty" validate:"omitempty,oneof=by-effort merged"`
+}
+
+// ValidatorOptions converts the tolerance to validation options
+// A nil tolerance compares effort and category exactly
+func (t *ToleranceConfig) ValidatorOptions() (validator.Options, error) {
+	if t == nil {
+		return validator.Options{}, nil
+	}
+	opts, err := validator.NewOptions(t.Effort, t.CategoryAtLeast)
+	if err != nil {
+		return validator.Options{}, err
+	}
+	opts.ExactVariables = t.ExactVariables
+	opts.Findings = t.Findings
+	return opts, nil
 }
 
 // ExpectedOutput is a union type for expected output
@@ -74,6 +486,9 @@ type ExpectedOutput struct {
 	// File path to YAML file containing expected RuleSets (as specified in YAML)
 	File string `yaml:"file,omitempty"`
 
+	// Template renders File as a Go template before parsing it, e.g. {{ .SourceRoot }} (optional)
+	Template bool `yaml:"template,omitempty"`
+
 	// ResolvedFilePath is the absolute path to the expected output file (not in YAML)
 	ResolvedFilePath string `yaml:"-"`
 }
@@ -119,12 +534,84 @@ func (td *TestDefinition) GetWorkDir() string {
 	return ".koncur/output"
 }
 
+// Environ returns the test environment variables as sorted KEY=value pairs
+func (td *TestDefinition) Environ() []string {
+	env := make([]string, 0, len(td.Env))
+	for _, key := range slices.Sorted(maps.Keys(td.Env)) {
+		env = append(env, key+"="+td.Env[key])
+	}
+	return env
+}
+
+// IsCancelTest returns true if the test validates the cancellation of the analysis instead of its output
+func (td *TestDefinition) IsCancelTest() bool {
+	return td.Cancel != nil
+}
+
+// IsAssessmentTest returns true if the test validates an assessment of the application instead of an analysis
+func (td *TestDefinition) IsAssessmentTest() bool {
+	return td.Assessment != nil
+}
+
+// IsMigrationTest returns true if the test validates the export of the application to an issue tracker
+// instead of an analysis
+func (td *TestDefinition) IsMigrationTest() bool {
+	return td.Migration != nil
+}
+
+// IsFixTest returns true if the test validates Kai fixes instead of analysis output
+func (td *TestDefinition) IsFixTest() bool {
+	return td.Fixes != nil
+}
+
+// IsAssetTest returns true if the test validates generated assets instead of analysis output
+func (td *TestDefinition) IsAssetTest() bool {
+	return td.Assets != nil
+}
+
+// ResolvePath resolves a path relative to the test file's directory
+func (td *TestDefinition) ResolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(td.GetTestDir(), path)
+}
+
+// IsMultiApplication returns true if the test analyzes more than one application
+func (td *TestDefinition) IsMultiApplication() bool {
+	return len(td.Analysis.Applications) > 0
+}
+
+// ForApplication returns a copy of the test that analyzes only the given application
+// The copy keeps the test file path so relative paths resolve the same way
+func (td *TestDefinition) ForApplication(app ApplicationConfig) *TestDefinition {
+	appTest := *td
+	appTest.Name = fmt.Sprintf("%s-%s", td.Name, app.Name)
+	appTest.Analysis.Application = app.Application
+	appTest.Analysis.ApplicationGitComponents = app.ApplicationGitComponents
+	appTest.Analysis.ApplicationImageComponents = app.ApplicationImageComponents
+	appTest.Analysis.Applications = nil
+	appTest.Expect.Output = app.Expect
+	return &appTest
+}
+
 // ParseGitURLs parses Git URLs in the analysis configuration
 // This should be called after loading the configuration
 func (ac *AnalysisConfig) ParseGitURLs() {
-	// Parse application Git URL if it's a Git URL
-	if IsGitURL(ac.Application) {
-		ac.ApplicationGitComponents = ParseGitURLWithPath(ac.Application)
+	// Parse application image or repository URL
+	// Images are checked first since their path separator also marks Git references
+	if IsImageURL(ac.Application) {
+		ac.ApplicationImageComponents = ParseImageURL(ac.Application)
+	} else {
+		ac.ApplicationGitComponents = parseRepositoryURL(ac.Application, ac.Git)
+	}
+	for i := range ac.Applications {
+		app := &ac.Applications[i]
+		if IsImageURL(app.Application) {
+			app.ApplicationImageComponents = ParseImageURL(app.Application)
+		} else {
+			app.ApplicationGitComponents = parseRepositoryURL(app.Application, ac.Git)
+		}
 	}
 
 	// Parse rules Git URLs
@@ -140,3 +627,17 @@ func (ac *AnalysisConfig) ParseGitURLs() {
 		}
 	}
 }
+
+// parseRepositoryURL parses a Subversion or Git URL, returning nil for local paths
+// Git clone options only apply to Git repositories
+func parseRepositoryURL(str string, options *GitOptions) *GitURLComponents {
+	if IsSVNURL(str) {
+		return ParseSVNURLWithPath(str)
+	}
+	if IsGitURL(str) {
+		components := ParseGitURLWithPath(str)
+		components.Options = options
+		return components
+	}
+	return nil
+}
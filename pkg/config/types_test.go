@@ -0,0 +1,832 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konveyor/analyzer-lsp/provider"
+)
+
+func TestTestDefinition_ForApplication(t *testing.T) {
+	test := &TestDefinition{
+		Name: "multi",
+		Analysis: AnalysisConfig{
+			LabelSelector: "konveyor.io/target=quarkus",
+			AnalysisMode:  "source-only",
+			Applications: []ApplicationConfig{
+				{
+					Name:        "frontend",
+					Application: "https://github.com/konveyor/frontend#main",
+					Expect:      ExpectedOutput{File: "expected-output-frontend.yaml"},
+				},
+			},
+		},
+	}
+	test.SetTestFilePath("/tests/multi/test.yaml")
+	test.Analysis.ParseGitURLs()
+
+	if !test.IsMultiApplication() {
+		t.Fatal("Expected test to be multi-application")
+	}
+
+	appTest := test.ForApplication(test.Analysis.Applications[0])
+	if appTest.Name != "multi-frontend" {
+		t.Errorf("Name = %v, want multi-frontend", appTest.Name)
+	}
+	if appTest.IsMultiApplication() {
+		t.Error("Expected application test to analyze a single application")
+	}
+	if appTest.Analysis.Application != "https://github.com/konveyor/frontend#main" {
+		t.Errorf("Application = %v", appTest.Analysis.Application)
+	}
+	if appTest.Analysis.ApplicationGitComponents == nil || appTest.Analysis.ApplicationGitComponents.Ref != "main" {
+		t.Errorf("ApplicationGitComponents = %+v", appTest.Analysis.ApplicationGitComponents)
+	}
+	if appTest.Analysis.LabelSelector != test.Analysis.LabelSelector {
+		t.Errorf("LabelSelector = %v, want %v", appTest.Analysis.LabelSelector, test.Analysis.LabelSelector)
+	}
+	if appTest.Expect.Output.File != "expected-output-frontend.yaml" {
+		t.Errorf("Expect.Output.File = %v", appTest.Expect.Output.File)
+	}
+	if appTest.GetTestDir() != "/tests/multi" {
+		t.Errorf("GetTestDir() = %v, want /tests/multi", appTest.GetTestDir())
+	}
+	if len(test.Analysis.Applications) != 1 {
+		t.Error("ForApplication must not modify the original test")
+	}
+}
+
+func TestLoad_MultiApplication(t *testing.T) {
+	dir := t.TempDir()
+	testYAML := `name: multi
+analysis:
+  analysisMode: source-only
+  applications:
+  - name: backend
+    application: /apps/backend
+    expect:
+      file: expected-output-backend.yaml
+  - name: frontend
+    application: /apps/frontend
+    expect:
+      file: expected-output-frontend.yaml
+expect:
+  exitCode: 0
+`
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(testYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"backend", "frontend"} {
+		content := "- name: " + name + "-rules\n  tags:\n  - Java\n"
+		if err := os.WriteFile(filepath.Join(dir, "expected-output-"+name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	test, err := Load(filepath.Join(dir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := Validate(test); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	for _, app := range test.Analysis.Applications {
+		if len(app.Expect.Result) != 1 || app.Expect.Result[0].Name != app.Name+"-rules" {
+			t.Errorf("application %s expected output not loaded: %+v", app.Name, app.Expect.Result)
+		}
+	}
+}
+
+func TestValidate_MultiApplication(t *testing.T) {
+	tests := []struct {
+		name    string
+		apps    []ApplicationConfig
+		app     string
+		wantErr bool
+	}{
+		{
+			name: "missing expected output",
+			apps: []ApplicationConfig{
+				{Name: "a", Application: "/apps/a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			apps: []ApplicationConfig{
+				{Name: "a", Application: "/apps/a", Expect: ExpectedOutput{File: "a.yaml", ResolvedFilePath: "/a.yaml"}},
+				{Name: "a", Application: "/apps/b", Expect: ExpectedOutput{File: "b.yaml", ResolvedFilePath: "/b.yaml"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "application and applications both set",
+			app:  "/apps/c",
+			apps: []ApplicationConfig{
+				{Name: "a", Application: "/apps/a", Expect: ExpectedOutput{File: "a.yaml"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid",
+			apps: []ApplicationConfig{
+				{Name: "a", Application: "/apps/a", Expect: ExpectedOutput{File: "a.yaml"}},
+				{Name: "b", Application: "/apps/b", Expect: ExpectedOutput{File: "b.yaml"}},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "multi",
+				Analysis: AnalysisConfig{
+					Application:  tt.app,
+					AnalysisMode: "source-only",
+					Applications: tt.apps,
+				},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_GitOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		git     *GitOptions
+		wantErr bool
+	}{
+		{name: "no options", git: nil},
+		{name: "valid commit", git: &GitOptions{Commit: "0a1b2c3d4e5f"}},
+		{name: "invalid commit", git: &GitOptions{Commit: "main"}, wantErr: true},
+		{name: "short commit", git: &GitOptions{Commit: "0a1b"}, wantErr: true},
+		{name: "empty sparse path", git: &GitOptions{SparsePaths: []string{""}}, wantErr: true},
+		{name: "full clone with submodules", git: &GitOptions{FullClone: true, Submodules: true, SparsePaths: []string{"app"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "git",
+				Analysis: AnalysisConfig{
+					Application:  "https://github.com/konveyor/app#main",
+					AnalysisMode: "source-only",
+					Git:          tt.git,
+				},
+				Expect: ExpectConfig{Output: ExpectedOutput{File: "expected-output.yaml"}},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			test.Analysis.ParseGitURLs()
+			if test.Analysis.ApplicationGitComponents.Options != tt.git {
+				t.Error("Expected git options on the application components")
+			}
+		})
+	}
+}
+
+func TestValidate_Tolerance(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance *ToleranceConfig
+		wantErr   bool
+	}{
+		{name: "no tolerance", tolerance: nil},
+		{name: "effort ranges", tolerance: &ToleranceConfig{Effort: map[string]string{"*": "1-3", "rule1": "5"}, CategoryAtLeast: true}},
+		{name: "invalid effort range", tolerance: &ToleranceConfig{Effort: map[string]string{"rule1": "3-1"}}, wantErr: true},
+		{name: "empty effort range", tolerance: &ToleranceConfig{Effort: map[string]string{"rule1": ""}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "tolerance",
+				Analysis: AnalysisConfig{
+					Application:  "/apps/a",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{
+					Output:    ExpectedOutput{File: "expected-output.yaml"},
+					Tolerance: tt.tolerance,
+				},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_ValidateOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		validateOnly []string
+		wantErr      bool
+	}{
+		{name: "all rulesets", validateOnly: nil},
+		{name: "listed rulesets", validateOnly: []string{"eap8/eap7", "cloud-readiness"}},
+		{name: "empty ruleset name", validateOnly: []string{""}, wantErr: true},
+		{name: "duplicate ruleset", validateOnly: []string{"cloud-readiness", "cloud-readiness"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "validate-only",
+				Analysis: AnalysisConfig{
+					Application:  "/apps/a",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{
+					Output:       ExpectedOutput{File: "expected-output.yaml"},
+					ValidateOnly: tt.validateOnly,
+				},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Env(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "no env", env: nil},
+		{name: "variables", env: map[string]string{"JAVA_OPTS": "-Xmx2g", "LOG_LEVEL": ""}},
+		{name: "empty name", env: map[string]string{"": "value"}, wantErr: true},
+		{name: "name with equals", env: map[string]string{"A=B": "value"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "env",
+				Env:  tt.env,
+				Analysis: AnalysisConfig{
+					Application:  "/apps/a",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{
+					Output: ExpectedOutput{File: "expected-output.yaml"},
+				},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Resources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *ResourceLimits
+		wantErr   bool
+	}{
+		{name: "no limits", resources: nil},
+		{name: "valid limits", resources: &ResourceLimits{CPUs: "0.5", Memory: "512m"}},
+		{name: "memory in bytes", resources: &ResourceLimits{Memory: "1073741824"}},
+		{name: "invalid memory unit", resources: &ResourceLimits{Memory: "4gb"}, wantErr: true},
+		{name: "invalid cpus", resources: &ResourceLimits{CPUs: "two"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name:      "resources",
+				Analysis:  AnalysisConfig{Application: "/apps/a", AnalysisMode: "source-only"},
+				Resources: tt.resources,
+				Expect:    ExpectConfig{Output: ExpectedOutput{File: "expected-output.yaml"}},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceLimits_Merge(t *testing.T) {
+	target := &ResourceLimits{CPUs: "4", Memory: "8g"}
+	merged := target.Merge(&ResourceLimits{Memory: "2g"})
+	if merged.CPUs != "4" || merged.Memory != "2g" {
+		t.Errorf("Merge() = %+v, want the test's memory with the target's cpus", merged)
+	}
+
+	var none *ResourceLimits
+	if !none.Merge(nil).IsZero() {
+		t.Error("Merge() of no limits should have none")
+	}
+}
+
+func TestHubTaskConfig_Merge(t *testing.T) {
+	priority, testPriority, preempt := 5, 10, true
+	target := &HubTaskConfig{Priority: &priority, PreemptEnabled: &preempt}
+	merged := target.Merge(&HubTaskConfig{Kind: "analyzer-v2", Priority: &testPriority})
+	if merged.GetKind() != "analyzer-v2" || *merged.Priority != 10 || !*merged.PreemptEnabled {
+		t.Errorf("Merge() = %+v, want the test's kind and priority with the target's preemption", merged)
+	}
+
+	var none *HubTaskConfig
+	if got := none.Merge(nil).GetKind(); got != DefaultHubTaskKind {
+		t.Errorf("GetKind() without a kind = %q, want %q", got, DefaultHubTaskKind)
+	}
+}
+
+func TestTestDefinition_Environ(t *testing.T) {
+	test := &TestDefinition{Env: map[string]string{"LOG_LEVEL": "debug", "JAVA_OPTS": "-Xmx2g -Dfoo=bar"}}
+	got := test.Environ()
+	want := []string{"JAVA_OPTS=-Xmx2g -Dfoo=bar", "LOG_LEVEL=debug"}
+	if len(got) != len(want) {
+		t.Fatalf("Environ() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Environ()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoad_ProviderSettings(t *testing.T) {
+	dir := t.TempDir()
+	testYAML := `name: provider-settings
+analysis:
+  application: /apps/a
+  analysisMode: source-only
+  providerSettings:
+  - name: java
+    providerSpecificConfig:
+      jvmMaxMem: 2g
+      excludedPaths: [target, build]
+      mavenIndex:
+        enabled: false
+  - name: go
+    binaryPath: /usr/local/bin/gopls
+  - name: builtin
+    excludedDirs: [/opt/input/source/generated]
+expect:
+  output:
+    result:
+    - name: rules
+`
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(testYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	test, err := Load(filepath.Join(dir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := Validate(test); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	configs := test.Analysis.ProviderConfigs()
+	if len(configs) != 3 {
+		t.Fatalf("ProviderConfigs() returned %d configs, want 3", len(configs))
+	}
+	if configs[0].Name != "java" || len(configs[0].InitConfig) != 1 {
+		t.Fatalf("java config = %+v", configs[0])
+	}
+	if got := configs[0].InitConfig[0].ProviderSpecificConfig["jvmMaxMem"]; got != "2g" {
+		t.Errorf("jvmMaxMem = %v, want 2g", got)
+	}
+	if configs[1].BinaryPath != "/usr/local/bin/gopls" || len(configs[1].InitConfig) != 0 {
+		t.Errorf("go config = %+v", configs[1])
+	}
+	if len(configs[2].InitConfig) != 1 || !reflect.DeepEqual(configs[2].InitConfig[0].ProviderSpecificConfig[provider.ExcludedDirsConfigKey], []interface{}{"/opt/input/source/generated"}) {
+		t.Errorf("builtin config = %+v, want its excluded dirs", configs[2])
+	}
+
+	// Nested settings must be JSON encodable for kantra and the Hub
+	data, err := json.Marshal(configs)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"mavenIndex":{"enabled":false}`) {
+		t.Errorf("nested settings not encoded: %s", data)
+	}
+
+	// Provider names must be unique
+	test.Analysis.ProviderSettings[1].Name = "java"
+	if err := Validate(test); err == nil {
+		t.Error("Validate() expected error for duplicate provider names")
+	}
+}
+
+func TestValidate_Providers(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []string
+		wantErr   bool
+	}{
+		{name: "detected", providers: nil},
+		{name: "supported providers", providers: []string{ProviderJava, ProviderGo, ProviderPython, ProviderNodeJS, ProviderDotnet}},
+		{name: "unknown provider", providers: []string{"rust"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "providers",
+				Analysis: AnalysisConfig{
+					Application:  "/apps/a",
+					AnalysisMode: "source-only",
+					Providers:    tt.providers,
+				},
+				Expect: ExpectConfig{
+					Output: ExpectedOutput{File: "expected-output.yaml"},
+				},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Cancel(t *testing.T) {
+	grace := Duration{Duration: 0}
+	tests := []struct {
+		name         string
+		cancel       *CancelConfig
+		applications []ApplicationConfig
+		wantErr      bool
+	}{
+		{name: "cancel after a delay", cancel: &CancelConfig{After: Duration{Duration: 30 * time.Second}}},
+		{name: "missing delay", cancel: &CancelConfig{}, wantErr: true},
+		{name: "zero grace period", cancel: &CancelConfig{After: Duration{Duration: time.Second}, GracePeriod: &grace}, wantErr: true},
+		{
+			name:         "multiple applications",
+			cancel:       &CancelConfig{After: Duration{Duration: time.Second}},
+			applications: []ApplicationConfig{{Name: "a", Application: "/apps/a"}},
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Cancellation tests don't need an expected output
+			test := &TestDefinition{
+				Name:   "cancel",
+				Cancel: tt.cancel,
+				Analysis: AnalysisConfig{
+					AnalysisMode: "source-only",
+					Applications: tt.applications,
+				},
+			}
+			if tt.applications == nil {
+				test.Analysis.Application = "/apps/a"
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := (&CancelConfig{}).GetGracePeriod(); got != DefaultCancelGracePeriod {
+		t.Errorf("GetGracePeriod() = %v, want %v", got, DefaultCancelGracePeriod)
+	}
+}
+
+func TestValidate_Assessment(t *testing.T) {
+	assessment := &AssessmentConfig{
+		Questionnaire: "questionnaire.yaml",
+		Answers:       []AssessmentAnswer{{Section: "Application details", Question: "Is the application stateless?", Answer: "Yes"}},
+	}
+	confidence := 120
+
+	tests := []struct {
+		name       string
+		assessment *AssessmentConfig
+		expected   *ExpectedAssessment
+		wantErr    bool
+	}{
+		{name: "expected risk", assessment: assessment, expected: &ExpectedAssessment{Risk: "green"}},
+		{name: "missing expectation", assessment: assessment, wantErr: true},
+		{name: "unknown risk", assessment: assessment, expected: &ExpectedAssessment{Risk: "orange"}, wantErr: true},
+		{name: "confidence out of range", assessment: assessment, expected: &ExpectedAssessment{Risk: "red", Confidence: &confidence}, wantErr: true},
+		{name: "missing answers", assessment: &AssessmentConfig{Questionnaire: "questionnaire.yaml"}, expected: &ExpectedAssessment{Risk: "green"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assessment tests don't need an expected output
+			test := &TestDefinition{
+				Name:       "assessment",
+				Assessment: tt.assessment,
+				Analysis: AnalysisConfig{
+					Application:  "https://github.com/konveyor/tackle-testapp",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{Assessment: tt.expected},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Migration(t *testing.T) {
+	mock := &MigrationConfig{Tracker: TrackerConfig{Project: "MIG", IssueType: "Story"}}
+
+	tests := []struct {
+		name      string
+		migration *MigrationConfig
+		expected  *ExpectedTicket
+		wantErr   bool
+	}{
+		{name: "mock Jira", migration: mock, expected: &ExpectedTicket{Summary: "Migrate"}},
+		{name: "missing expectation", migration: mock, wantErr: true},
+		{
+			name:      "Jira with identity",
+			migration: &MigrationConfig{Tracker: TrackerConfig{URL: "https://jira.example.com", Identity: "jira", Project: "MIG", IssueType: "Story"}},
+			expected:  &ExpectedTicket{},
+		},
+		{
+			name:      "Jira without identity",
+			migration: &MigrationConfig{Tracker: TrackerConfig{URL: "https://jira.example.com", Project: "MIG", IssueType: "Story"}},
+			expected:  &ExpectedTicket{},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown tracker kind",
+			migration: &MigrationConfig{Tracker: TrackerConfig{Kind: "github", Project: "MIG", IssueType: "Story"}},
+			expected:  &ExpectedTicket{},
+			wantErr:   true,
+		},
+		{name: "missing project", migration: &MigrationConfig{Tracker: TrackerConfig{IssueType: "Story"}}, expected: &ExpectedTicket{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name:      "migration",
+				Migration: tt.migration,
+				Analysis: AnalysisConfig{
+					Application:  "https://github.com/konveyor/tackle-testapp",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{Ticket: tt.expected},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_ExcludedPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		analysis AnalysisConfig
+		wantErr  bool
+	}{
+		{name: "relative paths", analysis: AnalysisConfig{ExcludedPaths: []string{"generated", "src/test"}, DependencyFolders: []string{"lib"}}},
+		{name: "absolute excluded path", analysis: AnalysisConfig{ExcludedPaths: []string{"/src/test"}}, wantErr: true},
+		{name: "dependency folder outside the application", analysis: AnalysisConfig{DependencyFolders: []string{"../lib"}}, wantErr: true},
+		{name: "duplicate excluded path", analysis: AnalysisConfig{ExcludedPaths: []string{"generated", "generated"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.analysis.Application = "https://github.com/konveyor/tackle-testapp"
+			tt.analysis.AnalysisMode = "source-only"
+			test := &TestDefinition{
+				Name:     "excluded",
+				Analysis: tt.analysis,
+				Expect:   ExpectConfig{Output: ExpectedOutput{File: "expected.yaml"}},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Artifacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		artifacts map[string]string
+		wantErr   string
+	}{
+		{name: "registered artifacts", artifacts: map[string]string{"dependencies": "expected-dependencies.yaml", "transactions": "expected-transactions.yaml"}},
+		{name: "unknown artifact", artifacts: map[string]string{"call-graph": "expected-call-graph.yaml"}, wantErr: "no validator for call-graph"},
+		{name: "missing expected file", artifacts: map[string]string{"dependencies": ""}, wantErr: "validation failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "artifacts",
+				Analysis: AnalysisConfig{
+					Application:  "https://github.com/konveyor/tackle-testapp",
+					AnalysisMode: "source-only",
+				},
+				Expect: ExpectConfig{Output: ExpectedOutput{File: "expected.yaml"}, Artifacts: tt.artifacts},
+			}
+			err := Validate(test)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Assets(t *testing.T) {
+	tests := []struct {
+		name    string
+		assets  *AssetsConfig
+		expect  ExpectConfig
+		wantErr bool
+	}{
+		{name: "expected assets", assets: &AssetsConfig{ChartDir: "chart"}, expect: ExpectConfig{Assets: "expected-assets"}},
+		{name: "missing expected assets", assets: &AssetsConfig{}, expect: ExpectConfig{}, wantErr: true},
+		{name: "unknown platform", assets: &AssetsConfig{Platform: "heroku"}, expect: ExpectConfig{Assets: "expected-assets"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "assets",
+				Analysis: AnalysisConfig{
+					Application:  "manifest.yml",
+					AnalysisMode: "source-only",
+				},
+				Assets: tt.assets,
+				Expect: tt.expect,
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTestDefinition_ResolvePath(t *testing.T) {
+	test := &TestDefinition{}
+	test.SetTestFilePath("/tests/assets/test.yaml")
+
+	if got := test.ResolvePath("chart"); got != "/tests/assets/chart" {
+		t.Errorf("ResolvePath(chart) = %s", got)
+	}
+	if got := test.ResolvePath("/charts/app"); got != "/charts/app" {
+		t.Errorf("ResolvePath(/charts/app) = %s", got)
+	}
+}
+
+func TestValidate_Fixes(t *testing.T) {
+	output := ExpectedOutput{File: "output.yaml"}
+	tests := []struct {
+		name    string
+		fixes   *FixConfig
+		expect  ExpectConfig
+		wantErr bool
+	}{
+		{name: "expected patches", fixes: &FixConfig{}, expect: ExpectConfig{Output: output, Patches: "patches"}},
+		{name: "missing expected patches", fixes: &FixConfig{}, expect: ExpectConfig{Output: output}, wantErr: true},
+		{name: "similarity out of range", fixes: &FixConfig{}, expect: ExpectConfig{Output: output, Patches: "patches", PatchSimilarity: 1.5}, wantErr: true},
+		{name: "negative iterations", fixes: &FixConfig{MaxIterations: -1}, expect: ExpectConfig{Output: output, Patches: "patches"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "fixes",
+				Analysis: AnalysisConfig{
+					Application:  "app",
+					AnalysisMode: "source-only",
+				},
+				Fixes:  tt.fixes,
+				Expect: tt.expect,
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpectConfig_GetPatchSimilarity(t *testing.T) {
+	if got := (&ExpectConfig{}).GetPatchSimilarity(); got != DefaultPatchSimilarity {
+		t.Errorf("GetPatchSimilarity() = %v, want default %v", got, DefaultPatchSimilarity)
+	}
+	if got := (&ExpectConfig{PatchSimilarity: 0.5}).GetPatchSimilarity(); got != 0.5 {
+		t.Errorf("GetPatchSimilarity() = %v, want 0.5", got)
+	}
+}
+
+func TestValidate_Requires(t *testing.T) {
+	tests := []struct {
+		name     string
+		requires *RequiresConfig
+		wantErr  bool
+	}{
+		{name: "capabilities and versions", requires: &RequiresConfig{Capabilities: []Capability{CapabilityIncidentSelector}, Versions: map[string]string{"kantra": "v0.7.0"}}},
+		{name: "unknown capability", requires: &RequiresConfig{Capabilities: []Capability{"time-travel"}}, wantErr: true},
+		{name: "empty version", requires: &RequiresConfig{Versions: map[string]string{"kantra": ""}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := &TestDefinition{
+				Name: "requires",
+				Analysis: AnalysisConfig{
+					Application:  "app",
+					AnalysisMode: "source-only",
+				},
+				Requires: tt.requires,
+				Expect:   ExpectConfig{Output: ExpectedOutput{File: "output.yaml"}},
+			}
+			err := Validate(test)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTestDefinition_RequiredCapabilities(t *testing.T) {
+	test := &TestDefinition{
+		Analysis: AnalysisConfig{
+			IncidentSelector: "!package",
+			Providers:        []string{"java"},
+			CustomTarget:     &CustomTargetConfig{Name: "acme", Rules: []string{"rules.yaml"}},
+		},
+		Requires: &RequiresConfig{Capabilities: []Capability{CapabilityIncidentSelector, CapabilityProviderSettings}},
+	}
+
+	got := test.RequiredCapabilities()
+	want := []Capability{CapabilityCustomTarget, CapabilityIncidentSelector, CapabilityProviderSelection, CapabilityProviderSettings}
+	if !slices.Equal(got, want) {
+		t.Errorf("RequiredCapabilities() = %v, want %v", got, want)
+	}
+	if got := (&TestDefinition{}).RequiredCapabilities(); len(got) != 0 {
+		t.Errorf("RequiredCapabilities() = %v, want none", got)
+	}
+}
+
+func TestLoad_SkipAndExpectedFailure(t *testing.T) {
+	dir := t.TempDir()
+	testYAML := `name: known-bug
+skip: waiting for the java provider fix
+expectedFailure: https://github.com/konveyor/analyzer-lsp/issues/1
+analysis:
+  application: /apps/a
+  analysisMode: source-only
+expect:
+  output:
+    result:
+    - name: rules
+`
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(testYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	test, err := Load(filepath.Join(dir, "test.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if test.Skip != "waiting for the java provider fix" {
+		t.Errorf("Skip = %q", test.Skip)
+	}
+	if test.ExpectedFailure != "https://github.com/konveyor/analyzer-lsp/issues/1" {
+		t.Errorf("ExpectedFailure = %q", test.ExpectedFailure)
+	}
+}
@@ -2,21 +2,135 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
 
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+// memoryPattern matches container memory sizes, a number with an optional b, k, m or g unit
+var memoryPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[bkmgBKMG]?$`)
+
 func init() {
 	validate = validator.New()
+	validate.RegisterValidation("memory", func(fl validator.FieldLevel) bool {
+		return memoryPattern.MatchString(fl.Field().String())
+	})
+	validate.RegisterValidation("image", func(fl validator.FieldLevel) bool {
+		return IsImageRef(fl.Field().String())
+	})
+	validate.RegisterValidation("fixture", func(fl validator.FieldLevel) bool {
+		return isKnownFixture(fl.Field().String())
+	})
 }
 
 // Validate checks if a test definition is valid
+// Matrix tests are valid when every variant is
 func Validate(test *TestDefinition) error {
+	if test.IsMatrix() {
+		return validateMatrix(test)
+	}
+
 	// Run struct validation
 	if err := validate.Struct(test); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return fmt.Errorf("validation failed: %w", validationErrors(test, err))
+	}
+
+	// Effort ranges are parsed up front so a typo fails validation rather than the run
+	if _, err := test.Expect.Tolerance.ValidatorOptions(); err != nil {
+		return fmt.Errorf("invalid tolerance: %w", err)
+	}
+
+	// Artifacts are compared by the validator registered for their kind
+	if err := test.Expect.validateArtifacts(); err != nil {
+		return err
+	}
+	if len(test.Expect.Artifacts) > 0 && test.IsMultiApplication() {
+		return fmt.Errorf("'expect.artifacts' can only be checked for a single application")
+	}
+
+	// Excluded paths and dependency folders are inside the application
+	for _, p := range slices.Concat(test.Analysis.ExcludedPaths, test.Analysis.DependencyFolders) {
+		if filepath.IsAbs(p) || !filepath.IsLocal(p) {
+			return fmt.Errorf("path %s must be relative to the application", p)
+		}
+	}
+
+	// Cancellation tests check that the canceled analysis left nothing behind, there is no output to expect
+	if test.IsCancelTest() {
+		if test.IsMultiApplication() || test.IsAssetTest() || test.IsFixTest() {
+			return fmt.Errorf("cancellation tests must analyze a single application")
+		}
+		if test.Cancel.After.Duration <= 0 {
+			return fmt.Errorf("cancellation tests must specify when to cancel the analysis in 'cancel.after'")
+		}
+		if test.Cancel.GetGracePeriod() <= 0 {
+			return fmt.Errorf("'cancel.gracePeriod' must be positive")
+		}
+		return nil
+	}
+
+	// Assessment tests don't analyze the application, there is no output to expect
+	if test.IsAssessmentTest() {
+		if test.IsMultiApplication() || test.IsAssetTest() || test.IsFixTest() {
+			return fmt.Errorf("assessment tests must assess a single application")
+		}
+		if test.Expect.Assessment == nil {
+			return fmt.Errorf("assessment tests must specify the expected risk in 'expect.assessment'")
+		}
+		return nil
+	}
+
+	// Migration tests don't analyze the application, the ticket it is exported to is validated
+	if test.IsMigrationTest() {
+		if test.IsMultiApplication() || test.IsAssetTest() || test.IsFixTest() || test.IsAssessmentTest() {
+			return fmt.Errorf("migration tests must export a single application")
+		}
+		if !test.Migration.Tracker.IsMock() && test.Migration.Tracker.Identity == "" {
+			return fmt.Errorf("migration tests exporting to %s must name the Hub identity holding the Jira credentials in 'migration.tracker.identity'", test.Migration.Tracker.URL)
+		}
+		if test.Expect.Ticket == nil {
+			return fmt.Errorf("migration tests must specify the expected ticket in 'expect.ticket'")
+		}
+		return nil
+	}
+
+	// Asset tests compare generated files instead of analysis output
+	if test.IsAssetTest() {
+		if test.IsMultiApplication() {
+			return fmt.Errorf("asset tests cannot analyze multiple applications")
+		}
+		if test.Expect.Assets == "" {
+			return fmt.Errorf("asset tests must specify the expected assets directory in 'expect.assets'")
+		}
+		return nil
+	}
+
+	// Fix tests take the incidents to fix from the expected output and compare patches
+	if test.IsFixTest() {
+		if test.IsMultiApplication() {
+			return fmt.Errorf("fix tests cannot analyze multiple applications")
+		}
+		if test.Expect.Patches == "" {
+			return fmt.Errorf("fix tests must specify the expected patches directory in 'expect.patches'")
+		}
+	}
+
+	// Multi-application tests carry an expected output per application
+	if test.IsMultiApplication() {
+		if len(test.Expect.Archetypes) > 0 {
+			return fmt.Errorf("'expect.archetypes' can only be checked for a single application")
+		}
+		for i := range test.Analysis.Applications {
+			app := &test.Analysis.Applications[i]
+			if err := validateExpectedOutput(&app.Expect); err != nil {
+				return fmt.Errorf("application %s: %w", app.Name, err)
+			}
+		}
+		return validateApplicationNames(test.Analysis.Applications)
 	}
 
 	// Custom validation: ExpectedOutput must have exactly one of Result or File
@@ -41,6 +155,21 @@ func validateExpectedOutput(output *ExpectedOutput) error {
 	if hasResult && hasFile && output.ResolvedFilePath == "" {
 		return fmt.Errorf("expected output cannot specify both 'result' and 'file'")
 	}
+	if output.Template && !hasFile {
+		return fmt.Errorf("expected output 'template' requires a 'file'")
+	}
 
 	return nil
 }
+
+// validateApplicationNames ensures application names are unique, since results are keyed by them
+func validateApplicationNames(apps []ApplicationConfig) error {
+	seen := make(map[string]bool)
+	for _, app := range apps {
+		if seen[app.Name] {
+			return fmt.Errorf("duplicate application name: %s", app.Name)
+		}
+		seen[app.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// TestFileName is the name of the file holding a test definition
+const TestFileName = "test.yaml"
+
+// Test is a test found while walking a directory
+type Test struct {
+	// File is the path to the test definition
+	File string
+
+	// Name is the name of the directory containing the test definition
+	Name string
+
+	// Definition is the loaded test definition, nil when the test is skipped or failed to load
+	Definition *config.TestDefinition
+
+	// Skipped is true when the test file is marked as SKIPPED
+	Skipped bool
+
+	// Err is the error encountered while loading the test definition
+	Err error
+}
+
+// FindTestFiles recursively finds all test.yaml files in the given directory
+func FindTestFiles(dir string) ([]string, error) {
+	var testFiles []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Base(path) == TestFileName {
+			testFiles = append(testFiles, path)
+		}
+		return nil
+	})
+
+	return testFiles, err
+}
+
+// IsSkipped checks if the test file contains a SKIPPED marker in the first few lines
+func IsSkipped(testFile string) bool {
+	content, err := os.ReadFile(testFile)
+	if err != nil {
+		return false
+	}
+
+	// Check first 500 bytes for SKIPPED marker
+	searchContent := string(content)
+	if len(searchContent) > 500 {
+		searchContent = searchContent[:500]
+	}
+
+	return strings.Contains(searchContent, "SKIPPED:") || strings.Contains(searchContent, "# SKIPPED")
+}
+
+// Discover walks root for test definitions and returns the tests matching all filters
+// Expected outputs are not loaded, tests are loaded again in full when they run
+func Discover(root string, filters []Filter) ([]Test, error) {
+	testFiles, err := FindTestFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests []Test
+	for _, testFile := range testFiles {
+		test := Test{
+			File:    testFile,
+			Name:    filepath.Base(filepath.Dir(testFile)),
+			Skipped: IsSkipped(testFile),
+		}
+		if !test.Skipped {
+			test.Definition, test.Err = config.LoadWithOptions(testFile, true)
+		}
+
+		if MatchAll(filters, test) {
+			tests = append(tests, test)
+		}
+	}
+
+	return tests, nil
+}
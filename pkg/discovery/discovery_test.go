@@ -0,0 +1,175 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected Filter
+		wantErr  bool
+	}{
+		{expr: "spring", expected: Filter{Field: FieldName, Op: OpContains, Value: "spring"}},
+		{expr: "name=daytrader", expected: Filter{Field: FieldName, Op: OpEquals, Value: "daytrader"}},
+		{expr: "name~spring.*", expected: Filter{Field: FieldName, Op: OpRegex, Value: "spring.*"}},
+		{expr: "tag = smoke", expected: Filter{Field: FieldTag, Op: OpEquals, Value: "smoke"}},
+		{expr: "target~quarkus", expected: Filter{Field: FieldTarget, Op: OpRegex, Value: "quarkus"}},
+		{expr: "owner=me", wantErr: true},
+		{expr: "name~[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseFilter(tt.expr)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseFilter(%q) expected error", tt.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) unexpected error: %v", tt.expr, err)
+			}
+			if got.Field != tt.expected.Field || got.Op != tt.expected.Op || got.Value != tt.expected.Value {
+				t.Errorf("ParseFilter(%q) = %+v, want %+v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFilterMatches(t *testing.T) {
+	test := Test{
+		Name: "spring-petclinic",
+		Definition: &config.TestDefinition{
+			Name: "Spring Petclinic",
+			Tags: []string{"smoke", "java"},
+			Analysis: config.AnalysisConfig{
+				Target: []string{"quarkus", "cloud-readiness"},
+			},
+		},
+	}
+	skipped := Test{Name: "spring-boot", Skipped: true}
+
+	tests := []struct {
+		expr     string
+		test     Test
+		expected bool
+	}{
+		{"petclinic", test, true},
+		{"name~^spring-.*", test, true},
+		{"name=Spring Petclinic", test, true},
+		{"name=spring", test, false},
+		{"tag=smoke", test, true},
+		{"tag=slow", test, false},
+		{"target=quarkus", test, true},
+		{"target~^eap", test, false},
+		{"name~spring.*", skipped, true},
+		{"tag=smoke", skipped, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr+"/"+tt.test.Name, func(t *testing.T) {
+			filter, err := ParseFilter(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := filter.Matches(tt.test); got != tt.expected {
+				t.Errorf("Matches() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func writeTest(t *testing.T, root, dir, content string) {
+	t.Helper()
+	path := filepath.Join(root, dir, TestFileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	writeTest(t, root, "spring-app", `name: spring-app
+tags: [smoke]
+analysis:
+  application: /src
+  analysisMode: source-only
+  target: [quarkus]
+expect:
+  output:
+    file: expected-output.yaml
+`)
+	writeTest(t, root, "nested/daytrader", `name: daytrader
+analysis:
+  application: /src
+  analysisMode: full
+expect:
+  output:
+    result: []
+`)
+	writeTest(t, root, "legacy", `# SKIPPED: not supported
+name: legacy
+`)
+
+	tests := []struct {
+		name     string
+		filters  []string
+		expected []string
+	}{
+		{name: "all", expected: []string{"daytrader", "legacy", "spring-app"}},
+		{name: "regex", filters: []string{"name~spring.*"}, expected: []string{"spring-app"}},
+		{name: "tag", filters: []string{"tag=smoke"}, expected: []string{"spring-app"}},
+		{name: "combined", filters: []string{"tag=smoke", "target=eap"}, expected: nil},
+		{name: "substring", filters: []string{"trader"}, expected: []string{"daytrader"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := ParseFilters(tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			found, err := Discover(root, filters)
+			if err != nil {
+				t.Fatalf("Discover() unexpected error: %v", err)
+			}
+
+			var names []string
+			for _, test := range found {
+				names = append(names, test.Name)
+			}
+			slices.Sort(names)
+			if !slices.Equal(names, tt.expected) {
+				t.Errorf("Discover() = %v, want %v", names, tt.expected)
+			}
+		})
+	}
+
+	// Expected output files are not loaded during discovery
+	found, err := Discover(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range found {
+		switch test.Name {
+		case "legacy":
+			if !test.Skipped || test.Definition != nil {
+				t.Errorf("legacy should be skipped without a definition")
+			}
+		default:
+			if test.Err != nil || test.Definition == nil {
+				t.Errorf("%s should load, got error %v", test.Name, test.Err)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package discovery
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Fields that tests can be filtered on
+const (
+	// FieldName matches the test name or the name of its directory
+	FieldName = "name"
+
+	// FieldTag matches the tags of the test
+	FieldTag = "tag"
+
+	// FieldTarget matches the migration targets of the analysis
+	FieldTarget = "target"
+)
+
+// Filter operators
+const (
+	// OpContains matches values containing the filter value, used by bare filters
+	OpContains = ""
+
+	// OpEquals matches values equal to the filter value
+	OpEquals = "="
+
+	// OpRegex matches values matching the filter value as a regular expression
+	OpRegex = "~"
+)
+
+// Filter selects tests by a field of their definition
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+
+	pattern *regexp.Regexp
+}
+
+// ParseFilter parses a filter expression
+// Expressions are either "field=value", "field~regex" or a bare value matched as part of the test name
+func ParseFilter(expr string) (Filter, error) {
+	i := strings.IndexAny(expr, "=~")
+	if i < 0 {
+		return Filter{Field: FieldName, Op: OpContains, Value: expr}, nil
+	}
+
+	filter := Filter{
+		Field: strings.TrimSpace(expr[:i]),
+		Op:    expr[i : i+1],
+		Value: strings.TrimSpace(expr[i+1:]),
+	}
+
+	switch filter.Field {
+	case FieldName, FieldTag, FieldTarget:
+	default:
+		return Filter{}, fmt.Errorf("unknown filter field %q in %q (must be %s, %s or %s)", filter.Field, expr, FieldName, FieldTag, FieldTarget)
+	}
+
+	if filter.Op == OpRegex {
+		pattern, err := regexp.Compile(filter.Value)
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid filter pattern %q: %w", filter.Value, err)
+		}
+		filter.pattern = pattern
+	}
+
+	return filter, nil
+}
+
+// ParseFilters parses several filter expressions
+func ParseFilters(exprs []string) ([]Filter, error) {
+	filters := make([]Filter, 0, len(exprs))
+	for _, expr := range exprs {
+		if expr == "" {
+			continue
+		}
+		filter, err := ParseFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// Matches returns true if the test matches the filter
+// Tests without a loaded definition only match name filters on their directory name
+func (f Filter) Matches(test Test) bool {
+	var values []string
+	switch f.Field {
+	case FieldName:
+		values = append(values, test.Name)
+		if test.Definition != nil {
+			values = append(values, test.Definition.Name)
+		}
+	case FieldTag:
+		if test.Definition != nil {
+			values = test.Definition.Tags
+		}
+	case FieldTarget:
+		if test.Definition != nil {
+			values = test.Definition.Analysis.Target
+		}
+	}
+
+	return slices.ContainsFunc(values, f.matchValue)
+}
+
+// matchValue matches a single value against the filter
+func (f Filter) matchValue(value string) bool {
+	switch f.Op {
+	case OpEquals:
+		return value == f.Value
+	case OpRegex:
+		return f.pattern.MatchString(value)
+	default:
+		return strings.Contains(value, f.Value)
+	}
+}
+
+// String returns the filter expression
+func (f Filter) String() string {
+	if f.Op == OpContains {
+		return f.Value
+	}
+	return f.Field + f.Op + f.Value
+}
+
+// MatchAll returns true if the test matches every filter
+func MatchAll(filters []Filter, test Test) bool {
+	for _, filter := range filters {
+		if !filter.Matches(test) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,333 @@
+package env
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// tackleCRD is established once the operator is installed
+	tackleCRD = "crd/tackles.tackle.konveyor.io"
+	// ingressManifest installs ingress-nginx for kind
+	ingressManifest = "https://raw.githubusercontent.com/kubernetes/ingress-nginx/main/deploy/static/provider/kind/deploy.yaml"
+	// olmReleaseURL is where OLM release manifests are published
+	olmReleaseURL = "https://github.com/operator-framework/operator-lifecycle-manager/releases/download"
+
+	// defaultPollInterval is how often readiness is checked
+	defaultPollInterval = 5 * time.Second
+)
+
+// Environment installs and tears down a Konveyor instance for a test run
+type Environment struct {
+	cfg *config.EnvConfig
+
+	// pollInterval is how often readiness is checked, replaced in tests
+	pollInterval time.Duration
+	// run executes a command with optional stdin and returns its combined output, replaced in tests
+	run func(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error)
+	// get requests a URL, replaced in tests
+	get func(ctx context.Context, url string) (int, error)
+}
+
+// New creates an environment for a configuration
+func New(cfg *config.EnvConfig) *Environment {
+	return &Environment{
+		cfg:          cfg,
+		pollInterval: defaultPollInterval,
+		run:          runCommand,
+		get:          httpGet,
+	}
+}
+
+// Up creates the kind cluster if configured, installs OLM and the operator, creates the Tackle
+// resource and waits until the Hub is ready
+func (e *Environment) Up(ctx context.Context) error {
+	log := util.GetLogger()
+
+	if e.cfg.Kind != nil {
+		if err := e.createCluster(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := e.installOLM(ctx); err != nil {
+		return err
+	}
+
+	log.Info("Installing Konveyor operator", "version", e.cfg.Operator.Version, "manifest", e.cfg.Operator.GetManifestURL())
+	if _, err := e.kubectl(ctx, nil, "apply", "-f", e.cfg.Operator.GetManifestURL()); err != nil {
+		return fmt.Errorf("failed to install operator: %w", err)
+	}
+
+	timeout := e.cfg.GetReadyTimeout()
+	namespace := e.cfg.Operator.GetNamespace()
+	if err := e.waitFor(ctx, "Tackle CRD", timeout, func() error {
+		_, err := e.kubectl(ctx, nil, "wait", "--for=condition=established", "--timeout=5s", tackleCRD)
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := e.waitFor(ctx, "operator", timeout, func() error {
+		return e.podsReady(ctx, namespace, "name=tackle-operator")
+	}); err != nil {
+		return err
+	}
+
+	cr, err := e.tackleResource()
+	if err != nil {
+		return err
+	}
+	log.Info("Creating Tackle resource", "namespace", namespace)
+	if _, err := e.kubectl(ctx, cr, "apply", "-f", "-"); err != nil {
+		return fmt.Errorf("failed to create Tackle resource: %w", err)
+	}
+
+	if err := e.waitFor(ctx, "Hub", timeout, func() error {
+		return e.podsReady(ctx, namespace, "app.kubernetes.io/name=tackle-hub")
+	}); err != nil {
+		return err
+	}
+	if e.cfg.HubURL != "" {
+		if err := e.waitFor(ctx, "Hub API", timeout, func() error {
+			status, err := e.get(ctx, e.cfg.HubURL)
+			if err != nil {
+				return err
+			}
+			if status >= 500 {
+				return fmt.Errorf("%s returned %d", e.cfg.HubURL, status)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Konveyor is ready", "version", e.cfg.Operator.Version, "namespace", namespace)
+	return nil
+}
+
+// Down deletes the kind cluster, or the Tackle resource and Konveyor namespace on an existing cluster
+func (e *Environment) Down(ctx context.Context) error {
+	log := util.GetLogger()
+
+	if e.cfg.Kind != nil {
+		name := e.cfg.Kind.GetName()
+		log.Info("Deleting kind cluster", "name", name)
+		if _, err := e.run(ctx, nil, "kind", e.kindArgs("delete", "cluster", "--name", name)...); err != nil {
+			return fmt.Errorf("failed to delete kind cluster %s: %w", name, err)
+		}
+		return nil
+	}
+
+	namespace := e.cfg.Operator.GetNamespace()
+	log.Info("Uninstalling Konveyor", "namespace", namespace)
+	// The operator removes the Hub's resources once the Tackle resource is deleted
+	if _, err := e.kubectl(ctx, nil, "delete", "tackle", "--all", "-n", namespace, "--ignore-not-found", "--wait"); err != nil {
+		return fmt.Errorf("failed to delete Tackle resource: %w", err)
+	}
+	if _, err := e.kubectl(ctx, nil, "delete", "namespace", namespace, "--ignore-not-found", "--wait"); err != nil {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace, err)
+	}
+	return nil
+}
+
+// createCluster creates the kind cluster unless it already exists
+func (e *Environment) createCluster(ctx context.Context) error {
+	log := util.GetLogger()
+	name := e.cfg.Kind.GetName()
+
+	out, err := e.run(ctx, nil, "kind", "get", "clusters")
+	if err != nil {
+		return fmt.Errorf("failed to list kind clusters: %w", err)
+	}
+	for _, existing := range strings.Fields(string(out)) {
+		if existing == name {
+			log.Info("Using existing kind cluster", "name", name)
+			return nil
+		}
+	}
+
+	args := []string{"create", "cluster", "--name", name, "--config", "-", "--wait", "5m"}
+	if e.cfg.Kind.NodeImage != "" {
+		args = append(args, "--image", e.cfg.Kind.NodeImage)
+	}
+	log.Info("Creating kind cluster", "name", name)
+	if _, err := e.run(ctx, kindConfig(e.cfg.Kind.Ingress), "kind", e.kindArgs(args...)...); err != nil {
+		return fmt.Errorf("failed to create kind cluster %s: %w", name, err)
+	}
+
+	if !e.cfg.Kind.Ingress {
+		return nil
+	}
+	log.Info("Installing ingress-nginx")
+	if _, err := e.kubectl(ctx, nil, "apply", "-f", ingressManifest); err != nil {
+		return fmt.Errorf("failed to install ingress-nginx: %w", err)
+	}
+	return e.waitFor(ctx, "ingress controller", e.cfg.GetReadyTimeout(), func() error {
+		return e.podsReady(ctx, "ingress-nginx", "app.kubernetes.io/component=controller")
+	})
+}
+
+// installOLM installs the Operator Lifecycle Manager unless the cluster already has it
+func (e *Environment) installOLM(ctx context.Context) error {
+	log := util.GetLogger()
+
+	if _, err := e.kubectl(ctx, nil, "get", "crd", "subscriptions.operators.coreos.com"); err == nil {
+		log.V(1).Info("OLM is already installed")
+		return nil
+	}
+
+	version := e.cfg.Operator.GetOLMVersion()
+	log.Info("Installing OLM", "version", version)
+	base := fmt.Sprintf("%s/%s", olmReleaseURL, version)
+	// CRDs are applied server side since they exceed the client side annotation limit
+	if _, err := e.kubectl(ctx, nil, "apply", "--server-side", "-f", base+"/crds.yaml"); err != nil {
+		return fmt.Errorf("failed to install OLM CRDs: %w", err)
+	}
+	if _, err := e.kubectl(ctx, nil, "wait", "--for=condition=established", "--timeout=60s", "-f", base+"/crds.yaml"); err != nil {
+		return fmt.Errorf("failed to wait for OLM CRDs: %w", err)
+	}
+	if _, err := e.kubectl(ctx, nil, "apply", "--server-side", "-f", base+"/olm.yaml"); err != nil {
+		return fmt.Errorf("failed to install OLM: %w", err)
+	}
+
+	timeout := e.cfg.GetReadyTimeout()
+	for _, selector := range []string{"app=olm-operator", "app=catalog-operator"} {
+		if err := e.waitFor(ctx, selector, timeout, func() error {
+			return e.podsReady(ctx, "olm", selector)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tackleResource returns the Tackle custom resource manifest
+func (e *Environment) tackleResource() ([]byte, error) {
+	cr := map[string]any{
+		"apiVersion": "tackle.konveyor.io/v1alpha1",
+		"kind":       "Tackle",
+		"metadata": map[string]any{
+			"name":      "tackle",
+			"namespace": e.cfg.Operator.GetNamespace(),
+		},
+		"spec": e.cfg.GetTackleSpec(),
+	}
+	data, err := yaml.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Tackle resource: %w", err)
+	}
+	return data, nil
+}
+
+// podsReady fails unless pods matching selector exist and are ready
+func (e *Environment) podsReady(ctx context.Context, namespace, selector string) error {
+	// kubectl wait fails right away when no pods exist yet, polling covers pods created later
+	_, err := e.kubectl(ctx, nil, "wait", "--for=condition=ready", "pod", "-l", selector, "-n", namespace, "--timeout=5s")
+	return err
+}
+
+// waitFor polls check until it succeeds or timeout passes
+func (e *Environment) waitFor(ctx context.Context, what string, timeout time.Duration, check func() error) error {
+	log := util.GetLogger()
+	log.Info("Waiting for readiness", "component", what, "timeout", timeout)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s: %w", what, err)
+		}
+		log.V(1).Info("Not ready yet", "component", what, "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for %s: %w", what, ctx.Err())
+		case <-time.After(e.pollInterval):
+		}
+	}
+}
+
+// kubectl runs kubectl against the configured cluster
+func (e *Environment) kubectl(ctx context.Context, stdin []byte, args ...string) ([]byte, error) {
+	if e.cfg.Kubeconfig != "" {
+		args = append([]string{"--kubeconfig", e.cfg.Kubeconfig}, args...)
+	}
+	if e.cfg.Kind != nil {
+		args = append([]string{"--context", "kind-" + e.cfg.Kind.GetName()}, args...)
+	}
+	return e.run(ctx, stdin, "kubectl", args...)
+}
+
+// kindArgs adds the configured kubeconfig to kind arguments
+func (e *Environment) kindArgs(args ...string) []string {
+	if e.cfg.Kubeconfig != "" {
+		args = append(args, "--kubeconfig", e.cfg.Kubeconfig)
+	}
+	return args
+}
+
+// kindConfig returns the kind cluster configuration, mapping ingress to host ports 8080 and 8443
+func kindConfig(ingress bool) []byte {
+	var b strings.Builder
+	b.WriteString("kind: Cluster\napiVersion: kind.x-k8s.io/v1alpha4\nnodes:\n- role: control-plane\n")
+	if ingress {
+		b.WriteString(`  kubeadmConfigPatches:
+  - |
+    kind: InitConfiguration
+    nodeRegistration:
+      kubeletExtraArgs:
+        node-labels: "ingress-ready=true"
+  extraPortMappings:
+  - containerPort: 80
+    hostPort: 8080
+    protocol: TCP
+  - containerPort: 443
+    hostPort: 8443
+    protocol: TCP
+`)
+	}
+	return []byte(b.String())
+}
+
+// runCommand executes a command, returning its output and failing with it on a non-zero exit
+func runCommand(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	util.GetLogger().V(1).Info("Executing command", "binary", name, "args", args)
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return out, fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// httpGet requests url and returns the response status
+func httpGet(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,177 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// fakeCommands records commands and fails those matching a prefix until they were tried often enough
+type fakeCommands struct {
+	commands []string
+	stdin    map[string]string
+	// failures maps command prefixes to the number of times they fail
+	failures map[string]int
+	// outputs maps command prefixes to their output
+	outputs map[string]string
+}
+
+func (f *fakeCommands) run(ctx context.Context, stdin []byte, name string, args ...string) ([]byte, error) {
+	command := name + " " + strings.Join(args, " ")
+	f.commands = append(f.commands, command)
+	if stdin != nil {
+		f.stdin[command] = string(stdin)
+	}
+	for prefix, n := range f.failures {
+		if strings.Contains(command, prefix) && n > 0 {
+			f.failures[prefix] = n - 1
+			return nil, errors.New("not ready")
+		}
+	}
+	for prefix, out := range f.outputs {
+		if strings.Contains(command, prefix) {
+			return []byte(out), nil
+		}
+	}
+	return nil, nil
+}
+
+// ran returns the index of the first command containing s, -1 if none
+func (f *fakeCommands) ran(s string) int {
+	for i, command := range f.commands {
+		if strings.Contains(command, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+func newTestEnvironment(cfg *config.EnvConfig, fake *fakeCommands) *Environment {
+	e := New(cfg)
+	e.pollInterval = time.Millisecond
+	e.run = fake.run
+	e.get = func(ctx context.Context, url string) (int, error) { return 200, nil }
+	return e
+}
+
+func TestEnvironment_UpKind(t *testing.T) {
+	fake := &fakeCommands{
+		stdin:    map[string]string{},
+		failures: map[string]int{"get crd subscriptions": 1, "name=tackle-operator": 2},
+		outputs:  map[string]string{"kind get clusters": "other\n"},
+	}
+	cfg := &config.EnvConfig{
+		Kind:     &config.KindConfig{Name: "nightly", NodeImage: "kindest/node:v1.30.0", Ingress: true},
+		Operator: config.OperatorConfig{Version: "v0.7.0"},
+		Tackle:   map[string]any{"cache_data_volume_size": "10Gi"},
+		HubURL:   "http://localhost:8080/hub",
+	}
+
+	if err := newTestEnvironment(cfg, fake).Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	order := []string{
+		"kind create cluster --name nightly --config - --wait 5m --image kindest/node:v1.30.0",
+		"kubectl --context kind-nightly apply -f " + ingressManifest,
+		"apply --server-side -f " + olmReleaseURL + "/v0.38.0/crds.yaml",
+		"apply -f https://raw.githubusercontent.com/konveyor/tackle2-operator/v0.7.0/tackle-k8s.yaml",
+		"name=tackle-operator",
+		"apply -f -",
+		"app.kubernetes.io/name=tackle-hub",
+	}
+	last := -1
+	for _, want := range order {
+		i := fake.ran(want)
+		if i <= last {
+			t.Fatalf("command %q not run after the previous step, commands:\n%s", want, strings.Join(fake.commands, "\n"))
+		}
+		last = i
+	}
+
+	if !strings.Contains(fake.stdin[fake.commands[fake.ran("kind create")]], "hostPort: 8080") {
+		t.Error("kind config should map ingress ports")
+	}
+	cr := fake.stdin[fake.commands[fake.ran("apply -f -")]]
+	for _, want := range []string{"kind: Tackle", "namespace: konveyor-tackle", `feature_auth_required: "false"`, "cache_data_volume_size: 10Gi"} {
+		if !strings.Contains(cr, want) {
+			t.Errorf("Tackle resource missing %q:\n%s", want, cr)
+		}
+	}
+}
+
+func TestEnvironment_UpExistingCluster(t *testing.T) {
+	fake := &fakeCommands{stdin: map[string]string{}, failures: map[string]int{}, outputs: map[string]string{"kind get clusters": "koncur-test\n"}}
+	cfg := &config.EnvConfig{
+		Kind:       &config.KindConfig{},
+		Kubeconfig: "/tmp/kubeconfig",
+		Operator:   config.OperatorConfig{Version: "main", Namespace: "konveyor", ManifestURL: "https://example.com/operator.yaml"},
+	}
+
+	if err := newTestEnvironment(cfg, fake).Up(context.Background()); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if fake.ran("kind create") != -1 {
+		t.Error("existing cluster should be reused")
+	}
+	if fake.ran("crds.yaml") != -1 {
+		t.Error("OLM should not be reinstalled")
+	}
+	if fake.ran("kubectl --context kind-koncur-test --kubeconfig /tmp/kubeconfig apply -f https://example.com/operator.yaml") == -1 {
+		t.Errorf("operator manifest override not applied:\n%s", strings.Join(fake.commands, "\n"))
+	}
+	if fake.ran("-n konveyor ") == -1 {
+		t.Error("configured namespace not used")
+	}
+}
+
+func TestEnvironment_UpTimeout(t *testing.T) {
+	fake := &fakeCommands{stdin: map[string]string{}, failures: map[string]int{"tackle-hub": 1 << 30}, outputs: map[string]string{}}
+	cfg := &config.EnvConfig{
+		Operator:     config.OperatorConfig{Version: "main"},
+		ReadyTimeout: &config.Duration{Duration: 20 * time.Millisecond},
+	}
+
+	err := newTestEnvironment(cfg, fake).Up(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for Hub") {
+		t.Errorf("Up() error = %v, want Hub timeout", err)
+	}
+}
+
+func TestEnvironment_Down(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.EnvConfig
+		want []string
+	}{
+		{
+			name: "kind",
+			cfg:  &config.EnvConfig{Kind: &config.KindConfig{Name: "nightly"}, Kubeconfig: "/tmp/kubeconfig"},
+			want: []string{"kind delete cluster --name nightly --kubeconfig /tmp/kubeconfig"},
+		},
+		{
+			name: "existing cluster",
+			cfg:  &config.EnvConfig{},
+			want: []string{
+				"kubectl delete tackle --all -n konveyor-tackle --ignore-not-found --wait",
+				"kubectl delete namespace konveyor-tackle --ignore-not-found --wait",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCommands{stdin: map[string]string{}, failures: map[string]int{}, outputs: map[string]string{}}
+			if err := newTestEnvironment(tt.cfg, fake).Down(context.Background()); err != nil {
+				t.Fatalf("Down() error = %v", err)
+			}
+			if strings.Join(fake.commands, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("commands = %v, want %v", fake.commands, tt.want)
+			}
+		})
+	}
+}
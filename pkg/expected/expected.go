@@ -0,0 +1,121 @@
+package expected
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/output"
+	"gopkg.in/yaml.v3"
+)
+
+// Schema versions of expected output files
+const (
+	// VersionLegacy is a plain list of rulesets, written before expected outputs were versioned. Findings
+	// without effort may be listed as violations, as older analyzers reported them
+	VersionLegacy = 1
+	// VersionSplitInsights is a document with the version and the rulesets, findings without effort are
+	// insights
+	VersionSplitInsights = 2
+	// CurrentVersion is the version koncur writes and validates against
+	CurrentVersion = VersionSplitInsights
+)
+
+// Document is an expected output file of the current schema
+type Document struct {
+	Version  int                `json:"version" yaml:"version"`
+	RuleSets []konveyor.RuleSet `json:"rulesets" yaml:"rulesets"`
+}
+
+// migrations upgrade rulesets from a version to the next one, the first upgrades VersionLegacy
+var migrations = []func([]konveyor.RuleSet) []konveyor.RuleSet{
+	splitInsights,
+}
+
+// Version returns the schema version of an expected output. Files without a version are legacy lists of
+// rulesets, including analyzer and Tackle Hub outputs
+func Version(data []byte) (int, error) {
+	var header struct {
+		Version *int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &header); err != nil || header.Version == nil {
+		return VersionLegacy, nil
+	}
+	if *header.Version < VersionLegacy || *header.Version > CurrentVersion {
+		return 0, fmt.Errorf("unknown expected output version %d, this koncur supports versions up to %d", *header.Version, CurrentVersion)
+	}
+	return *header.Version, nil
+}
+
+// Parse reads an expected output of any version and upgrades its rulesets to the current version. It
+// returns the version of the file
+func Parse(data []byte) ([]konveyor.RuleSet, int, error) {
+	rulesets, version, err := Decode(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return Migrate(rulesets, version), version, nil
+}
+
+// Decode reads the rulesets of an expected output of any version as they are in the file, without
+// upgrading them. It returns the version of the file
+func Decode(data []byte) ([]konveyor.RuleSet, int, error) {
+	version, err := Version(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version == VersionLegacy {
+		rulesets, err := output.Parse(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		return rulesets, version, nil
+	}
+	var doc Document
+	if output.DetectFormat(data) == output.FormatJSON {
+		err = json.Unmarshal(data, &doc)
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse expected output version %d: %w", version, err)
+	}
+	return doc.RuleSets, version, nil
+}
+
+// Migrate upgrades rulesets from a version to the current one
+func Migrate(rulesets []konveyor.RuleSet, from int) []konveyor.RuleSet {
+	for _, migrate := range migrations[from-VersionLegacy:] {
+		rulesets = migrate(rulesets)
+	}
+	return rulesets
+}
+
+// splitInsights moves the violations without effort of legacy expected outputs to the insights, where
+// current analyzers report them
+func splitInsights(rulesets []konveyor.RuleSet) []konveyor.RuleSet {
+	migrated := slices.Clone(rulesets)
+	for i, rs := range migrated {
+		if len(rs.Violations) == 0 {
+			continue
+		}
+		violations := map[string]konveyor.Violation{}
+		insights := maps.Clone(rs.Insights)
+		for id, v := range rs.Violations {
+			if v.Effort != nil && *v.Effort > 0 {
+				violations[id] = v
+				continue
+			}
+			if insights == nil {
+				insights = map[string]konveyor.Violation{}
+			}
+			insights[id] = v
+		}
+		migrated[i].Violations, migrated[i].Insights = violations, insights
+		if len(violations) == 0 {
+			migrated[i].Violations = nil
+		}
+	}
+	return migrated
+}
@@ -0,0 +1,113 @@
+package expected
+
+import (
+	"strings"
+	"testing"
+)
+
+const legacyYAML = `- name: quarkus
+  violations:
+    quarkus-00001:
+      description: Use Quarkus
+      category: mandatory
+      effort: 3
+      incidents: []
+    quarkus-00002:
+      description: Java version
+      category: potential
+      incidents: []
+  insights:
+    info-00001:
+      description: Informational
+      incidents: []
+`
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{name: "list of rulesets", data: legacyYAML, want: VersionLegacy},
+		{name: "json list of rulesets", data: `[{"name": "quarkus"}]`, want: VersionLegacy},
+		{name: "empty file", data: "", want: VersionLegacy},
+		{name: "current version", data: "version: 2\nrulesets: []\n", want: CurrentVersion},
+		{name: "future version", data: "version: 3\nrulesets: []\n", wantErr: true},
+		{name: "invalid version", data: "version: 0\nrulesets: []\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Version([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Version() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Version() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_MigratesLegacy(t *testing.T) {
+	rulesets, version, err := Parse([]byte(legacyYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if version != VersionLegacy {
+		t.Errorf("version = %d, want %d", version, VersionLegacy)
+	}
+	if len(rulesets) != 1 {
+		t.Fatalf("Parse() = %d rulesets, want 1", len(rulesets))
+	}
+	rs := rulesets[0]
+	if _, ok := rs.Violations["quarkus-00001"]; !ok || len(rs.Violations) != 1 {
+		t.Errorf("Violations = %v, want only quarkus-00001", rs.Violations)
+	}
+	for _, id := range []string{"quarkus-00002", "info-00001"} {
+		if _, ok := rs.Insights[id]; !ok {
+			t.Errorf("Insights = %v, want %s", rs.Insights, id)
+		}
+	}
+
+	// The raw rulesets keep the violation without effort
+	raw, _, err := Decode([]byte(legacyYAML))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if _, ok := raw[0].Violations["quarkus-00002"]; !ok {
+		t.Errorf("Decode() migrated the rulesets: %v", raw[0].Violations)
+	}
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	rulesets, _, err := Parse([]byte(legacyYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	for _, asJSON := range []bool{false, true} {
+		data, err := Marshal(rulesets, asJSON)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if asJSON && !strings.HasPrefix(string(data), "{\n  \"version\": 2,") {
+			t.Errorf("Marshal() JSON =\n%s", data)
+		}
+		if !asJSON && !strings.HasPrefix(string(data), "version: 2\nrulesets:\n") {
+			t.Errorf("Marshal() YAML =\n%s", data)
+		}
+
+		parsed, version, err := Parse(data)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if version != CurrentVersion {
+			t.Errorf("version = %d, want %d", version, CurrentVersion)
+		}
+		if len(parsed) != 1 || len(parsed[0].Violations) != 1 || len(parsed[0].Insights) != 2 {
+			t.Errorf("Parse() = %+v, want the migrated rulesets", parsed)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package expected
+
+import (
+	"encoding/json"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/output"
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+func init() {
+	// Long strings aren't wrapped, a changed message or code snip changes its own lines of the file only
+	yaml2.FutureLineWrap()
+}
+
+// Marshal renders rulesets as an expected output of the current version, as YAML or JSON. Rulesets are
+// written normalized, so recording the same findings again gives the same file
+func Marshal(rulesets []konveyor.RuleSet, asJSON bool) ([]byte, error) {
+	return encode(Document{Version: CurrentVersion, RuleSets: Normalize(rulesets)}, asJSON)
+}
+
+// Format rewrites an expected output in the layout Marshal writes, keeping its version. It changes the
+// layout only, the rulesets compare the same before and after
+func Format(data []byte, asJSON bool) ([]byte, error) {
+	rulesets, version, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if version == VersionLegacy {
+		return encode(Normalize(rulesets), asJSON)
+	}
+	return encode(Document{Version: version, RuleSets: Normalize(rulesets)}, asJSON)
+}
+
+// Normalize returns rulesets the way expected outputs keep them: in canonical order, without the
+// trailing whitespace of code snip lines, so code snips are written as block scalars
+func Normalize(rulesets []konveyor.RuleSet) []konveyor.RuleSet {
+	normalized := output.Canonicalize(rulesets)
+	for _, rs := range normalized {
+		trimCodeSnips(rs.Violations)
+		trimCodeSnips(rs.Insights)
+	}
+	return normalized
+}
+
+// trimCodeSnips trims the code snips of the incidents in place, canonical rulesets have their own
+// incidents
+func trimCodeSnips(violations map[string]konveyor.Violation) {
+	for _, v := range violations {
+		for i := range v.Incidents {
+			v.Incidents[i].CodeSnip = output.TrimCodeSnip(v.Incidents[i].CodeSnip)
+		}
+	}
+}
+
+// EncodeYAML renders values of konveyor types as expected outputs are written: fields in the order of
+// their types, map keys sorted, lists at the indentation of their key, long strings on one line and
+// multi-line strings as literal block scalars
+func EncodeYAML(v any) ([]byte, error) {
+	// konveyor types were designed for yaml.v2, v3 recurses infinitely into their MarshalYAML
+	return yaml2.Marshal(v)
+}
+
+func encode(v any, asJSON bool) ([]byte, error) {
+	if !asJSON {
+		return EncodeYAML(v)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
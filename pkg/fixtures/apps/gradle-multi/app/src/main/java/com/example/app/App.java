@@ -0,0 +1,13 @@
+package com.example.app;
+
+import com.example.lib.Store;
+
+/**
+ * Stores a greeting with the library subproject.
+ */
+public class App {
+
+    public static void main(String[] args) throws Exception {
+        new Store("/tmp/gradle-multi.txt").save("Hello from gradle-multi");
+    }
+}
@@ -0,0 +1,22 @@
+package com.example.lib;
+
+import java.io.FileWriter;
+import java.io.IOException;
+
+/**
+ * Writes to local storage, so cloud-readiness rules report an incident in the lib subproject.
+ */
+public class Store {
+
+    private final String path;
+
+    public Store(String path) {
+        this.path = path;
+    }
+
+    public void save(String content) throws IOException {
+        try (FileWriter writer = new FileWriter(path)) {
+            writer.write(content);
+        }
+    }
+}
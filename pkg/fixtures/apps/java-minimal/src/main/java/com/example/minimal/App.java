@@ -0,0 +1,16 @@
+package com.example.minimal;
+
+import java.io.FileWriter;
+import java.io.IOException;
+
+/**
+ * Writes to local storage, so cloud-readiness rules report an incident.
+ */
+public class App {
+
+    public static void main(String[] args) throws IOException {
+        try (FileWriter writer = new FileWriter("/tmp/minimal.txt")) {
+            writer.write("Hello from java-minimal");
+        }
+    }
+}
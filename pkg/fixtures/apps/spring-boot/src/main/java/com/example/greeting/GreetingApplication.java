@@ -0,0 +1,12 @@
+package com.example.greeting;
+
+import org.springframework.boot.SpringApplication;
+import org.springframework.boot.autoconfigure.SpringBootApplication;
+
+@SpringBootApplication
+public class GreetingApplication {
+
+    public static void main(String[] args) {
+        SpringApplication.run(GreetingApplication.class, args);
+    }
+}
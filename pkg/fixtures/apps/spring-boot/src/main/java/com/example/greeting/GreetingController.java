@@ -0,0 +1,19 @@
+package com.example.greeting;
+
+import javax.servlet.http.HttpServletRequest;
+
+import org.springframework.web.bind.annotation.GetMapping;
+import org.springframework.web.bind.annotation.RequestParam;
+import org.springframework.web.bind.annotation.RestController;
+
+/**
+ * Uses javax.servlet, so Jakarta EE and Spring Boot 3 migration rules report incidents.
+ */
+@RestController
+public class GreetingController {
+
+    @GetMapping("/greeting")
+    public String greeting(@RequestParam(defaultValue = "World") String name, HttpServletRequest request) {
+        return String.format("Hello, %s from %s", name, request.getRemoteAddr());
+    }
+}
@@ -0,0 +1,108 @@
+// Package fixtures embeds small applications tests can analyze without network access, instead of
+// cloning applications such as tackle-testapp from GitHub
+package fixtures
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Fixtures
+const (
+	// JavaMinimal is a Maven project without dependencies
+	JavaMinimal = "java-minimal"
+	// SpringBoot is a Spring Boot 2 web application
+	SpringBoot = "spring-boot"
+	// GradleMulti is a Gradle multi-project build with app and lib subprojects
+	GradleMulti = "gradle-multi"
+	// LegacyJar is a jar holding a compiled EJB-era class, analyzed as a binary
+	LegacyJar = "legacy.jar"
+)
+
+//go:embed apps
+var apps embed.FS
+
+// binaries are the fixtures built when they are materialized
+var binaries = map[string]func(path string) error{
+	LegacyJar: writeLegacyJar,
+}
+
+// Names returns the names of all fixtures
+func Names() []string {
+	names := make([]string, 0, len(binaries))
+	entries, _ := fs.ReadDir(apps, "apps")
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	for name := range binaries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Exists reports whether a fixture is known
+func Exists(name string) bool {
+	return slices.Contains(Names(), name)
+}
+
+// IsBinary reports whether a fixture is a binary artifact rather than source code
+func IsBinary(name string) bool {
+	_, ok := binaries[name]
+	return ok
+}
+
+// Materialize writes a fixture into dir/fixtures and returns the absolute path of its source directory
+// or binary. An earlier copy is replaced, so tests always analyze the embedded fixture
+func Materialize(name, dir string) (string, error) {
+	if !Exists(name) {
+		return "", fmt.Errorf("unknown fixture %q, available fixtures: %s", name, strings.Join(Names(), ", "))
+	}
+
+	target, err := filepath.Abs(filepath.Join(dir, "fixtures", name))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return "", fmt.Errorf("failed to remove fixture %s: %w", target, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+
+	if write, ok := binaries[name]; ok {
+		if err := write(target); err != nil {
+			return "", fmt.Errorf("failed to build fixture %s: %w", name, err)
+		}
+		return target, nil
+	}
+	if err := copyFixture(path.Join("apps", name), target); err != nil {
+		return "", fmt.Errorf("failed to write fixture %s: %w", name, err)
+	}
+	return target, nil
+}
+
+// copyFixture copies an embedded directory to target
+func copyFixture(root, target string) error {
+	return fs.WalkDir(apps, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := strings.TrimPrefix(strings.TrimPrefix(name, root), "/")
+		dest := filepath.Join(target, filepath.FromSlash(rel))
+		if entry.IsDir() {
+			return os.MkdirAll(dest, 0755)
+		}
+		data, err := apps.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(dest, data, 0644)
+	})
+}
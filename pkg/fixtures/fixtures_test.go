@@ -0,0 +1,84 @@
+package fixtures
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	for _, name := range []string{JavaMinimal, SpringBoot, GradleMulti, LegacyJar} {
+		if !Exists(name) {
+			t.Errorf("fixture %s is missing from %v", name, Names())
+		}
+	}
+	if Exists("tackle-testapp") {
+		t.Error("Exists() of an unknown fixture should be false")
+	}
+}
+
+func TestMaterialize_Source(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Materialize(SpringBoot, dir)
+	if err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+	if path != filepath.Join(dir, "fixtures", SpringBoot) {
+		t.Errorf("Materialize() = %s", path)
+	}
+	for _, file := range []string{"pom.xml", "src/main/java/com/example/greeting/GreetingController.java", "src/main/resources/application.properties"} {
+		if _, err := os.Stat(filepath.Join(path, file)); err != nil {
+			t.Errorf("fixture file %s: %v", file, err)
+		}
+	}
+
+	// Materializing again replaces changes of a previous test
+	stale := filepath.Join(path, "stale.txt")
+	if err := os.WriteFile(stale, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Materialize(SpringBoot, dir); err != nil {
+		t.Fatalf("Materialize() again error = %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("Materialize() should replace an earlier copy")
+	}
+
+	if _, err := Materialize("unknown", dir); err == nil {
+		t.Error("Materialize() of an unknown fixture should fail")
+	}
+}
+
+func TestMaterialize_LegacyJar(t *testing.T) {
+	path, err := Materialize(LegacyJar, t.TempDir())
+	if err != nil {
+		t.Fatalf("Materialize() error = %v", err)
+	}
+	if !IsBinary(LegacyJar) || IsBinary(JavaMinimal) {
+		t.Error("only the legacy jar should be a binary fixture")
+	}
+
+	jar, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("legacy jar isn't a zip: %v", err)
+	}
+	defer jar.Close()
+	var class []byte
+	for _, file := range jar.File {
+		if file.Name == legacyClass+".class" {
+			r, err := file.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(r)
+			r.Close()
+			class = buf.Bytes()
+		}
+	}
+	if !bytes.HasPrefix(class, []byte{0xCA, 0xFE, 0xBA, 0xBE}) || !bytes.Contains(class, []byte(legacyDescriptor)) {
+		t.Errorf("legacy jar has no class referencing %s", legacyDescriptor)
+	}
+}
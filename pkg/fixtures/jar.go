@@ -0,0 +1,87 @@
+package fixtures
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"os"
+)
+
+// legacyClass is the class of the legacy jar, with a javax.ejb field so migration rules match its
+// decompiled source
+const (
+	legacyClass      = "com/example/legacy/LegacyBean"
+	legacyField      = "context"
+	legacyDescriptor = "Ljavax/ejb/SessionContext;"
+)
+
+// writeLegacyJar writes a jar holding the legacy class and a manifest
+func writeLegacyJar(path string) error {
+	var buf bytes.Buffer
+	jar := zip.NewWriter(&buf)
+	entries := []struct {
+		name string
+		data []byte
+	}{
+		{name: "META-INF/MANIFEST.MF", data: []byte("Manifest-Version: 1.0\r\nCreated-By: koncur\r\n\r\n")},
+		{name: legacyClass + ".class", data: legacyClassFile()},
+	}
+	for _, entry := range entries {
+		w, err := jar.Create(entry.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			return err
+		}
+	}
+	if err := jar.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// legacyClassFile returns the class file of a public class with a single private field, compiled
+// for Java 8. Building it avoids a JDK and a checked-in binary
+func legacyClassFile() []byte {
+	var buf bytes.Buffer
+	u16 := func(v uint16) { binary.Write(&buf, binary.BigEndian, v) }
+	utf8 := func(s string) {
+		buf.WriteByte(1) // CONSTANT_Utf8
+		u16(uint16(len(s)))
+		buf.WriteString(s)
+	}
+	class := func(nameIndex uint16) {
+		buf.WriteByte(7) // CONSTANT_Class
+		u16(nameIndex)
+	}
+
+	buf.Write([]byte{0xCA, 0xFE, 0xBA, 0xBE})
+	u16(0)  // minor version
+	u16(52) // major version, Java 8
+
+	// Constant pool, indexes start at 1
+	u16(7)
+	utf8(legacyClass)        // #1
+	class(1)                 // #2
+	utf8("java/lang/Object") // #3
+	class(3)                 // #4
+	utf8(legacyField)        // #5
+	utf8(legacyDescriptor)   // #6
+
+	u16(0x0021) // ACC_PUBLIC | ACC_SUPER
+	u16(2)      // this class
+	u16(4)      // super class
+	u16(0)      // interfaces
+
+	// Fields
+	u16(1)
+	u16(0x0002) // ACC_PRIVATE
+	u16(5)      // name
+	u16(6)      // descriptor
+	u16(0)      // attributes
+
+	u16(0) // methods
+	u16(0) // attributes
+	return buf.Bytes()
+}
@@ -0,0 +1,140 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub REST API, GitHub Enterprise servers use https://<host>/api/v3
+const DefaultAPIURL = "https://api.github.com"
+
+// Client calls the GitHub REST API for a single repository
+type Client struct {
+	apiURL string
+	token  string
+	owner  string
+	repo   string
+	client *http.Client
+}
+
+// NewClient creates a client for repository, given as owner/name
+func NewClient(apiURL, token, repository string) (*Client, error) {
+	if token == "" {
+		return nil, fmt.Errorf("a GitHub token is required, set GITHUB_TOKEN")
+	}
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("invalid GitHub repository %q, expected owner/name", repository)
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return &Client{
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+		token:  token,
+		owner:  owner,
+		repo:   repo,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Status is a commit status
+type Status struct {
+	State       string `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+// CheckRun is a check run with its output
+type CheckRun struct {
+	Name        string          `json:"name,omitempty"`
+	HeadSHA     string          `json:"head_sha,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Conclusion  string          `json:"conclusion,omitempty"`
+	DetailsURL  string          `json:"details_url,omitempty"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	Output      *CheckRunOutput `json:"output,omitempty"`
+}
+
+// CheckRunOutput is the summary and annotations of a check run
+type CheckRunOutput struct {
+	Title       string       `json:"title"`
+	Summary     string       `json:"summary"`
+	Text        string       `json:"text,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// Annotation points a check run message at a line of a file
+type Annotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
+
+// CreateStatus sets a commit status on sha
+func (c *Client) CreateStatus(ctx context.Context, sha string, status Status) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/statuses/%s", c.owner, c.repo, sha), status, nil)
+}
+
+// CreateCheckRun creates a check run and returns its ID
+func (c *Client) CreateCheckRun(ctx context.Context, checkRun CheckRun) (int64, error) {
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/check-runs", c.owner, c.repo), checkRun, &created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// UpdateCheckRun updates a check run, annotations are added to the existing ones
+func (c *Client) UpdateCheckRun(ctx context.Context, id int64, checkRun CheckRun) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/%s/check-runs/%d", c.owner, c.repo, id), checkRun, nil)
+}
+
+// do sends a JSON request to the API and decodes the response into result
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s failed: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/notify"
+	"github.com/konveyor/test-harness/pkg/validator"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ModeCheck reports a check run with annotations
+	ModeCheck = "check"
+	// ModeStatus reports a commit status
+	ModeStatus = "status"
+
+	// maxAnnotations is the number of annotations GitHub accepts per request
+	maxAnnotations = 50
+	// maxSnippet is the length expected and actual snippets are truncated to
+	maxSnippet = 2000
+	// maxDescription is the length GitHub accepts for status descriptions
+	maxDescription = 140
+)
+
+// Reporter reports run results to a commit
+type Reporter struct {
+	Client *Client
+	// SHA of the commit being reported on
+	SHA string
+	// Name of the check run or status context
+	Name string
+	// DetailsURL links to the run's report
+	DetailsURL string
+	// BaseDir makes test file paths relative for annotations, usually the repository root
+	BaseDir string
+}
+
+// Report posts the run as a check run or a commit status depending on mode
+// Failures map test names to their validation errors
+func (r *Reporter) Report(ctx context.Context, mode string, run *history.Run, failures map[string][]validator.ValidationError) error {
+	summary := notify.SummaryFromRun(run, r.DetailsURL)
+
+	switch mode {
+	case ModeStatus:
+		state := "success"
+		if summary.Failures() > 0 {
+			state = "failure"
+		}
+		return r.Client.CreateStatus(ctx, r.SHA, Status{
+			State:       state,
+			TargetURL:   r.DetailsURL,
+			Description: truncate(fmt.Sprintf("%d passed, %d failed, %d errored, %d skipped", summary.Passed, summary.Failed, summary.Errored, summary.Skipped), maxDescription),
+			Context:     r.Name,
+		})
+	case ModeCheck:
+		return r.reportCheckRun(ctx, summary, r.Annotations(run, failures))
+	default:
+		return fmt.Errorf("unknown GitHub report mode %q, expected %s or %s", mode, ModeCheck, ModeStatus)
+	}
+}
+
+// reportCheckRun creates a completed check run, adding annotations in batches GitHub accepts
+func (r *Reporter) reportCheckRun(ctx context.Context, summary notify.Summary, annotations []Annotation) error {
+	conclusion := "success"
+	if summary.Failures() > 0 {
+		conclusion = "failure"
+	}
+	output := CheckRunOutput{
+		Title:   fmt.Sprintf("%d passed, %d failed, %d errored, %d skipped", summary.Passed, summary.Failed, summary.Errored, summary.Skipped),
+		Summary: checkSummary(summary),
+	}
+
+	now := time.Now().UTC()
+	batch := annotations[:min(len(annotations), maxAnnotations)]
+	first := output
+	first.Annotations = batch
+	id, err := r.Client.CreateCheckRun(ctx, CheckRun{
+		Name:        r.Name,
+		HeadSHA:     r.SHA,
+		Status:      "completed",
+		Conclusion:  conclusion,
+		DetailsURL:  r.DetailsURL,
+		CompletedAt: &now,
+		Output:      &first,
+	})
+	if err != nil {
+		return err
+	}
+
+	for start := maxAnnotations; start < len(annotations); start += maxAnnotations {
+		next := output
+		next.Annotations = annotations[start:min(len(annotations), start+maxAnnotations)]
+		if err := r.Client.UpdateCheckRun(ctx, id, CheckRun{Output: &next}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Annotations returns an annotation on the test file for every validation error and execution error,
+// a notice for tests skipped with a reason and expected failures, and a failure for unexpected passes
+func (r *Reporter) Annotations(run *history.Run, failures map[string][]validator.ValidationError) []Annotation {
+	var annotations []Annotation
+	for _, result := range run.Results {
+		path := r.relativePath(result.File)
+		switch result.Outcome {
+		case history.OutcomeError:
+			annotations = append(annotations, Annotation{
+				Path:            path,
+				StartLine:       1,
+				EndLine:         1,
+				AnnotationLevel: "failure",
+				Title:           fmt.Sprintf("%s: error", result.Name),
+				Message:         withDiagnosis(result.Error, result.Diagnosis),
+			})
+		case history.OutcomeSkipped:
+			if result.Reason == "" {
+				continue
+			}
+			annotations = append(annotations, Annotation{
+				Path:            path,
+				StartLine:       1,
+				EndLine:         1,
+				AnnotationLevel: "notice",
+				Title:           fmt.Sprintf("%s: skipped", result.Name),
+				Message:         result.Reason,
+			})
+		case history.OutcomeExpectedFailure:
+			annotations = append(annotations, Annotation{
+				Path:            path,
+				StartLine:       1,
+				EndLine:         1,
+				AnnotationLevel: "notice",
+				Title:           fmt.Sprintf("%s: expected failure", result.Name),
+				Message:         result.Reason,
+			})
+		case history.OutcomeUnexpectedPass:
+			annotations = append(annotations, Annotation{
+				Path:            path,
+				StartLine:       1,
+				EndLine:         1,
+				AnnotationLevel: "failure",
+				Title:           fmt.Sprintf("%s: unexpected pass", result.Name),
+				Message:         fmt.Sprintf("The test passed but is marked as an expected failure (%s), remove expectedFailure if it is fixed", result.Reason),
+			})
+		case history.OutcomeFailed:
+			for _, failure := range failures[result.Name] {
+				annotations = append(annotations, Annotation{
+					Path:            path,
+					StartLine:       1,
+					EndLine:         1,
+					AnnotationLevel: "failure",
+					Title:           fmt.Sprintf("%s: %s", result.Name, failure.Path),
+					Message:         withDiagnosis(failure.Message, result.Diagnosis),
+					RawDetails:      rawDetails(failure),
+				})
+			}
+		}
+	}
+	return annotations
+}
+
+// withDiagnosis appends the probable cause of a failure to its message
+func withDiagnosis(message, diagnosis string) string {
+	if diagnosis == "" {
+		return message
+	}
+	return message + "\n\n" + strings.ToUpper(diagnosis[:1]) + diagnosis[1:]
+}
+
+// relativePath returns a test file path relative to the base directory with forward slashes
+func (r *Reporter) relativePath(file string) string {
+	if r.BaseDir != "" {
+		if abs, err := filepath.Abs(file); err == nil {
+			if rel, err := filepath.Rel(r.BaseDir, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+		}
+	}
+	return filepath.ToSlash(file)
+}
+
+// rawDetails formats the expected and actual values of a validation error
+func rawDetails(failure validator.ValidationError) string {
+	var parts []string
+	if failure.Expected != nil {
+		parts = append(parts, "Expected:\n"+snippet(failure.Expected))
+	}
+	if failure.Actual != nil {
+		parts = append(parts, "Actual:\n"+snippet(failure.Actual))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// snippet renders a value as YAML, truncated to maxSnippet
+func snippet(value any) string {
+	text, ok := value.(string)
+	if !ok {
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			text = fmt.Sprintf("%v", value)
+		} else {
+			text = string(data)
+		}
+	}
+	return truncate(strings.TrimSpace(text), maxSnippet)
+}
+
+// truncate shortens s to at most n bytes, marking the cut
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
+
+// checkSummary returns the Markdown summary of a check run
+func checkSummary(summary notify.Summary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Target: `%s`\n\n", summary.Target)
+	b.WriteString("| Passed | Failed | Errored | Skipped |\n|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| %d | %d | %d | %d |\n", summary.Passed, summary.Failed, summary.Errored, summary.Skipped)
+	if len(summary.FailedTests) > 0 {
+		b.WriteString("\n**Failed tests**\n\n")
+		for _, name := range summary.FailedTests {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+	if summary.ReportURL != "" {
+		fmt.Fprintf(&b, "\n[View report](%s)\n", summary.ReportURL)
+	}
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/validator"
+)
+
+type apiRequest struct {
+	Method string
+	Path   string
+	Body   map[string]any
+}
+
+// fakeGitHub records API requests, check runs are created with ID 7
+func fakeGitHub(t *testing.T) (*Client, *[]apiRequest) {
+	t.Helper()
+	var requests []apiRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		json.Unmarshal(data, &body)
+		requests = append(requests, apiRequest{Method: r.Method, Path: r.URL.Path, Body: body})
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 7}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "token", "konveyor/rulesets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, &requests
+}
+
+func failedRun() (*history.Run, map[string][]validator.ValidationError) {
+	run := &history.Run{
+		Target: "kantra",
+		Results: []history.TestResult{
+			{Name: "daytrader", File: "/repo/tests/daytrader/test.yaml", Outcome: history.OutcomePassed},
+			{Name: "petclinic", File: "/repo/tests/petclinic/test.yaml", Outcome: history.OutcomeFailed},
+			{Name: "coolstore", File: "/repo/tests/coolstore/test.yaml", Outcome: history.OutcomeError, Error: "execution failed: timeout"},
+		},
+	}
+	failures := map[string][]validator.ValidationError{
+		"petclinic": {{
+			Path:     "rulesets[eap8].violations[javax-00001]",
+			Message:  "Incident count mismatch",
+			Expected: map[string]int{"incidents": 3},
+			Actual:   map[string]int{"incidents": 2},
+		}},
+	}
+	return run, failures
+}
+
+func TestNewClient(t *testing.T) {
+	if _, err := NewClient("", "", "konveyor/rulesets"); err == nil {
+		t.Error("NewClient() expected error without a token")
+	}
+	for _, repo := range []string{"rulesets", "konveyor/", "a/b/c"} {
+		if _, err := NewClient("", "token", repo); err == nil {
+			t.Errorf("NewClient() expected error for repository %q", repo)
+		}
+	}
+}
+
+func TestReporter_CheckRun(t *testing.T) {
+	client, requests := fakeGitHub(t)
+	reporter := &Reporter{Client: client, SHA: "abc123", Name: "koncur", BaseDir: "/repo", DetailsURL: "https://ci.example.com/1"}
+
+	run, failures := failedRun()
+	if err := reporter.Report(context.Background(), ModeCheck, run, failures); err != nil {
+		t.Fatalf("Report() unexpected error: %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(*requests))
+	}
+	req := (*requests)[0]
+	if req.Method != http.MethodPost || req.Path != "/repos/konveyor/rulesets/check-runs" {
+		t.Errorf("request = %s %s", req.Method, req.Path)
+	}
+	if req.Body["conclusion"] != "failure" || req.Body["head_sha"] != "abc123" || req.Body["status"] != "completed" {
+		t.Errorf("unexpected check run: %v", req.Body)
+	}
+
+	output := req.Body["output"].(map[string]any)
+	if !strings.Contains(output["summary"].(string), "- petclinic") {
+		t.Errorf("summary missing failed test: %s", output["summary"])
+	}
+	annotations := output["annotations"].([]any)
+	if len(annotations) != 2 {
+		t.Fatalf("got %d annotations, want 2", len(annotations))
+	}
+	first := annotations[0].(map[string]any)
+	if first["path"] != "tests/petclinic/test.yaml" {
+		t.Errorf("annotation path = %v, want relative test file", first["path"])
+	}
+	if details := first["raw_details"].(string); !strings.Contains(details, "Expected:\nincidents: 3") || !strings.Contains(details, "Actual:\nincidents: 2") {
+		t.Errorf("unexpected raw details: %s", details)
+	}
+	if second := annotations[1].(map[string]any); second["message"] != "execution failed: timeout" {
+		t.Errorf("error annotation message = %v", second["message"])
+	}
+}
+
+func TestReporter_CheckRunAnnotationBatches(t *testing.T) {
+	client, requests := fakeGitHub(t)
+	reporter := &Reporter{Client: client, SHA: "abc123", Name: "koncur"}
+
+	run, failures := failedRun()
+	for i := range 119 {
+		failures["petclinic"] = append(failures["petclinic"], validator.ValidationError{Path: fmt.Sprintf("incident[%d]", i), Message: "Missing incident"})
+	}
+	if err := reporter.Report(context.Background(), ModeCheck, run, failures); err != nil {
+		t.Fatalf("Report() unexpected error: %v", err)
+	}
+
+	// 121 annotations are sent as 50 on creation and two updates of 50 and 21
+	wantCounts := []int{50, 50, 21}
+	if len(*requests) != len(wantCounts) {
+		t.Fatalf("sent %d requests, want %d", len(*requests), len(wantCounts))
+	}
+	for i, req := range *requests {
+		if i > 0 && (req.Method != http.MethodPatch || req.Path != "/repos/konveyor/rulesets/check-runs/7") {
+			t.Errorf("request %d = %s %s, want check run update", i, req.Method, req.Path)
+		}
+		got := len(req.Body["output"].(map[string]any)["annotations"].([]any))
+		if got != wantCounts[i] {
+			t.Errorf("request %d sent %d annotations, want %d", i, got, wantCounts[i])
+		}
+	}
+}
+
+func TestReporter_Status(t *testing.T) {
+	client, requests := fakeGitHub(t)
+	reporter := &Reporter{Client: client, SHA: "abc123", Name: "koncur/kantra", DetailsURL: "https://ci.example.com/1"}
+
+	run, failures := failedRun()
+	if err := reporter.Report(context.Background(), ModeStatus, run, failures); err != nil {
+		t.Fatalf("Report() unexpected error: %v", err)
+	}
+
+	req := (*requests)[0]
+	if req.Path != "/repos/konveyor/rulesets/statuses/abc123" {
+		t.Errorf("request path = %s", req.Path)
+	}
+	if req.Body["state"] != "failure" || req.Body["context"] != "koncur/kantra" || req.Body["description"] != "1 passed, 1 failed, 1 errored, 0 skipped" {
+		t.Errorf("unexpected status: %v", req.Body)
+	}
+}
+
+func TestReporter_UnknownMode(t *testing.T) {
+	client, _ := fakeGitHub(t)
+	reporter := &Reporter{Client: client}
+	run, failures := failedRun()
+	if err := reporter.Report(context.Background(), "comment", run, failures); err == nil {
+		t.Error("Report() expected error for an unknown mode")
+	}
+}
+
+func TestReporter_SkippedAnnotations(t *testing.T) {
+	reporter := &Reporter{BaseDir: "/repo"}
+	run := &history.Run{Results: []history.TestResult{
+		{Name: "seam-booking", File: "/repo/tests/seam-booking/test.yaml", Outcome: history.OutcomeSkipped, Reason: "requires incident-selector, not supported by tackle-hub v0.6.0"},
+		{Name: "nerd-dinner", File: "/repo/tests/nerd-dinner/test.yaml", Outcome: history.OutcomeSkipped},
+	}}
+
+	annotations := reporter.Annotations(run, nil)
+	if len(annotations) != 1 {
+		t.Fatalf("got %d annotations, want 1 for the skip with a reason", len(annotations))
+	}
+	if a := annotations[0]; a.AnnotationLevel != "notice" || a.Path != "tests/seam-booking/test.yaml" || !strings.Contains(a.Message, "incident-selector") {
+		t.Errorf("unexpected annotation: %+v", a)
+	}
+}
+
+func TestReporter_ExpectedFailureAnnotations(t *testing.T) {
+	reporter := &Reporter{BaseDir: "/repo"}
+	run := &history.Run{Results: []history.TestResult{
+		{Name: "jakarta-ee", File: "/repo/tests/jakarta-ee/test.yaml", Outcome: history.OutcomeExpectedFailure, Reason: "https://github.com/konveyor/analyzer-lsp/issues/1"},
+		{Name: "tomcat", File: "/repo/tests/tomcat/test.yaml", Outcome: history.OutcomeUnexpectedPass, Reason: "https://github.com/konveyor/analyzer-lsp/issues/2"},
+	}}
+
+	annotations := reporter.Annotations(run, nil)
+	if len(annotations) != 2 {
+		t.Fatalf("got %d annotations, want 2", len(annotations))
+	}
+	if a := annotations[0]; a.AnnotationLevel != "notice" || a.Message != run.Results[0].Reason {
+		t.Errorf("unexpected expected failure annotation: %+v", a)
+	}
+	if a := annotations[1]; a.AnnotationLevel != "failure" || a.Path != "tests/tomcat/test.yaml" || !strings.Contains(a.Message, "issues/2") {
+		t.Errorf("unexpected unexpected pass annotation: %+v", a)
+	}
+}
+
+func TestReporter_DiagnosisAnnotations(t *testing.T) {
+	reporter := &Reporter{BaseDir: "/repo"}
+	run := &history.Run{Results: []history.TestResult{
+		{Name: "coolstore", File: "/repo/tests/coolstore/test.yaml", Outcome: history.OutcomeError, Error: "execution failed: exit code 1",
+			Diagnosis: "probable cause: a Maven repository rejected the credentials (HTTP 401) (check mavenSettings)"},
+	}}
+
+	annotations := reporter.Annotations(run, nil)
+	if len(annotations) != 1 {
+		t.Fatalf("got %d annotations, want 1", len(annotations))
+	}
+	if a := annotations[0]; !strings.HasPrefix(a.Message, "execution failed") || !strings.Contains(a.Message, "\n\nProbable cause: a Maven repository") {
+		t.Errorf("unexpected annotation message: %q", a.Message)
+	}
+}
@@ -0,0 +1,121 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDir is where run history is stored, next to the test outputs
+const DefaultDir = ".koncur/history"
+
+// Outcome is the result of a single test in a run
+type Outcome string
+
+const (
+	// OutcomePassed means the output matched the expected output
+	OutcomePassed Outcome = "passed"
+	// OutcomeFailed means the output didn't match or the exit code was wrong
+	OutcomeFailed Outcome = "failed"
+	// OutcomeError means the test couldn't be executed
+	OutcomeError Outcome = "error"
+	// OutcomeSkipped means the test was marked as skipped
+	OutcomeSkipped Outcome = "skipped"
+	// OutcomeExpectedFailure means a test marked as an expected failure failed
+	OutcomeExpectedFailure Outcome = "xfail"
+	// OutcomeUnexpectedPass means a test marked as an expected failure passed
+	OutcomeUnexpectedPass Outcome = "xpass"
+)
+
+// TestResult records the outcome of one test in a run
+type TestResult struct {
+	Name     string        `json:"name"`
+	File     string        `json:"file"`
+	Outcome  Outcome       `json:"outcome"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+	// Reason explains why a test was skipped, or links the issue of an expected failure
+	Reason string `json:"reason,omitempty"`
+	// Diagnosis is the probable cause of a failure recognized from the output, with a remediation hint
+	Diagnosis string `json:"diagnosis,omitempty"`
+}
+
+// Run records the outcomes of all tests executed by one koncur run
+type Run struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Target    string       `json:"target"`
+	Results   []TestResult `json:"results"`
+}
+
+// Store appends runs to and reads them from a JSON history directory, one file per run
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store for the given history directory
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Append writes a run to the history directory
+func (s *Store) Append(run *Run) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run: %w", err)
+	}
+
+	// Timestamps sort lexically, so file names keep runs in order
+	name := fmt.Sprintf("run-%s.json", run.Timestamp.UTC().Format("20060102T150405.000000000Z"))
+	if err := os.WriteFile(filepath.Join(s.dir, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write run history: %w", err)
+	}
+
+	return nil
+}
+
+// Load reads the most recent runs, oldest first
+// A limit of 0 or less loads every run
+func (s *Store) Load(limit int) ([]Run, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	var runs []Run
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read run history %s: %w", entry.Name(), err)
+		}
+
+		var run Run
+		if err := json.Unmarshal(data, &run); err != nil {
+			return nil, fmt.Errorf("failed to parse run history %s: %w", entry.Name(), err)
+		}
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[len(runs)-limit:]
+	}
+	return runs, nil
+}
@@ -0,0 +1,140 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStore_AppendLoad(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		run := &Run{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			Target:    "kantra",
+			Results: []TestResult{
+				{Name: "test-a", Outcome: OutcomePassed, Duration: time.Minute},
+			},
+		}
+		if err := store.Append(run); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	runs, err := store.Load(0)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(runs) != 3 {
+		t.Fatalf("Load() returned %d runs, want 3", len(runs))
+	}
+	if !runs[0].Timestamp.Equal(start) {
+		t.Errorf("Expected oldest run first, got %v", runs[0].Timestamp)
+	}
+
+	runs, err = store.Load(2)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(runs) != 2 || !runs[1].Timestamp.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("Load(2) = %+v, want the 2 most recent runs", runs)
+	}
+	if runs[1].Results[0].Duration != time.Minute {
+		t.Errorf("Duration = %v, want 1m", runs[1].Results[0].Duration)
+	}
+}
+
+func TestStore_LoadMissingDir(t *testing.T) {
+	runs, err := NewStore(t.TempDir() + "/missing").Load(10)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(runs) != 0 {
+		t.Errorf("Load() = %v, want no runs", runs)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	outcomes := map[string][]Outcome{
+		"stable":     {OutcomePassed, OutcomePassed, OutcomePassed, OutcomePassed},
+		"regressed":  {OutcomePassed, OutcomePassed, OutcomeFailed, OutcomeError},
+		"flaky":      {OutcomePassed, OutcomeFailed, OutcomePassed, OutcomeFailed},
+		"skip-flaky": {OutcomePassed, OutcomeSkipped, OutcomeError, OutcomePassed},
+		"known-bug":  {OutcomeExpectedFailure, OutcomeExpectedFailure, OutcomeUnexpectedPass, OutcomePassed},
+	}
+
+	var runs []Run
+	for i := 0; i < 4; i++ {
+		run := Run{Timestamp: time.Unix(int64(i), 0)}
+		for name, o := range outcomes {
+			run.Results = append(run.Results, TestResult{Name: name, Outcome: o[i]})
+		}
+		runs = append(runs, run)
+	}
+
+	tests := []struct {
+		name      string
+		passRate  float64
+		flips     int
+		skipped   int
+		wantFlaky bool
+	}{
+		{name: "flaky", passRate: 0.5, flips: 3, wantFlaky: true},
+		{name: "known-bug", passRate: 0.5, flips: 1, wantFlaky: false},
+		{name: "regressed", passRate: 0.5, flips: 1, wantFlaky: false},
+		{name: "skip-flaky", passRate: 2.0 / 3.0, flips: 2, skipped: 1, wantFlaky: true},
+		{name: "stable", passRate: 1, flips: 0, wantFlaky: false},
+	}
+
+	stats := Summarize(runs)
+	if len(stats) != len(tests) {
+		t.Fatalf("Summarize() returned %d tests, want %d", len(stats), len(tests))
+	}
+	for i, tt := range tests {
+		s := stats[i]
+		if s.Name != tt.name {
+			t.Fatalf("stats[%d].Name = %v, want %v", i, s.Name, tt.name)
+		}
+		if s.Runs != 4 {
+			t.Errorf("%s: Runs = %d, want 4", tt.name, s.Runs)
+		}
+		if s.PassRate() != tt.passRate {
+			t.Errorf("%s: PassRate() = %v, want %v", tt.name, s.PassRate(), tt.passRate)
+		}
+		if s.Flips != tt.flips {
+			t.Errorf("%s: Flips = %d, want %d", tt.name, s.Flips, tt.flips)
+		}
+		if s.Skipped != tt.skipped {
+			t.Errorf("%s: Skipped = %d, want %d", tt.name, s.Skipped, tt.skipped)
+		}
+		if s.Flaky() != tt.wantFlaky {
+			t.Errorf("%s: Flaky() = %v, want %v", tt.name, s.Flaky(), tt.wantFlaky)
+		}
+	}
+}
+
+func TestLastDurations(t *testing.T) {
+	runs := []Run{
+		{Results: []TestResult{
+			{Name: "daytrader", Outcome: OutcomePassed, Duration: 20 * time.Minute},
+			{Name: "coolstore", Outcome: OutcomePassed, Duration: 5 * time.Minute},
+		}},
+		{Results: []TestResult{
+			{Name: "daytrader", Outcome: OutcomePassed, Duration: 22 * time.Minute},
+			{Name: "coolstore", Outcome: OutcomeError, Duration: time.Second},
+			{Name: "skipped", Outcome: OutcomeSkipped},
+		}},
+	}
+
+	got := LastDurations(runs)
+	want := map[string]time.Duration{"daytrader": 22 * time.Minute, "coolstore": 5 * time.Minute}
+	if len(got) != len(want) {
+		t.Fatalf("LastDurations() = %v, want %v", got, want)
+	}
+	for name, d := range want {
+		if got[name] != d {
+			t.Errorf("LastDurations()[%s] = %s, want %s", name, got[name], d)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"sort"
+	"time"
+)
+
+// FlakyFlips is the number of outcome changes that mark a test as flaky
+// A single change is a regression or a fix, going back and forth is flakiness
+const FlakyFlips = 2
+
+// TestStats summarizes the outcomes of one test across runs
+type TestStats struct {
+	Name    string
+	Runs    int
+	Passed  int
+	Failed  int
+	Skipped int
+
+	// Flips counts how often the outcome changed between consecutive executed runs
+	Flips int
+
+	// Outcomes lists the outcome of every run the test was part of, oldest first
+	Outcomes []Outcome
+}
+
+// PassRate returns the share of executed runs that passed, skipped runs don't count
+func (s TestStats) PassRate() float64 {
+	executed := s.Passed + s.Failed
+	if executed == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(executed)
+}
+
+// Flaky returns true if the test both passed and failed and its result alternated
+func (s TestStats) Flaky() bool {
+	return s.Passed > 0 && s.Failed > 0 && s.Flips >= FlakyFlips
+}
+
+// Summarize computes per-test statistics for runs ordered oldest first
+// Errors and expected failures count as failures, unexpected passes as passes, tests are sorted by name
+func Summarize(runs []Run) []TestStats {
+	stats := map[string]*TestStats{}
+	last := map[string]Outcome{}
+
+	for _, run := range runs {
+		for _, result := range run.Results {
+			s, ok := stats[result.Name]
+			if !ok {
+				s = &TestStats{Name: result.Name}
+				stats[result.Name] = s
+			}
+			s.Runs++
+			s.Outcomes = append(s.Outcomes, result.Outcome)
+
+			outcome := result.Outcome
+			switch outcome {
+			case OutcomeSkipped:
+				s.Skipped++
+				continue
+			case OutcomePassed, OutcomeUnexpectedPass:
+				s.Passed++
+				outcome = OutcomePassed
+			default:
+				s.Failed++
+				outcome = OutcomeFailed
+			}
+
+			if prev, ok := last[result.Name]; ok && prev != outcome {
+				s.Flips++
+			}
+			last[result.Name] = outcome
+		}
+	}
+
+	result := make([]TestStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// LastDurations returns the duration of the most recent passing run of each test, for runs ordered
+// oldest first. Failed runs are left out since they often end early
+func LastDurations(runs []Run) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	for _, run := range runs {
+		for _, result := range run.Results {
+			if result.Outcome == OutcomePassed && result.Duration > 0 {
+				durations[result.Name] = result.Duration
+			}
+		}
+	}
+	return durations
+}
@@ -0,0 +1,307 @@
+package jira
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// StatusToDo is the status of the issues created in the mock
+const StatusToDo = "To Do"
+
+// Project is a Jira project and the types of issues it accepts
+type Project struct {
+	ID         string      `json:"id"`
+	Key        string      `json:"key"`
+	Name       string      `json:"name"`
+	IssueTypes []IssueType `json:"issueTypes"`
+}
+
+// IssueType is a type of Jira issue, e.g. Story
+type IssueType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Issue is an issue created in the mock
+type Issue struct {
+	ID          string
+	Key         string
+	Project     string
+	IssueType   string
+	Summary     string
+	Description string
+	Status      string
+}
+
+// Mock is a Jira server implementing the part of the REST API the Hub uses to export applications, for
+// hermetic migration tests. It accepts the configured basic authentication only
+type Mock struct {
+	username string
+	password string
+	server   *http.Server
+	listener net.Listener
+
+	mu       sync.Mutex
+	nextID   int
+	projects []Project
+	issues   []Issue
+}
+
+// NewMock starts a mock Jira listening on an address, e.g. :8089 or 127.0.0.1:0
+func NewMock(address, username, password string) (*Mock, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start mock Jira on %s: %w", address, err)
+	}
+	m := &Mock{username: username, password: password, listener: listener, nextID: 10000}
+
+	mux := http.NewServeMux()
+	// Jira Cloud serves version 3 of the API, Jira on premises version 2
+	mux.HandleFunc("GET /rest/api/{version}/myself", m.myself)
+	mux.HandleFunc("GET /rest/api/{version}/serverInfo", m.serverInfo)
+	mux.HandleFunc("GET /rest/api/{version}/project", m.listProjects)
+	mux.HandleFunc("GET /rest/api/{version}/project/{project}", m.getProject)
+	mux.HandleFunc("POST /rest/api/{version}/issue", m.createIssue)
+	mux.HandleFunc("GET /rest/api/{version}/issue/{issue}", m.getIssue)
+	mux.HandleFunc("/rest/api/{version}/search", m.search)
+	m.server = &http.Server{Handler: m.authenticated(mux)}
+
+	go func() {
+		if err := m.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			util.GetLogger().Info("Mock Jira stopped", "error", err.Error())
+		}
+	}()
+	return m, nil
+}
+
+// Address returns the address the mock listens on
+func (m *Mock) Address() string {
+	return m.listener.Addr().String()
+}
+
+// Close stops the mock
+func (m *Mock) Close() error {
+	return m.server.Close()
+}
+
+// AddProject creates a project accepting issue types in the mock and returns it
+func (m *Mock) AddProject(key string, issueTypes ...string) Project {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	project := Project{ID: m.newID(), Key: key, Name: key}
+	for _, name := range issueTypes {
+		project.IssueTypes = append(project.IssueTypes, IssueType{ID: m.newID(), Name: name})
+	}
+	m.projects = append(m.projects, project)
+	return project
+}
+
+// Issues returns the issues created in the mock
+func (m *Mock) Issues() []Issue {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.issues)
+}
+
+// Issue returns a created issue by key
+func (m *Mock) Issue(key string) (Issue, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	i := slices.IndexFunc(m.issues, func(issue Issue) bool { return issue.Key == key || issue.ID == key })
+	if i < 0 {
+		return Issue{}, false
+	}
+	return m.issues[i], true
+}
+
+func (m *Mock) newID() string {
+	m.nextID++
+	return strconv.Itoa(m.nextID)
+}
+
+func (m *Mock) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != m.username || password != m.password {
+			writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *Mock) myself(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"name": m.username, "displayName": m.username, "active": true})
+}
+
+func (m *Mock) serverInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"baseUrl": "http://" + r.Host, "version": "9.12.0", "deploymentType": "Server"})
+}
+
+func (m *Mock) listProjects(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	writeJSON(w, http.StatusOK, m.projects)
+}
+
+func (m *Mock) getProject(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	project, ok := m.findProject(r.PathValue("project"))
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("No project could be found with key '%s'.", r.PathValue("project")))
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+// findProject finds a project by ID or key, the caller holds the lock
+func (m *Mock) findProject(idOrKey string) (Project, bool) {
+	i := slices.IndexFunc(m.projects, func(p Project) bool { return p.ID == idOrKey || p.Key == idOrKey })
+	if i < 0 {
+		return Project{}, false
+	}
+	return m.projects[i], true
+}
+
+// reference refers to a project or issue type by ID, key or name
+type reference struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+func (m *Mock) createIssue(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Fields struct {
+			Project     reference `json:"project"`
+			IssueType   reference `json:"issuetype"`
+			Summary     string    `json:"summary"`
+			Description any       `json:"description"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid issue: %v", err))
+		return
+	}
+	fields := request.Fields
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	project, ok := m.findProject(cmp.Or(fields.Project.ID, fields.Project.Key))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "project is required")
+		return
+	}
+	i := slices.IndexFunc(project.IssueTypes, func(t IssueType) bool {
+		return t.ID == fields.IssueType.ID || t.Name == fields.IssueType.Name && t.Name != ""
+	})
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, "Specify a valid issue type")
+		return
+	}
+	if fields.Summary == "" {
+		writeError(w, http.StatusBadRequest, "You must specify a summary of the issue.")
+		return
+	}
+
+	issue := Issue{
+		ID:          m.newID(),
+		Key:         fmt.Sprintf("%s-%d", project.Key, len(m.issues)+1),
+		Project:     project.Key,
+		IssueType:   project.IssueTypes[i].Name,
+		Summary:     fields.Summary,
+		Description: description(fields.Description),
+		Status:      StatusToDo,
+	}
+	m.issues = append(m.issues, issue)
+	writeJSON(w, http.StatusCreated, map[string]string{"id": issue.ID, "key": issue.Key, "self": m.issueURL(r, issue)})
+}
+
+func (m *Mock) getIssue(w http.ResponseWriter, r *http.Request) {
+	issue, ok := m.Issue(r.PathValue("issue"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Issue Does Not Exist")
+		return
+	}
+	writeJSON(w, http.StatusOK, m.issueJSON(r, issue))
+}
+
+// search returns the issues whose keys are listed in a JQL query, e.g. key in (MIG-1, MIG-2). Other
+// queries return every issue
+func (m *Mock) search(w http.ResponseWriter, r *http.Request) {
+	jql := r.URL.Query().Get("jql")
+	if r.Method == http.MethodPost {
+		var request struct {
+			JQL string `json:"jql"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid search: %v", err))
+			return
+		}
+		jql = request.JQL
+	}
+
+	issues := []map[string]any{}
+	for _, issue := range m.Issues() {
+		if strings.Contains(jql, "key") && !strings.Contains(jql, issue.Key) {
+			continue
+		}
+		issues = append(issues, m.issueJSON(r, issue))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"startAt": 0, "maxResults": len(issues), "total": len(issues), "issues": issues})
+}
+
+func (m *Mock) issueURL(r *http.Request, issue Issue) string {
+	return fmt.Sprintf("http://%s/rest/api/%s/issue/%s", r.Host, r.PathValue("version"), issue.ID)
+}
+
+func (m *Mock) issueJSON(r *http.Request, issue Issue) map[string]any {
+	return map[string]any{
+		"id":   issue.ID,
+		"key":  issue.Key,
+		"self": m.issueURL(r, issue),
+		"fields": map[string]any{
+			"summary":     issue.Summary,
+			"description": issue.Description,
+			"project":     map[string]string{"key": issue.Project},
+			"issuetype":   map[string]string{"name": issue.IssueType},
+			"status":      map[string]string{"name": issue.Status},
+		},
+	}
+}
+
+// description returns the text of an issue description, a string in version 2 of the API and an Atlassian
+// document in version 3
+func description(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an error in the format of Jira
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"errorMessages": []string{message}, "errors": map[string]string{}})
+}
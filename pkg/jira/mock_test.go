@@ -0,0 +1,88 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestMock(t *testing.T) {
+	mock, err := NewMock("127.0.0.1:0", "koncur", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	project := mock.AddProject("MIG", "Story", "Task")
+	url := "http://" + mock.Address() + "/rest/api/2"
+
+	call := func(method, path, username string, body any) (*http.Response, map[string]any) {
+		t.Helper()
+		var data bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&data).Encode(body)
+		}
+		req, err := http.NewRequest(method, url+path, &data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth(username, "secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var decoded map[string]any
+		json.NewDecoder(resp.Body).Decode(&decoded)
+		return resp, decoded
+	}
+	issue := func(projectKey, issueType, summary string) map[string]any {
+		return map[string]any{"fields": map[string]any{
+			"project":     map[string]string{"key": projectKey},
+			"issuetype":   map[string]string{"name": issueType},
+			"summary":     summary,
+			"description": "Created by the harness",
+		}}
+	}
+
+	if resp, _ := call(http.MethodGet, "/myself", "someone", nil); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("myself with wrong credentials status = %d, want 401", resp.StatusCode)
+	}
+	if resp, body := call(http.MethodGet, "/project/"+project.ID, "koncur", nil); resp.StatusCode != http.StatusOK || body["key"] != "MIG" {
+		t.Errorf("get project = %d %v, want MIG", resp.StatusCode, body)
+	}
+
+	tests := []struct {
+		name       string
+		issue      map[string]any
+		wantStatus int
+	}{
+		{name: "created", issue: issue("MIG", "Story", "Migrate inventory"), wantStatus: http.StatusCreated},
+		{name: "unknown project", issue: issue("OPS", "Story", "Migrate inventory"), wantStatus: http.StatusBadRequest},
+		{name: "unknown issue type", issue: issue("MIG", "Epic", "Migrate inventory"), wantStatus: http.StatusBadRequest},
+		{name: "missing summary", issue: issue("MIG", "Task", ""), wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if resp, body := call(http.MethodPost, "/issue", "koncur", tt.issue); resp.StatusCode != tt.wantStatus {
+				t.Errorf("create issue status = %d %v, want %d", resp.StatusCode, body, tt.wantStatus)
+			}
+		})
+	}
+
+	issues := mock.Issues()
+	if len(issues) != 1 {
+		t.Fatalf("Issues() = %v, want 1 issue", issues)
+	}
+	created := issues[0]
+	if created.Key != "MIG-1" || created.IssueType != "Story" || created.Summary != "Migrate inventory" || created.Status != StatusToDo {
+		t.Errorf("created issue = %+v", created)
+	}
+	resp, body := call(http.MethodGet, "/issue/MIG-1", "koncur", nil)
+	if resp.StatusCode != http.StatusOK || body["id"] != created.ID {
+		t.Errorf("get issue = %d %v, want %s", resp.StatusCode, body, created.ID)
+	}
+	if _, body := call(http.MethodPost, "/search", "koncur", map[string]string{"jql": "key in (MIG-1)"}); body["total"] != 1.0 {
+		t.Errorf("search total = %v, want 1", body["total"])
+	}
+}
@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Cluster is the subset of the Kubernetes API used to dispatch tests
+type Cluster interface {
+	CreateConfigMap(ctx context.Context, cm *corev1.ConfigMap) error
+	DeleteConfigMap(ctx context.Context, namespace, name string) error
+	CreateJob(ctx context.Context, job *batchv1.Job) error
+	GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error)
+	DeleteJob(ctx context.Context, namespace, name string) error
+}
+
+// clientsetCluster implements Cluster with a client-go clientset
+type clientsetCluster struct {
+	clientset kubernetes.Interface
+}
+
+// NewCluster connects to the cluster of a kubeconfig file
+// An empty path uses $KUBECONFIG, ~/.kube/config or the in-cluster configuration
+// It also returns the kubeconfig's current namespace
+func NewCluster(kubeconfig string) (Cluster, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get namespace: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	return &clientsetCluster{clientset: clientset}, namespace, nil
+}
+
+func (c *clientsetCluster) CreateConfigMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	_, err := c.clientset.CoreV1().ConfigMaps(cm.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	return err
+}
+
+func (c *clientsetCluster) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	return c.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (c *clientsetCluster) CreateJob(ctx context.Context, job *batchv1.Job) error {
+	_, err := c.clientset.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	return err
+}
+
+func (c *clientsetCluster) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	return c.clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// DeleteJob deletes a Job along with its pods
+func (c *clientsetCluster) DeleteJob(ctx context.Context, namespace, name string) error {
+	propagation := metav1.DeletePropagationBackground
+	return c.clientset.BatchV1().Jobs(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+}
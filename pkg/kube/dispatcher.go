@@ -0,0 +1,191 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+	"github.com/konveyor/test-harness/pkg/util"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultPollInterval is how often Job status is checked
+const defaultPollInterval = 10 * time.Second
+
+// TestJob is a test to run as a Job
+type TestJob struct {
+	// Name of the test, the directory containing its definition
+	Name string
+	// File is the test definition path inside the harness image
+	File string
+}
+
+// Dispatcher runs tests as Kubernetes Jobs and aggregates their results
+type Dispatcher struct {
+	Cluster Cluster
+	Results ResultStore
+	Options JobOptions
+	// TargetConfig is mounted into the Jobs from a ConfigMap when set
+	TargetConfig []byte
+	// Parallelism limits how many Jobs run at once, zero for no limit
+	Parallelism int
+	// KeepJobs leaves Jobs and the ConfigMap in the cluster for debugging
+	KeepJobs bool
+	// PollInterval is how often Job status is checked, defaultPollInterval when zero
+	PollInterval time.Duration
+}
+
+// Dispatch runs every test as a Job and returns the aggregated run
+// Tests whose Job fails without writing results are recorded as errors, matrix tests record a result per variant
+func (d *Dispatcher) Dispatch(ctx context.Context, tests []TestJob) (*history.Run, error) {
+	log := util.GetLogger()
+	opts := d.Options
+
+	if d.TargetConfig != nil {
+		cm := NewTargetConfigMap(opts, d.TargetConfig)
+		if err := d.Cluster.CreateConfigMap(ctx, cm); err != nil {
+			return nil, fmt.Errorf("failed to create target config map: %w", err)
+		}
+		opts.TargetConfigMap = cm.Name
+		if !d.KeepJobs {
+			defer func() {
+				if err := d.Cluster.DeleteConfigMap(context.Background(), cm.Namespace, cm.Name); err != nil {
+					log.Info("Warning: failed to delete config map", "name", cm.Name, "error", err.Error())
+				}
+			}()
+		}
+	}
+
+	parallelism := d.Parallelism
+	if parallelism <= 0 {
+		parallelism = len(tests)
+	}
+	sem := make(chan struct{}, max(parallelism, 1))
+
+	results := make([][]history.TestResult, len(tests))
+	var wg sync.WaitGroup
+	for i, test := range tests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = d.runTest(ctx, opts, test)
+		}()
+	}
+	wg.Wait()
+
+	run := &history.Run{
+		Timestamp: time.Now(),
+		Target:    d.target(),
+	}
+	for _, recorded := range results {
+		run.Results = append(run.Results, recorded...)
+	}
+	return run, ctx.Err()
+}
+
+// runTest creates a test's Job, waits for it to finish and loads its results
+func (d *Dispatcher) runTest(ctx context.Context, opts JobOptions, test TestJob) []history.TestResult {
+	log := util.GetLogger()
+	result := history.TestResult{Name: test.Name, File: test.File, Outcome: history.OutcomeError}
+
+	job := NewJob(opts, test.Name, test.File)
+	start := time.Now()
+	if err := d.Cluster.CreateJob(ctx, job); err != nil {
+		result.Error = fmt.Sprintf("failed to create job: %v", err)
+		return []history.TestResult{result}
+	}
+	log.Info("Created job", "test", test.Name, "job", job.Name)
+	if !d.KeepJobs {
+		defer func() {
+			if err := d.Cluster.DeleteJob(context.Background(), job.Namespace, job.Name); err != nil {
+				log.Info("Warning: failed to delete job", "name", job.Name, "error", err.Error())
+			}
+		}()
+	}
+
+	finished, err := d.wait(ctx, job.Namespace, job.Name)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return []history.TestResult{result}
+	}
+
+	// A failed Job may still have recorded a failed test before exiting
+	run, loadErr := d.Results.Load(ctx, opts.RunID, job.Name)
+	if loadErr != nil || len(run.Results) == 0 {
+		if reason := failureReason(finished); reason != "" {
+			result.Error = fmt.Sprintf("job %s failed: %s", job.Name, reason)
+		} else if loadErr != nil {
+			result.Error = fmt.Sprintf("job %s: %v", job.Name, loadErr)
+		} else {
+			result.Error = fmt.Sprintf("job %s recorded no results", job.Name)
+		}
+		return []history.TestResult{result}
+	}
+
+	for i := range run.Results {
+		run.Results[i].File = test.File
+		log.Info("Job finished", "test", run.Results[i].Name, "outcome", run.Results[i].Outcome)
+	}
+	return run.Results
+}
+
+// wait polls a Job until it succeeds or fails
+func (d *Dispatcher) wait(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	interval := d.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := d.Cluster.GetJob(ctx, namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %s: %w", name, err)
+		}
+		if jobFinished(job) {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for job %s: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// target returns the target type recorded for the run
+func (d *Dispatcher) target() string {
+	if d.Options.TargetType != "" {
+		return d.Options.TargetType
+	}
+	return "kubernetes"
+}
+
+// jobFinished reports whether a Job completed or failed
+func jobFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// failureReason returns why a Job failed, empty if it didn't
+func failureReason(job *batchv1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+			return strings.TrimSpace(cond.Reason + ": " + cond.Message)
+		}
+	}
+	return ""
+}
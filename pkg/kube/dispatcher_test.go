@@ -0,0 +1,250 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// fakeCluster completes Jobs as soon as they are created, recording what the harness in each Job would write
+type fakeCluster struct {
+	mu         sync.Mutex
+	resultsDir string
+	// outcomes maps test names to the outcome their Job records, a missing test fails its Job
+	outcomes map[string]history.Outcome
+	// variants maps matrix test names to their variants, whose Job records a result each
+	variants   map[string][]string
+	jobs       map[string]*batchv1.Job
+	configMaps map[string]*corev1.ConfigMap
+	deleted    []string
+	running    int
+	maxRunning int
+}
+
+func newFakeCluster(resultsDir string, outcomes map[string]history.Outcome) *fakeCluster {
+	return &fakeCluster{
+		resultsDir: resultsDir,
+		outcomes:   outcomes,
+		jobs:       map[string]*batchv1.Job{},
+		configMaps: map[string]*corev1.ConfigMap{},
+	}
+}
+
+func (f *fakeCluster) CreateConfigMap(ctx context.Context, cm *corev1.ConfigMap) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.configMaps[cm.Name] = cm
+	return nil
+}
+
+func (f *fakeCluster) DeleteConfigMap(ctx context.Context, namespace, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.configMaps, name)
+	f.deleted = append(f.deleted, "configmap/"+name)
+	return nil
+}
+
+func (f *fakeCluster) CreateJob(ctx context.Context, job *batchv1.Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.jobs[job.Name] = job
+	f.running++
+	f.maxRunning = max(f.maxRunning, f.running)
+	return nil
+}
+
+func (f *fakeCluster) GetJob(ctx context.Context, namespace, name string) (*batchv1.Job, error) {
+	f.mu.Lock()
+	job, ok := f.jobs[name]
+	f.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("job %s not found", name)
+	}
+	// Let other Jobs start so parallelism can be observed
+	time.Sleep(5 * time.Millisecond)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(job.Status.Conditions) > 0 {
+		return job, nil
+	}
+	f.running--
+
+	testName := job.Annotations[TestLabel]
+	outcome, ok := f.outcomes[testName]
+	if !ok {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"}}
+		return job, nil
+	}
+
+	run := history.Run{Target: "mock", Results: []history.TestResult{{Name: testName, File: "in-image", Outcome: outcome, Duration: time.Second}}}
+	if variants, ok := f.variants[testName]; ok {
+		run.Results = nil
+		for _, v := range variants {
+			run.Results = append(run.Results, history.TestResult{Name: testName + "-" + v, File: "in-image", Outcome: outcome})
+		}
+	}
+	data, _ := json.Marshal(run)
+	file := filepath.Join(f.resultsDir, filepath.FromSlash(ResultKey(job.Labels[RunIDLabel], job.Name)))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return nil, err
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	return job, nil
+}
+
+func (f *fakeCluster) DeleteJob(ctx context.Context, namespace, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, "job/"+name)
+	return nil
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	dir := t.TempDir()
+	cluster := newFakeCluster(dir, map[string]history.Outcome{
+		"daytrader": history.OutcomePassed,
+		"petclinic": history.OutcomeFailed,
+		"coolstore": history.OutcomePassed,
+	})
+	d := &Dispatcher{
+		Cluster:      cluster,
+		Results:      &DirResultStore{Dir: dir},
+		Options:      JobOptions{Namespace: "ci", RunID: "run1", TargetType: "mock", ResultsPVC: "results"},
+		TargetConfig: []byte("type: mock\n"),
+		Parallelism:  2,
+		PollInterval: time.Millisecond,
+	}
+
+	tests := []TestJob{
+		{Name: "daytrader", File: "tests/daytrader/test.yaml"},
+		{Name: "petclinic", File: "tests/petclinic/test.yaml"},
+		{Name: "coolstore", File: "tests/coolstore/test.yaml"},
+		{Name: "broken", File: "tests/broken/test.yaml"},
+	}
+	run, err := d.Dispatch(context.Background(), tests)
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if run.Target != "mock" || len(run.Results) != len(tests) {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	want := []history.Outcome{history.OutcomePassed, history.OutcomeFailed, history.OutcomePassed, history.OutcomeError}
+	for i, result := range run.Results {
+		if result.Name != tests[i].Name || result.File != tests[i].File || result.Outcome != want[i] {
+			t.Errorf("result %d = %+v, want %s %s", i, result, tests[i].Name, want[i])
+		}
+	}
+	if run.Results[3].Error == "" {
+		t.Error("failed job should record an error")
+	}
+
+	if cluster.maxRunning > 2 {
+		t.Errorf("%d jobs ran at once, parallelism is 2", cluster.maxRunning)
+	}
+	if len(cluster.configMaps) != 0 {
+		t.Errorf("config map not cleaned up: %v", cluster.configMaps)
+	}
+	if len(cluster.deleted) != len(tests)+1 {
+		t.Errorf("deleted %v, want all jobs and the config map", cluster.deleted)
+	}
+	for _, job := range cluster.jobs {
+		if args := job.Spec.Template.Spec.Containers[0].Args; args[3] != "--target-config" {
+			t.Errorf("job %s should use the mounted target config: %v", job.Name, args)
+		}
+	}
+}
+
+func TestDispatcher_KeepJobs(t *testing.T) {
+	dir := t.TempDir()
+	cluster := newFakeCluster(dir, map[string]history.Outcome{"daytrader": history.OutcomePassed})
+	d := &Dispatcher{
+		Cluster:      cluster,
+		Results:      &DirResultStore{Dir: dir},
+		Options:      JobOptions{RunID: "run1"},
+		TargetConfig: []byte("type: mock\n"),
+		KeepJobs:     true,
+		PollInterval: time.Millisecond,
+	}
+
+	run, err := d.Dispatch(context.Background(), []TestJob{{Name: "daytrader", File: "tests/daytrader/test.yaml"}})
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if run.Results[0].Outcome != history.OutcomePassed {
+		t.Errorf("Outcome = %s, want passed", run.Results[0].Outcome)
+	}
+	if len(cluster.deleted) != 0 || len(cluster.configMaps) != 1 {
+		t.Errorf("resources should be kept, deleted %v", cluster.deleted)
+	}
+}
+
+func TestDispatcher_MatrixVariants(t *testing.T) {
+	dir := t.TempDir()
+	cluster := newFakeCluster(dir, map[string]history.Outcome{"daytrader": history.OutcomePassed})
+	cluster.variants = map[string][]string{"daytrader": {"full-quarkus", "source-only-quarkus"}}
+	d := &Dispatcher{
+		Cluster:      cluster,
+		Results:      &DirResultStore{Dir: dir},
+		Options:      JobOptions{RunID: "run1"},
+		PollInterval: time.Millisecond,
+	}
+
+	run, err := d.Dispatch(context.Background(), []TestJob{{Name: "daytrader", File: "tests/daytrader/test.yaml"}})
+	if err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("got %d results, want one per variant", len(run.Results))
+	}
+	for i, want := range []string{"daytrader-full-quarkus", "daytrader-source-only-quarkus"} {
+		if run.Results[i].Name != want || run.Results[i].File != "tests/daytrader/test.yaml" {
+			t.Errorf("Results[%d] = %+v, want %s", i, run.Results[i], want)
+		}
+	}
+}
+
+func TestDirResultStore_Load(t *testing.T) {
+	dir := t.TempDir()
+	store := &DirResultStore{Dir: dir}
+
+	if _, err := store.Load(context.Background(), "run1", "job"); err == nil {
+		t.Error("Load() should fail for missing results")
+	}
+
+	file := filepath.Join(dir, "run1", "job", "run.json")
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(context.Background(), "run1", "job"); err == nil {
+		t.Error("Load() should fail for invalid results")
+	}
+
+	if err := os.WriteFile(file, []byte(`{"target":"kantra","results":[{"name":"daytrader","outcome":"passed"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run, err := store.Load(context.Background(), "run1", "job")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if run.Target != "kantra" || len(run.Results) != 1 || run.Results[0].Outcome != history.OutcomePassed {
+		t.Errorf("unexpected run: %+v", run)
+	}
+}
@@ -0,0 +1,186 @@
+package kube
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RunIDLabel labels the Jobs and ConfigMap of a dispatched run
+	RunIDLabel = "koncur.konveyor.io/run-id"
+	// TestLabel holds the test name a Job runs, truncated to a valid label value
+	TestLabel = "koncur.konveyor.io/test"
+
+	// ResultsMountPath is where the results volume is mounted in Jobs
+	ResultsMountPath = "/results"
+	// ConfigMountPath is where the target configuration is mounted in Jobs
+	ConfigMountPath = "/config"
+	// targetConfigKey is the ConfigMap key holding the target configuration
+	targetConfigKey = "target.yaml"
+
+	// maxNameLength is the length limit of DNS-1123 labels used for Job names
+	maxNameLength = 63
+)
+
+// JobOptions configures the Jobs running the tests of a run
+type JobOptions struct {
+	// Namespace the Jobs are created in
+	Namespace string
+	// Image of the harness, containing koncur and the test suite at the same relative paths
+	Image string
+	// RunID identifies the run, Jobs and results are named after it
+	RunID string
+	// TargetType is passed with --target when there is no target configuration
+	TargetType string
+	// TargetConfigMap holds the target configuration, mounted at ConfigMountPath
+	TargetConfigMap string
+	// ResultsPVC is the shared claim results are written to, mounted at ResultsMountPath
+	ResultsPVC string
+	// ArtifactsURL uploads each Job's work directory and results to object storage
+	ArtifactsURL string
+	// EnvSecret is a Secret whose keys are added to the environment, e.g. storage credentials
+	EnvSecret string
+	// ServiceAccount runs the Jobs' pods
+	ServiceAccount string
+	// Timeout limits how long a Job may run, zero for no limit
+	Timeout time.Duration
+}
+
+// JobName returns the name of the Job running a test, a valid DNS-1123 label unique per run and test
+func JobName(runID, testName string) string {
+	name := dnsLabel("koncur-" + runID + "-" + testName)
+	sum := sha256.Sum256([]byte(runID + "/" + testName))
+	suffix := hex.EncodeToString(sum[:])[:8]
+	name = strings.TrimRight(name[:min(len(name), maxNameLength-len(suffix)-1)], "-")
+	return name + "-" + suffix
+}
+
+// ResultKey returns where a Job writes its results, relative to the results volume or artifacts prefix
+// It matches the run.json uploaded with artifacts
+func ResultKey(runID, jobName string) string {
+	return path.Join(runID, jobName, "run.json")
+}
+
+// NewJob returns the Job running a single test file
+// The test file path is relative to the image's working directory
+func NewJob(opts JobOptions, testName, testFile string) *batchv1.Job {
+	name := JobName(opts.RunID, testName)
+	labels := map[string]string{
+		RunIDLabel: dnsLabel(opts.RunID),
+		TestLabel:  dnsLabel(testName),
+	}
+
+	args := []string{"run", testFile, "--no-history"}
+	if opts.TargetConfigMap != "" {
+		args = append(args, "--target-config", path.Join(ConfigMountPath, targetConfigKey))
+	} else if opts.TargetType != "" {
+		args = append(args, "--target", opts.TargetType)
+	}
+
+	var volumes []corev1.Volume
+	var mounts []corev1.VolumeMount
+	if opts.TargetConfigMap != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "target-config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: opts.TargetConfigMap}},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "target-config", MountPath: ConfigMountPath, ReadOnly: true})
+	}
+	if opts.ResultsPVC != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "results",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: opts.ResultsPVC},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "results", MountPath: ResultsMountPath})
+		args = append(args, "--result-file", path.Join(ResultsMountPath, ResultKey(opts.RunID, name)))
+	}
+	if opts.ArtifactsURL != "" {
+		// The run ID keeps every Job's artifacts and results apart below the run
+		args = append(args, "--artifacts-url", opts.ArtifactsURL, "--run-id", path.Join(opts.RunID, name))
+	}
+
+	var envFrom []corev1.EnvFromSource
+	if opts.EnvSecret != "" {
+		envFrom = append(envFrom, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: opts.EnvSecret}},
+		})
+	}
+
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   opts.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{TestLabel: testName},
+		},
+		Spec: batchv1.JobSpec{
+			// Failed tests are reported, not retried
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: opts.ServiceAccount,
+					Containers: []corev1.Container{{
+						Name:         "koncur",
+						Image:        opts.Image,
+						Args:         args,
+						EnvFrom:      envFrom,
+						VolumeMounts: mounts,
+					}},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	if opts.Timeout > 0 {
+		deadline := int64(opts.Timeout.Seconds())
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	return job
+}
+
+// NewTargetConfigMap returns the ConfigMap holding the target configuration of a run
+func NewTargetConfigMap(opts JobOptions, targetConfig []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dnsLabel("koncur-" + opts.RunID + "-target"),
+			Namespace: opts.Namespace,
+			Labels:    map[string]string{RunIDLabel: dnsLabel(opts.RunID)},
+		},
+		Data: map[string]string{targetConfigKey: string(targetConfig)},
+	}
+}
+
+// dnsLabel lowercases s and replaces characters not allowed in DNS-1123 labels with hyphens
+func dnsLabel(s string) string {
+	var b strings.Builder
+	for _, ch := range strings.ToLower(s) {
+		if (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') {
+			b.WriteRune(ch)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	label := b.String()
+	for strings.Contains(label, "--") {
+		label = strings.ReplaceAll(label, "--", "-")
+	}
+	label = strings.Trim(label, "-")
+	if len(label) > maxNameLength {
+		label = strings.TrimRight(label[:maxNameLength], "-")
+	}
+	return label
+}
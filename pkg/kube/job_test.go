@@ -0,0 +1,120 @@
+package kube
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJobName(t *testing.T) {
+	tests := []struct {
+		name     string
+		runID    string
+		testName string
+		prefix   string
+	}{
+		{name: "simple", runID: "20250101-100000", testName: "daytrader", prefix: "koncur-20250101-100000-daytrader-"},
+		{name: "invalid characters", runID: "Run_1", testName: "tackle.hub/Coolstore", prefix: "koncur-run-1-tackle-hub-coolstore-"},
+		{name: "long", runID: "run", testName: strings.Repeat("a", 100), prefix: "koncur-run-aaaa"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := JobName(tt.runID, tt.testName)
+			if !strings.HasPrefix(name, tt.prefix) {
+				t.Errorf("JobName() = %q, want prefix %q", name, tt.prefix)
+			}
+			if len(name) > maxNameLength {
+				t.Errorf("JobName() = %q is longer than %d", name, maxNameLength)
+			}
+			if name != dnsLabel(name) {
+				t.Errorf("JobName() = %q is not a DNS label", name)
+			}
+		})
+	}
+
+	if JobName("run", "a-test") == JobName("run", "a.test") {
+		t.Error("JobName() should differ for test names sanitizing to the same label")
+	}
+}
+
+func TestNewJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       JobOptions
+		wantArgs   []string
+		wantMounts []string
+	}{
+		{
+			name:     "target type",
+			opts:     JobOptions{RunID: "run1", TargetType: "kantra"},
+			wantArgs: []string{"run", "tests/daytrader/test.yaml", "--no-history", "--target", "kantra"},
+		},
+		{
+			name: "config map and pvc",
+			opts: JobOptions{RunID: "run1", TargetType: "kantra", TargetConfigMap: "koncur-run1-target", ResultsPVC: "results"},
+			wantArgs: []string{"run", "tests/daytrader/test.yaml", "--no-history", "--target-config", "/config/target.yaml",
+				"--result-file", "/results/run1/" + JobName("run1", "daytrader") + "/run.json"},
+			wantMounts: []string{ConfigMountPath, ResultsMountPath},
+		},
+		{
+			name: "artifacts",
+			opts: JobOptions{RunID: "run1", ArtifactsURL: "s3://bucket/ci"},
+			wantArgs: []string{"run", "tests/daytrader/test.yaml", "--no-history",
+				"--artifacts-url", "s3://bucket/ci", "--run-id", "run1/" + JobName("run1", "daytrader")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := NewJob(tt.opts, "daytrader", "tests/daytrader/test.yaml")
+			container := job.Spec.Template.Spec.Containers[0]
+			if !slices.Equal(container.Args, tt.wantArgs) {
+				t.Errorf("Args = %v, want %v", container.Args, tt.wantArgs)
+			}
+			var mounts []string
+			for _, m := range container.VolumeMounts {
+				mounts = append(mounts, m.MountPath)
+			}
+			if !slices.Equal(mounts, tt.wantMounts) {
+				t.Errorf("mounts = %v, want %v", mounts, tt.wantMounts)
+			}
+			if len(job.Spec.Template.Spec.Volumes) != len(tt.wantMounts) {
+				t.Errorf("got %d volumes, want %d", len(job.Spec.Template.Spec.Volumes), len(tt.wantMounts))
+			}
+		})
+	}
+}
+
+func TestNewJob_Spec(t *testing.T) {
+	job := NewJob(JobOptions{
+		Namespace:      "ci",
+		Image:          "quay.io/konveyor/koncur:latest",
+		RunID:          "run1",
+		EnvSecret:      "storage",
+		ServiceAccount: "koncur",
+		Timeout:        30 * time.Minute,
+	}, "daytrader", "tests/daytrader/test.yaml")
+
+	if job.Namespace != "ci" || job.Labels[RunIDLabel] != "run1" || job.Annotations[TestLabel] != "daytrader" {
+		t.Errorf("unexpected metadata: %+v", job.ObjectMeta)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("BackoffLimit = %d, want 0", *job.Spec.BackoffLimit)
+	}
+	if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != 1800 {
+		t.Errorf("ActiveDeadlineSeconds = %v, want 1800", job.Spec.ActiveDeadlineSeconds)
+	}
+	pod := job.Spec.Template.Spec
+	if pod.RestartPolicy != "Never" || pod.ServiceAccountName != "koncur" {
+		t.Errorf("unexpected pod spec: %+v", pod)
+	}
+	container := pod.Containers[0]
+	if container.Image != "quay.io/konveyor/koncur:latest" {
+		t.Errorf("Image = %q", container.Image)
+	}
+	if len(container.EnvFrom) != 1 || container.EnvFrom[0].SecretRef.Name != "storage" {
+		t.Errorf("EnvFrom = %+v, want secret storage", container.EnvFrom)
+	}
+}
@@ -0,0 +1,55 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/konveyor/test-harness/pkg/artifacts"
+	"github.com/konveyor/test-harness/pkg/history"
+)
+
+// ResultStore reads the results written by Jobs
+type ResultStore interface {
+	// Load returns the run recorded by a Job
+	Load(ctx context.Context, runID, jobName string) (*history.Run, error)
+}
+
+// DirResultStore reads results from a local directory, e.g. where the shared results PVC is mounted
+type DirResultStore struct {
+	Dir string
+}
+
+// Load reads a Job's results from the directory
+func (s *DirResultStore) Load(ctx context.Context, runID, jobName string) (*history.Run, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, filepath.FromSlash(ResultKey(runID, jobName))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read results: %w", err)
+	}
+	return parseRun(data)
+}
+
+// ObjectResultStore reads results uploaded with the Jobs' artifacts
+type ObjectResultStore struct {
+	Uploader *artifacts.Uploader
+}
+
+// Load downloads a Job's results from object storage
+func (s *ObjectResultStore) Load(ctx context.Context, runID, jobName string) (*history.Run, error) {
+	data, err := s.Uploader.Get(ctx, ResultKey(runID, jobName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download results: %w", err)
+	}
+	return parseRun(data)
+}
+
+// parseRun decodes a run written with --result-file or uploaded as run.json
+func parseRun(data []byte) (*history.Run, error) {
+	var run history.Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse results: %w", err)
+	}
+	return &run, nil
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/history"
+)
+
+// Summary describes the outcome of a run for notifications
+type Summary struct {
+	Timestamp time.Time `json:"timestamp"`
+	Target    string    `json:"target"`
+	Total     int       `json:"total"`
+	Passed    int       `json:"passed"`
+	Failed    int       `json:"failed"`
+	Errored   int       `json:"errored"`
+	Skipped   int       `json:"skipped"`
+	// ExpectedFailures are failures of tests marked as known bugs, they don't fail the run
+	ExpectedFailures int `json:"expectedFailures,omitempty"`
+	// UnexpectedPasses are passes of tests marked as known bugs, they fail the run
+	UnexpectedPasses int      `json:"unexpectedPasses,omitempty"`
+	FailedTests      []string `json:"failedTests,omitempty"`
+	ReportURL        string   `json:"reportURL,omitempty"`
+}
+
+// SummaryFromRun counts the outcomes of a run
+func SummaryFromRun(run *history.Run, reportURL string) Summary {
+	summary := Summary{
+		Timestamp: run.Timestamp,
+		Target:    run.Target,
+		Total:     len(run.Results),
+		ReportURL: reportURL,
+	}
+	for _, result := range run.Results {
+		switch result.Outcome {
+		case history.OutcomePassed:
+			summary.Passed++
+		case history.OutcomeFailed:
+			summary.Failed++
+			summary.FailedTests = append(summary.FailedTests, result.Name)
+		case history.OutcomeError:
+			summary.Errored++
+			summary.FailedTests = append(summary.FailedTests, result.Name)
+		case history.OutcomeSkipped:
+			summary.Skipped++
+		case history.OutcomeExpectedFailure:
+			summary.ExpectedFailures++
+		case history.OutcomeUnexpectedPass:
+			summary.UnexpectedPasses++
+			summary.FailedTests = append(summary.FailedTests, result.Name)
+		}
+	}
+	return summary
+}
+
+// Failures returns the number of tests that failed, couldn't be executed or passed unexpectedly
+func (s Summary) Failures() int {
+	return s.Failed + s.Errored + s.UnexpectedPasses
+}
+
+// Title returns a one line description of the run
+func (s Summary) Title() string {
+	status := "passed"
+	if s.Failures() > 0 {
+		status = "failed"
+	}
+	title := fmt.Sprintf("koncur run on %s %s: %d passed, %d failed, %d errored, %d skipped",
+		s.Target, status, s.Passed, s.Failed, s.Errored, s.Skipped)
+	if s.ExpectedFailures > 0 {
+		title += fmt.Sprintf(", %d expected failures", s.ExpectedFailures)
+	}
+	if s.UnexpectedPasses > 0 {
+		title += fmt.Sprintf(", %d unexpected passes", s.UnexpectedPasses)
+	}
+	return title
+}
+
+// Text returns a plain text description of the run
+func (s Summary) Text() string {
+	var b strings.Builder
+	b.WriteString(s.Title())
+	b.WriteString("\n")
+	if len(s.FailedTests) > 0 {
+		b.WriteString("\nFailed tests:\n")
+		for _, name := range s.FailedTests {
+			fmt.Fprintf(&b, "  - %s\n", name)
+		}
+	}
+	if s.ReportURL != "" {
+		fmt.Fprintf(&b, "\nReport: %s\n", s.ReportURL)
+	}
+	return b.String()
+}
+
+// Sender delivers run summaries to a notification channel
+type Sender interface {
+	// Name returns the sender type
+	Name() string
+
+	// Send delivers the summary
+	Send(ctx context.Context, summary Summary) error
+}
+
+// NewSender creates a sender from its configuration
+func NewSender(cfg config.NotifierConfig) (Sender, error) {
+	switch cfg.Type {
+	case "slack":
+		if cfg.Slack == nil {
+			return nil, fmt.Errorf("slack notifier requires slack configuration")
+		}
+		return NewSlackSender(cfg.Slack.WebhookURL), nil
+	case "webhook":
+		if cfg.Webhook == nil {
+			return nil, fmt.Errorf("webhook notifier requires webhook configuration")
+		}
+		return NewWebhookSender(cfg.Webhook.URL, cfg.Webhook.Headers), nil
+	case "email":
+		if cfg.Email == nil {
+			return nil, fmt.Errorf("email notifier requires email configuration")
+		}
+		return NewEmailSender(cfg.Email), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %s", cfg.Type)
+	}
+}
+
+// Notify sends the summary to every configured notifier if the configuration's thresholds are met
+// Every notifier is tried, the returned error joins their failures
+func Notify(ctx context.Context, cfg *config.NotifyConfig, summary Summary) (bool, error) {
+	if !cfg.ShouldNotify(summary.Failures()) {
+		return false, nil
+	}
+	if cfg.ReportURL != "" {
+		summary.ReportURL = cfg.ReportURL
+	}
+
+	var errs []error
+	for _, notifierConfig := range cfg.Notifiers {
+		sender, err := NewSender(notifierConfig)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := sender.Send(ctx, summary); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sender.Name(), err))
+		}
+	}
+	return true, errors.Join(errs...)
+}
@@ -0,0 +1,193 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/history"
+)
+
+func testRun() *history.Run {
+	return &history.Run{
+		Timestamp: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+		Target:    "kantra",
+		Results: []history.TestResult{
+			{Name: "daytrader", Outcome: history.OutcomePassed},
+			{Name: "petclinic", Outcome: history.OutcomeFailed},
+			{Name: "coolstore", Outcome: history.OutcomeError},
+			{Name: "seam-booking", Outcome: history.OutcomeSkipped},
+		},
+	}
+}
+
+func TestSummaryFromRun(t *testing.T) {
+	summary := SummaryFromRun(testRun(), "https://ci.example.com/run/1")
+
+	if summary.Total != 4 || summary.Passed != 1 || summary.Failed != 1 || summary.Errored != 1 || summary.Skipped != 1 {
+		t.Errorf("unexpected counts: %+v", summary)
+	}
+	if !slices.Equal(summary.FailedTests, []string{"petclinic", "coolstore"}) {
+		t.Errorf("FailedTests = %v", summary.FailedTests)
+	}
+	text := summary.Text()
+	for _, want := range []string{"failed: 1 passed, 1 failed, 1 errored, 1 skipped", "- petclinic", "- coolstore", "Report: https://ci.example.com/run/1"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestSummaryFromRun_ExpectedFailures(t *testing.T) {
+	run := testRun()
+	run.Results = append(run.Results,
+		history.TestResult{Name: "jakarta-ee", Outcome: history.OutcomeExpectedFailure, Reason: "https://github.com/konveyor/analyzer-lsp/issues/1"},
+		history.TestResult{Name: "tomcat", Outcome: history.OutcomeUnexpectedPass, Reason: "https://github.com/konveyor/analyzer-lsp/issues/2"},
+	)
+	summary := SummaryFromRun(run, "")
+
+	if summary.ExpectedFailures != 1 || summary.UnexpectedPasses != 1 {
+		t.Errorf("unexpected counts: %+v", summary)
+	}
+	if summary.Failures() != 3 {
+		t.Errorf("Failures() = %d, want 3 including the unexpected pass", summary.Failures())
+	}
+	if !slices.Contains(summary.FailedTests, "tomcat") || slices.Contains(summary.FailedTests, "jakarta-ee") {
+		t.Errorf("FailedTests = %v, want the unexpected pass and not the expected failure", summary.FailedTests)
+	}
+	if title := summary.Title(); !strings.HasSuffix(title, "1 skipped, 1 expected failures, 1 unexpected passes") {
+		t.Errorf("Title() = %q", title)
+	}
+}
+
+// recordingServer records the bodies posted to it
+func recordingServer(t *testing.T, status int) (*httptest.Server, *[]*http.Request, *[]string) {
+	t.Helper()
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, r)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &bodies
+}
+
+func TestSlackSender(t *testing.T) {
+	server, _, bodies := recordingServer(t, http.StatusOK)
+
+	summary := SummaryFromRun(testRun(), "https://ci.example.com/run/1")
+	if err := NewSlackSender(server.URL).Send(context.Background(), summary); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+
+	var msg map[string]string
+	if err := json.Unmarshal([]byte((*bodies)[0]), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(msg["text"], ":x: ") || !strings.Contains(msg["text"], "<https://ci.example.com/run/1|View report>") {
+		t.Errorf("unexpected slack message: %s", msg["text"])
+	}
+}
+
+func TestWebhookSender(t *testing.T) {
+	server, requests, bodies := recordingServer(t, http.StatusAccepted)
+
+	sender := NewWebhookSender(server.URL, map[string]string{"Authorization": "Bearer token"})
+	if err := sender.Send(context.Background(), SummaryFromRun(testRun(), "")); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+
+	if got := (*requests)[0].Header.Get("Authorization"); got != "Bearer token" {
+		t.Errorf("Authorization header = %q", got)
+	}
+	var summary Summary
+	if err := json.Unmarshal([]byte((*bodies)[0]), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Failed != 1 || summary.Target != "kantra" {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestWebhookSender_Rejected(t *testing.T) {
+	server, _, _ := recordingServer(t, http.StatusUnauthorized)
+	err := NewWebhookSender(server.URL, nil).Send(context.Background(), Summary{})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Send() error = %v, want 401", err)
+	}
+}
+
+func TestEmailSender(t *testing.T) {
+	sender := NewEmailSender(&config.EmailNotifierConfig{
+		Host:     "smtp.example.com",
+		Username: "koncur",
+		Password: "secret",
+		From:     "koncur@example.com",
+		To:       []string{"team@example.com", "lead@example.com"},
+	})
+
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	sender.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		if auth == nil {
+			t.Error("expected authentication with a username")
+		}
+		return nil
+	}
+
+	if err := sender.Send(context.Background(), SummaryFromRun(testRun(), "")); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+	if gotAddr != "smtp.example.com:587" {
+		t.Errorf("addr = %s, want default submission port", gotAddr)
+	}
+	if len(gotTo) != 2 {
+		t.Errorf("to = %v", gotTo)
+	}
+	for _, want := range []string{"To: team@example.com, lead@example.com\r\n", "Subject: koncur run on kantra failed", "\r\n  - petclinic\r\n"} {
+		if !strings.Contains(gotMsg, want) {
+			t.Errorf("message missing %q:\n%s", want, gotMsg)
+		}
+	}
+}
+
+func TestNotify(t *testing.T) {
+	server, _, bodies := recordingServer(t, http.StatusOK)
+	failing, _, _ := recordingServer(t, http.StatusInternalServerError)
+
+	cfg := &config.NotifyConfig{
+		ReportURL: "https://reports.example.com/latest",
+		Notifiers: []config.NotifierConfig{
+			{Type: "webhook", Webhook: &config.WebhookNotifierConfig{URL: failing.URL}},
+			{Type: "webhook", Webhook: &config.WebhookNotifierConfig{URL: server.URL}},
+		},
+	}
+
+	// A passing run isn't notified by default
+	passing := &history.Run{Target: "kantra", Results: []history.TestResult{{Name: "a", Outcome: history.OutcomePassed}}}
+	if sent, err := Notify(context.Background(), cfg, SummaryFromRun(passing, "")); sent || err != nil {
+		t.Errorf("Notify() = %v, %v for a passing run", sent, err)
+	}
+
+	// Every notifier is tried even when one fails
+	sent, err := Notify(context.Background(), cfg, SummaryFromRun(testRun(), "s3://artifacts"))
+	if !sent || err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Notify() = %v, %v, want the failing webhook error", sent, err)
+	}
+	if len(*bodies) != 1 || !strings.Contains((*bodies)[0], "https://reports.example.com/latest") {
+		t.Errorf("bodies = %v, want one summary linking the configured report", *bodies)
+	}
+}
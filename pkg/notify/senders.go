@@ -0,0 +1,163 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// defaultSMTPPort is the mail submission port, which uses STARTTLS
+const defaultSMTPPort = 587
+
+// SlackSender posts summaries to a Slack incoming webhook
+type SlackSender struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackSender creates a sender for a Slack incoming webhook
+func NewSlackSender(webhookURL string) *SlackSender {
+	return &SlackSender{webhookURL: webhookURL, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Name returns the sender type
+func (s *SlackSender) Name() string {
+	return "slack"
+}
+
+// Send posts the summary as a Slack message
+func (s *SlackSender) Send(ctx context.Context, summary Summary) error {
+	icon := ":white_check_mark:"
+	if summary.Failures() > 0 {
+		icon = ":x:"
+	}
+	text := icon + " " + summary.Text()
+	if summary.ReportURL != "" {
+		// Slack link markup keeps the message short
+		text = strings.Replace(text, "Report: "+summary.ReportURL, fmt.Sprintf("<%s|View report>", summary.ReportURL), 1)
+	}
+	return postJSON(ctx, s.client, s.webhookURL, nil, map[string]string{"text": text})
+}
+
+// WebhookSender posts summaries as JSON to any HTTP endpoint
+type WebhookSender struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewWebhookSender creates a sender posting to url with additional headers, e.g. for authentication
+func NewWebhookSender(url string, headers map[string]string) *WebhookSender {
+	return &WebhookSender{url: url, headers: headers, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// Name returns the sender type
+func (w *WebhookSender) Name() string {
+	return "webhook"
+}
+
+// Send posts the summary as JSON
+func (w *WebhookSender) Send(ctx context.Context, summary Summary) error {
+	return postJSON(ctx, w.client, w.url, w.headers, summary)
+}
+
+// postJSON posts body as JSON and fails on non-2xx responses
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification rejected: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// EmailSender mails summaries over SMTP
+type EmailSender struct {
+	addr     string
+	host     string
+	username string
+	password string
+	from     string
+	to       []string
+
+	// sendMail is smtp.SendMail, replaced in tests
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailSender creates a sender for an SMTP server
+func NewEmailSender(cfg *config.EmailNotifierConfig) *EmailSender {
+	port := cfg.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	return &EmailSender{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(port)),
+		host:     cfg.Host,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     cfg.From,
+		to:       cfg.To,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name returns the sender type
+func (e *EmailSender) Name() string {
+	return "email"
+}
+
+// Send mails the summary as plain text
+// STARTTLS is used when the server supports it, and is required for authentication
+func (e *EmailSender) Send(ctx context.Context, summary Summary) error {
+	var auth smtp.Auth
+	if e.username != "" {
+		auth = smtp.PlainAuth("", e.username, e.password, e.host)
+	}
+	if err := e.sendMail(e.addr, auth, e.from, e.to, e.message(summary)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// message builds the RFC 5322 message for a summary
+func (e *EmailSender) message(summary Summary) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", summary.Title())
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))
+	return []byte(b.String())
+}
@@ -0,0 +1,83 @@
+package output
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Canonicalize returns rulesets in a deterministic order: rulesets by name, then the contents of each
+// ruleset as CanonicalRuleSet orders them. Analyzers don't order their output reliably, comparing and
+// recording canonical rulesets avoids diffs that are only reordering. The rulesets aren't modified
+func Canonicalize(rulesets []konveyor.RuleSet) []konveyor.RuleSet {
+	if rulesets == nil {
+		return nil
+	}
+	canonical := make([]konveyor.RuleSet, 0, len(rulesets))
+	for _, rs := range rulesets {
+		canonical = append(canonical, CanonicalRuleSet(rs))
+	}
+	slices.SortStableFunc(canonical, func(a, b konveyor.RuleSet) int { return strings.Compare(a.Name, b.Name) })
+	return canonical
+}
+
+// CanonicalRuleSet returns a copy of a ruleset with its tags, unmatched and skipped rules sorted, and the
+// labels and incidents of its violations and insights sorted. Violations are keyed by rule ID, they are
+// written sorted by it
+func CanonicalRuleSet(rs konveyor.RuleSet) konveyor.RuleSet {
+	rs.Tags = sortedStrings(rs.Tags)
+	rs.Unmatched = sortedStrings(rs.Unmatched)
+	rs.Skipped = sortedStrings(rs.Skipped)
+	rs.Violations = canonicalViolations(rs.Violations)
+	rs.Insights = canonicalViolations(rs.Insights)
+	return rs
+}
+
+func canonicalViolations(violations map[string]konveyor.Violation) map[string]konveyor.Violation {
+	if violations == nil {
+		return nil
+	}
+	canonical := make(map[string]konveyor.Violation, len(violations))
+	for id, v := range violations {
+		v.Labels = sortedStrings(v.Labels)
+		v.Incidents = slices.Clone(v.Incidents)
+		slices.SortStableFunc(v.Incidents, compareIncidents)
+		canonical[id] = v
+	}
+	return canonical
+}
+
+// compareIncidents orders incidents by URI, line number and message, incidents without a line first
+func compareIncidents(a, b konveyor.Incident) int {
+	return cmp.Or(
+		strings.Compare(string(a.URI), string(b.URI)),
+		cmp.Compare(lineNumber(a), lineNumber(b)),
+		strings.Compare(a.Message, b.Message),
+	)
+}
+
+func lineNumber(incident konveyor.Incident) int {
+	if incident.LineNumber == nil {
+		return -1
+	}
+	return *incident.LineNumber
+}
+
+func sortedStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return slices.Sorted(slices.Values(s))
+}
+
+// TrimCodeSnip removes the trailing whitespace of the lines of a code snip. Analyzers pad the numbers of
+// empty lines, the padding can't be written in YAML block scalars and isn't compared
+func TrimCodeSnip(snip string) string {
+	lines := strings.Split(snip, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n")
+}
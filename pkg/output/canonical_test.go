@@ -0,0 +1,73 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+func TestCanonicalize(t *testing.T) {
+	line := func(n int) *int { return &n }
+	rulesets := []konveyor.RuleSet{
+		{
+			Name:      "quarkus",
+			Tags:      []string{"Servlet", "EJB"},
+			Unmatched: []string{"quarkus-00002", "quarkus-00001"},
+			Violations: map[string]konveyor.Violation{
+				"quarkus-00003": {
+					Labels: []string{"konveyor.io/target=quarkus", "konveyor.io/source=java-ee"},
+					Incidents: []konveyor.Incident{
+						{URI: uri.URI("file:///src/b/Main.java"), LineNumber: line(3)},
+						{URI: uri.URI("file:///src/a/Main.java"), LineNumber: line(20), Message: "second"},
+						{URI: uri.URI("file:///src/a/Main.java"), LineNumber: line(20), Message: "first"},
+						{URI: uri.URI("file:///src/a/Main.java"), LineNumber: line(4)},
+						{URI: uri.URI("file:///src/a/pom.xml")},
+					},
+				},
+			},
+		},
+		{Name: "eap8", Tags: []string{"JSF"}},
+	}
+	original := []string{string(rulesets[0].Violations["quarkus-00003"].Incidents[0].URI), rulesets[0].Tags[0]}
+
+	got := Canonicalize(rulesets)
+
+	if got[0].Name != "eap8" || got[1].Name != "quarkus" {
+		t.Fatalf("Canonicalize() rulesets = %s, %s, want eap8, quarkus", got[0].Name, got[1].Name)
+	}
+	quarkus := got[1]
+	if want := []string{"EJB", "Servlet"}; !reflect.DeepEqual(quarkus.Tags, want) {
+		t.Errorf("Tags = %v, want %v", quarkus.Tags, want)
+	}
+	if want := []string{"quarkus-00001", "quarkus-00002"}; !reflect.DeepEqual(quarkus.Unmatched, want) {
+		t.Errorf("Unmatched = %v, want %v", quarkus.Unmatched, want)
+	}
+	v := quarkus.Violations["quarkus-00003"]
+	if want := []string{"konveyor.io/source=java-ee", "konveyor.io/target=quarkus"}; !reflect.DeepEqual(v.Labels, want) {
+		t.Errorf("Labels = %v, want %v", v.Labels, want)
+	}
+	var incidents []string
+	for _, incident := range v.Incidents {
+		incidents = append(incidents, string(incident.URI)+":"+incident.Message)
+	}
+	want := []string{
+		"file:///src/a/Main.java:",
+		"file:///src/a/Main.java:first",
+		"file:///src/a/Main.java:second",
+		"file:///src/a/pom.xml:",
+		"file:///src/b/Main.java:",
+	}
+	if !reflect.DeepEqual(incidents, want) {
+		t.Errorf("Incidents = %v, want %v", incidents, want)
+	}
+	if *v.Incidents[0].LineNumber != 4 {
+		t.Errorf("first incident at line %d, want 4", *v.Incidents[0].LineNumber)
+	}
+
+	// The input isn't modified
+	if rulesets[0].Name != "quarkus" || string(rulesets[0].Violations["quarkus-00003"].Incidents[0].URI) != original[0] || rulesets[0].Tags[0] != original[1] {
+		t.Errorf("Canonicalize() modified its input: %+v", rulesets[0])
+	}
+}
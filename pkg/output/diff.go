@@ -0,0 +1,76 @@
+package output
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Change is a difference in the incidents of a rule between two analysis outputs
+type Change struct {
+	RuleSet string
+	RuleID  string
+	// Previous and Current are the numbers of incidents, -1 when the rule wasn't reported
+	Previous int
+	Current  int
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Previous < 0:
+		return fmt.Sprintf("+ %s/%s: %d incident(s)", c.RuleSet, c.RuleID, c.Current)
+	case c.Current < 0:
+		return fmt.Sprintf("- %s/%s: no longer reported, had %d incident(s)", c.RuleSet, c.RuleID, c.Previous)
+	default:
+		return fmt.Sprintf("~ %s/%s: %d -> %d incident(s)", c.RuleSet, c.RuleID, c.Previous, c.Current)
+	}
+}
+
+// Diff compares the violations and insights of two analysis outputs, so consecutive runs of the same
+// analysis only show what changed. Changes are sorted by ruleset and rule
+func Diff(previous, current []konveyor.RuleSet) []Change {
+	before, after := incidentCounts(previous), incidentCounts(current)
+	var changes []Change
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			before[key] = -1
+		}
+	}
+	for _, key := range slices.SortedFunc(maps.Keys(before), compareRuleKeys) {
+		prev, cur := before[key], -1
+		if n, ok := after[key]; ok {
+			cur = n
+		}
+		if prev != cur {
+			changes = append(changes, Change{RuleSet: key.ruleSet, RuleID: key.ruleID, Previous: prev, Current: cur})
+		}
+	}
+	return changes
+}
+
+// ruleKey identifies a rule of a ruleset
+type ruleKey struct {
+	ruleSet string
+	ruleID  string
+}
+
+func compareRuleKeys(a, b ruleKey) int {
+	return cmp.Or(strings.Compare(a.ruleSet, b.ruleSet), strings.Compare(a.ruleID, b.ruleID))
+}
+
+// incidentCounts counts the incidents of the violations and insights of each rule
+func incidentCounts(rulesets []konveyor.RuleSet) map[ruleKey]int {
+	counts := map[ruleKey]int{}
+	for _, rs := range rulesets {
+		for _, violations := range []map[string]konveyor.Violation{rs.Violations, rs.Insights} {
+			for id, v := range violations {
+				counts[ruleKey{rs.Name, id}] += len(v.Incidents)
+			}
+		}
+	}
+	return counts
+}
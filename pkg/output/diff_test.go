@@ -0,0 +1,67 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestDiff(t *testing.T) {
+	incidents := func(n int) konveyor.Violation {
+		return konveyor.Violation{Incidents: make([]konveyor.Incident, n)}
+	}
+	previous := []konveyor.RuleSet{
+		{
+			Name: "quarkus",
+			Violations: map[string]konveyor.Violation{
+				"quarkus-00001": incidents(2),
+				"quarkus-00002": incidents(1),
+				"quarkus-00003": incidents(4),
+			},
+			Insights: map[string]konveyor.Violation{"info-00001": incidents(1)},
+		},
+	}
+	current := []konveyor.RuleSet{
+		{
+			Name: "quarkus",
+			Violations: map[string]konveyor.Violation{
+				"quarkus-00001": incidents(2),
+				"quarkus-00003": incidents(1),
+				"quarkus-00004": incidents(3),
+			},
+			Insights: map[string]konveyor.Violation{"info-00001": incidents(1)},
+		},
+		{
+			Name:       "eap8",
+			Violations: map[string]konveyor.Violation{"eap8-00001": incidents(1)},
+		},
+	}
+
+	got := Diff(previous, current)
+	want := []Change{
+		{RuleSet: "eap8", RuleID: "eap8-00001", Previous: -1, Current: 1},
+		{RuleSet: "quarkus", RuleID: "quarkus-00002", Previous: 1, Current: -1},
+		{RuleSet: "quarkus", RuleID: "quarkus-00003", Previous: 4, Current: 1},
+		{RuleSet: "quarkus", RuleID: "quarkus-00004", Previous: -1, Current: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Diff() = %+v, want %+v", got, want)
+	}
+
+	wantStrings := []string{
+		"+ eap8/eap8-00001: 1 incident(s)",
+		"- quarkus/quarkus-00002: no longer reported, had 1 incident(s)",
+		"~ quarkus/quarkus-00003: 4 -> 1 incident(s)",
+		"+ quarkus/quarkus-00004: 3 incident(s)",
+	}
+	for i, c := range got {
+		if c.String() != wantStrings[i] {
+			t.Errorf("Change.String() = %q, want %q", c.String(), wantStrings[i])
+		}
+	}
+
+	if changes := Diff(current, current); len(changes) != 0 {
+		t.Errorf("Diff() of the same output = %+v, want none", changes)
+	}
+}
@@ -0,0 +1,113 @@
+package output
+
+import (
+	"sort"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/tackle2-hub/api"
+	"go.lsp.dev/uri"
+)
+
+const (
+	// DiscoveryRuleSet holds the language tags reported by the hub
+	DiscoveryRuleSet = "discovery-rules"
+	// TechnologyUsageRuleSet holds the technology tags reported by the hub
+	TechnologyUsageRuleSet = "technology-usage"
+)
+
+// FromHubInsights converts Tackle Hub insights and application tags to rulesets
+// Insights without effort are reported as insights, the rest as violations.
+// Tags are added to the ruleset matching their discovery source.
+func FromHubInsights(insights []api.Insight, tags []api.TagRef) []konveyor.RuleSet {
+	rulesets := map[string]konveyor.RuleSet{}
+	for _, insight := range insights {
+		rs := rulesets[insight.RuleSet]
+		rs.Name = insight.RuleSet
+		if rs.Insights == nil {
+			rs.Insights = map[string]konveyor.Violation{}
+		}
+		if rs.Violations == nil {
+			rs.Violations = map[string]konveyor.Violation{}
+		}
+		incidents := []konveyor.Incident{}
+		for _, i := range insight.Incidents {
+			incidents = append(incidents, konveyor.Incident{
+				URI:        uri.File(normalizeHubPath(i.File)),
+				Message:    i.Message,
+				CodeSnip:   i.CodeSnip,
+				LineNumber: &i.Line,
+			})
+		}
+		links := []konveyor.Link{}
+		for _, l := range insight.Links {
+			links = append(links, konveyor.Link{
+				URL:   l.URL,
+				Title: l.Title,
+			})
+		}
+
+		v := konveyor.Violation{
+			Description: insight.Description,
+			Category:    (*konveyor.Category)(&insight.Category),
+			Labels:      insight.Labels,
+			Incidents:   incidents,
+			Links:       links,
+			Effort:      &insight.Effort,
+		}
+
+		if insight.Effort == 0 {
+			rs.Insights[insight.Rule] = v
+		} else {
+			rs.Violations[insight.Rule] = v
+		}
+		rulesets[insight.RuleSet] = rs
+	}
+
+	// Ensure discovery-rules and technology-usage rulesets exist
+	for _, name := range []string{DiscoveryRuleSet, TechnologyUsageRuleSet} {
+		if _, exists := rulesets[name]; !exists {
+			rulesets[name] = konveyor.RuleSet{
+				Name: name,
+				Tags: []string{},
+			}
+		}
+	}
+
+	// Add tags to appropriate rulesets based on source
+	for _, tag := range tags {
+		var name string
+		switch tag.Source {
+		case "language-discovery":
+			name = DiscoveryRuleSet
+		case "tech-discovery":
+			name = TechnologyUsageRuleSet
+		default:
+			continue
+		}
+		rs := rulesets[name]
+		rs.Tags = append(rs.Tags, tag.Name)
+		rulesets[name] = rs
+	}
+
+	result := make([]konveyor.RuleSet, 0, len(rulesets))
+	for _, rs := range rulesets {
+		result = append(result, rs)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// normalizeHubPath maps paths inside the hub's analyzer container to the expected output format
+func normalizeHubPath(path string) string {
+	if strings.Contains(path, "/cache/m2") {
+		path = strings.ReplaceAll(path, "/cache/m2/", "/m2/")
+	}
+	// Remove container-specific path prefix
+	if strings.Contains(path, "/opt/input/source/") {
+		path = strings.ReplaceAll(path, "/opt/input/source", "/source")
+	}
+	return path
+}
@@ -0,0 +1,69 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/konveyor/tackle2-hub/api"
+)
+
+func TestFromHubInsights(t *testing.T) {
+	insights := []api.Insight{
+		{
+			RuleSet:  "eap8",
+			Rule:     "eap8-00001",
+			Category: "mandatory",
+			Effort:   5,
+			Incidents: []api.Incident{
+				{File: "/cache/m2/repository/org/lib.jar", Line: 1},
+				{File: "/opt/input/source/src/App.java", Line: 10},
+			},
+		},
+		{
+			RuleSet: "eap8",
+			Rule:    "eap8-00002",
+		},
+	}
+	tags := []api.TagRef{
+		{Name: "Java", Source: "language-discovery"},
+		{Name: "Servlet", Source: "tech-discovery"},
+		{Name: "Manual", Source: "manual"},
+	}
+
+	rulesets := FromHubInsights(insights, tags)
+
+	names := make([]string, len(rulesets))
+	for i, rs := range rulesets {
+		names[i] = rs.Name
+	}
+	want := []string{DiscoveryRuleSet, "eap8", TechnologyUsageRuleSet}
+	if len(names) != len(want) {
+		t.Fatalf("ruleset names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("ruleset names = %v, want %v", names, want)
+		}
+	}
+
+	eap := rulesets[1]
+	v, ok := eap.Violations["eap8-00001"]
+	if !ok {
+		t.Fatalf("Expected eap8-00001 to be a violation")
+	}
+	if got := string(v.Incidents[0].URI); got != "file:///m2/repository/org/lib.jar" {
+		t.Errorf("Incident URI = %v, want file:///m2/repository/org/lib.jar", got)
+	}
+	if got := string(v.Incidents[1].URI); got != "file:///source/src/App.java" {
+		t.Errorf("Incident URI = %v, want file:///source/src/App.java", got)
+	}
+	if _, ok := eap.Insights["eap8-00002"]; !ok {
+		t.Error("Expected eap8-00002 without effort to be an insight")
+	}
+
+	if tags := rulesets[0].Tags; len(tags) != 1 || tags[0] != "Java" {
+		t.Errorf("discovery-rules tags = %v, want [Java]", tags)
+	}
+	if tags := rulesets[2].Tags; len(tags) != 1 || tags[0] != "Servlet" {
+		t.Errorf("technology-usage tags = %v, want [Servlet]", tags)
+	}
+}
@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/tackle2-hub/api"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a representation of analysis output
+type Format string
+
+const (
+	// FormatYAML is the analyzer's output.yaml
+	FormatYAML Format = "yaml"
+	// FormatJSON is the analyzer's output rendered as JSON (--json-output)
+	FormatJSON Format = "json"
+	// FormatHubInsights is the Tackle Hub insights (formerly issues) REST representation
+	FormatHubInsights Format = "hub-insights"
+)
+
+// Load reads analysis output in any supported format and normalizes it to rulesets
+func Load(path string) ([]konveyor.RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output file %s: %w", path, err)
+	}
+
+	rulesets, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output file %s: %w", path, err)
+	}
+	return rulesets, nil
+}
+
+// Parse detects the format of analysis output and normalizes it to rulesets
+func Parse(data []byte) ([]konveyor.RuleSet, error) {
+	var rulesets []konveyor.RuleSet
+
+	switch format := DetectFormat(data); format {
+	case FormatHubInsights:
+		var insights []api.Insight
+		if err := json.Unmarshal(data, &insights); err != nil {
+			return nil, fmt.Errorf("failed to parse hub insights JSON: %w", err)
+		}
+		rulesets = FromHubInsights(insights, nil)
+	case FormatJSON:
+		if err := json.Unmarshal(data, &rulesets); err != nil {
+			return nil, fmt.Errorf("failed to parse output JSON: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, &rulesets); err != nil {
+			return nil, fmt.Errorf("failed to parse output YAML: %w", err)
+		}
+	}
+
+	return rulesets, nil
+}
+
+// DetectFormat returns the format of analysis output
+// JSON is recognized by its leading bracket, hub insights by their per-rule entries
+func DetectFormat(data []byte) Format {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '[' && trimmed[0] != '{') {
+		return FormatYAML
+	}
+
+	// Flow-style YAML also starts with a bracket, only valid JSON is treated as JSON
+	var entries []map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &entries); err != nil {
+		if json.Valid(trimmed) {
+			return FormatJSON
+		}
+		return FormatYAML
+	}
+
+	// Insights carry the rule they were raised by, rulesets don't
+	for _, entry := range entries {
+		if _, ok := entry["rule"]; ok {
+			return FormatHubInsights
+		}
+	}
+	return FormatJSON
+}
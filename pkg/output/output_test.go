@@ -0,0 +1,145 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const yamlOutput = `- name: cloud-readiness
+  violations:
+    session-00001:
+      description: Avoid use of HttpSession
+      category: mandatory
+      effort: 3
+      incidents:
+      - uri: file:///src/main/java/MyServlet.java
+        lineNumber: 42
+`
+
+const jsonOutput = `[
+  {
+    "name": "cloud-readiness",
+    "violations": {
+      "session-00001": {
+        "description": "Avoid use of HttpSession",
+        "category": "mandatory",
+        "effort": 3,
+        "incidents": [
+          {"uri": "file:///src/main/java/MyServlet.java", "lineNumber": 42}
+        ]
+      }
+    }
+  }
+]`
+
+const hubInsightsOutput = `[
+  {
+    "id": 1,
+    "analysis": 1,
+    "ruleset": "cloud-readiness",
+    "rule": "session-00001",
+    "name": "session-00001",
+    "description": "Avoid use of HttpSession",
+    "category": "mandatory",
+    "effort": 3,
+    "incidents": [
+      {"file": "/opt/input/source/src/main/java/MyServlet.java", "line": 42, "message": "HttpSession"}
+    ],
+    "labels": ["konveyor.io/target=cloud-readiness"]
+  },
+  {
+    "id": 2,
+    "analysis": 1,
+    "ruleset": "cloud-readiness",
+    "rule": "info-00001",
+    "name": "info-00001",
+    "description": "Informational",
+    "labels": []
+  }
+]`
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{name: "yaml", data: yamlOutput, want: FormatYAML},
+		{name: "json", data: jsonOutput, want: FormatJSON},
+		{name: "hub insights", data: hubInsightsOutput, want: FormatHubInsights},
+		{name: "empty", data: "", want: FormatYAML},
+		{name: "empty json list", data: "[]", want: FormatJSON},
+		{name: "flow style yaml", data: "[{name: a}]", want: FormatYAML},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectFormat([]byte(tt.data)); got != tt.want {
+				t.Errorf("DetectFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		data         string
+		wantRuleSets int
+		wantErr      bool
+	}{
+		{name: "yaml", file: "output.yaml", data: yamlOutput, wantRuleSets: 1},
+		{name: "json", file: "output.json", data: jsonOutput, wantRuleSets: 1},
+		{name: "hub insights", file: "insights.json", data: hubInsightsOutput, wantRuleSets: 3},
+		{name: "invalid", file: "output.yaml", data: "name: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			rulesets, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(rulesets) != tt.wantRuleSets {
+				t.Fatalf("Load() returned %d rulesets, want %d", len(rulesets), tt.wantRuleSets)
+			}
+
+			var found bool
+			for _, rs := range rulesets {
+				if rs.Name != "cloud-readiness" {
+					continue
+				}
+				found = true
+				v, ok := rs.Violations["session-00001"]
+				if !ok {
+					t.Fatalf("Expected violation session-00001, got %+v", rs.Violations)
+				}
+				if v.Effort == nil || *v.Effort != 3 {
+					t.Errorf("Effort = %v, want 3", v.Effort)
+				}
+				if len(v.Incidents) != 1 || v.Incidents[0].LineNumber == nil || *v.Incidents[0].LineNumber != 42 {
+					t.Errorf("Incidents = %+v", v.Incidents)
+				}
+			}
+			if !found {
+				t.Error("Expected cloud-readiness ruleset")
+			}
+		})
+	}
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for missing output file")
+	}
+}
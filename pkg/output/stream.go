@@ -0,0 +1,172 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v3"
+)
+
+// Stream reads analysis output ruleset by ruleset and calls fn with each of them, so outputs of hundreds
+// of megabytes are validated without holding all their rulesets in memory. Block style YAML lists and
+// JSON arrays are streamed. Hub insights, which are grouped into rulesets, and other YAML are loaded at
+// once. An error returned by fn stops reading and is returned
+func Stream(path string, fn func(konveyor.RuleSet) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read output file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	first, err := firstByte(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read output file %s: %w", path, err)
+	}
+	if first == '[' {
+		err = streamJSON(reader, fn)
+	} else {
+		err = streamYAML(reader, fn)
+	}
+	if errors.Is(err, errNotStreamable) {
+		return streamLoaded(path, fn)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse output file %s: %w", path, err)
+	}
+	return nil
+}
+
+// errNotStreamable reports output that has to be loaded at once
+var errNotStreamable = errors.New("output can't be streamed")
+
+// streamLoaded loads the whole output and calls fn with each of its rulesets
+func streamLoaded(path string, fn func(konveyor.RuleSet) error) error {
+	rulesets, err := Load(path)
+	if err != nil {
+		return err
+	}
+	for _, rs := range rulesets {
+		if err := fn(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstByte returns the first byte of the output that isn't white space without consuming it, zero for
+// empty output
+func firstByte(reader *bufio.Reader) (byte, error) {
+	for n := 1; ; n++ {
+		peeked, err := reader.Peek(n)
+		if len(peeked) < n {
+			if err == io.EOF {
+				return 0, nil
+			}
+			return 0, err
+		}
+		if b := peeked[n-1]; b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return b, nil
+		}
+	}
+}
+
+// streamJSON decodes the elements of a JSON array one by one
+// Arrays of hub insights can't be streamed, their first element tells them apart
+func streamJSON(reader io.Reader, fn func(konveyor.RuleSet) error) error {
+	dec := json.NewDecoder(reader)
+	if _, err := dec.Token(); err != nil {
+		return errNotStreamable
+	}
+	for first := true; dec.More(); first = false {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if first {
+				// Flow style YAML also starts with a bracket
+				return errNotStreamable
+			}
+			return fmt.Errorf("failed to parse output JSON: %w", err)
+		}
+		if first && isHubInsight(raw) {
+			return errNotStreamable
+		}
+		var rs konveyor.RuleSet
+		if err := json.Unmarshal(raw, &rs); err != nil {
+			return fmt.Errorf("failed to parse output JSON: %w", err)
+		}
+		if err := fn(rs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isHubInsight reports whether a JSON object is a hub insight, insights carry the rule they were raised by
+func isHubInsight(raw json.RawMessage) bool {
+	var entry map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return false
+	}
+	_, ok := entry["rule"]
+	return ok
+}
+
+// streamYAML decodes the items of a block style YAML list one by one. Items start with a dash in the first
+// column, the lines of an item are always indented. Output that isn't such a list is reported before any
+// ruleset is decoded, so it can be loaded instead
+func streamYAML(reader *bufio.Reader, fn func(konveyor.RuleSet) error) error {
+	var item bytes.Buffer
+	started := false
+	decode := func() error {
+		if item.Len() == 0 {
+			return nil
+		}
+		var rulesets []konveyor.RuleSet
+		if err := yaml.Unmarshal(item.Bytes(), &rulesets); err != nil {
+			return fmt.Errorf("failed to parse output YAML: %w", err)
+		}
+		item.Reset()
+		for _, rs := range rulesets {
+			if err := fn(rs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			trimmed := bytes.TrimRight(line, "\r\n")
+			switch {
+			case bytes.Equal(trimmed, []byte("-")) || bytes.HasPrefix(trimmed, []byte("- ")):
+				if decodeErr := decode(); decodeErr != nil {
+					return decodeErr
+				}
+				started = true
+				item.Write(line)
+			case started && (bytes.Equal(trimmed, []byte("---")) || bytes.Equal(trimmed, []byte("..."))):
+				// Like Load, only the first document is read
+				return decode()
+			case bytes.Equal(trimmed, []byte("---")):
+			case started:
+				item.Write(line)
+			case len(bytes.TrimSpace(trimmed)) > 0 && trimmed[0] != '#':
+				// The output isn't a block style list, e.g. an empty flow style list
+				return errNotStreamable
+			}
+		}
+		if err == io.EOF {
+			return decode()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+const multiYAMLOutput = `# analysis output
+---
+- name: cloud-readiness
+  tags:
+  - Servlet
+  violations:
+    session-00001:
+      description: |-
+        Avoid use of HttpSession
+        - it isn't replicated
+      effort: 3
+- name: empty
+-
+  name: discovery
+  tags: [Java]
+`
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr bool
+	}{
+		{name: "yaml", file: "output.yaml", data: yamlOutput},
+		{name: "yaml with several rulesets", file: "output.yaml", data: multiYAMLOutput},
+		{name: "json", file: "output.json", data: jsonOutput},
+		{name: "hub insights", file: "insights.json", data: hubInsightsOutput},
+		{name: "flow style yaml", file: "output.yaml", data: "[{name: cloud-readiness, tags: [Servlet]}]"},
+		{name: "empty", file: "output.yaml", data: ""},
+		{name: "invalid", file: "output.yaml", data: "- name: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			var streamed []konveyor.RuleSet
+			err := Stream(path, func(rs konveyor.RuleSet) error {
+				streamed = append(streamed, rs)
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Stream() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			loaded, err := Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(streamed) != len(loaded) || (len(loaded) > 0 && !reflect.DeepEqual(streamed, loaded)) {
+				t.Errorf("Stream() = %+v, want the rulesets of Load() %+v", streamed, loaded)
+			}
+		})
+	}
+}
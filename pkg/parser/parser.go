@@ -1,26 +1,14 @@
 package parser
 
 import (
-	"fmt"
-	"os"
-
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
-	"gopkg.in/yaml.v3"
+	"github.com/konveyor/test-harness/pkg/output"
 )
 
-// ParseOutput reads and parses the analyzer output.yaml file
+// ParseOutput reads and parses the analyzer output file
+// YAML, JSON and Tackle Hub insights are accepted, see output.Load
 func ParseOutput(outputFile string) ([]konveyor.RuleSet, error) {
-	data, err := os.ReadFile(outputFile)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read output file %s: %w", outputFile, err)
-	}
-
-	var rulesets []konveyor.RuleSet
-	if err := yaml.Unmarshal(data, &rulesets); err != nil {
-		return nil, fmt.Errorf("failed to parse output YAML: %w", err)
-	}
-
-	return rulesets, nil
+	return output.Load(outputFile)
 }
 
 // FilterRuleSets filters out rulesets that don't have violations, insights, or tags
@@ -0,0 +1,239 @@
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Reporter receives the progress of the running test
+type Reporter interface {
+	// Update reports the current step of the test, e.g. cloning or a Hub task state
+	Update(status string)
+	// Stop ends the progress of the test before its results are printed
+	Stop()
+}
+
+type reporterKey struct{}
+
+// WithReporter returns a context carrying a reporter, so targets report progress without knowing how
+// it is shown
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Update reports the current step of the running test, it does nothing without a reporter
+func Update(ctx context.Context, status string) {
+	if r, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		r.Update(status)
+	}
+}
+
+// Stop ends the progress of the running test, it does nothing without a reporter
+func Stop(ctx context.Context) {
+	if r, ok := ctx.Value(reporterKey{}).(Reporter); ok {
+		r.Stop()
+	}
+}
+
+// spinner frames of the live display
+var spinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Display intervals
+const (
+	// refreshInterval redraws the live status line
+	refreshInterval = 100 * time.Millisecond
+	// heartbeatInterval prints that a test is still running when the output isn't a terminal
+	heartbeatInterval = time.Minute
+)
+
+// Display shows the progress of a run. On a terminal, a status line with a spinner shows the running test,
+// its step, the elapsed time and the percentage of its expected duration. Otherwise steps are printed as
+// lines, with a heartbeat every minute so CI logs show that long analyses are alive.
+// A nil display shows nothing
+type Display struct {
+	out       io.Writer
+	live      bool
+	total     int
+	heartbeat time.Duration
+
+	mu        sync.Mutex
+	running   bool
+	index     int
+	name      string
+	status    string
+	started   time.Time
+	expected  time.Duration
+	lastPrint time.Time
+	frame     int
+	drawn     bool
+	stop      chan struct{}
+	stopped   chan struct{}
+}
+
+// NewDisplay creates a display of a run of total tests, live when out is a terminal
+func NewDisplay(out *os.File, total int) *Display {
+	return newDisplay(out, isTerminal(out), total, heartbeatInterval)
+}
+
+func newDisplay(out io.Writer, live bool, total int, heartbeat time.Duration) *Display {
+	return &Display{out: out, live: live, total: total, heartbeat: heartbeat}
+}
+
+// isTerminal reports whether a file is a character device, such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Start shows a test as running. expected is its usual duration, zero when unknown
+func (d *Display) Start(index int, name string, expected time.Duration) {
+	if d == nil {
+		return
+	}
+	d.Stop()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.running = true
+	d.index, d.name, d.status = index, name, ""
+	d.started, d.lastPrint = time.Now(), time.Now()
+	d.expected = expected
+	d.stop, d.stopped = make(chan struct{}), make(chan struct{})
+
+	interval := d.heartbeat
+	if d.live {
+		interval = refreshInterval
+		d.draw()
+	}
+	go d.tick(interval, d.stop, d.stopped)
+}
+
+// tick redraws the status line, or prints heartbeats, until the test stops
+func (d *Display) tick(interval time.Duration, stop, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			d.mu.Lock()
+			if d.live {
+				d.frame++
+				d.draw()
+			} else if time.Since(d.lastPrint) >= d.heartbeat {
+				fmt.Fprintf(d.out, "  … still running%s\n", d.describe())
+				d.lastPrint = time.Now()
+			}
+			d.mu.Unlock()
+		}
+	}
+}
+
+// Update shows the current step of the running test
+func (d *Display) Update(status string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.running || status == d.status {
+		return
+	}
+	d.status = status
+	if d.live {
+		d.draw()
+		return
+	}
+	fmt.Fprintf(d.out, "  ⟳ %s (%s elapsed)\n", status, d.elapsed())
+	d.lastPrint = time.Now()
+}
+
+// Stop clears the status line of the running test, so its results can be printed
+func (d *Display) Stop() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	if !d.running {
+		d.mu.Unlock()
+		return
+	}
+	d.running = false
+	d.clear()
+	stop, stopped := d.stop, d.stopped
+	d.mu.Unlock()
+
+	close(stop)
+	<-stopped
+}
+
+// Write prints log output above the status line
+func (d *Display) Write(p []byte) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clear()
+	n, err := d.out.Write(p)
+	if d.running && d.live {
+		d.draw()
+	}
+	return n, err
+}
+
+// draw replaces the status line, the lock must be held
+func (d *Display) draw() {
+	line := fmt.Sprintf("%s %s%s", spinner[d.frame%len(spinner)], d.title(), d.describe())
+	fmt.Fprintf(d.out, "\r\033[K%s", line)
+	d.drawn = true
+}
+
+// clear removes the status line, the lock must be held
+func (d *Display) clear() {
+	if d.drawn {
+		fmt.Fprint(d.out, "\r\033[K")
+		d.drawn = false
+	}
+}
+
+// title names the running test and its position in the run
+func (d *Display) title() string {
+	if d.total > 1 {
+		return fmt.Sprintf("[%d/%d] %s", d.index, d.total, d.name)
+	}
+	return d.name
+}
+
+// describe returns the step, elapsed time and percentage of the running test
+func (d *Display) describe() string {
+	parts := []string{}
+	if d.status != "" {
+		parts = append(parts, d.status)
+	}
+	elapsed := d.elapsed()
+	if pct, ok := percent(elapsed, d.expected); ok {
+		parts = append(parts, fmt.Sprintf("%s elapsed, ~%d%%", elapsed, pct))
+	} else {
+		parts = append(parts, fmt.Sprintf("%s elapsed", elapsed))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// elapsed returns the time the running test has taken, rounded to seconds
+func (d *Display) elapsed() time.Duration {
+	return time.Since(d.started).Round(time.Second)
+}
+
+// percent estimates how far along a test is from its expected duration, capped at 99% because a test
+// taking longer than usual isn't done. ok is false when the expected duration is unknown
+func percent(elapsed, expected time.Duration) (int, bool) {
+	if expected <= 0 {
+		return 0, false
+	}
+	return min(int(elapsed*100/expected), 99), true
+}
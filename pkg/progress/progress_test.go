@@ -0,0 +1,107 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a buffer safe for the display's ticker goroutine
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDisplay_Lines(t *testing.T) {
+	out := &syncBuffer{}
+	d := newDisplay(out, false, 3, 20*time.Millisecond)
+
+	d.Start(2, "daytrader", time.Hour)
+	ctx := WithReporter(context.Background(), d)
+	Update(ctx, "task Running")
+	Update(ctx, "task Running")
+	time.Sleep(100 * time.Millisecond)
+	Stop(ctx)
+
+	got := out.String()
+	if strings.Count(got, "⟳ task Running") != 1 {
+		t.Errorf("steps should be printed once when they change:\n%s", got)
+	}
+	if !strings.Contains(got, "… still running (task Running, ") || !strings.Contains(got, "~0%") {
+		t.Errorf("missing heartbeat:\n%s", got)
+	}
+	if strings.Contains(got, "\r") {
+		t.Errorf("lines output contains a status line:\n%s", got)
+	}
+
+	// Nothing is printed once the test stopped
+	Update(ctx, "task Succeeded")
+	if strings.Contains(out.String(), "Succeeded") {
+		t.Errorf("update after Stop was printed:\n%s", out.String())
+	}
+}
+
+func TestDisplay_Live(t *testing.T) {
+	out := &syncBuffer{}
+	d := newDisplay(out, true, 3, time.Minute)
+
+	d.Start(1, "coolstore", 0)
+	d.Update("analyzing")
+	if _, err := d.Write([]byte("level=INFO msg=log\n")); err != nil {
+		t.Fatal(err)
+	}
+	d.Stop()
+
+	got := out.String()
+	if !strings.Contains(got, "[1/3] coolstore (analyzing, 0s elapsed)") {
+		t.Errorf("missing status line:\n%q", got)
+	}
+	// Logs clear the status line, which is drawn again below them
+	if !strings.Contains(got, "\r\033[Klevel=INFO msg=log\n") || !strings.HasSuffix(got, "\r\033[K") {
+		t.Errorf("status line not cleared around logs and on stop:\n%q", got)
+	}
+}
+
+func TestDisplay_Nil(t *testing.T) {
+	var d *Display
+	ctx := WithReporter(context.Background(), d)
+	d.Start(1, "daytrader", 0)
+	Update(ctx, "analyzing")
+	Stop(ctx)
+	// A context without reporter is ignored too
+	Update(context.Background(), "analyzing")
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		elapsed, expected time.Duration
+		want              int
+		wantOK            bool
+	}{
+		{elapsed: 5 * time.Minute, expected: 20 * time.Minute, want: 25, wantOK: true},
+		{elapsed: 30 * time.Minute, expected: 20 * time.Minute, want: 99, wantOK: true},
+		{elapsed: time.Minute, expected: 0, want: 0, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := percent(tt.elapsed, tt.expected)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("percent(%s, %s) = %d, %v, want %d, %v", tt.elapsed, tt.expected, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
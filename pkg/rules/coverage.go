@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Selector selects rules by their labels, e.g. a parsed label selector
+type Selector interface {
+	Evaluate(labels []string) bool
+}
+
+// CoverageWarning reports an expected output that can't be produced by the rules a test runs
+type CoverageWarning struct {
+	RuleSet string
+	RuleID  string
+	Message string
+}
+
+func (w CoverageWarning) String() string {
+	if w.RuleID == "" {
+		return fmt.Sprintf("%s: %s", w.RuleSet, w.Message)
+	}
+	return fmt.Sprintf("%s/%s: %s", w.RuleSet, w.RuleID, w.Message)
+}
+
+// CheckCoverage compares the rules expected to fire or not to match with the rules of the given rulesets
+// that the selector selects. Rules are selected by their labels and the labels of their ruleset, like the
+// analyzer does, konveyor.io/include labels override the selector. Expected rulesets that aren't among the given rulesets, e.g. default rulesets, aren't
+// checked. Warnings are reported for:
+//   - expected rules that aren't selected or aren't defined in their ruleset
+//   - selected rules missing from the expected output, tag-only rules aren't listed in the output
+//   - rulesets with selected rules that aren't in the expected output
+func CheckCoverage(rulesets []*RuleSet, selector Selector, expected []konveyor.RuleSet) []CoverageWarning {
+	expectedByName := make(map[string]konveyor.RuleSet, len(expected))
+	for _, ers := range expected {
+		if _, exists := expectedByName[ers.Name]; !exists {
+			expectedByName[ers.Name] = ers
+		}
+	}
+
+	var warnings []CoverageWarning
+	for _, rs := range rulesets {
+		selected := map[string]Rule{}
+		defined := map[string]bool{}
+		for _, r := range rs.Rules {
+			defined[r.RuleID] = true
+			if selects(selector, rs, r) {
+				selected[r.RuleID] = r
+			}
+		}
+
+		ers, exists := expectedByName[rs.Name]
+		if !exists {
+			if count := countReported(selected); count > 0 {
+				warnings = append(warnings, CoverageWarning{
+					RuleSet: rs.Name,
+					Message: fmt.Sprintf("%d selected rule(s) but the ruleset is not in the expected output", count),
+				})
+			}
+			continue
+		}
+
+		referenced := expectedRuleIDs(ers)
+		for _, id := range slices.Sorted(maps.Keys(referenced)) {
+			switch _, ok := selected[id]; {
+			case !defined[id]:
+				warnings = append(warnings, CoverageWarning{
+					RuleSet: rs.Name,
+					RuleID:  id,
+					Message: fmt.Sprintf("expected in %s but not defined in %s", referenced[id], rs.Path),
+				})
+			case !ok:
+				warnings = append(warnings, CoverageWarning{
+					RuleSet: rs.Name,
+					RuleID:  id,
+					Message: fmt.Sprintf("expected in %s but not selected by the label selector", referenced[id]),
+				})
+			}
+		}
+		for _, id := range slices.Sorted(maps.Keys(selected)) {
+			if _, ok := referenced[id]; ok || selected[id].IsTagOnly() || slices.Contains(ers.Skipped, id) {
+				continue
+			}
+			warnings = append(warnings, CoverageWarning{
+				RuleSet: rs.Name,
+				RuleID:  id,
+				Message: "selected by the label selector but not in the expected output",
+			})
+		}
+	}
+	return warnings
+}
+
+// expectedRuleIDs returns the rules an expected ruleset reports as matched or unmatched, with the list
+// they are in
+func expectedRuleIDs(ers konveyor.RuleSet) map[string]string {
+	ids := map[string]string{}
+	for id := range ers.Violations {
+		ids[id] = "violations"
+	}
+	for id := range ers.Insights {
+		ids[id] = "insights"
+	}
+	for _, id := range ers.Unmatched {
+		ids[id] = "unmatched"
+	}
+	return ids
+}
+
+// countReported counts the rules the analyzer reports in its output, tag-only rules are only reported
+// through their tags
+func countReported(rules map[string]Rule) int {
+	count := 0
+	for _, r := range rules {
+		if !r.IsTagOnly() {
+			count++
+		}
+	}
+	return count
+}
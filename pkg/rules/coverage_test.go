@@ -0,0 +1,159 @@
+package rules
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// anyLabel selects rules having one of its labels
+type anyLabel []string
+
+func (s anyLabel) Evaluate(labels []string) bool {
+	for _, l := range labels {
+		if slices.Contains(s, l) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckCoverage(t *testing.T) {
+	effort := 3
+	rulesets := []*RuleSet{
+		{
+			Name:   "quarkus",
+			Path:   "rules/quarkus",
+			Labels: []string{"konveyor.io/source=java-ee"},
+			Rules: []Rule{
+				{RuleID: "quarkus-00001", Message: "Use Quarkus", Effort: &effort, Labels: []string{"konveyor.io/target=quarkus"}},
+				{RuleID: "quarkus-00002", Message: "Replace JSF", Effort: &effort, Labels: []string{"konveyor.io/target=quarkus"}},
+				{RuleID: "linux-00001", Message: "Avoid Windows paths", Labels: []string{"konveyor.io/target=linux"}},
+				{RuleID: "technology-usage-00001", Tag: []string{"Servlet"}},
+			},
+		},
+	}
+	quarkus := anyLabel{"konveyor.io/target=quarkus"}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		expected []konveyor.RuleSet
+		want     []string
+	}{
+		{
+			name:     "every selected rule expected",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+				Unmatched:  []string{"quarkus-00002"},
+			}},
+		},
+		{
+			name:     "skipped rules are expected",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+				Skipped:    []string{"quarkus-00002"},
+			}},
+		},
+		{
+			name:     "expected rule not selected",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+				Insights:   map[string]konveyor.Violation{"linux-00001": {}},
+				Unmatched:  []string{"quarkus-00002"},
+			}},
+			want: []string{"quarkus/linux-00001: expected in insights but not selected by the label selector"},
+		},
+		{
+			name:     "expected rule not defined",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}, "quarkus-00003": {}},
+				Unmatched:  []string{"quarkus-00002"},
+			}},
+			want: []string{"quarkus/quarkus-00003: expected in violations but not defined in rules/quarkus"},
+		},
+		{
+			name:     "selected rule not expected",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+			}},
+			want: []string{"quarkus/quarkus-00002: selected by the label selector but not in the expected output"},
+		},
+		{
+			name:     "ruleset labels are inherited",
+			selector: anyLabel{"konveyor.io/source=java-ee"},
+			expected: []konveyor.RuleSet{{
+				Name:       "quarkus",
+				Violations: map[string]konveyor.Violation{"quarkus-00001": {}, "quarkus-00002": {}},
+				Insights:   map[string]konveyor.Violation{"linux-00001": {}},
+			}},
+		},
+		{
+			name:     "no selector selects every rule",
+			expected: []konveyor.RuleSet{{Name: "quarkus", Violations: map[string]konveyor.Violation{"quarkus-00001": {}, "quarkus-00002": {}}}},
+			want:     []string{"quarkus/linux-00001: selected by the label selector but not in the expected output"},
+		},
+		{
+			name:     "selected ruleset not expected",
+			selector: quarkus,
+			expected: []konveyor.RuleSet{{Name: "cloud-readiness"}},
+			want:     []string{"quarkus: 2 selected rule(s) but the ruleset is not in the expected output"},
+		},
+		{
+			name:     "only tag rules selected",
+			selector: anyLabel{"none"},
+			expected: []konveyor.RuleSet{{Name: "cloud-readiness"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, w := range CheckCoverage(rulesets, tt.selector, tt.expected) {
+				got = append(got, w.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckCoverage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCoverage_IncludeLabels(t *testing.T) {
+	rulesets := []*RuleSet{{
+		Name: "quarkus",
+		Path: "rules/quarkus",
+		Rules: []Rule{
+			{RuleID: "quarkus-00001", Message: "Use Quarkus", Labels: []string{"konveyor.io/target=quarkus", "konveyor.io/include=never"}},
+			{RuleID: "linux-00001", Message: "Avoid Windows paths", Labels: []string{"konveyor.io/target=linux", "konveyor.io/include=always"}},
+		},
+	}}
+	expected := []konveyor.RuleSet{{
+		Name:       "quarkus",
+		Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+	}}
+
+	var got []string
+	for _, w := range CheckCoverage(rulesets, anyLabel{"konveyor.io/target=quarkus"}, expected) {
+		got = append(got, w.String())
+	}
+	want := []string{
+		"quarkus/quarkus-00001: expected in violations but not selected by the label selector",
+		"quarkus/linux-00001: selected by the label selector but not in the expected output",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckCoverage() = %v, want %v", got, want)
+	}
+}
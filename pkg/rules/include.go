@@ -0,0 +1,161 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// IncludeLabel forces or suppresses a rule regardless of the label selector, e.g. konveyor.io/include=never
+const IncludeLabel = "konveyor.io/include"
+
+// Values of the include label
+const (
+	// IncludeAlways runs the rule whatever the label selector selects
+	IncludeAlways = "always"
+	// IncludeNever never runs the rule, even when the label selector selects it
+	IncludeNever = "never"
+)
+
+// Include returns the include label value of a rule of the ruleset, empty when neither has one
+// The rule's own label wins over the label of its ruleset
+func (rs *RuleSet) Include(r Rule) string {
+	for _, labels := range [][]string{r.Labels, rs.Labels} {
+		for _, l := range labels {
+			if value, ok := strings.CutPrefix(l, IncludeLabel+"="); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// selects reports whether the analyzer runs a rule of the ruleset with the selector, include labels
+// override the selector. A nil selector selects every rule
+func selects(selector Selector, rs *RuleSet, r Rule) bool {
+	switch rs.Include(r) {
+	case IncludeAlways:
+		return true
+	case IncludeNever:
+		return false
+	}
+	return selector == nil || selector.Evaluate(append(append([]string{}, rs.Labels...), r.Labels...))
+}
+
+// IncludeFailure reports a rule whose include label the analyzer didn't honor
+type IncludeFailure struct {
+	RuleSet string
+	RuleID  string
+	Include string
+	// Found is the output list holding the rule, empty when the rule isn't in the output
+	Found   string
+	Message string
+}
+
+func (f IncludeFailure) String() string {
+	return fmt.Sprintf("%s/%s: %s", f.RuleSet, f.RuleID, f.Message)
+}
+
+// IncludeChecker checks that the rules labeled include=always are evaluated and the rules labeled
+// include=never aren't, whatever the label selector. Actual rulesets are added one by one as they are
+// read, before they are filtered, since rulesets without findings still list their unmatched rules
+type IncludeChecker struct {
+	// rules maps ruleset names to their labeled rules by ID
+	rules map[string]map[string]Rule
+	// include maps ruleset names to the include label values of their labeled rules
+	include map[string]map[string]string
+	// found maps ruleset names to the output lists their labeled rules were found in
+	found map[string]map[string]string
+}
+
+// NewIncludeChecker creates a checker for the rules of the rulesets that carry an include label
+func NewIncludeChecker(rulesets []*RuleSet) *IncludeChecker {
+	c := &IncludeChecker{
+		rules:   map[string]map[string]Rule{},
+		include: map[string]map[string]string{},
+		found:   map[string]map[string]string{},
+	}
+	for _, rs := range rulesets {
+		for _, r := range rs.Rules {
+			value := rs.Include(r)
+			if value != IncludeAlways && value != IncludeNever {
+				continue
+			}
+			if c.rules[rs.Name] == nil {
+				c.rules[rs.Name] = map[string]Rule{}
+				c.include[rs.Name] = map[string]string{}
+			}
+			c.rules[rs.Name][r.RuleID] = r
+			c.include[rs.Name][r.RuleID] = value
+		}
+	}
+	return c
+}
+
+// Empty reports whether no rule carries an include label, there is nothing to check then
+func (c *IncludeChecker) Empty() bool {
+	return len(c.rules) == 0
+}
+
+// Add records where the labeled rules of an actual ruleset are. Matched tag-only rules are only
+// reported through their tags
+func (c *IncludeChecker) Add(rs konveyor.RuleSet) {
+	labeled := c.rules[rs.Name]
+	if len(labeled) == 0 {
+		return
+	}
+	if c.found[rs.Name] == nil {
+		c.found[rs.Name] = map[string]string{}
+	}
+	found := c.found[rs.Name]
+	record := func(id, list string) {
+		if _, ok := labeled[id]; ok && found[id] == "" {
+			found[id] = list
+		}
+	}
+	for id := range rs.Violations {
+		record(id, "violations")
+	}
+	for id := range rs.Insights {
+		record(id, "insights")
+	}
+	for _, id := range rs.Unmatched {
+		record(id, "unmatched")
+	}
+	for _, id := range rs.Skipped {
+		record(id, "skipped")
+	}
+	for id, r := range labeled {
+		if r.IsTagOnly() && slices.ContainsFunc(r.Tag, func(tag string) bool { return slices.Contains(rs.Tags, tag) }) {
+			record(id, "tags")
+		}
+	}
+}
+
+// Failures returns the labeled rules the analyzer didn't honor, sorted by ruleset and rule ID. Rules
+// labeled include=always must be evaluated, i.e. be matched or unmatched, rules labeled include=never
+// may at most be skipped
+func (c *IncludeChecker) Failures() []IncludeFailure {
+	var failures []IncludeFailure
+	for _, name := range slices.Sorted(maps.Keys(c.include)) {
+		for _, id := range slices.Sorted(maps.Keys(c.include[name])) {
+			value, list := c.include[name][id], c.found[name][id]
+			f := IncludeFailure{RuleSet: name, RuleID: id, Include: value, Found: list}
+			switch {
+			case value == IncludeAlways && list == "":
+				f.Message = fmt.Sprintf("labeled %s=%s but not in the output", IncludeLabel, value)
+			case value == IncludeAlways && list == "skipped":
+				f.Message = fmt.Sprintf("labeled %s=%s but skipped", IncludeLabel, value)
+			case value == IncludeNever && list != "" && list != "skipped":
+				f.Message = fmt.Sprintf("labeled %s=%s but evaluated, found in %s", IncludeLabel, value, list)
+			default:
+				continue
+			}
+			failures = append(failures, f)
+		}
+	}
+	return failures
+}
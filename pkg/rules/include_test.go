@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestRuleSetInclude(t *testing.T) {
+	tests := []struct {
+		name     string
+		ruleset  []string
+		rule     []string
+		expected string
+	}{
+		{name: "no label"},
+		{name: "rule label", rule: []string{"konveyor.io/target=quarkus", "konveyor.io/include=always"}, expected: IncludeAlways},
+		{name: "ruleset label", ruleset: []string{"konveyor.io/include=never"}, expected: IncludeNever},
+		{name: "rule label wins", ruleset: []string{"konveyor.io/include=never"}, rule: []string{"konveyor.io/include=always"}, expected: IncludeAlways},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &RuleSet{Name: "rules", Labels: tt.ruleset}
+			if got := rs.Include(Rule{RuleID: "rule-00001", Labels: tt.rule}); got != tt.expected {
+				t.Errorf("Include() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIncludeChecker(t *testing.T) {
+	always := []string{"konveyor.io/include=always"}
+	never := []string{"konveyor.io/include=never"}
+	rulesets := []*RuleSet{
+		{
+			Name: "quarkus",
+			Rules: []Rule{
+				{RuleID: "always-matched", Message: "Matched", Labels: always},
+				{RuleID: "always-unmatched", Message: "Unmatched", Labels: always},
+				{RuleID: "always-skipped", Message: "Skipped", Labels: always},
+				{RuleID: "always-missing", Message: "Missing", Labels: always},
+				{RuleID: "always-tag", Tag: []string{"Servlet"}, Labels: always},
+				{RuleID: "never-matched", Message: "Matched", Labels: never},
+				{RuleID: "never-unmatched", Message: "Unmatched", Labels: never},
+				{RuleID: "never-skipped", Message: "Skipped", Labels: never},
+				{RuleID: "never-missing", Message: "Missing", Labels: never},
+				{RuleID: "unlabeled", Message: "Missing"},
+			},
+		},
+		{
+			Name:   "excluded",
+			Labels: never,
+			Rules:  []Rule{{RuleID: "excluded-00001", Message: "Excluded"}},
+		},
+	}
+
+	checker := NewIncludeChecker(rulesets)
+	if checker.Empty() {
+		t.Fatal("Empty() = true, want labeled rules")
+	}
+	checker.Add(konveyor.RuleSet{
+		Name:       "quarkus",
+		Violations: map[string]konveyor.Violation{"always-matched": {}},
+		Insights:   map[string]konveyor.Violation{"never-matched": {}},
+		Unmatched:  []string{"always-unmatched", "never-unmatched"},
+		Skipped:    []string{"always-skipped", "never-skipped"},
+		Tags:       []string{"Servlet"},
+	})
+	// Rules are found in the ruleset they belong to
+	checker.Add(konveyor.RuleSet{
+		Name:       "other",
+		Violations: map[string]konveyor.Violation{"excluded-00001": {}, "always-missing": {}},
+	})
+
+	got := checker.Failures()
+	want := []IncludeFailure{
+		{RuleSet: "quarkus", RuleID: "always-missing", Include: IncludeAlways, Message: "labeled konveyor.io/include=always but not in the output"},
+		{RuleSet: "quarkus", RuleID: "always-skipped", Include: IncludeAlways, Found: "skipped", Message: "labeled konveyor.io/include=always but skipped"},
+		{RuleSet: "quarkus", RuleID: "never-matched", Include: IncludeNever, Found: "insights", Message: "labeled konveyor.io/include=never but evaluated, found in insights"},
+		{RuleSet: "quarkus", RuleID: "never-unmatched", Include: IncludeNever, Found: "unmatched", Message: "labeled konveyor.io/include=never but evaluated, found in unmatched"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Failures() = %+v, want %+v", got, want)
+	}
+
+	if !NewIncludeChecker([]*RuleSet{{Name: "plain", Rules: []Rule{{RuleID: "plain-00001"}}}}).Empty() {
+		t.Error("Empty() = false, want true without include labels")
+	}
+}
@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSetFileName is the name of the file describing a ruleset in a rules directory
+const RuleSetFileName = "ruleset.yaml"
+
+// Rule contains the metadata of an analyzer rule that the harness cares about
+// The "when" condition is intentionally not parsed, the harness never evaluates rules
+type Rule struct {
+	RuleID      string             `yaml:"ruleID"`
+	Description string             `yaml:"description,omitempty"`
+	Message     string             `yaml:"message,omitempty"`
+	Category    *konveyor.Category `yaml:"category,omitempty"`
+	Effort      *int               `yaml:"effort,omitempty"`
+	Labels      []string           `yaml:"labels,omitempty"`
+	Links       []konveyor.Link    `yaml:"links,omitempty"`
+	Tag         []string           `yaml:"tag,omitempty"`
+}
+
+// IsTagOnly returns true if the rule only generates tags and no incidents
+func (r Rule) IsTagOnly() bool {
+	return len(r.Tag) > 0 && r.Message == ""
+}
+
+// IsInsight returns true if the rule produces an insight rather than a violation
+// The analyzer reports rules without effort as insights
+func (r Rule) IsInsight() bool {
+	return r.Effort == nil || *r.Effort == 0
+}
+
+// RuleSet is a set of rules loaded from a rules file or directory
+type RuleSet struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Labels      []string `yaml:"labels,omitempty"`
+	Rules       []Rule   `yaml:"-"`
+
+	// Path is the file or directory the ruleset was loaded from
+	Path string `yaml:"-"`
+}
+
+// LabelSelector builds a label selector matching the source and target
+// technologies referenced by the ruleset and its rules
+func (rs *RuleSet) LabelSelector() string {
+	seen := map[string]bool{}
+	var labels []string
+	add := func(label string) {
+		if !strings.HasPrefix(label, konveyor.SourceTechnologyLabel) &&
+			!strings.HasPrefix(label, konveyor.TargetTechnologyLabel) {
+			return
+		}
+		if !seen[label] {
+			seen[label] = true
+			labels = append(labels, label)
+		}
+	}
+	for _, l := range rs.Labels {
+		add(l)
+	}
+	for _, r := range rs.Rules {
+		for _, l := range r.Labels {
+			add(l)
+		}
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, " || ")
+}
+
+// Load reads rulesets from a rules file or a directory of rules
+// Every directory containing rule files becomes one ruleset, named after its
+// ruleset.yaml when present, otherwise after the directory or file itself
+func Load(path string) ([]*RuleSet, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat rules path: %w", err)
+	}
+
+	if !info.IsDir() {
+		rs, err := newRuleSet(filepath.Dir(path), strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		if err != nil {
+			return nil, err
+		}
+		rules, err := loadRulesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rs.Rules = rules
+		rs.Path = path
+		return []*RuleSet{rs}, nil
+	}
+
+	byDir := map[string]*RuleSet{}
+	var dirs []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isYAMLFile(p) || filepath.Base(p) == RuleSetFileName {
+			return nil
+		}
+		dir := filepath.Dir(p)
+		rs, ok := byDir[dir]
+		if !ok {
+			rs, err = newRuleSet(dir, filepath.Base(dir))
+			if err != nil {
+				return err
+			}
+			rs.Path = dir
+			byDir[dir] = rs
+			dirs = append(dirs, dir)
+		}
+		rules, err := loadRulesFile(p)
+		if err != nil {
+			return err
+		}
+		rs.Rules = append(rs.Rules, rules...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rulesets := make([]*RuleSet, 0, len(dirs))
+	for _, dir := range dirs {
+		if len(byDir[dir].Rules) > 0 {
+			rulesets = append(rulesets, byDir[dir])
+		}
+	}
+	return rulesets, nil
+}
+
+// newRuleSet reads the ruleset.yaml in dir if present, falling back to defaultName
+func newRuleSet(dir, defaultName string) (*RuleSet, error) {
+	rs := &RuleSet{}
+	data, err := os.ReadFile(filepath.Join(dir, RuleSetFileName))
+	if err == nil {
+		if err := yaml.Unmarshal(data, rs); err != nil {
+			return nil, fmt.Errorf("failed to parse %s in %s: %w", RuleSetFileName, dir, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s in %s: %w", RuleSetFileName, dir, err)
+	}
+	if rs.Name == "" {
+		rs.Name = defaultName
+	}
+	return rs, nil
+}
+
+// loadRulesFile parses a YAML file containing a list of rules
+func loadRulesFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file %s: %w", path, err)
+	}
+
+	var rules []Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file %s: %w", path, err)
+	}
+
+	// Skip entries that are not rules
+	valid := make([]Rule, 0, len(rules))
+	for _, r := range rules {
+		if r.RuleID != "" {
+			valid = append(valid, r)
+		}
+	}
+	return valid, nil
+}
+
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
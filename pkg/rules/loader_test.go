@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testRules = `- ruleID: session-00001
+  description: Avoid use of HttpSession
+  message: HttpSession is not recommended
+  category: mandatory
+  effort: 3
+  labels:
+  - konveyor.io/source=java-ee
+  - konveyor.io/target=quarkus
+  when:
+    java.referenced:
+      pattern: javax.servlet.http.HttpSession
+- ruleID: technology-usage-00001
+  tag:
+  - Servlet
+  when:
+    java.referenced:
+      pattern: javax.servlet*
+- ruleID: info-00001
+  message: Informational
+  labels:
+  - konveyor.io/target=cloud-readiness
+  when:
+    builtin.file:
+      pattern: "*.properties"
+`
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestLoad_SingleFile(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "my-rules.yaml")
+	writeFile(t, rulesFile, testRules)
+
+	rulesets, err := Load(rulesFile)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(rulesets) != 1 {
+		t.Fatalf("expected 1 ruleset, got %d", len(rulesets))
+	}
+
+	rs := rulesets[0]
+	if rs.Name != "my-rules" {
+		t.Errorf("expected ruleset name 'my-rules', got '%s'", rs.Name)
+	}
+	if len(rs.Rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rs.Rules))
+	}
+	if rs.Rules[0].IsInsight() || rs.Rules[0].IsTagOnly() {
+		t.Error("expected session-00001 to be a violation")
+	}
+	if !rs.Rules[1].IsTagOnly() {
+		t.Error("expected technology-usage-00001 to be tag only")
+	}
+	if !rs.Rules[2].IsInsight() {
+		t.Error("expected info-00001 to be an insight")
+	}
+
+	want := "konveyor.io/source=java-ee || konveyor.io/target=cloud-readiness || konveyor.io/target=quarkus"
+	if got := rs.LabelSelector(); got != want {
+		t.Errorf("LabelSelector() = %q, want %q", got, want)
+	}
+}
+
+func TestLoad_Directory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "eap", "ruleset.yaml"), "name: eap8/eap7\ndescription: EAP rules\nlabels:\n- konveyor.io/target=eap8\n")
+	writeFile(t, filepath.Join(dir, "eap", "rules-1.yaml"), testRules)
+	writeFile(t, filepath.Join(dir, "eap", "rules-2.yaml"), "- ruleID: eap-00001\n  message: test\n  effort: 1\n")
+	writeFile(t, filepath.Join(dir, "other", "rules.yaml"), "- ruleID: other-00001\n  message: test\n  effort: 1\n")
+	writeFile(t, filepath.Join(dir, "empty", "ruleset.yaml"), "name: empty\n")
+
+	rulesets, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(rulesets) != 2 {
+		t.Fatalf("expected 2 rulesets, got %d", len(rulesets))
+	}
+
+	if rulesets[0].Name != "eap8/eap7" {
+		t.Errorf("expected ruleset name from ruleset.yaml, got '%s'", rulesets[0].Name)
+	}
+	if len(rulesets[0].Rules) != 4 {
+		t.Errorf("expected 4 rules in eap ruleset, got %d", len(rulesets[0].Rules))
+	}
+	if rulesets[1].Name != "other" {
+		t.Errorf("expected ruleset name from directory, got '%s'", rulesets[1].Name)
+	}
+}
+
+func TestLoad_NotFound(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing rules path")
+	}
+}
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"slices"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Triggered records the rules that fired in the validated outputs of a suite, by ruleset name
+type Triggered struct {
+	rules map[string]map[string]bool
+	tags  map[string]map[string]bool
+}
+
+// NewTriggered creates an empty record of triggered rules
+func NewTriggered() *Triggered {
+	return &Triggered{
+		rules: map[string]map[string]bool{},
+		tags:  map[string]map[string]bool{},
+	}
+}
+
+// Add records the violations, insights and tags of a validated ruleset
+func (t *Triggered) Add(rs konveyor.RuleSet) {
+	add := func(m map[string]map[string]bool, key string) {
+		if m[rs.Name] == nil {
+			m[rs.Name] = map[string]bool{}
+		}
+		m[rs.Name][key] = true
+	}
+	for id := range rs.Violations {
+		add(t.rules, id)
+	}
+	for id := range rs.Insights {
+		add(t.rules, id)
+	}
+	for _, tag := range rs.Tags {
+		add(t.tags, tag)
+	}
+}
+
+// covers reports whether a rule of a ruleset fired, tag-only rules fired when one of their tags was added
+func (t *Triggered) covers(ruleset string, r Rule) bool {
+	if t.rules[ruleset][r.RuleID] {
+		return true
+	}
+	if r.IsTagOnly() {
+		for _, tag := range r.Tag {
+			if t.tags[ruleset][tag] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RuleSetCoverage is the share of a ruleset's rules that fired in at least one validated output
+type RuleSetCoverage struct {
+	Name      string   `json:"name"`
+	Path      string   `json:"path"`
+	Rules     int      `json:"rules"`
+	Covered   []string `json:"covered"`
+	Uncovered []string `json:"uncovered"`
+}
+
+// Percent returns the percentage of the ruleset's rules that are covered
+func (c RuleSetCoverage) Percent() float64 {
+	if c.Rules == 0 {
+		return 0
+	}
+	return float64(len(c.Covered)) * 100 / float64(c.Rules)
+}
+
+// Coverage reports which rules of each ruleset were triggered, rule IDs are sorted
+func Coverage(rulesets []*RuleSet, triggered *Triggered) []RuleSetCoverage {
+	report := make([]RuleSetCoverage, 0, len(rulesets))
+	for _, rs := range rulesets {
+		c := RuleSetCoverage{Name: rs.Name, Path: rs.Path, Rules: len(rs.Rules), Covered: []string{}, Uncovered: []string{}}
+		for _, r := range rs.Rules {
+			if triggered.covers(rs.Name, r) {
+				c.Covered = append(c.Covered, r.RuleID)
+			} else {
+				c.Uncovered = append(c.Uncovered, r.RuleID)
+			}
+		}
+		slices.Sort(c.Covered)
+		slices.Sort(c.Uncovered)
+		report = append(report, c)
+	}
+	return report
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestCoverage(t *testing.T) {
+	effort := 1
+	rulesets := []*RuleSet{
+		{
+			Name: "quarkus",
+			Path: "rules/quarkus",
+			Rules: []Rule{
+				{RuleID: "quarkus-00002", Message: "Replace JSF", Effort: &effort},
+				{RuleID: "quarkus-00001", Message: "Use Quarkus", Effort: &effort},
+				{RuleID: "info-00001", Message: "Informational"},
+				{RuleID: "technology-usage-00001", Tag: []string{"Servlet"}},
+			},
+		},
+		{
+			Name:  "linux",
+			Path:  "rules/linux",
+			Rules: []Rule{{RuleID: "linux-00001", Message: "Avoid Windows paths"}},
+		},
+	}
+
+	triggered := NewTriggered()
+	triggered.Add(konveyor.RuleSet{
+		Name:       "quarkus",
+		Violations: map[string]konveyor.Violation{"quarkus-00001": {}},
+		Unmatched:  []string{"quarkus-00002"},
+	})
+	triggered.Add(konveyor.RuleSet{
+		Name:     "quarkus",
+		Insights: map[string]konveyor.Violation{"info-00001": {}},
+		Tags:     []string{"Servlet"},
+	})
+	// Rules are covered by the ruleset they belong to
+	triggered.Add(konveyor.RuleSet{
+		Name:       "other",
+		Violations: map[string]konveyor.Violation{"linux-00001": {}},
+	})
+
+	got := Coverage(rulesets, triggered)
+	want := []RuleSetCoverage{
+		{
+			Name:      "quarkus",
+			Path:      "rules/quarkus",
+			Rules:     4,
+			Covered:   []string{"info-00001", "quarkus-00001", "technology-usage-00001"},
+			Uncovered: []string{"quarkus-00002"},
+		},
+		{
+			Name:      "linux",
+			Path:      "rules/linux",
+			Rules:     1,
+			Covered:   []string{},
+			Uncovered: []string{"linux-00001"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Coverage() = %+v, want %+v", got, want)
+	}
+	if p := got[0].Percent(); p != 75 {
+		t.Errorf("Percent() = %v, want 75", p)
+	}
+	if p := got[1].Percent(); p != 0 {
+		t.Errorf("Percent() = %v, want 0", p)
+	}
+}
@@ -0,0 +1,209 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/tackle2-hub/binding"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// Seeded records the resources a seed created in a Hub, so only they are removed after the tests
+type Seeded struct {
+	client *binding.RichClient
+
+	tagCategories    []uint
+	tags             []uint
+	stakeholders     []uint
+	businessServices []uint
+	archetypes       []uint
+	migrationWaves   []uint
+}
+
+// Apply creates the resources of a seed in a Hub. Tag categories and tags the Hub already has are
+// reused, any other resource that already exists fails the seed, e.g. left by an interrupted run. The
+// resources created before a failure are removed
+func Apply(client *binding.RichClient, s *Seed) (*Seeded, error) {
+	seeded := &Seeded{client: client}
+	if err := seeded.apply(s); err != nil {
+		if removeErr := seeded.Remove(); removeErr != nil {
+			err = errors.Join(err, removeErr)
+		}
+		return nil, err
+	}
+	return seeded, nil
+}
+
+func (sd *Seeded) apply(s *Seed) error {
+	log := util.GetLogger()
+
+	if err := sd.applyTags(s.TagCategories); err != nil {
+		return err
+	}
+	tags, err := sd.client.Tag.List()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	existing, err := sd.client.Stakeholder.List()
+	if err != nil {
+		return fmt.Errorf("failed to list stakeholders: %w", err)
+	}
+	stakeholders := map[string]api.Ref{}
+	for _, sh := range existing {
+		stakeholders[sh.Email] = api.Ref{ID: sh.ID, Name: sh.Name}
+	}
+	for _, sh := range s.Stakeholders {
+		if _, ok := stakeholders[sh.Email]; ok {
+			return fmt.Errorf("stakeholder %s already exists in the Hub", sh.Email)
+		}
+		created := &api.Stakeholder{Name: sh.Name, Email: sh.Email}
+		if err := sd.client.Stakeholder.Create(created); err != nil {
+			return fmt.Errorf("failed to create stakeholder %s: %w", sh.Email, err)
+		}
+		sd.stakeholders = append(sd.stakeholders, created.ID)
+		stakeholders[sh.Email] = api.Ref{ID: created.ID, Name: created.Name}
+	}
+	stakeholderRef := func(email string) (api.Ref, error) {
+		ref, ok := stakeholders[email]
+		if !ok {
+			return ref, fmt.Errorf("stakeholder %s not found", email)
+		}
+		return ref, nil
+	}
+
+	for _, bs := range s.BusinessServices {
+		created := &api.BusinessService{Name: bs.Name, Description: bs.Description}
+		if bs.Owner != "" {
+			owner, err := stakeholderRef(bs.Owner)
+			if err != nil {
+				return fmt.Errorf("business service %s: %w", bs.Name, err)
+			}
+			created.Stakeholder = &owner
+		}
+		if err := sd.client.BusinessService.Create(created); err != nil {
+			return fmt.Errorf("failed to create business service %s: %w", bs.Name, err)
+		}
+		sd.businessServices = append(sd.businessServices, created.ID)
+	}
+
+	for _, a := range s.Archetypes {
+		created := &api.Archetype{Name: a.Name, Description: a.Description}
+		if created.Criteria, err = tagRefs(tags, a.Criteria); err != nil {
+			return fmt.Errorf("archetype %s: %w", a.Name, err)
+		}
+		if created.Tags, err = tagRefs(tags, a.Tags); err != nil {
+			return fmt.Errorf("archetype %s: %w", a.Name, err)
+		}
+		for _, email := range a.Stakeholders {
+			ref, err := stakeholderRef(email)
+			if err != nil {
+				return fmt.Errorf("archetype %s: %w", a.Name, err)
+			}
+			created.Stakeholders = append(created.Stakeholders, ref)
+		}
+		if err := sd.client.Archetype.Create(created); err != nil {
+			return fmt.Errorf("failed to create archetype %s: %w", a.Name, err)
+		}
+		sd.archetypes = append(sd.archetypes, created.ID)
+	}
+
+	for _, w := range s.MigrationWaves {
+		// Dates were checked when the seed was loaded
+		start, end, _ := w.Dates()
+		created := &api.MigrationWave{Name: w.Name, StartDate: start, EndDate: end}
+		if err := sd.client.MigrationWave.Create(created); err != nil {
+			return fmt.Errorf("failed to create migration wave %s: %w", w.Name, err)
+		}
+		sd.migrationWaves = append(sd.migrationWaves, created.ID)
+	}
+
+	log.Info("Seeded Hub", "tagCategories", len(sd.tagCategories), "tags", len(sd.tags), "stakeholders", len(sd.stakeholders),
+		"businessServices", len(sd.businessServices), "archetypes", len(sd.archetypes), "migrationWaves", len(sd.migrationWaves))
+	return nil
+}
+
+// applyTags creates the tag categories and tags of the seed the Hub doesn't have
+func (sd *Seeded) applyTags(categories []TagCategory) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	existing, err := sd.client.TagCategory.List()
+	if err != nil {
+		return fmt.Errorf("failed to list tag categories: %w", err)
+	}
+	tags, err := sd.client.Tag.List()
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	for _, c := range categories {
+		i := slices.IndexFunc(existing, func(e api.TagCategory) bool { return e.Name == c.Name })
+		var category api.TagCategory
+		if i >= 0 {
+			category = existing[i]
+		} else {
+			category = api.TagCategory{Name: c.Name, Color: c.Color}
+			if err := sd.client.TagCategory.Create(&category); err != nil {
+				return fmt.Errorf("failed to create tag category %s: %w", c.Name, err)
+			}
+			sd.tagCategories = append(sd.tagCategories, category.ID)
+		}
+
+		for _, name := range c.Tags {
+			if _, ok := findTag(tags, TagRef{Category: c.Name, Tag: name}); ok {
+				continue
+			}
+			tag := &api.Tag{Name: name, Category: api.Ref{ID: category.ID, Name: category.Name}}
+			if err := sd.client.Tag.Create(tag); err != nil {
+				return fmt.Errorf("failed to create tag %s/%s: %w", c.Name, name, err)
+			}
+			sd.tags = append(sd.tags, tag.ID)
+		}
+	}
+	return nil
+}
+
+// findTag returns the tag a reference refers to
+func findTag(tags []api.Tag, ref TagRef) (api.Tag, bool) {
+	i := slices.IndexFunc(tags, func(t api.Tag) bool { return t.Name == ref.Tag && t.Category.Name == ref.Category })
+	if i < 0 {
+		return api.Tag{}, false
+	}
+	return tags[i], true
+}
+
+// tagRefs resolves tag references to the Hub's tags
+func tagRefs(tags []api.Tag, refs []TagRef) ([]api.TagRef, error) {
+	var resolved []api.TagRef
+	for _, ref := range refs {
+		tag, ok := findTag(tags, ref)
+		if !ok {
+			return nil, fmt.Errorf("tag %s not found", ref)
+		}
+		resolved = append(resolved, api.TagRef{ID: tag.ID, Name: tag.Name})
+	}
+	return resolved, nil
+}
+
+// Remove deletes the resources the seed created, the ones referring to others first. It goes on after a
+// failure and returns all errors
+func (sd *Seeded) Remove() error {
+	var errs []error
+	remove := func(kind string, ids []uint, del func(uint) error) {
+		for _, id := range slices.Backward(ids) {
+			if err := del(id); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete %s %d: %w", kind, id, err))
+			}
+		}
+	}
+	remove("migration wave", sd.migrationWaves, sd.client.MigrationWave.Delete)
+	remove("archetype", sd.archetypes, sd.client.Archetype.Delete)
+	remove("business service", sd.businessServices, sd.client.BusinessService.Delete)
+	remove("stakeholder", sd.stakeholders, sd.client.Stakeholder.Delete)
+	remove("tag", sd.tags, sd.client.Tag.Delete)
+	remove("tag category", sd.tagCategories, sd.client.TagCategory.Delete)
+	return errors.Join(errs...)
+}
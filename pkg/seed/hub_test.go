@@ -0,0 +1,29 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/konveyor/tackle2-hub/api"
+)
+
+func TestTagRefs(t *testing.T) {
+	tags := []api.Tag{
+		{Resource: api.Resource{ID: 1}, Name: "Java", Category: api.Ref{ID: 10, Name: "Language"}},
+		{Resource: api.Resource{ID: 2}, Name: "Java", Category: api.Ref{ID: 11, Name: "Runtime"}},
+		{Resource: api.Resource{ID: 3}, Name: "Spring Boot", Category: api.Ref{ID: 12, Name: "Framework"}},
+	}
+
+	got, err := tagRefs(tags, []TagRef{{Category: "Runtime", Tag: "Java"}, {Category: "Framework", Tag: "Spring Boot"}})
+	if err != nil {
+		t.Fatalf("tagRefs() error = %v", err)
+	}
+	want := []api.TagRef{{ID: 2, Name: "Java"}, {ID: 3, Name: "Spring Boot"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tagRefs() = %+v, want %+v", got, want)
+	}
+
+	if _, err := tagRefs(tags, []TagRef{{Category: "Framework", Tag: "Quarkus"}}); err == nil {
+		t.Error("tagRefs() resolved a missing tag")
+	}
+}
@@ -0,0 +1,175 @@
+package seed
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Seed is the content of a seed file, created in a Hub before the tests and removed after them
+type Seed struct {
+	TagCategories    []TagCategory     `yaml:"tagCategories,omitempty"`
+	Stakeholders     []Stakeholder     `yaml:"stakeholders,omitempty"`
+	BusinessServices []BusinessService `yaml:"businessServices,omitempty"`
+	Archetypes       []Archetype       `yaml:"archetypes,omitempty"`
+	MigrationWaves   []MigrationWave   `yaml:"migrationWaves,omitempty"`
+}
+
+// TagCategory is a tag category and its tags. Categories the Hub already has, e.g. Language, get the
+// missing tags only
+type TagCategory struct {
+	Name  string   `yaml:"name"`
+	Color string   `yaml:"color,omitempty"`
+	Tags  []string `yaml:"tags,omitempty"`
+}
+
+// TagRef refers to a tag of the seed or of the Hub by category and name
+type TagRef struct {
+	Category string `yaml:"category"`
+	Tag      string `yaml:"tag"`
+}
+
+func (r TagRef) String() string {
+	return fmt.Sprintf("%s/%s", r.Category, r.Tag)
+}
+
+// Stakeholder is a person owning business services and archetypes, referred to by email
+type Stakeholder struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+// BusinessService groups applications serving a business function
+type BusinessService struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Owner is the email of a stakeholder of the seed or of the Hub (optional)
+	Owner string `yaml:"owner,omitempty"`
+}
+
+// Archetype groups the applications whose tags include all of its criteria, e.g. after an analysis
+// tagged them
+type Archetype struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Criteria    []TagRef `yaml:"criteria"`
+	// Tags are added to the applications of the archetype (optional)
+	Tags []TagRef `yaml:"tags,omitempty"`
+	// Stakeholders are emails of stakeholders of the seed or of the Hub (optional)
+	Stakeholders []string `yaml:"stakeholders,omitempty"`
+}
+
+// MigrationWave schedules the migration of applications, dates are YYYY-MM-DD
+type MigrationWave struct {
+	Name      string `yaml:"name"`
+	StartDate string `yaml:"startDate"`
+	EndDate   string `yaml:"endDate"`
+}
+
+// Dates returns the parsed start and end dates of the wave
+func (w MigrationWave) Dates() (start, end time.Time, err error) {
+	if start, err = time.Parse(time.DateOnly, w.StartDate); err != nil {
+		return start, end, fmt.Errorf("invalid start date %q, expected YYYY-MM-DD", w.StartDate)
+	}
+	if end, err = time.Parse(time.DateOnly, w.EndDate); err != nil {
+		return start, end, fmt.Errorf("invalid end date %q, expected YYYY-MM-DD", w.EndDate)
+	}
+	return start, end, nil
+}
+
+// Load reads and validates a seed file
+func Load(path string) (*Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+
+	var s Seed
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file %s: %w", path, err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid seed file %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Validate checks that every resource has a unique name and its required fields. References to tags and
+// stakeholders the Hub may already have are resolved when the seed is applied
+func (s *Seed) Validate() error {
+	unique := func(kind string, names []string) error {
+		seen := map[string]bool{}
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("%s must have a name", kind)
+			}
+			if seen[name] {
+				return fmt.Errorf("duplicate %s %q", kind, name)
+			}
+			seen[name] = true
+		}
+		return nil
+	}
+
+	var categories, stakeholders, services, archetypes, waves []string
+	for _, c := range s.TagCategories {
+		categories = append(categories, c.Name)
+		if err := unique(fmt.Sprintf("tag of category %q", c.Name), c.Tags); err != nil {
+			return err
+		}
+	}
+	for _, sh := range s.Stakeholders {
+		if sh.Email == "" {
+			return fmt.Errorf("stakeholder %q must have an email", sh.Name)
+		}
+		if sh.Name == "" {
+			return fmt.Errorf("stakeholder %q must have a name", sh.Email)
+		}
+		stakeholders = append(stakeholders, sh.Email)
+	}
+	for _, bs := range s.BusinessServices {
+		services = append(services, bs.Name)
+	}
+	for _, a := range s.Archetypes {
+		archetypes = append(archetypes, a.Name)
+		if len(a.Criteria) == 0 {
+			return fmt.Errorf("archetype %q must have criteria", a.Name)
+		}
+		for _, ref := range append(append([]TagRef{}, a.Criteria...), a.Tags...) {
+			if ref.Category == "" || ref.Tag == "" {
+				return fmt.Errorf("tags of archetype %q must have a category and a tag", a.Name)
+			}
+		}
+	}
+	for _, w := range s.MigrationWaves {
+		waves = append(waves, w.Name)
+		start, end, err := w.Dates()
+		if err != nil {
+			return fmt.Errorf("migration wave %q: %w", w.Name, err)
+		}
+		if end.Before(start) {
+			return fmt.Errorf("migration wave %q ends before it starts", w.Name)
+		}
+	}
+
+	for _, kind := range []struct {
+		name  string
+		names []string
+	}{
+		{"tag category", categories},
+		{"stakeholder email", stakeholders},
+		{"business service", services},
+		{"archetype", archetypes},
+		{"migration wave", waves},
+	} {
+		if err := unique(kind.name, kind.names); err != nil {
+			return err
+		}
+	}
+	return nil
+}
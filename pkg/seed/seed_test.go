@@ -0,0 +1,66 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load(filepath.Join("testdata", "seed.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(s.TagCategories) != 1 || len(s.TagCategories[0].Tags) != 2 || len(s.Stakeholders) != 1 ||
+		len(s.BusinessServices) != 1 || len(s.Archetypes) != 1 || len(s.MigrationWaves) != 1 {
+		t.Fatalf("Load() = %+v, want every resource of the seed", s)
+	}
+	if got := s.Archetypes[0].Criteria[0].String(); got != "Framework/Spring Boot" {
+		t.Errorf("criteria = %s, want Framework/Spring Boot", got)
+	}
+
+	unknown := filepath.Join(t.TempDir(), "seed.yaml")
+	if err := os.WriteFile(unknown, []byte("applications:\n  - name: app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(unknown); err == nil {
+		t.Error("Load() accepted an unknown field")
+	}
+}
+
+func TestSeedValidate(t *testing.T) {
+	criteria := []TagRef{{Category: "Language", Tag: "Java"}}
+	wave := MigrationWave{Name: "Wave 1", StartDate: "2026-01-05", EndDate: "2026-03-31"}
+
+	tests := []struct {
+		name    string
+		seed    Seed
+		wantErr string
+	}{
+		{name: "valid", seed: Seed{Archetypes: []Archetype{{Name: "Java", Criteria: criteria}}, MigrationWaves: []MigrationWave{wave}}},
+		{name: "duplicate tag", seed: Seed{TagCategories: []TagCategory{{Name: "Framework", Tags: []string{"Quarkus", "Quarkus"}}}}, wantErr: `duplicate tag of category "Framework"`},
+		{name: "unnamed business service", seed: Seed{BusinessServices: []BusinessService{{Description: "Payments"}}}, wantErr: "business service must have a name"},
+		{name: "stakeholder without email", seed: Seed{Stakeholders: []Stakeholder{{Name: "Jane"}}}, wantErr: "must have an email"},
+		{name: "duplicate stakeholder", seed: Seed{Stakeholders: []Stakeholder{{Name: "Jane", Email: "j@example.com"}, {Name: "John", Email: "j@example.com"}}}, wantErr: "duplicate stakeholder email"},
+		{name: "archetype without criteria", seed: Seed{Archetypes: []Archetype{{Name: "Java"}}}, wantErr: "must have criteria"},
+		{name: "incomplete tag", seed: Seed{Archetypes: []Archetype{{Name: "Java", Criteria: []TagRef{{Tag: "Java"}}}}}, wantErr: "must have a category and a tag"},
+		{name: "invalid date", seed: Seed{MigrationWaves: []MigrationWave{{Name: "Wave 1", StartDate: "01/05/2026", EndDate: "2026-03-31"}}}, wantErr: "invalid start date"},
+		{name: "wave ending before it starts", seed: Seed{MigrationWaves: []MigrationWave{{Name: "Wave 1", StartDate: "2026-03-31", EndDate: "2026-01-05"}}}, wantErr: "ends before it starts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.seed.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
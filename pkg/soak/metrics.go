@@ -0,0 +1,47 @@
+package soak
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// residentMemoryMetric is the resident memory of a process exposed by Prometheus clients, e.g. the Hub's
+const residentMemoryMetric = "process_resident_memory_bytes"
+
+// ScrapeMemory returns the resident memory in bytes of the process exposing Prometheus metrics at the URL
+func ScrapeMemory(ctx context.Context, metricsURL string) (uint64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to get metrics from %s: %s", metricsURL, resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		// Samples are "name value" or "name value timestamp", comments start with #
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != residentMemoryMetric {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s value %q: %w", residentMemoryMetric, fields[1], err)
+		}
+		return uint64(value), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read metrics: %w", err)
+	}
+	return 0, fmt.Errorf("%s not found in the metrics at %s", residentMemoryMetric, metricsURL)
+}
@@ -0,0 +1,155 @@
+package soak
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+)
+
+// Schedule repeats a suite every Interval until Duration has passed since the first iteration started
+type Schedule struct {
+	Interval time.Duration
+	Duration time.Duration
+}
+
+// Next returns when the iteration following the one started at previous starts: an interval later, or
+// right away when that iteration ran longer than the interval
+func (s Schedule) Next(previous, now time.Time) time.Time {
+	next := previous.Add(s.Interval)
+	if next.Before(now) {
+		return now
+	}
+	return next
+}
+
+// End returns when the soak started at start ends, no iteration starts at or after it
+func (s Schedule) End(start time.Time) time.Time {
+	return start.Add(s.Duration)
+}
+
+// Iteration records one run of the suite
+type Iteration struct {
+	Index    int                  `json:"index"`
+	Start    time.Time            `json:"start"`
+	Duration time.Duration        `json:"duration"`
+	Results  []history.TestResult `json:"results"`
+	// PeakMemory is the largest peak memory in bytes of the analyses run locally, e.g. kantra, 0 when unknown
+	PeakMemory uint64 `json:"peakMemory,omitempty"`
+	// HubMemory is the resident memory in bytes of the Hub after the iteration, 0 when it isn't scraped
+	HubMemory uint64 `json:"hubMemory,omitempty"`
+}
+
+// succeeded counts the tests of the iteration that ran and the ones that succeeded. Skipped tests
+// didn't run, expected failures succeed as long as they fail
+func (it *Iteration) succeeded() (succeeded, ran int) {
+	for _, result := range it.Results {
+		switch result.Outcome {
+		case history.OutcomeSkipped:
+			continue
+		case history.OutcomePassed, history.OutcomeExpectedFailure:
+			succeeded++
+		}
+		ran++
+	}
+	return succeeded, ran
+}
+
+// SuccessRate returns the share of the tests of the iteration that succeeded, 1 when none ran
+func (it *Iteration) SuccessRate() float64 {
+	succeeded, ran := it.succeeded()
+	if ran == 0 {
+		return 1
+	}
+	return float64(succeeded) / float64(ran)
+}
+
+// Report holds the iterations of a soak
+type Report struct {
+	Start      time.Time   `json:"start"`
+	Iterations []Iteration `json:"iterations"`
+}
+
+// SuccessRate returns the share of the tests run over all iterations that succeeded, 1 when none ran
+func (r *Report) SuccessRate() float64 {
+	succeeded, ran := 0, 0
+	for i := range r.Iterations {
+		s, n := r.Iterations[i].succeeded()
+		succeeded += s
+		ran += n
+	}
+	if ran == 0 {
+		return 1
+	}
+	return float64(succeeded) / float64(ran)
+}
+
+// Drift compares a measure of the first iteration to the last one that has it
+type Drift struct {
+	Measure string  `json:"measure"`
+	First   float64 `json:"first"`
+	Last    float64 `json:"last"`
+	// Memory is set when the measure is in bytes, durations are in seconds
+	Memory bool `json:"memory,omitempty"`
+}
+
+// Change returns the relative change from the first to the last value, e.g. 0.25 for a 25% growth
+func (d Drift) Change() float64 {
+	if d.First == 0 {
+		return 0
+	}
+	return (d.Last - d.First) / d.First
+}
+
+func (d Drift) String() string {
+	format := func(v float64) string {
+		if d.Memory {
+			return fmt.Sprintf("%.0f MiB", v/(1<<20))
+		}
+		return (time.Duration(v * float64(time.Second))).Round(time.Second).String()
+	}
+	return fmt.Sprintf("%s: %s -> %s (%+.0f%%)", d.Measure, format(d.First), format(d.Last), d.Change()*100)
+}
+
+// Drifts compares the durations of the iterations and of each test that ran in them, and the memory of
+// the analyses and the Hub, between the first and the last iteration measuring them. Measures taken in
+// fewer than two iterations have no drift
+func (r *Report) Drifts() []Drift {
+	var drifts []Drift
+	add := func(measure string, memory bool, values []float64) {
+		if len(values) < 2 {
+			return
+		}
+		drifts = append(drifts, Drift{Measure: measure, First: values[0], Last: values[len(values)-1], Memory: memory})
+	}
+
+	var iterations, peak, hub []float64
+	var tests []string
+	durations := map[string][]float64{}
+	for _, it := range r.Iterations {
+		iterations = append(iterations, it.Duration.Seconds())
+		if it.PeakMemory > 0 {
+			peak = append(peak, float64(it.PeakMemory))
+		}
+		if it.HubMemory > 0 {
+			hub = append(hub, float64(it.HubMemory))
+		}
+		for _, result := range it.Results {
+			if result.Outcome == history.OutcomeSkipped {
+				continue
+			}
+			if _, ok := durations[result.Name]; !ok {
+				tests = append(tests, result.Name)
+			}
+			durations[result.Name] = append(durations[result.Name], result.Duration.Seconds())
+		}
+	}
+
+	add("iteration duration", false, iterations)
+	for _, name := range tests {
+		add(name+" duration", false, durations[name])
+	}
+	add("analysis peak memory", true, peak)
+	add("Hub memory", true, hub)
+	return drifts
+}
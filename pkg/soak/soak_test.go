@@ -0,0 +1,116 @@
+package soak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/history"
+)
+
+func TestScheduleNext(t *testing.T) {
+	schedule := Schedule{Interval: 30 * time.Minute, Duration: 2 * time.Hour}
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		finished time.Duration
+		want     time.Duration
+	}{
+		{name: "within the interval", finished: 10 * time.Minute, want: 30 * time.Minute},
+		{name: "longer than the interval", finished: 45 * time.Minute, want: 45 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := schedule.Next(start, start.Add(tt.finished)); !got.Equal(start.Add(tt.want)) {
+				t.Errorf("Next() = %v, want %v", got, start.Add(tt.want))
+			}
+		})
+	}
+	if got := schedule.End(start); !got.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("End() = %v, want 2h after the start", got)
+	}
+}
+
+func TestReport(t *testing.T) {
+	result := func(name string, outcome history.Outcome, seconds int) history.TestResult {
+		return history.TestResult{Name: name, Outcome: outcome, Duration: time.Duration(seconds) * time.Second}
+	}
+	report := &Report{Iterations: []Iteration{
+		{
+			Duration:   100 * time.Second,
+			PeakMemory: 400 << 20,
+			Results: []history.TestResult{
+				result("a", history.OutcomePassed, 60),
+				result("b", history.OutcomeExpectedFailure, 40),
+				result("c", history.OutcomeSkipped, 0),
+			},
+		},
+		{
+			Duration:   150 * time.Second,
+			PeakMemory: 500 << 20,
+			HubMemory:  200 << 20,
+			Results: []history.TestResult{
+				result("a", history.OutcomeFailed, 90),
+				result("b", history.OutcomeError, 60),
+				result("c", history.OutcomePassed, 10),
+			},
+		},
+	}}
+
+	if got := report.Iterations[0].SuccessRate(); got != 1 {
+		t.Errorf("Iterations[0].SuccessRate() = %v, want 1", got)
+	}
+	if got := report.Iterations[1].SuccessRate(); got != 1.0/3 {
+		t.Errorf("Iterations[1].SuccessRate() = %v, want 1/3", got)
+	}
+	if got := report.SuccessRate(); got != 0.6 {
+		t.Errorf("SuccessRate() = %v, want 0.6", got)
+	}
+
+	want := []Drift{
+		{Measure: "iteration duration", First: 100, Last: 150},
+		{Measure: "a duration", First: 60, Last: 90},
+		{Measure: "b duration", First: 40, Last: 60},
+		{Measure: "analysis peak memory", First: 400 << 20, Last: 500 << 20, Memory: true},
+	}
+	got := report.Drifts()
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Drifts() = %+v, want %+v", got, want)
+	}
+	if got[0].Change() != 0.5 || got[0].String() != "iteration duration: 1m40s -> 2m30s (+50%)" {
+		t.Errorf("Change() = %v, String() = %q", got[0].Change(), got[0].String())
+	}
+	if got[3].String() != "analysis peak memory: 400 MiB -> 500 MiB (+25%)" {
+		t.Errorf("String() = %q", got[3].String())
+	}
+}
+
+func TestScrapeMemory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "# HELP process_resident_memory_bytes Resident memory size in bytes.")
+		fmt.Fprintln(w, "# TYPE process_resident_memory_bytes gauge")
+		fmt.Fprintln(w, "process_resident_memory_bytes 1.2345678e+08")
+	}))
+	defer server.Close()
+
+	got, err := ScrapeMemory(context.Background(), server.URL+"/metrics")
+	if err != nil {
+		t.Fatalf("ScrapeMemory() error = %v", err)
+	}
+	if got != 123456780 {
+		t.Errorf("ScrapeMemory() = %d, want 123456780", got)
+	}
+	if _, err := ScrapeMemory(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("ScrapeMemory() of a missing endpoint succeeded")
+	}
+}
@@ -0,0 +1,177 @@
+package targets
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// IsArchiveFile returns true if the path appears to be a source archive (.zip, .tar, .tar.gz or .tgz)
+func IsArchiveFile(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasSuffix(lower, ".zip") ||
+		strings.HasSuffix(lower, ".tar") ||
+		strings.HasSuffix(lower, ".tar.gz") ||
+		strings.HasSuffix(lower, ".tgz")
+}
+
+// ExtractArchive extracts a source archive into workDir/archive and returns the directory to analyze
+// Relative archive paths are resolved against testDir. When the archive holds a single
+// top-level directory, that directory is returned.
+func ExtractArchive(archivePath, testDir, workDir string) (string, error) {
+	log := util.GetLogger()
+
+	if !filepath.IsAbs(archivePath) {
+		archivePath = filepath.Join(testDir, archivePath)
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		return "", fmt.Errorf("archive file not found at %s: %w", archivePath, err)
+	}
+
+	extractDir, err := filepath.Abs(filepath.Join(workDir, "archive"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// Always extract from scratch so a changed archive is picked up
+	if err := os.RemoveAll(extractDir); err != nil {
+		return "", fmt.Errorf("failed to clean archive directory: %w", err)
+	}
+	if err := os.MkdirAll(extractDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	log.Info("Extracting archive", "archive", archivePath, "dest", extractDir)
+
+	if strings.HasSuffix(strings.ToLower(archivePath), ".zip") {
+		err = extractZip(archivePath, extractDir)
+	} else {
+		err = extractTar(archivePath, extractDir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to extract archive %s: %w", archivePath, err)
+	}
+
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read archive directory: %w", err)
+	}
+	if len(entries) == 1 && entries[0].IsDir() {
+		return filepath.Join(extractDir, entries[0].Name()), nil
+	}
+	return extractDir, nil
+}
+
+// safeJoin joins an archive entry name to dest, rejecting entries that escape it
+func safeJoin(dest, name string) (string, error) {
+	target := filepath.Join(dest, name)
+	if target != dest && !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+		return "", fmt.Errorf("illegal path in archive: %s", name)
+	}
+	return target, nil
+}
+
+func extractZip(archivePath, dest string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, f := range reader.File {
+		target, err := safeJoin(dest, f.Name)
+		if err != nil {
+			return err
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = writeFile(target, src, f.Mode())
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractTar(archivePath, dest string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	lower := strings.ToLower(archivePath)
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target, err := safeJoin(dest, header.Name)
+		if err != nil {
+			return err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, os.FileMode(header.Mode)); err != nil {
+				return err
+			}
+		default:
+			// Links and special files are not needed for analysis
+			continue
+		}
+	}
+}
+
+func writeFile(target string, src io.Reader, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
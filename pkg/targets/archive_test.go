@@ -0,0 +1,147 @@
+package targets
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsArchiveFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"app.zip", true},
+		{"app.tar", true},
+		{"app.tar.gz", true},
+		{"APP.TGZ", true},
+		{"/path/to/app.zip", true},
+		{"app.war", false},
+		{"app.gz", false},
+		{"/path/to/source", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := IsArchiveFile(tt.path); got != tt.expected {
+				t.Errorf("IsArchiveFile(%q) = %v, want %v", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func writeZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTarGz(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractArchive(t *testing.T) {
+	tests := []struct {
+		name      string
+		archive   string
+		files     map[string]string
+		expectDir string
+		expectErr bool
+	}{
+		{
+			name:    "zip with top-level directory",
+			archive: "app.zip",
+			files: map[string]string{
+				"app/pom.xml":                "<project/>",
+				"app/src/main/java/App.java": "class App {}",
+			},
+			expectDir: "archive/app",
+		},
+		{
+			name:    "tar.gz without top-level directory",
+			archive: "app.tar.gz",
+			files: map[string]string{
+				"pom.xml":                "<project/>",
+				"src/main/java/App.java": "class App {}",
+			},
+			expectDir: "archive",
+		},
+		{
+			name:    "zip with path traversal",
+			archive: "evil.zip",
+			files: map[string]string{
+				"../evil.txt": "evil",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDir := t.TempDir()
+			archivePath := filepath.Join(testDir, tt.archive)
+			if filepath.Ext(tt.archive) == ".zip" {
+				writeZip(t, archivePath, tt.files)
+			} else {
+				writeTarGz(t, archivePath, tt.files)
+			}
+
+			// Relative archive paths resolve against the test directory
+			dir, err := ExtractArchive(tt.archive, testDir, testDir)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("ExtractArchive() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+
+			if want := filepath.Join(testDir, tt.expectDir); dir != want {
+				t.Errorf("ExtractArchive() = %v, want %v", dir, want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "pom.xml")); err != nil {
+				t.Errorf("Expected pom.xml to be extracted: %v", err)
+			}
+		})
+	}
+}
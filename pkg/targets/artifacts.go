@@ -0,0 +1,89 @@
+package targets
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ArtifactKind identifies an output of an execution
+type ArtifactKind string
+
+// Artifacts targets produce
+const (
+	// ArtifactOutput is the analysis output validated against the expected output
+	ArtifactOutput ArtifactKind = "output"
+	// ArtifactDependencies lists the dependencies found by the analysis
+	ArtifactDependencies ArtifactKind = "dependencies"
+	// ArtifactTransactions is the transaction report of analyzers that emit one (tech preview)
+	ArtifactTransactions ArtifactKind = "transactions"
+	// ArtifactStaticReport is the directory holding the HTML report
+	ArtifactStaticReport ArtifactKind = "static-report"
+	// ArtifactAnalysisLog is the log of the analyzer
+	ArtifactAnalysisLog ArtifactKind = "analysis-log"
+	// ArtifactStdout is the standard output of the executed command
+	ArtifactStdout ArtifactKind = "stdout"
+	// ArtifactStderr is the standard error of the executed command
+	ArtifactStderr ArtifactKind = "stderr"
+)
+
+// artifactFiles are the names of the artifacts kantra writes to its output directory
+var artifactFiles = map[ArtifactKind]string{
+	ArtifactOutput:       "output.yaml",
+	ArtifactDependencies: "dependencies.yaml",
+	ArtifactTransactions: "transactions.yaml",
+	ArtifactStaticReport: "static-report",
+	ArtifactAnalysisLog:  "analysis.log",
+}
+
+// artifactManifest records the artifacts of an execution in its work directory, so they are found
+// again when validating the outputs of a previous run
+const artifactManifest = "artifacts.json"
+
+// OutputArtifacts maps artifact kinds to their paths
+type OutputArtifacts map[ArtifactKind]string
+
+// Output returns the analysis output file, empty when there is none
+func (a OutputArtifacts) Output() string {
+	return a[ArtifactOutput]
+}
+
+// collectArtifacts returns the artifacts found in an output directory
+// The analysis output is always included, a missing output is reported when it is parsed
+func collectArtifacts(outputDir string) OutputArtifacts {
+	artifacts := OutputArtifacts{ArtifactOutput: filepath.Join(outputDir, artifactFiles[ArtifactOutput])}
+	for kind, name := range artifactFiles {
+		path := filepath.Join(outputDir, name)
+		if _, err := os.Stat(path); err == nil {
+			artifacts[kind] = path
+		}
+	}
+	return artifacts
+}
+
+// writeArtifactManifest records the artifacts of an execution in its work directory
+func writeArtifactManifest(workDir string, artifacts OutputArtifacts) error {
+	data, err := json.MarshalIndent(artifacts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal artifacts: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, artifactManifest), data, 0644); err != nil {
+		return fmt.Errorf("failed to write artifact manifest: %w", err)
+	}
+	return nil
+}
+
+// readArtifactManifest returns the artifacts recorded in a work directory
+// Work directories of older runs have no manifest, their artifacts are collected from the output directory
+func readArtifactManifest(workDir string) OutputArtifacts {
+	data, err := os.ReadFile(filepath.Join(workDir, artifactManifest))
+	if err != nil {
+		return collectArtifacts(filepath.Join(workDir, "output"))
+	}
+	var artifacts OutputArtifacts
+	if err := json.Unmarshal(data, &artifacts); err != nil || artifacts.Output() == "" {
+		return collectArtifacts(filepath.Join(workDir, "output"))
+	}
+	return artifacts
+}
@@ -0,0 +1,115 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCollectArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"output.yaml", "analysis.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "static-report"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts := collectArtifacts(dir)
+	want := OutputArtifacts{
+		ArtifactOutput:       filepath.Join(dir, "output.yaml"),
+		ArtifactAnalysisLog:  filepath.Join(dir, "analysis.log"),
+		ArtifactStaticReport: filepath.Join(dir, "static-report"),
+	}
+	if len(artifacts) != len(want) {
+		t.Fatalf("collectArtifacts() = %v, want %v", artifacts, want)
+	}
+	for kind, path := range want {
+		if artifacts[kind] != path {
+			t.Errorf("artifacts[%s] = %s, want %s", kind, artifacts[kind], path)
+		}
+	}
+
+	// A missing output is still recorded, the error surfaces when it is parsed
+	if got := collectArtifacts(t.TempDir()); len(got) != 1 || got.Output() == "" {
+		t.Errorf("collectArtifacts() of an empty directory = %v", got)
+	}
+}
+
+func TestArtifactManifest(t *testing.T) {
+	workDir := t.TempDir()
+
+	// Without a manifest the conventional output directory is used
+	if got := readArtifactManifest(workDir).Output(); got != filepath.Join(workDir, "output", "output.yaml") {
+		t.Errorf("Output() without manifest = %s", got)
+	}
+
+	artifacts := OutputArtifacts{
+		ArtifactOutput:       "/results/run-1/output.yaml",
+		ArtifactDependencies: "/results/run-1/dependencies.yaml",
+	}
+	if err := writeArtifactManifest(workDir, artifacts); err != nil {
+		t.Fatalf("writeArtifactManifest() error = %v", err)
+	}
+	got := readArtifactManifest(workDir)
+	if got.Output() != artifacts.Output() || got[ArtifactDependencies] != artifacts[ArtifactDependencies] {
+		t.Errorf("readArtifactManifest() = %v, want %v", got, artifacts)
+	}
+}
+
+func TestExecutionResult_ArtifactDirs(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ExecutionResult
+		want   []string
+	}{
+		{
+			name:   "output in work directory",
+			result: ExecutionResult{WorkDir: "/work/test", Artifacts: OutputArtifacts{ArtifactOutput: "/work/test/output/output.yaml"}},
+			want:   []string{"/work/test"},
+		},
+		{
+			name:   "output directory elsewhere",
+			result: ExecutionResult{WorkDir: "/work/test", Artifacts: OutputArtifacts{ArtifactOutput: "/results/test/output.yaml"}},
+			want:   []string{"/work/test", "/results/test"},
+		},
+		{
+			name: "applications",
+			result: ExecutionResult{
+				WorkDir:              "/work",
+				ApplicationWorkDirs:  map[string]string{"b": "/work/test-b", "a": "/work/test-a"},
+				ApplicationArtifacts: map[string]OutputArtifacts{"a": {ArtifactOutput: "/work/test-a/output/output.yaml"}},
+			},
+			want: []string{"/work/test-a", "/work/test-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.result.ArtifactDirs(); !slices.Equal(got, tt.want) {
+				t.Errorf("ArtifactDirs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKantraTarget_TestOutputDir(t *testing.T) {
+	tests := []struct {
+		outputDir string
+		want      string
+	}{
+		{outputDir: "output", want: "/work/test-20250101-100000/output"},
+		{outputDir: "results/kantra", want: "/work/test-20250101-100000/results/kantra"},
+		{outputDir: "/mnt/results", want: "/mnt/results/test-20250101-100000"},
+	}
+
+	for _, tt := range tests {
+		k := &KantraTarget{outputDir: tt.outputDir}
+		if got := k.testOutputDir("/work/test-20250101-100000"); got != tt.want {
+			t.Errorf("testOutputDir() with %s = %s, want %s", tt.outputDir, got, tt.want)
+		}
+	}
+}
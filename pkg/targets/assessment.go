@@ -0,0 +1,143 @@
+package targets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+// Assess creates the questionnaire of an assessment test, assesses the test's application with the test's
+// answers and records the risk and confidence the Hub computes. The questionnaire and the assessment are
+// deleted afterwards, so the test can run again
+func (t *TackleHubTarget) Assess(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	start := time.Now()
+
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	questionnaire, err := loadQuestionnaire(test.ResolvePath(test.Assessment.Questionnaire))
+	if err != nil {
+		return nil, err
+	}
+	existing, err := t.client.Questionnaire.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questionnaires: %w", err)
+	}
+	if slices.ContainsFunc(existing, func(q api.Questionnaire) bool { return q.Name == questionnaire.Name }) {
+		return nil, fmt.Errorf("questionnaire %q already exists in the Hub, delete it or rename the test's questionnaire", questionnaire.Name)
+	}
+
+	progress.Update(ctx, "creating questionnaire")
+	if err := t.client.Questionnaire.Create(questionnaire); err != nil {
+		return nil, fmt.Errorf("failed to create questionnaire %s: %w", questionnaire.Name, err)
+	}
+	defer func() {
+		if err := t.client.Questionnaire.Delete(questionnaire.ID); err != nil {
+			log.Info("Failed to delete questionnaire", "id", questionnaire.ID, "error", err.Error())
+		}
+	}()
+	log.Info("Questionnaire created", "id", questionnaire.ID, "name", questionnaire.Name)
+
+	progress.Update(ctx, "creating application")
+	app, err := t.createApplication(ctx, test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+
+	// The Hub copies the sections of the questionnaire into the assessment, answers are selected there
+	progress.Update(ctx, "assessing application")
+	assessment := &api.Assessment{Questionnaire: api.Ref{ID: questionnaire.ID, Name: questionnaire.Name}}
+	if err := t.client.Application.Assessment(app.ID).Create(assessment); err != nil {
+		return nil, fmt.Errorf("failed to create assessment of application %s: %w", app.Name, err)
+	}
+	defer func() {
+		if err := t.client.Assessment.Delete(assessment.ID); err != nil {
+			log.Info("Failed to delete assessment", "id", assessment.ID, "error", err.Error())
+		}
+	}()
+	if err := selectAnswers(assessment.Sections, test.Assessment.Answers); err != nil {
+		return nil, err
+	}
+	if err := t.client.Assessment.Update(assessment); err != nil {
+		return nil, fmt.Errorf("failed to submit the answers of assessment %d: %w", assessment.ID, err)
+	}
+
+	assessed, err := t.client.Assessment.Get(assessment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment %d: %w", assessment.ID, err)
+	}
+	log.Info("Application assessed", "assessmentID", assessed.ID, "status", assessed.Status, "risk", assessed.Risk, "confidence", assessed.Confidence)
+
+	return &ExecutionResult{
+		WorkDir:  workDir,
+		Duration: time.Since(start),
+		Assessment: &AssessmentResult{
+			Status:     assessed.Status,
+			Risk:       assessed.Risk,
+			Confidence: assessed.Confidence,
+		},
+	}, nil
+}
+
+// loadQuestionnaire reads a questionnaire YAML file in the format the Hub imports. Its fields are the
+// JSON fields of the Hub API
+func loadQuestionnaire(path string) (*api.Questionnaire, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read questionnaire: %w", err)
+	}
+	var content map[string]any
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse questionnaire %s: %w", path, err)
+	}
+	converted, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert questionnaire %s: %w", path, err)
+	}
+	questionnaire := &api.Questionnaire{}
+	if err := json.Unmarshal(converted, questionnaire); err != nil {
+		return nil, fmt.Errorf("invalid questionnaire %s: %w", path, err)
+	}
+	if questionnaire.Name == "" || len(questionnaire.Sections) == 0 {
+		return nil, fmt.Errorf("invalid questionnaire %s: a name and sections are required", path)
+	}
+	return questionnaire, nil
+}
+
+// selectAnswers selects the answers of an assessment's questions, every answer must match a section,
+// question and answer text of the questionnaire
+func selectAnswers(sections []api.Section, answers []config.AssessmentAnswer) error {
+	for _, answer := range answers {
+		s := slices.IndexFunc(sections, func(s api.Section) bool { return s.Name == answer.Section })
+		if s < 0 {
+			return fmt.Errorf("section %q not found in the questionnaire", answer.Section)
+		}
+		questions := sections[s].Questions
+		q := slices.IndexFunc(questions, func(q api.Question) bool { return q.Text == answer.Question })
+		if q < 0 {
+			return fmt.Errorf("question %q not found in section %q", answer.Question, answer.Section)
+		}
+		choices := questions[q].Answers
+		a := slices.IndexFunc(choices, func(a api.Answer) bool { return a.Text == answer.Answer })
+		if a < 0 {
+			return fmt.Errorf("answer %q not found for question %q", answer.Answer, answer.Question)
+		}
+		// A question has a single selected answer
+		for i := range choices {
+			choices[i].Selected = i == a
+		}
+	}
+	return nil
+}
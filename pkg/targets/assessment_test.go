@@ -0,0 +1,135 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+func TestLoadQuestionnaire(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `name: Cloud readiness
+description: Checks the readiness of an application for the cloud
+sections:
+  - order: 1
+    name: Deployment
+    questions:
+      - order: 1
+        text: How is the application deployed?
+        answers:
+          - order: 1
+            text: Containers
+            risk: green
+          - order: 2
+            text: Manually
+            risk: red
+thresholds:
+  red: 1
+riskMessages:
+  red: High risk
+`,
+		},
+		{name: "without sections", content: "name: Empty\n", wantErr: "a name and sections are required"},
+		{name: "invalid YAML", content: "name: [", wantErr: "failed to parse questionnaire"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "questionnaire.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			questionnaire, err := loadQuestionnaire(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("loadQuestionnaire() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadQuestionnaire() error = %v", err)
+			}
+			if questionnaire.Name != "Cloud readiness" || len(questionnaire.Sections) != 1 {
+				t.Fatalf("loadQuestionnaire() = %+v, want the Cloud readiness questionnaire with one section", questionnaire)
+			}
+			answers := questionnaire.Sections[0].Questions[0].Answers
+			if len(answers) != 2 || answers[1].Text != "Manually" || answers[1].Risk != "red" {
+				t.Errorf("loadQuestionnaire() answers = %+v, want Containers and Manually", answers)
+			}
+		})
+	}
+}
+
+func TestSelectAnswers(t *testing.T) {
+	sections := func() []api.Section {
+		return []api.Section{{
+			Name: "Deployment",
+			Questions: []api.Question{{
+				Text:    "How is the application deployed?",
+				Answers: []api.Answer{{Text: "Containers", Selected: true}, {Text: "Manually"}},
+			}},
+		}}
+	}
+	answer := func(section, question, text string) config.AssessmentAnswer {
+		return config.AssessmentAnswer{Section: section, Question: question, Answer: text}
+	}
+
+	tests := []struct {
+		name    string
+		answers []config.AssessmentAnswer
+		want    []bool
+		wantErr string
+	}{
+		{
+			name:    "selects the answer",
+			answers: []config.AssessmentAnswer{answer("Deployment", "How is the application deployed?", "Manually")},
+			want:    []bool{false, true},
+		},
+		{
+			name:    "unknown section",
+			answers: []config.AssessmentAnswer{answer("Security", "How is the application deployed?", "Manually")},
+			wantErr: `section "Security" not found`,
+		},
+		{
+			name:    "unknown question",
+			answers: []config.AssessmentAnswer{answer("Deployment", "Who owns it?", "Manually")},
+			wantErr: `question "Who owns it?" not found`,
+		},
+		{
+			name:    "unknown answer",
+			answers: []config.AssessmentAnswer{answer("Deployment", "How is the application deployed?", "Serverless")},
+			wantErr: `answer "Serverless" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := sections()
+			err := selectAnswers(s, tt.answers)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectAnswers() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectAnswers() error = %v", err)
+			}
+			for i, answer := range s[0].Questions[0].Answers {
+				if answer.Selected != tt.want[i] {
+					t.Errorf("answer %s selected = %v, want %v", answer.Text, answer.Selected, tt.want[i])
+				}
+			}
+		})
+	}
+}
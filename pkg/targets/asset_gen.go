@@ -0,0 +1,153 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// AssetGenTarget implements Target for asset generation with kantra discover and generate
+type AssetGenTarget struct {
+	binaryPath string
+}
+
+// NewAssetGenTarget creates a new asset generation target
+func NewAssetGenTarget(cfg *config.AssetGenConfig) (*AssetGenTarget, error) {
+	var binaryPath string
+	if cfg != nil && cfg.BinaryPath != "" {
+		binaryPath = cfg.BinaryPath
+	} else {
+		var err error
+		binaryPath, err = exec.LookPath("kantra")
+		if err != nil {
+			return nil, fmt.Errorf("kantra binary not found in PATH: %w", err)
+		}
+	}
+
+	return &AssetGenTarget{binaryPath: binaryPath}, nil
+}
+
+// Name returns the target name
+func (a *AssetGenTarget) Name() string {
+	return "asset-gen"
+}
+
+// Version returns the version of kantra generating the assets
+func (a *AssetGenTarget) Version(ctx context.Context) (string, error) {
+	return kantraVersion(ctx, a.binaryPath)
+}
+
+// Capabilities are unknown, asset generation doesn't depend on analysis features
+func (a *AssetGenTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	return nil, nil
+}
+
+// Execute discovers the application configuration and generates assets from it
+// The generated files are returned in the result's AssetsDir
+func (a *AssetGenTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	log.Info("Executing asset generation", "test", test.Name)
+
+	if !test.IsAssetTest() {
+		return nil, fmt.Errorf("test %s does not configure assets", test.Name)
+	}
+	if test.Analysis.ApplicationGitComponents != nil || test.Analysis.ApplicationImageComponents != nil {
+		return nil, fmt.Errorf("asset generation requires a local application manifest")
+	}
+
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute work directory: %w", err)
+	}
+
+	start := time.Now()
+	result := &ExecutionResult{WorkDir: workDir}
+
+	// Step 1: Discover the application configuration
+	discoverDir := filepath.Join(absWorkDir, "discover")
+	args := []string{"discover", test.Assets.GetPlatform(),
+		"--input", test.ResolvePath(test.Analysis.Application),
+		"--output-dir", discoverDir,
+	}
+	if err := a.run(ctx, test, args, workDir, result); err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+
+	// Without a chart the discovery manifest is the generated asset
+	if test.Assets.ChartDir == "" {
+		result.AssetsDir = discoverDir
+		result.Duration = time.Since(start)
+		LogResult(log, result)
+		return result, nil
+	}
+
+	// Step 2: Render the chart with the discovered configuration
+	manifest, err := findDiscoveryManifest(discoverDir)
+	if err != nil {
+		return nil, err
+	}
+	assetsDir := filepath.Join(absWorkDir, "assets")
+	args = []string{"generate", "helm",
+		"--input", manifest,
+		"--chart-dir", test.ResolvePath(test.Assets.ChartDir),
+		"--output-dir", assetsDir,
+	}
+	if err := a.run(ctx, test, args, workDir, result); err != nil {
+		return nil, fmt.Errorf("asset generation failed: %w", err)
+	}
+
+	result.AssetsDir = assetsDir
+	result.Duration = time.Since(start)
+	LogResult(log, result)
+	return result, nil
+}
+
+// run executes a kantra command and accumulates its output into result
+func (a *AssetGenTarget) run(ctx context.Context, test *config.TestDefinition, args []string, workDir string, result *ExecutionResult) error {
+	cmdResult, err := ExecuteCommandWithEnv(ctx, a.binaryPath, args, test.Environ(), workDir, test.GetTimeout())
+	if err != nil {
+		return err
+	}
+	result.ExitCode = cmdResult.ExitCode
+	// Commands append to the same logs in the work directory
+	result.Artifacts = cmdResult.Artifacts
+	result.Stdout += cmdResult.Stdout
+	result.Stderr += cmdResult.Stderr
+	return nil
+}
+
+// findDiscoveryManifest returns the single YAML manifest written by kantra discover
+func findDiscoveryManifest(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read discovery output: %w", err)
+	}
+
+	var manifests []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			manifests = append(manifests, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	switch len(manifests) {
+	case 0:
+		return "", fmt.Errorf("no discovery manifest found in %s", dir)
+	case 1:
+		return manifests[0], nil
+	default:
+		return "", fmt.Errorf("found %d discovery manifests in %s, expected one", len(manifests), dir)
+	}
+}
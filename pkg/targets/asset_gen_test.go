@@ -0,0 +1,127 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// fakeKantra writes a script that records its arguments and creates a file in the --output-dir
+func fakeKantra(t *testing.T, dir string) string {
+	t.Helper()
+	script := `#!/bin/sh
+echo "$@" >> "` + filepath.Join(dir, "calls.log") + `"
+out=""
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--output-dir" ]; then out="$2"; fi
+  shift
+done
+/bin/mkdir -p "$out"
+echo "name: app" > "$out/manifest.yaml"
+`
+	path := filepath.Join(dir, "kantra")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAssetGenTarget_Execute(t *testing.T) {
+	tests := []struct {
+		name      string
+		chartDir  string
+		calls     []string
+		assetsDir string
+	}{
+		{
+			name:      "discovery only",
+			calls:     []string{"discover cloud-foundry --input"},
+			assetsDir: "discover",
+		},
+		{
+			name:      "helm chart",
+			chartDir:  "chart",
+			calls:     []string{"discover cloud-foundry --input", "generate helm --input"},
+			assetsDir: "assets",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			target, err := NewAssetGenTarget(&config.AssetGenConfig{BinaryPath: fakeKantra(t, dir)})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			test := &config.TestDefinition{
+				Name:    "assets",
+				WorkDir: filepath.Join(dir, "work"),
+				Assets:  &config.AssetsConfig{ChartDir: tt.chartDir},
+			}
+			test.Analysis.Application = "manifest.yml"
+			test.SetTestFilePath(filepath.Join(dir, "test.yaml"))
+
+			result, err := target.Execute(context.Background(), test)
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if filepath.Base(result.AssetsDir) != tt.assetsDir {
+				t.Errorf("AssetsDir = %s, want %s directory", result.AssetsDir, tt.assetsDir)
+			}
+			if _, err := os.Stat(filepath.Join(result.AssetsDir, "manifest.yaml")); err != nil {
+				t.Errorf("generated asset not found: %v", err)
+			}
+
+			data, err := os.ReadFile(filepath.Join(dir, "calls.log"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			calls := strings.Split(strings.TrimSpace(string(data)), "\n")
+			if len(calls) != len(tt.calls) {
+				t.Fatalf("kantra called %d times, want %d: %v", len(calls), len(tt.calls), calls)
+			}
+			for i, prefix := range tt.calls {
+				if !strings.HasPrefix(calls[i], prefix) {
+					t.Errorf("call %d = %q, want prefix %q", i, calls[i], prefix)
+				}
+			}
+			if !strings.Contains(calls[0], filepath.Join(dir, "manifest.yml")) {
+				t.Errorf("discovery input not resolved against the test directory: %s", calls[0])
+			}
+		})
+	}
+}
+
+func TestAssetGenTarget_RequiresAssets(t *testing.T) {
+	target := &AssetGenTarget{binaryPath: "/bin/true"}
+	if _, err := target.Execute(context.Background(), &config.TestDefinition{Name: "analysis"}); err == nil {
+		t.Error("Execute() expected error for a test without assets")
+	}
+}
+
+func TestFindDiscoveryManifest(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := findDiscoveryManifest(dir); err == nil {
+		t.Error("findDiscoveryManifest() expected error for an empty directory")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "discover.yaml"), []byte("name: app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := findDiscoveryManifest(dir)
+	if err != nil || got != filepath.Join(dir, "discover.yaml") {
+		t.Errorf("findDiscoveryManifest() = %s, %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "other.yml"), []byte("name: other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := findDiscoveryManifest(dir); err == nil {
+		t.Error("findDiscoveryManifest() expected error for several manifests")
+	}
+}
@@ -0,0 +1,113 @@
+package targets
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+var (
+	// gradleIncludePattern matches include statements of Gradle settings, including arguments continued
+	// on the next lines after a comma
+	gradleIncludePattern = regexp.MustCompile(`(?m)^\s*include\b(?:[^\n]*,[ \t]*\n)*[^\n]*`)
+	// quotedPattern matches the quoted project paths of an include statement
+	quotedPattern = regexp.MustCompile(`["']([^"']+)["']`)
+	// mavenModulePattern matches the modules of a Maven POM
+	mavenModulePattern = regexp.MustCompile(`<module>\s*([^<\s]+)\s*</module>`)
+)
+
+// DetectBuildTool returns the build tool of a Java application directory, empty when it has no build file
+func DetectBuildTool(dir string) string {
+	for _, file := range []string{"settings.gradle", "settings.gradle.kts", "build.gradle", "build.gradle.kts"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return config.BuildToolGradle
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "pom.xml")); err == nil {
+		return config.BuildToolMaven
+	}
+	return ""
+}
+
+// CheckSubprojects checks that the build of an application directory declares the expected subprojects
+func CheckSubprojects(dir string, build *config.BuildOptions) error {
+	tool := build.Tool
+	if tool == "" {
+		tool = DetectBuildTool(dir)
+	}
+
+	var declared []string
+	var err error
+	switch tool {
+	case config.BuildToolGradle:
+		declared, err = gradleSubprojects(dir)
+	case config.BuildToolMaven:
+		declared, err = mavenModules(dir, "")
+	default:
+		return fmt.Errorf("no Maven or Gradle build found in %s", dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, subproject := range build.Subprojects {
+		if !slices.Contains(declared, normalizeSubproject(subproject)) {
+			return fmt.Errorf("%s build in %s doesn't declare subproject %s, it declares: %s", tool, dir, subproject, strings.Join(declared, ", "))
+		}
+	}
+	return nil
+}
+
+// gradleSubprojects returns the projects included by the Gradle settings of a directory
+func gradleSubprojects(dir string) ([]string, error) {
+	var data []byte
+	var err error
+	for _, file := range []string{"settings.gradle", "settings.gradle.kts"} {
+		if data, err = os.ReadFile(filepath.Join(dir, file)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("no Gradle settings found in %s", dir)
+	}
+
+	var subprojects []string
+	for _, include := range gradleIncludePattern.FindAllString(string(data), -1) {
+		for _, match := range quotedPattern.FindAllStringSubmatch(include, -1) {
+			subprojects = append(subprojects, normalizeSubproject(match[1]))
+		}
+	}
+	return subprojects, nil
+}
+
+// mavenModules returns the modules of the POM of a directory and of its modules, prefixed with their
+// parent modules
+func mavenModules(dir, parent string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read POM: %w", err)
+	}
+
+	var modules []string
+	for _, match := range mavenModulePattern.FindAllStringSubmatch(string(data), -1) {
+		module := path.Join(parent, match[1])
+		modules = append(modules, normalizeSubproject(module))
+		// Modules may be aggregators themselves, modules without a POM are reported by Maven
+		if nested, err := mavenModules(filepath.Join(dir, match[1]), module); err == nil {
+			modules = append(modules, nested...)
+		}
+	}
+	return modules, nil
+}
+
+// normalizeSubproject returns a subproject path without the leading colon of Gradle paths, with colons
+// separating nested subprojects like Gradle, e.g. services:api for services/api
+func normalizeSubproject(subproject string) string {
+	return strings.TrimPrefix(strings.ReplaceAll(subproject, "/", ":"), ":")
+}
@@ -0,0 +1,105 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/fixtures"
+)
+
+func TestCheckSubprojects(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		build   config.BuildOptions
+		wantErr string
+	}{
+		{
+			name:  "gradle includes",
+			files: map[string]string{"settings.gradle": "rootProject.name = 'shop'\ninclude 'app',\n    ':services:api'\n"},
+			build: config.BuildOptions{Subprojects: []string{"app", "services:api", ":services:api"}},
+		},
+		{
+			name:  "gradle kotlin settings",
+			files: map[string]string{"settings.gradle.kts": "include(\":app\", \":lib\")\nincludeBuild(\"plugins\")\n"},
+			build: config.BuildOptions{Subprojects: []string{"lib"}},
+		},
+		{
+			name:    "included build isn't a subproject",
+			files:   map[string]string{"settings.gradle.kts": "include(\":app\")\nincludeBuild(\"plugins\")\n"},
+			build:   config.BuildOptions{Subprojects: []string{"plugins"}},
+			wantErr: "doesn't declare subproject plugins, it declares: app",
+		},
+		{
+			name: "nested maven modules",
+			files: map[string]string{
+				"pom.xml":          "<modules><module>services</module><module>web</module></modules>",
+				"services/pom.xml": "<modules>\n  <module>api</module>\n</modules>",
+			},
+			build: config.BuildOptions{Subprojects: []string{"web", "services/api", "services:api"}},
+		},
+		{
+			name:    "missing maven module",
+			files:   map[string]string{"pom.xml": "<modules><module>web</module></modules>"},
+			build:   config.BuildOptions{Subprojects: []string{"batch"}},
+			wantErr: "maven build",
+		},
+		{
+			name:    "tool without its build files",
+			files:   map[string]string{"pom.xml": "<modules><module>web</module></modules>"},
+			build:   config.BuildOptions{Tool: config.BuildToolGradle, Subprojects: []string{"web"}},
+			wantErr: "no Gradle settings",
+		},
+		{
+			name:    "no build",
+			files:   map[string]string{"README.md": ""},
+			build:   config.BuildOptions{Subprojects: []string{"web"}},
+			wantErr: "no Maven or Gradle build",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSubprojects(writeFiles(t, tt.files), &tt.build)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckSubprojects() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckSubprojects() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSubprojects_GradleFixture(t *testing.T) {
+	dir, err := fixtures.Materialize(fixtures.GradleMulti, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool := DetectBuildTool(dir); tool != config.BuildToolGradle {
+		t.Errorf("DetectBuildTool() = %s, want gradle", tool)
+	}
+	if err := CheckSubprojects(dir, &config.BuildOptions{Subprojects: []string{"app", "lib"}}); err != nil {
+		t.Errorf("CheckSubprojects() error = %v", err)
+	}
+}
@@ -0,0 +1,356 @@
+package targets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// partialOutputs are the artifacts a canceled analysis must not leave in its output directory
+var partialOutputs = []ArtifactKind{ArtifactOutput, ArtifactDependencies, ArtifactStaticReport}
+
+// canceledCommand is the outcome of a command interrupted by runCanceled
+type canceledCommand struct {
+	// finished is set when the command exited before it was interrupted
+	finished bool
+	// killed is set when the command didn't exit within the grace period after the interrupt
+	killed bool
+	// orphaned is set when processes the command started were still running after it exited
+	orphaned  bool
+	exitCode  int
+	duration  time.Duration
+	artifacts OutputArtifacts
+}
+
+// runCanceled runs a command and interrupts it after a delay, like a user pressing Ctrl+C, then waits for
+// it to exit. The command is killed when it doesn't exit within the grace period. Its output is streamed
+// to stdout.log and stderr.log in the work directory
+func runCanceled(ctx context.Context, binary string, args, env []string, workDir string, after, grace time.Duration) (*canceledCommand, error) {
+	log := util.GetLogger()
+	log.Info("Executing command to cancel", "binary", binary, "args", args, "workDir", workDir, "after", after)
+
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = workDir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	stdoutFile, err := openRotatingFile(filepath.Join(workDir, stdoutLog), maxLogSize, maxLogBackups)
+	if err != nil {
+		return nil, err
+	}
+	defer stdoutFile.Close()
+	stderrFile, err := openRotatingFile(filepath.Join(workDir, stderrLog), maxLogSize, maxLogBackups)
+	if err != nil {
+		return nil, err
+	}
+	defer stderrFile.Close()
+	cmd.Stdout, cmd.Stderr = stdoutFile, stderrFile
+	// Children left running hold the output pipes, they are closed after the grace period
+	cmd.WaitDelay = grace
+
+	// Processes started by the command inherit the write end of a pipe, its read end reaches EOF once all
+	// of them exited. Windows doesn't pass extra files to processes
+	running, inherited, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipe: %w", err)
+	}
+	defer running.Close()
+	if runtime.GOOS != "windows" {
+		cmd.ExtraFiles = []*os.File{inherited}
+	}
+
+	start := time.Now()
+	err = cmd.Start()
+	inherited.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute command: %w", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	result := &canceledCommand{artifacts: OutputArtifacts{
+		ArtifactStdout: absPath(stdoutFile.path),
+		ArtifactStderr: absPath(stderrFile.path),
+	}}
+	finish := func(err error) *canceledCommand {
+		result.duration = time.Since(start)
+		result.orphaned = stillOpen(running)
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.exitCode = exitErr.ExitCode()
+		}
+		return result
+	}
+
+	select {
+	case err := <-done:
+		result.finished = true
+		return finish(err), nil
+	case <-ctx.Done():
+		_ = cmd.Process.Kill()
+		<-done
+		return nil, ctx.Err()
+	case <-time.After(after):
+	}
+
+	log.Info("Interrupting command", "binary", binary, "pid", cmd.Process.Pid)
+	if err := cmd.Process.Signal(os.Interrupt); err != nil {
+		// Interrupts aren't supported on every platform, the command can only be killed there
+		log.Info("Failed to interrupt command", "error", err)
+	}
+	select {
+	case err := <-done:
+		return finish(err), nil
+	case <-time.After(grace):
+		// Commands that exited while their children hold the output can't be killed anymore
+		if err := cmd.Process.Kill(); err == nil {
+			log.Info("Killed command that didn't exit after the interrupt", "binary", binary, "grace", grace)
+			result.killed = true
+		}
+		return finish(<-done), nil
+	}
+}
+
+// stillOpen reports whether a process still holds the write end of a pipe, waiting briefly for processes
+// that are exiting
+func stillOpen(pipe *os.File) bool {
+	if err := pipe.SetReadDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
+		return false
+	}
+	_, err := pipe.Read(make([]byte, 1))
+	return errors.Is(err, os.ErrDeadlineExceeded)
+}
+
+// leftoverOutputs describes the partial outputs found in the output directory of a canceled analysis
+func leftoverOutputs(outputDir string) []string {
+	var leftovers []string
+	for _, kind := range partialOutputs {
+		path := filepath.Join(outputDir, artifactFiles[kind])
+		if _, err := os.Stat(path); err == nil {
+			leftovers = append(leftovers, fmt.Sprintf("partial %s left in %s", artifactFiles[kind], outputDir))
+		}
+	}
+	return leftovers
+}
+
+// runningContainers returns the IDs of the running containers of a container tool
+func runningContainers(ctx context.Context, tool string, env []string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, tool, "ps", "-q", "--no-trunc")
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers with %s: %w", tool, err)
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// ExecuteCanceled runs kantra analyze, interrupts it after the test's delay and records the partial
+// outputs and the containers the canceled run left behind. Containers running before the analysis
+// started are ignored, runs without containers only have their outputs checked
+func (k *KantraTarget) ExecuteCanceled(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	log.Info("Executing Kantra analysis to cancel", "test", test.Name)
+
+	run, err := k.prepareRun(ctx, test)
+	if err != nil {
+		return nil, err
+	}
+
+	tool, toolEnv, err := k.cancelContainerTool(test)
+	if err != nil {
+		return nil, err
+	}
+	var before []string
+	if tool != "" {
+		if before, err = runningContainers(ctx, tool, toolEnv); err != nil {
+			return nil, err
+		}
+	}
+
+	progress.Update(ctx, fmt.Sprintf("analyzing, canceling after %v", test.Cancel.After.Duration))
+	cmd, err := runCanceled(ctx, k.binaryPath, run.args, run.env, run.workDir, test.Cancel.After.Duration, test.Cancel.GetGracePeriod())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutionResult{
+		ExitCode:  cmd.exitCode,
+		Duration:  cmd.duration,
+		WorkDir:   run.workDir,
+		Artifacts: cmd.artifacts,
+		Canceled:  !cmd.finished,
+	}
+	if cmd.killed {
+		result.Leftovers = append(result.Leftovers, fmt.Sprintf("kantra didn't exit within %v of the interrupt and was killed", test.Cancel.GetGracePeriod()))
+	}
+	if cmd.orphaned {
+		result.Leftovers = append(result.Leftovers, "processes started by kantra were still running after it exited")
+	}
+	if tool != "" {
+		after, err := runningContainers(ctx, tool, toolEnv)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range after {
+			if !slices.Contains(before, id) {
+				result.Leftovers = append(result.Leftovers, fmt.Sprintf("container %s still running", id))
+			}
+		}
+	}
+	result.Leftovers = append(result.Leftovers, leftoverOutputs(run.outputDir)...)
+	if err := writeArtifactManifest(run.workDir, result.Artifacts); err != nil {
+		return nil, err
+	}
+
+	log.Info("Canceled analysis", "test", test.Name, "canceled", result.Canceled, "leftovers", result.Leftovers)
+	return result, nil
+}
+
+// cancelContainerTool returns the container tool running the analysis containers and the environment
+// selecting its engine, empty when kantra runs locally
+func (k *KantraTarget) cancelContainerTool(test *config.TestDefinition) (string, []string, error) {
+	if k.runLocal {
+		return "", nil, nil
+	}
+	tool := k.containerTool
+	if testTool := test.Env["CONTAINER_TOOL"]; testTool != "" {
+		tool = testTool
+	}
+	if tool == "" {
+		var err error
+		if tool, err = FindContainerTool(); err != nil {
+			return "", nil, err
+		}
+	}
+	var env []string
+	if k.containerHost != "" {
+		env = append(env, containerHostEnv(tool, k.containerHost))
+	}
+	return tool, env, nil
+}
+
+// ExecuteCanceled creates the analysis task of a test, cancels it after the test's delay and records what
+// the canceled task left behind: a task that doesn't reach the Canceled state within the grace period,
+// other tasks of the application still active, and an analysis stored by the canceled task
+func (t *TackleHubTarget) ExecuteCanceled(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	start := time.Now()
+
+	if test.RequireMavenSettings && t.mavenSettings == "" {
+		return nil, fmt.Errorf("test requires maven settings but none configured in target config")
+	}
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.verifyImages(); err != nil {
+		return nil, err
+	}
+
+	var customTarget *api.Target
+	if test.Analysis.CustomTarget != nil {
+		progress.Update(ctx, "creating custom target")
+		if customTarget, err = t.createCustomTarget(test); err != nil {
+			return nil, err
+		}
+		defer t.deleteCustomTarget(customTarget)
+	}
+
+	progress.Update(ctx, "creating application")
+	app, err := t.createApplication(ctx, test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+	progress.Update(ctx, "creating task")
+	task, err := t.createAnalysisTask(ctx, test, app, customTarget)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create analysis task: %w", err)
+	}
+	if err := t.submitTask(task.ID); err != nil {
+		return nil, fmt.Errorf("failed to submit task: %w", err)
+	}
+
+	result := &ExecutionResult{WorkDir: workDir}
+	progress.Update(ctx, fmt.Sprintf("task %d, canceling after %v", task.ID, test.Cancel.After.Duration))
+	state, err := t.waitTaskState(ctx, task.ID, test.Cancel.After.Duration, TaskStateSucceeded, TaskStateFailed, TaskStateCanceled)
+	if err != nil {
+		return nil, err
+	}
+	if state == TaskStateSucceeded || state == TaskStateFailed {
+		log.Info("Task finished before it was canceled", "taskID", task.ID, "state", state)
+		result.Duration = time.Since(start)
+		return result, nil
+	}
+
+	log.Info("Canceling task", "taskID", task.ID, "state", state)
+	if err := t.cancelTask(task.ID); err != nil {
+		return nil, fmt.Errorf("failed to cancel task: %w", err)
+	}
+	result.Canceled = true
+	grace := test.Cancel.GetGracePeriod()
+	if state, err = t.waitTaskState(ctx, task.ID, grace, TaskStateCanceled); err != nil {
+		return nil, err
+	}
+	if state != TaskStateCanceled {
+		result.Leftovers = append(result.Leftovers, fmt.Sprintf("task %d is %s %v after it was canceled, expected %s", task.ID, state, grace, TaskStateCanceled))
+	}
+
+	// Tasks left running keep their pods
+	tasks, err := t.client.Task.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	for _, id := range activeApplicationTasks(tasks, app.ID) {
+		if id != task.ID {
+			result.Leftovers = append(result.Leftovers, fmt.Sprintf("task %d of application %d still active", id, app.ID))
+		}
+	}
+
+	// Applications without an analysis have none to get
+	var analysis api.Analysis
+	if err := t.client.Client.Get(fmt.Sprintf("/applications/%d/analysis", app.ID), &analysis); err == nil && analysis.CreateTime.After(start) {
+		result.Leftovers = append(result.Leftovers, fmt.Sprintf("analysis %d stored for the canceled task", analysis.ID))
+	}
+
+	result.Duration = time.Since(start)
+	log.Info("Canceled analysis", "test", test.Name, "taskID", task.ID, "leftovers", result.Leftovers)
+	return result, nil
+}
+
+// waitTaskState polls a task until it reaches one of the states or the timeout expires, and returns its
+// last state
+func (t *TackleHubTarget) waitTaskState(ctx context.Context, taskID uint, timeout time.Duration, states ...string) (string, error) {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		task, err := t.client.Task.Get(taskID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get task status: %w", err)
+		}
+		if slices.Contains(states, task.State) {
+			return task.State, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-deadline:
+			return task.State, nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunCanceled(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands are interrupted with signals")
+	}
+
+	tests := []struct {
+		name         string
+		script       string
+		wantFinished bool
+		wantKilled   bool
+		wantOrphaned bool
+		wantExitCode int
+	}{
+		{name: "finished before the interrupt", script: "exit 3", wantFinished: true, wantExitCode: 3},
+		{name: "exits on interrupt", script: "trap 'kill $!; exit 130' INT; sleep 5 & wait", wantExitCode: 130},
+		{name: "leaves a child running", script: "trap 'exit 130' INT; sleep 2 & wait", wantOrphaned: true},
+		// The child ignores the interrupt as well and outlives the killed shell
+		{name: "ignores interrupt", script: "trap '' INT; sleep 2 >/dev/null & wait; wait", wantKilled: true, wantOrphaned: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workDir := t.TempDir()
+			got, err := runCanceled(context.Background(), "sh", []string{"-c", tt.script}, nil, workDir, 200*time.Millisecond, 500*time.Millisecond)
+			if err != nil {
+				t.Fatalf("runCanceled() error = %v", err)
+			}
+			if got.finished != tt.wantFinished || got.killed != tt.wantKilled || got.orphaned != tt.wantOrphaned {
+				t.Errorf("runCanceled() finished = %v, killed = %v, orphaned = %v, want %v, %v and %v",
+					got.finished, got.killed, got.orphaned, tt.wantFinished, tt.wantKilled, tt.wantOrphaned)
+			}
+			if tt.wantExitCode != 0 && got.exitCode != tt.wantExitCode {
+				t.Errorf("runCanceled() exit code = %d, want %d", got.exitCode, tt.wantExitCode)
+			}
+			if got.artifacts[ArtifactStdout] == "" || got.artifacts[ArtifactStderr] == "" {
+				t.Errorf("runCanceled() artifacts = %v, want the command logs", got.artifacts)
+			}
+		})
+	}
+}
+
+func TestLeftoverOutputs(t *testing.T) {
+	outputDir := t.TempDir()
+	if got := leftoverOutputs(outputDir); len(got) != 0 {
+		t.Errorf("leftoverOutputs() of an empty directory = %v", got)
+	}
+
+	for _, name := range []string{"output.yaml", "analysis.log"} {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte("partial"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(outputDir, "static-report"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got := leftoverOutputs(outputDir)
+	if len(got) != 2 || !strings.Contains(got[0], "output.yaml") || !strings.Contains(got[1], "static-report") {
+		t.Errorf("leftoverOutputs() = %v, want the partial output and static report, not the log", got)
+	}
+}
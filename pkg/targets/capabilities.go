@@ -0,0 +1,109 @@
+package targets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// Capabilities are the features a target supports, nil when they are unknown
+type Capabilities map[config.Capability]bool
+
+// Supports reports whether the target has a capability, unknown capabilities are assumed supported
+func (c Capabilities) Supports(capability config.Capability) bool {
+	if c == nil {
+		return true
+	}
+	return c[capability]
+}
+
+// capabilityVersions maps target types to the first version supporting each capability
+// Capabilities missing from a target's map aren't supported by any of its versions
+var capabilityVersions = map[string]map[config.Capability]string{
+	"kantra": {
+		config.CapabilityIncidentSelector:  "v0.3.0",
+		config.CapabilityProviderSettings:  "v0.6.0",
+		config.CapabilityProviderSelection: "v0.7.0",
+	},
+	"tackle-hub": {
+		// The analyzer addon doesn't take an incident selector
+		config.CapabilityProviderSelection: "v0.5.0",
+		config.CapabilityProviderSettings:  "v0.7.0",
+		// Custom targets are uploaded rule files, kantra takes rules directly
+		config.CapabilityCustomTarget: "v0.3.0",
+	},
+}
+
+// capabilitiesFor returns the capabilities of a target type at a version
+// They are unknown (nil) for versions that can't be parsed, e.g. development builds
+func capabilitiesFor(targetType, version string) Capabilities {
+	minimums, ok := capabilityVersions[targetType]
+	if !ok {
+		return nil
+	}
+	if _, ok := parseVersion(version); !ok {
+		return nil
+	}
+
+	capabilities := Capabilities{}
+	for capability, minimum := range minimums {
+		capabilities[capability] = compareVersions(version, minimum) >= 0
+	}
+	return capabilities
+}
+
+// Unsupported returns why a target can't run a test: missing capabilities and versions older than
+// the test requires. It is empty when the test can run
+func Unsupported(test *config.TestDefinition, targetType, version string, capabilities Capabilities) []string {
+	var reasons []string
+	for _, capability := range test.RequiredCapabilities() {
+		if !capabilities.Supports(capability) {
+			reasons = append(reasons, fmt.Sprintf("requires %s, not supported by %s %s", capability, targetType, version))
+		}
+	}
+
+	if test.Requires != nil {
+		if minimum, ok := test.Requires.Versions[targetType]; ok {
+			// Versions that can't be parsed are assumed new enough
+			if _, known := parseVersion(version); known && compareVersions(version, minimum) < 0 {
+				reasons = append(reasons, fmt.Sprintf("requires %s %s or newer, found %s", targetType, minimum, version))
+			}
+		}
+	}
+	return reasons
+}
+
+// versionPattern matches the release part of versions such as v0.6.0, 0.6 or v0.6.0-ea89gcd
+var versionPattern = regexp.MustCompile(`v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// parseVersion returns the major, minor and patch numbers of the first version in s
+func parseVersion(s string) ([3]int, bool) {
+	match := versionPattern.FindStringSubmatch(s)
+	if match == nil {
+		return [3]int{}, false
+	}
+	var parts [3]int
+	for i, part := range match[1:] {
+		if part != "" {
+			parts[i], _ = strconv.Atoi(part)
+		}
+	}
+	return parts, true
+}
+
+// compareVersions compares the release parts of two versions, pre-release suffixes are ignored
+func compareVersions(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
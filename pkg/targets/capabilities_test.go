@@ -0,0 +1,198 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "v0.6.0", b: "v0.6.0", want: 0},
+		{a: "v0.6.0-ea89gcd", b: "v0.6.0", want: 0},
+		{a: "0.7", b: "v0.6.3", want: 1},
+		{a: "v0.5.10", b: "v0.6.0", want: -1},
+		{a: "v1.0.0", b: "v0.99.0", want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCapabilitiesFor(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		version     string
+		supported   []config.Capability
+		unsupported []config.Capability
+	}{
+		{
+			name:        "old kantra",
+			target:      "kantra",
+			version:     "v0.5.0",
+			supported:   []config.Capability{config.CapabilityIncidentSelector},
+			unsupported: []config.Capability{config.CapabilityProviderSettings, config.CapabilityProviderSelection},
+		},
+		{
+			name:      "current kantra",
+			target:    "kantra",
+			version:   "v0.7.1",
+			supported: []config.Capability{config.CapabilityIncidentSelector, config.CapabilityProviderSettings, config.CapabilityProviderSelection},
+		},
+		{
+			name:        "hub never supports incident selectors",
+			target:      "tackle-hub",
+			version:     "v0.8.0-ea89gcd",
+			supported:   []config.Capability{config.CapabilityProviderSettings, config.CapabilityCustomTarget},
+			unsupported: []config.Capability{config.CapabilityIncidentSelector},
+		},
+		{
+			name:        "kantra never supports custom targets",
+			target:      "kantra",
+			version:     "v0.8.0",
+			unsupported: []config.Capability{config.CapabilityCustomTarget},
+		},
+		{
+			name:      "development build",
+			target:    "kantra",
+			version:   "latest",
+			supported: config.KnownCapabilities,
+		},
+		{
+			name:      "target without versions",
+			target:    "vscode",
+			version:   "v1.0.0",
+			supported: config.KnownCapabilities,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capabilities := capabilitiesFor(tt.target, tt.version)
+			for _, c := range tt.supported {
+				if !capabilities.Supports(c) {
+					t.Errorf("%s %s should support %s", tt.target, tt.version, c)
+				}
+			}
+			for _, c := range tt.unsupported {
+				if capabilities.Supports(c) {
+					t.Errorf("%s %s should not support %s", tt.target, tt.version, c)
+				}
+			}
+		})
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		test    *config.TestDefinition
+		target  string
+		version string
+		want    []string
+	}{
+		{
+			name:    "supported",
+			test:    &config.TestDefinition{Analysis: config.AnalysisConfig{IncidentSelector: "!package"}},
+			target:  "kantra",
+			version: "v0.6.0",
+		},
+		{
+			name:    "missing capability",
+			test:    &config.TestDefinition{Analysis: config.AnalysisConfig{IncidentSelector: "!package"}},
+			target:  "tackle-hub",
+			version: "v0.7.0",
+			want:    []string{"requires incident-selector, not supported by tackle-hub v0.7.0"},
+		},
+		{
+			name: "declared capability and version",
+			test: &config.TestDefinition{Requires: &config.RequiresConfig{
+				Capabilities: []config.Capability{config.CapabilityProviderSelection},
+				Versions:     map[string]string{"kantra": "v0.8.0", "tackle-hub": "v0.5.0"},
+			}},
+			target:  "kantra",
+			version: "v0.6.2",
+			want: []string{
+				"requires provider-selection, not supported by kantra v0.6.2",
+				"requires kantra v0.8.0 or newer, found v0.6.2",
+			},
+		},
+		{
+			name:    "unknown version runs everything",
+			test:    &config.TestDefinition{Requires: &config.RequiresConfig{Versions: map[string]string{"kantra": "v0.8.0"}}},
+			target:  "kantra",
+			version: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unsupported(tt.test, tt.target, tt.version, capabilitiesFor(tt.target, tt.version))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Unsupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKantraVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "version line", output: "version: v0.6.0\nSHA: 1a2b3c\nImage: quay.io/konveyor/kantra:v0.6.0\n", want: "v0.6.0"},
+		{name: "bare version", output: "kantra 0.7.1\n", want: "0.7.1"},
+		{name: "no version", output: "unknown command\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binary := filepath.Join(t.TempDir(), "kantra")
+			script := "#!/bin/sh\ncat <<'EOF'\n" + tt.output + "EOF\n"
+			if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			target := &KantraTarget{binaryPath: binary}
+			got, err := target.Version(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Version() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Version() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockTarget_Capabilities(t *testing.T) {
+	target, err := NewMockTarget(&config.MockConfig{Version: "v0.5.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	capabilities, err := target.Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if capabilities.Supports(config.CapabilityProviderSettings) || !capabilities.Supports(config.CapabilityIncidentSelector) {
+		t.Errorf("mock should simulate kantra v0.5.0 capabilities: %v", capabilities)
+	}
+
+	target, _ = NewMockTarget(nil)
+	if capabilities, _ := target.Capabilities(context.Background()); capabilities != nil {
+		t.Errorf("mock without a version should have unknown capabilities: %v", capabilities)
+	}
+}
@@ -0,0 +1,129 @@
+package targets
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Command output limits
+const (
+	// outputTailSize is how much of the end of each output stream is kept in memory
+	outputTailSize = 64 * 1024
+	// maxLogSize rotates a command log once it grows beyond this size
+	maxLogSize = 50 * 1024 * 1024
+	// maxLogBackups is the number of rotated logs kept per stream, older output is dropped
+	maxLogBackups = 2
+)
+
+// tailBuffer keeps the last bytes written to it
+type tailBuffer struct {
+	mu        sync.Mutex
+	size      int
+	buf       []byte
+	truncated int64
+}
+
+func newTailBuffer(size int) *tailBuffer {
+	return &tailBuffer{size: size}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	// Trimming once the buffer doubles keeps copies rare
+	if len(t.buf) > 2*t.size {
+		drop := len(t.buf) - t.size
+		t.truncated += int64(drop)
+		t.buf = append(t.buf[:0], t.buf[drop:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the end of the output, noting how much was left out
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, truncated := t.buf, t.truncated
+	if len(data) > t.size {
+		truncated += int64(len(data) - t.size)
+		data = data[len(data)-t.size:]
+	}
+	if truncated == 0 {
+		return string(data)
+	}
+	return fmt.Sprintf("[%d bytes truncated]\n%s", truncated, data)
+}
+
+// rotatingFile appends to a log file, renaming it to <path>.1, <path>.2... when it grows too large
+type rotatingFile struct {
+	path    string
+	maxSize int64
+	backups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens a log for appending, output of earlier commands in the same file is kept
+func openRotatingFile(path string, maxSize int64, backups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open command log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat command log: %w", err)
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the backups, dropping the oldest, and starts a new log
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close command log: %w", err)
+	}
+	if r.backups > 0 {
+		for i := r.backups - 1; i > 0; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+		}
+		if err := os.Rename(r.path, r.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate command log: %w", err)
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return fmt.Errorf("failed to rotate command log: %w", err)
+	}
+	return r.open()
+}
+
+// Close closes the log
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
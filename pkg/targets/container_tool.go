@@ -0,0 +1,95 @@
+package targets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// Files of runs with container settings, written to the work directory
+const (
+	// containerToolWrapper runs the container tool with the test's flags, kantra uses it as CONTAINER_TOOL
+	containerToolWrapper = "container-tool.sh"
+	// oomKilledMarker records the containers killed for running out of memory
+	oomKilledMarker = "oom-killed"
+)
+
+// oomExitCode is the exit code of containers killed by the kernel, e.g. for running out of memory
+const oomExitCode = 137
+
+// containerRunFlags returns the flags added to every container kantra runs
+func containerRunFlags(limits *config.ResourceLimits, pullPolicy string) []string {
+	var flags []string
+	if limits.CPUs != "" {
+		flags = append(flags, "--cpus", limits.CPUs)
+	}
+	if limits.Memory != "" {
+		flags = append(flags, "--memory", limits.Memory)
+	}
+	if pullPolicy != "" {
+		flags = append(flags, "--pull="+pullPolicy)
+	}
+	return flags
+}
+
+// containerHostEnv returns the variable selecting the engine socket of a container tool,
+// DOCKER_HOST for docker and CONTAINER_HOST for podman
+func containerHostEnv(tool, host string) string {
+	if strings.Contains(filepath.Base(tool), "docker") {
+		return "DOCKER_HOST=" + host
+	}
+	return "CONTAINER_HOST=" + host
+}
+
+// writeContainerWrapper writes a script running the container tool with flags added to every container
+// run. When detectOOM is set, containers run in the foreground that are killed are recorded in the OOM
+// marker. Returns the absolute path of the script
+func writeContainerWrapper(tool string, flags []string, detectOOM bool, workDir string) (string, error) {
+	quoted := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		quoted = append(quoted, shellQuote(flag))
+	}
+
+	marker, err := filepath.Abs(filepath.Join(workDir, oomKilledMarker))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute marker path: %w", err)
+	}
+	detect := ""
+	if detectOOM {
+		detect = fmt.Sprintf("if [ $status -eq %d ]; then echo \"$*\" >> %s; fi\n\t", oomExitCode, shellQuote(marker))
+	}
+
+	script := fmt.Sprintf(`#!/bin/sh
+# Runs %[1]s with the container settings of the test
+if [ "$1" = "run" ]; then
+	shift
+	%[1]s run %[2]s "$@"
+	status=$?
+	%[3]sexit $status
+fi
+exec %[1]s "$@"
+`, shellQuote(tool), strings.Join(quoted, " "), detect)
+
+	path, err := filepath.Abs(filepath.Join(workDir, containerToolWrapper))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute wrapper path: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return "", fmt.Errorf("failed to write container tool wrapper: %w", err)
+	}
+	return path, nil
+}
+
+// oomKilled reports whether a container of the run in the work directory ran out of memory
+func oomKilled(workDir string) bool {
+	_, err := os.Stat(filepath.Join(workDir, oomKilledMarker))
+	return err == nil
+}
+
+// shellQuote quotes a string for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,70 @@
+package targets
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+func TestWriteContainerWrapper(t *testing.T) {
+	workDir := t.TempDir()
+	// The fake container tool records its arguments and fails like a container killed for memory
+	tool := filepath.Join(workDir, "podman's")
+	argsFile := filepath.Join(workDir, "args")
+	if err := os.WriteFile(tool, []byte("#!/bin/sh\necho \"$@\" >> "+argsFile+"\n[ \"$1\" = run ] && exit 137\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	flags := containerRunFlags(&config.ResourceLimits{CPUs: "2", Memory: "512m"}, "never")
+	wrapper, err := writeContainerWrapper(tool, flags, true, workDir)
+	if err != nil {
+		t.Fatalf("writeContainerWrapper() error = %v", err)
+	}
+
+	if err := exec.Command(wrapper, "pull", "quay.io/konveyor/kantra").Run(); err != nil {
+		t.Fatalf("wrapper pull error = %v", err)
+	}
+	if oomKilled(workDir) {
+		t.Error("other commands than run should not be recorded as out of memory")
+	}
+
+	err = exec.Command(wrapper, "run", "--name", "analyzer", "quay.io/konveyor/kantra").Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != oomExitCode {
+		t.Fatalf("wrapper run error = %v, want exit code %d", err, oomExitCode)
+	}
+	if !oomKilled(workDir) {
+		t.Error("killed container was not recorded as out of memory")
+	}
+
+	args, _ := os.ReadFile(argsFile)
+	want := "pull quay.io/konveyor/kantra\nrun --cpus 2 --memory 512m --pull=never --name analyzer quay.io/konveyor/kantra\n"
+	if string(args) != want {
+		t.Errorf("container tool args = %q, want %q", args, want)
+	}
+}
+
+func TestWriteContainerWrapper_NoOOMDetection(t *testing.T) {
+	workDir := t.TempDir()
+	wrapper, err := writeContainerWrapper("/usr/bin/podman", containerRunFlags(&config.ResourceLimits{CPUs: "1"}, ""), false, workDir)
+	if err != nil {
+		t.Fatalf("writeContainerWrapper() error = %v", err)
+	}
+	script, _ := os.ReadFile(wrapper)
+	// Killed containers aren't out of memory without a memory limit
+	if strings.Contains(string(script), oomKilledMarker) || strings.Contains(string(script), "--memory") {
+		t.Errorf("wrapper without memory limit:\n%s", script)
+	}
+}
+
+func TestContainerHostEnv(t *testing.T) {
+	if got := containerHostEnv("/usr/local/bin/docker", "unix:///var/run/docker.sock"); got != "DOCKER_HOST=unix:///var/run/docker.sock" {
+		t.Errorf("containerHostEnv(docker) = %q", got)
+	}
+	if got := containerHostEnv("", "unix:///run/podman/podman.sock"); got != "CONTAINER_HOST=unix:///run/podman/podman.sock" {
+		t.Errorf("containerHostEnv(default) = %q", got)
+	}
+}
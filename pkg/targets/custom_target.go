@@ -0,0 +1,102 @@
+package targets
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// createCustomTarget uploads the rule files and image of a test's custom target and creates the target,
+// like adding a custom migration target in the UI. The target is returned with the labels the Hub parsed
+// from the rules, it and its files are deleted with deleteCustomTarget
+func (t *TackleHubTarget) createCustomTarget(test *config.TestDefinition) (*api.Target, error) {
+	custom := test.Analysis.CustomTarget
+	existing, err := t.client.Target.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list targets: %w", err)
+	}
+	if slices.ContainsFunc(existing, func(target api.Target) bool { return target.Name == custom.Name }) {
+		return nil, fmt.Errorf("target %q already exists in the Hub, delete it or rename the test's custom target", custom.Name)
+	}
+
+	target := &api.Target{
+		Name:        custom.Name,
+		Description: custom.Description,
+		RuleSet:     &api.RuleSet{Name: custom.Name, Description: custom.Description},
+	}
+	// Files uploaded before a failure are deleted with the target
+	defer func() {
+		if target.ID == 0 {
+			t.deleteCustomTarget(target)
+		}
+	}()
+	for _, rule := range custom.Rules {
+		file, err := t.client.File.Put(test.ResolvePath(rule))
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload rules %s: %w", rule, err)
+		}
+		target.RuleSet.Rules = append(target.RuleSet.Rules, api.Rule{File: &api.Ref{ID: file.ID, Name: file.Name}})
+	}
+	if custom.Image != "" {
+		file, err := t.client.File.Put(test.ResolvePath(custom.Image))
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload image %s: %w", custom.Image, err)
+		}
+		target.Image = api.Ref{ID: file.ID, Name: file.Name}
+	}
+
+	if err := t.client.Target.Create(target); err != nil {
+		return nil, fmt.Errorf("failed to create target %s: %w", custom.Name, err)
+	}
+	created, err := t.client.Target.Get(target.ID)
+	if err != nil {
+		t.deleteCustomTarget(target)
+		return nil, fmt.Errorf("failed to get target %s: %w", custom.Name, err)
+	}
+	if len(created.Labels) == 0 {
+		t.deleteCustomTarget(created)
+		return nil, fmt.Errorf("hub found no konveyor.io/target labels in the rules of target %s", custom.Name)
+	}
+	util.GetLogger().Info("Custom target created", "id", created.ID, "name", created.Name, "labels", targetLabels(created))
+	return created, nil
+}
+
+// deleteCustomTarget deletes a custom target and the files uploaded for it, failures are logged only
+func (t *TackleHubTarget) deleteCustomTarget(target *api.Target) {
+	if target.ID != 0 {
+		t.deleteResource("target", target.ID, t.client.Target.Delete)
+	}
+	if target.RuleSet != nil {
+		for _, rule := range target.RuleSet.Rules {
+			if rule.File != nil {
+				t.deleteResource("rules file", rule.File.ID, t.client.File.Delete)
+			}
+		}
+	}
+	if target.Image.ID != 0 {
+		t.deleteResource("image file", target.Image.ID, t.client.File.Delete)
+	}
+}
+
+// selectCustomTarget adds the ruleset of a custom target to the rules of an analysis. Without a label
+// selector of the test, the target's labels are selected, like choosing the target in the UI
+func selectCustomTarget(rules *Rules, target *api.Target, labelSelector string) {
+	if target.RuleSet != nil {
+		rules.RuleSets = append(rules.RuleSets, api.Ref{ID: target.RuleSet.ID, Name: target.RuleSet.Name})
+	}
+	if labelSelector == "" {
+		rules.Labels.Included = append(rules.Labels.Included, targetLabels(target)...)
+	}
+}
+
+// targetLabels returns the labels of a target, e.g. konveyor.io/target=acme
+func targetLabels(target *api.Target) []string {
+	labels := make([]string, 0, len(target.Labels))
+	for _, label := range target.Labels {
+		labels = append(labels, label.Label)
+	}
+	return labels
+}
@@ -0,0 +1,38 @@
+package targets
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/konveyor/tackle2-hub/api"
+)
+
+func TestSelectCustomTarget(t *testing.T) {
+	target := &api.Target{
+		Name:    "acme",
+		Labels:  []api.TargetLabel{{Name: "acme", Label: "konveyor.io/target=acme"}},
+		RuleSet: &api.RuleSet{Resource: api.Resource{ID: 7}, Name: "acme"},
+	}
+
+	tests := []struct {
+		name          string
+		labelSelector string
+		wantIncluded  []string
+	}{
+		{name: "target labels selected", wantIncluded: []string{"konveyor.io/target=acme"}},
+		{name: "label selector of the test", labelSelector: "konveyor.io/target=acme || konveyor.io/target=quarkus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules Rules
+			selectCustomTarget(&rules, target, tt.labelSelector)
+			if len(rules.RuleSets) != 1 || rules.RuleSets[0].ID != 7 {
+				t.Errorf("RuleSets = %v, want the target's ruleset", rules.RuleSets)
+			}
+			if !slices.Equal(rules.Labels.Included, tt.wantIncluded) {
+				t.Errorf("Labels.Included = %v, want %v", rules.Labels.Included, tt.wantIncluded)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package targets
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// Diagnosis is the probable cause of a failed execution, recognized from its output
+type Diagnosis struct {
+	// Cause describes what most likely went wrong
+	Cause string
+	// Hint suggests how to fix it
+	Hint string
+}
+
+// String returns the cause and the hint on one line
+func (d *Diagnosis) String() string {
+	return fmt.Sprintf("probable cause: %s (%s)", d.Cause, d.Hint)
+}
+
+// failureSignature recognizes a known failure in the output of an execution
+type failureSignature struct {
+	pattern *regexp.Regexp
+	Diagnosis
+}
+
+// failureSignatures are checked in order, causes that trigger others come first, e.g. a language server
+// running out of memory crashes
+var failureSignatures = []failureSignature{
+	{
+		pattern: regexp.MustCompile(`(?i)OOMKilled|java\.lang\.OutOfMemoryError|out of memory|exit code:? 137`),
+		Diagnosis: Diagnosis{
+			Cause: "the analysis ran out of memory",
+			Hint:  "raise resources.memory, the memory of the Hub's analyzer addon or -Xmx in JAVA_OPTS",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)return code is:? 401|status code:? 401|401 unauthorized|not authorized.*(maven|repository)`),
+		Diagnosis: Diagnosis{
+			Cause: "a Maven repository rejected the credentials (HTTP 401)",
+			Hint:  "check the servers of the target's mavenSettings and set requireMavenSettings on tests needing them",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)(provider.{0,80}(init|start|ready)|(init|start).{0,80}provider).{0,80}(timed? ?out|deadline exceeded)|timed? ?out waiting for.{0,40}provider`),
+		Diagnosis: Diagnosis{
+			Cause: "a provider didn't start in time",
+			Hint:  "check the provider images and logs, dependency downloads slow the java provider down, raise the test's timeout or use a Maven mirror",
+		},
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)jsonrpc2: (connection|conn) (closed|is closed)|language server.{0,40}(crash|exited|terminated)|(lsp|language server).{0,40}panic`),
+		Diagnosis: Diagnosis{
+			Cause: "a language server crashed during the analysis",
+			Hint:  "see the provider's errors in the analysis log, crashes are often caused by memory limits",
+		},
+	},
+}
+
+// Diagnose returns the probable cause of the first known failure found in texts, nil when there is none
+func Diagnose(texts ...string) *Diagnosis {
+	for _, signature := range failureSignatures {
+		for _, text := range texts {
+			if signature.pattern.MatchString(text) {
+				diagnosis := signature.Diagnosis
+				return &diagnosis
+			}
+		}
+	}
+	return nil
+}
+
+// Diagnose returns the probable cause of a failed execution from its output and the end of the
+// analysis log, nil when no known failure is found
+func (r *ExecutionResult) Diagnose() *Diagnosis {
+	if r.OOMKilled {
+		diagnosis := failureSignatures[0].Diagnosis
+		return &diagnosis
+	}
+	texts := []string{r.Stderr, r.Stdout}
+	if r.Error != nil {
+		texts = append(texts, r.Error.Error())
+	}
+	if log := r.Artifacts[ArtifactAnalysisLog]; log != "" {
+		if tail, err := readTail(log, outputTailSize); err == nil {
+			texts = append(texts, tail)
+		}
+	}
+	return Diagnose(texts...)
+}
+
+// readTail returns the last size bytes of a file
+func readTail(path string, size int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > size {
+		if _, err := file.Seek(-size, io.SeekEnd); err != nil {
+			return "", err
+		}
+	}
+	data, err := io.ReadAll(file)
+	return string(data), err
+}
@@ -0,0 +1,75 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantCause string
+	}{
+		{
+			name:      "maven unauthorized",
+			output:    "Could not transfer artifact org.acme:lib:pom:1.0 from/to private: Return code is: 401, ReasonPhrase: Unauthorized.",
+			wantCause: "a Maven repository rejected the credentials (HTTP 401)",
+		},
+		{
+			name:      "java out of memory",
+			output:    "Exception in thread \"main\" java.lang.OutOfMemoryError: Java heap space",
+			wantCause: "the analysis ran out of memory",
+		},
+		{
+			name:      "provider init timeout",
+			output:    "error=\"unable to init the providers\" provider=java error=\"context deadline exceeded\"",
+			wantCause: "a provider didn't start in time",
+		},
+		{
+			name:      "language server crash",
+			output:    "failed to get references: jsonrpc2: connection closed",
+			wantCause: "a language server crashed during the analysis",
+		},
+		{
+			name:   "unknown failure",
+			output: "error: unknown flag --foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnosis := Diagnose(tt.output)
+			if tt.wantCause == "" {
+				if diagnosis != nil {
+					t.Errorf("Diagnose() = %v, want nil", diagnosis)
+				}
+				return
+			}
+			if diagnosis == nil || diagnosis.Cause != tt.wantCause {
+				t.Errorf("Diagnose() = %v, want cause %q", diagnosis, tt.wantCause)
+			}
+		})
+	}
+}
+
+func TestExecutionResult_Diagnose(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "analysis.log")
+	content := strings.Repeat("info: analyzing\n", outputTailSize/8) + "jsonrpc2: connection closed\n"
+	if err := os.WriteFile(log, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := &ExecutionResult{ExitCode: 1, Artifacts: OutputArtifacts{ArtifactAnalysisLog: log}}
+	if diagnosis := result.Diagnose(); diagnosis == nil || !strings.Contains(diagnosis.Cause, "language server") {
+		t.Errorf("Diagnose() from the analysis log = %v", diagnosis)
+	}
+
+	result = &ExecutionResult{ExitCode: 137, OOMKilled: true}
+	if diagnosis := result.Diagnose(); diagnosis == nil || !strings.Contains(diagnosis.Cause, "memory") {
+		t.Errorf("Diagnose() of an OOM killed analysis = %v", diagnosis)
+	}
+}
@@ -1,20 +1,41 @@
 package targets
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/konveyor/test-harness/pkg/util"
 )
 
-// ExecuteCommand runs a command with timeout and captures output
+// Command logs written to the work directory
+const (
+	stdoutLog = "stdout.log"
+	stderrLog = "stderr.log"
+)
+
+// ExecuteCommand runs a command with timeout and captures the end of its output
 func ExecuteCommand(ctx context.Context, binary string, args []string, workDir string, timeout time.Duration) (*ExecutionResult, error) {
+	return executeCommand(ctx, binary, args, nil, workDir, false, timeout)
+}
+
+// ExecuteCommandWithEnv runs a command like ExecuteCommand with additional KEY=value environment variables
+// The variables are added to the current environment and override existing values. The output is streamed
+// to stdout.log and stderr.log in the work directory, rotated when they grow large, and recorded as the
+// stdout and stderr artifacts of the result. Only the end of the output is kept in memory
+// On a non-zero exit code the result is returned with the error
+func ExecuteCommandWithEnv(ctx context.Context, binary string, args []string, env []string, workDir string, timeout time.Duration) (*ExecutionResult, error) {
+	return executeCommand(ctx, binary, args, env, workDir, true, timeout)
+}
+
+// executeCommand runs a command, streaming its output to logs in the work directory when logs is set
+func executeCommand(ctx context.Context, binary string, args []string, env []string, workDir string, logs bool, timeout time.Duration) (*ExecutionResult, error) {
 	log := util.GetLogger()
 	log.Info("Executing command", "binary", binary, "args", args, "workDir", workDir)
 
@@ -25,11 +46,35 @@ func ExecuteCommand(ctx context.Context, binary string, args []string, workDir s
 	// Create command
 	cmd := exec.CommandContext(execCtx, binary, args...)
 	cmd.Dir = workDir
+	if len(env) > 0 {
+		// Only names are logged since values may hold credentials
+		log.V(1).Info("Setting command environment", "variables", envNames(env))
+		cmd.Env = append(os.Environ(), env...)
+	}
 
-	// Capture stdout and stderr
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	// Capture the end of stdout and stderr, the whole output goes to the logs
+	stdout, stderr := newTailBuffer(outputTailSize), newTailBuffer(outputTailSize)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	var artifacts OutputArtifacts
+	if logs {
+		stdoutFile, err := openRotatingFile(filepath.Join(workDir, stdoutLog), maxLogSize, maxLogBackups)
+		if err != nil {
+			return nil, err
+		}
+		defer stdoutFile.Close()
+		stderrFile, err := openRotatingFile(filepath.Join(workDir, stderrLog), maxLogSize, maxLogBackups)
+		if err != nil {
+			return nil, err
+		}
+		defer stderrFile.Close()
+
+		cmd.Stdout = io.MultiWriter(stdoutFile, stdout)
+		cmd.Stderr = io.MultiWriter(stderrFile, stderr)
+		artifacts = OutputArtifacts{
+			ArtifactStdout: absPath(stdoutFile.path),
+			ArtifactStderr: absPath(stderrFile.path),
+		}
+	}
 
 	// Execute
 	start := time.Now()
@@ -48,23 +93,46 @@ func ExecuteCommand(ctx context.Context, binary string, args []string, workDir s
 	}
 
 	result := &ExecutionResult{
-		ExitCode: exitCode,
-		Duration: duration,
-		WorkDir:  workDir,
-		Stdout:   stdout.String(),
-		Stderr:   stderr.String(),
-		Error:    err,
+		ExitCode:   exitCode,
+		Duration:   duration,
+		WorkDir:    workDir,
+		Artifacts:  artifacts,
+		Stdout:     stdout.String(),
+		Stderr:     stderr.String(),
+		Error:      err,
+		PeakMemory: peakMemory(cmd.ProcessState),
 	}
 
 	log.Info("Command completed", "exitCode", exitCode, "duration", duration)
 
 	if exitCode != 0 {
-		return nil, fmt.Errorf("command failed with exit code: %d: %s", exitCode, stderr.String())
+		if logs {
+			return result, fmt.Errorf("command failed with exit code: %d, see %s: %s", exitCode, artifacts[ArtifactStderr], stderr.String())
+		}
+		return result, fmt.Errorf("command failed with exit code: %d: %s", exitCode, stderr.String())
 	}
 
 	return result, nil
 }
 
+// absPath returns the absolute form of a path, or the path itself when it can't be determined
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+// envNames returns the names of KEY=value environment variables
+func envNames(env []string) []string {
+	names := make([]string, 0, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		names = append(names, name)
+	}
+	return names
+}
+
 // PrepareWorkDir creates a unique work directory for test execution
 func PrepareWorkDir(baseDir, testName string) (string, error) {
 	// Sanitize test name to avoid issues with special characters and spaces
@@ -98,7 +166,7 @@ func LogResult(log logr.Logger, result *ExecutionResult) {
 	log.Info("Execution result",
 		"exitCode", result.ExitCode,
 		"duration", result.Duration,
-		"outputFile", result.OutputFile,
+		"artifacts", result.Artifacts,
 	)
 
 	if result.Stdout != "" {
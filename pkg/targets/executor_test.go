@@ -0,0 +1,110 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExecuteCommandWithEnv(t *testing.T) {
+	env := []string{"KONCUR_TEST_VAR=from test", "HOME=/koncur"}
+	result, err := ExecuteCommandWithEnv(context.Background(), "/bin/sh", []string{"-c", "echo $KONCUR_TEST_VAR:$HOME"}, env, t.TempDir(), time.Minute)
+	if err != nil {
+		t.Fatalf("ExecuteCommandWithEnv() unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(result.Stdout); got != "from test:/koncur" {
+		t.Errorf("Stdout = %q, want %q", got, "from test:/koncur")
+	}
+	if runtime.GOOS != "windows" && result.PeakMemory == 0 {
+		t.Error("PeakMemory = 0, want the peak memory of the command")
+	}
+}
+
+func TestEnvNames(t *testing.T) {
+	got := envNames([]string{"JAVA_OPTS=-Dfoo=bar", "EMPTY="})
+	if len(got) != 2 || got[0] != "JAVA_OPTS" || got[1] != "EMPTY" {
+		t.Errorf("envNames() = %v, want [JAVA_OPTS EMPTY]", got)
+	}
+}
+
+func TestExecuteCommandWithEnv_Logs(t *testing.T) {
+	workDir := t.TempDir()
+	script := "head -c 200000 /dev/zero | tr '\\0' 'x'; echo; echo done; echo warning >&2"
+	result, err := ExecuteCommandWithEnv(context.Background(), "/bin/sh", []string{"-c", script}, nil, workDir, time.Minute)
+	if err != nil {
+		t.Fatalf("ExecuteCommandWithEnv() unexpected error: %v", err)
+	}
+
+	// The whole output is in the logs, only its end in memory
+	stdout, err := os.ReadFile(result.Artifacts[ArtifactStdout])
+	if err != nil {
+		t.Fatalf("stdout log: %v", err)
+	}
+	if len(stdout) != 200006 {
+		t.Errorf("stdout log has %d bytes, want 200006", len(stdout))
+	}
+	if !strings.HasPrefix(result.Stdout, "[") || !strings.HasSuffix(result.Stdout, "done\n") || len(result.Stdout) > outputTailSize+100 {
+		t.Errorf("Stdout kept %d bytes: %.40q", len(result.Stdout), result.Stdout)
+	}
+	if result.Stderr != "warning\n" || result.Artifacts[ArtifactStderr] != filepath.Join(workDir, stderrLog) {
+		t.Errorf("Stderr = %q, artifacts = %v", result.Stderr, result.Artifacts)
+	}
+
+	// A failing command points at its log
+	result, err = ExecuteCommandWithEnv(context.Background(), "/bin/sh", []string{"-c", "echo broken >&2; exit 3"}, nil, workDir, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), stderrLog) || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("ExecuteCommandWithEnv() error = %v", err)
+	}
+	if result == nil || result.ExitCode != 3 {
+		t.Errorf("ExecuteCommandWithEnv() result = %+v, want the failed command's result", result)
+	}
+	if stderr, _ := os.ReadFile(filepath.Join(workDir, stderrLog)); string(stderr) != "warning\nbroken\n" {
+		t.Errorf("stderr log = %q, output of successive commands should be appended", stderr)
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	tail := newTailBuffer(4)
+	tail.Write([]byte("ab"))
+	if got := tail.String(); got != "ab" {
+		t.Errorf("String() = %q, want ab", got)
+	}
+	for _, s := range []string{"cdef", "ghij", "k"} {
+		tail.Write([]byte(s))
+	}
+	if got := tail.String(); got != "[7 bytes truncated]\nhijk" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stdout.log")
+	log, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"first-1\n", "second-2\n", "third-3\n", "fourth-4\n"} {
+		if _, err := log.Write([]byte(s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The oldest output is dropped once every backup is used
+	want := map[string]string{path: "fourth-4\n", path + ".1": "third-3\n", path + ".2": "second-2\n"}
+	for file, content := range want {
+		got, err := os.ReadFile(file)
+		if err != nil || string(got) != content {
+			t.Errorf("%s = %q, %v, want %q", filepath.Base(file), got, err, content)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("%s.3 should not exist", filepath.Base(path))
+	}
+}
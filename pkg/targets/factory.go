@@ -19,6 +19,10 @@ func NewTarget(cfg *config.TargetConfig) (Target, error) {
 		return NewKaiRPCTarget(cfg.KaiRPC)
 	case "vscode":
 		return NewVSCodeTarget(cfg.VSCode)
+	case "asset-gen":
+		return NewAssetGenTarget(cfg.AssetGen)
+	case "mock":
+		return NewMockTarget(cfg.Mock)
 	default:
 		return nil, fmt.Errorf("unknown target type: %s", cfg.Type)
 	}
@@ -71,6 +71,34 @@ func TestNewTarget(t *testing.T) {
 			wantType: "vscode",
 			wantErr:  false,
 		},
+		{
+			name: "asset-gen target",
+			cfg: &config.TargetConfig{
+				Type: "asset-gen",
+				AssetGen: &config.AssetGenConfig{
+					BinaryPath: "/usr/local/bin/kantra",
+				},
+			},
+			wantType: "asset-gen",
+			wantErr:  false,
+		},
+		{
+			name: "mock target",
+			cfg: &config.TargetConfig{
+				Type: "mock",
+			},
+			wantType: "mock",
+			wantErr:  false,
+		},
+		{
+			name: "mock target with unknown failure",
+			cfg: &config.TargetConfig{
+				Type: "mock",
+				Mock: &config.MockConfig{Failure: "crash"},
+			},
+			wantErr:    true,
+			errContain: "unknown mock failure",
+		},
 		{
 			name: "unknown target type",
 			cfg: &config.TargetConfig{
@@ -0,0 +1,134 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// defaultImageAppPaths are searched, in order, when an image reference doesn't specify the application path
+var defaultImageAppPaths = []string{
+	"/deployments",
+	"/opt/app-root/src",
+	"/app",
+	"/opt/app",
+	"/usr/local/tomcat/webapps",
+	"/opt/jboss/wildfly/standalone/deployments",
+}
+
+// FindContainerTool returns the container tool to use, honoring CONTAINER_TOOL like kantra does
+func FindContainerTool() (string, error) {
+	if tool := os.Getenv("CONTAINER_TOOL"); tool != "" {
+		return tool, nil
+	}
+	for _, tool := range []string{"podman", "docker"} {
+		if path, err := exec.LookPath(tool); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no container tool found in PATH (podman or docker), set CONTAINER_TOOL")
+}
+
+// ExtractImageApplication pulls a container image and copies the application out of it
+// Returns the path to the extracted binary artifact or source directory
+func ExtractImageApplication(ctx context.Context, components *config.ImageComponents, workDir string) (string, error) {
+	log := util.GetLogger()
+
+	extractDir, err := filepath.Abs(filepath.Join(workDir, "image"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	// Reuse a previous extraction of the same image
+	if entries, err := os.ReadDir(extractDir); err == nil && len(entries) > 0 {
+		log.Info("Image application already extracted, skipping pull", "dest", extractDir)
+		return resolveExtractedApplication(extractDir)
+	}
+
+	tool, err := FindContainerTool()
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("Pulling container image", "image", components.Image, "tool", tool)
+	if _, err := ExecuteCommand(ctx, tool, []string{"pull", components.Image}, ".", 10*time.Minute); err != nil {
+		return "", fmt.Errorf("failed to pull image %s: %w", components.Image, err)
+	}
+
+	// Create (but don't start) a container so files can be copied out of it
+	created, err := ExecuteCommand(ctx, tool, []string{"create", components.Image}, ".", time.Minute)
+	if err != nil {
+		return "", fmt.Errorf("failed to create container from image %s: %w", components.Image, err)
+	}
+	containerID := strings.TrimSpace(created.Stdout)
+	defer func() {
+		if _, err := ExecuteCommand(context.Background(), tool, []string{"rm", containerID}, ".", time.Minute); err != nil {
+			log.Info("Warning: failed to remove container", "container", containerID, "error", err.Error())
+		}
+	}()
+
+	if err := os.MkdirAll(extractDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create image extraction directory: %w", err)
+	}
+
+	paths := defaultImageAppPaths
+	if components.Path != "" {
+		paths = []string{components.Path}
+	}
+
+	for _, p := range paths {
+		dest := filepath.Join(extractDir, filepath.Base(p))
+		if _, err := ExecuteCommand(ctx, tool, []string{"cp", fmt.Sprintf("%s:%s", containerID, p), dest}, ".", 5*time.Minute); err != nil {
+			log.V(1).Info("Application path not found in image", "path", p, "error", err.Error())
+			continue
+		}
+		log.Info("Extracted application from image", "image", components.Image, "path", p, "dest", dest)
+		return resolveExtractedApplication(extractDir)
+	}
+
+	os.RemoveAll(extractDir)
+	if components.Path != "" {
+		return "", fmt.Errorf("path %s not found in image %s", components.Path, components.Image)
+	}
+	return "", fmt.Errorf("no application found in image %s, specify the path with %s<image>#<path>", components.Image, config.ImageScheme)
+}
+
+// resolveExtractedApplication returns the application to analyze from an extraction directory
+// A single binary artifact is analyzed as a binary, anything else as source
+func resolveExtractedApplication(extractDir string) (string, error) {
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read image extraction directory: %w", err)
+	}
+	if len(entries) != 1 {
+		return extractDir, nil
+	}
+
+	extracted := filepath.Join(extractDir, entries[0].Name())
+	if !entries[0].IsDir() {
+		return extracted, nil
+	}
+
+	// A copied directory holding exactly one artifact (e.g. /deployments/app.war)
+	var binaries []string
+	inner, err := os.ReadDir(extracted)
+	if err != nil {
+		return "", fmt.Errorf("failed to read extracted directory: %w", err)
+	}
+	for _, e := range inner {
+		if !e.IsDir() && IsBinaryFile(e.Name()) {
+			binaries = append(binaries, filepath.Join(extracted, e.Name()))
+		}
+	}
+	if len(binaries) == 1 {
+		return binaries[0], nil
+	}
+	return extracted, nil
+}
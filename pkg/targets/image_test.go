@@ -0,0 +1,72 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveExtractedApplication(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  []string
+		expect string
+	}{
+		{
+			name:   "single artifact copied directly",
+			files:  []string{"app.war"},
+			expect: "app.war",
+		},
+		{
+			name:   "directory with one artifact",
+			files:  []string{"deployments/app.jar", "deployments/README"},
+			expect: "deployments/app.jar",
+		},
+		{
+			name:   "directory with several artifacts is source",
+			files:  []string{"deployments/a.jar", "deployments/b.jar"},
+			expect: "deployments",
+		},
+		{
+			name:   "source directory",
+			files:  []string{"src/pom.xml", "src/main/java/App.java"},
+			expect: "src",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractDir := t.TempDir()
+			for _, f := range tt.files {
+				path := filepath.Join(extractDir, f)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := resolveExtractedApplication(extractDir)
+			if err != nil {
+				t.Fatalf("resolveExtractedApplication() error = %v", err)
+			}
+			want := filepath.Join(extractDir, tt.expect)
+			if got != want {
+				t.Errorf("resolveExtractedApplication() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFindContainerTool_Env(t *testing.T) {
+	t.Setenv("CONTAINER_TOOL", "/usr/bin/custom-tool")
+
+	tool, err := FindContainerTool()
+	if err != nil {
+		t.Fatalf("FindContainerTool() error = %v", err)
+	}
+	if tool != "/usr/bin/custom-tool" {
+		t.Errorf("FindContainerTool() = %v, want /usr/bin/custom-tool", tool)
+	}
+}
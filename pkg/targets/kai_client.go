@@ -0,0 +1,116 @@
+package targets
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+)
+
+// kaiClient is a JSON-RPC 2.0 client for the Kai RPC server
+// Messages are framed with Content-Length headers like the language server protocol
+type kaiClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	nextID int
+}
+
+// kaiRequest is a JSON-RPC request
+type kaiRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// kaiResponse is a JSON-RPC response, notifications have no ID
+type kaiResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// dialKai connects to the Kai RPC server at addr
+func dialKai(ctx context.Context, addr string) (*kaiClient, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to kai rpc server at %s: %w", addr, err)
+	}
+	return &kaiClient{conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// Close closes the connection to the server
+func (c *kaiClient) Close() error {
+	return c.conn.Close()
+}
+
+// call sends a request and decodes the result of its response into result
+// Notifications and responses to other requests received meanwhile are skipped
+func (c *kaiClient) call(ctx context.Context, method string, params, result any) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	c.nextID++
+	id := c.nextID
+	body, err := json.Marshal(kaiRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
+	}
+	if _, err := fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		return fmt.Errorf("failed to send %s request: %w", method, err)
+	}
+
+	for {
+		data, err := c.readMessage()
+		if err != nil {
+			return fmt.Errorf("failed to read %s response: %w", method, err)
+		}
+
+		var resp kaiResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return fmt.Errorf("failed to parse %s response: %w", method, err)
+		}
+		if resp.ID == nil || *resp.ID != id {
+			continue
+		}
+		if resp.Error != nil {
+			return fmt.Errorf("%s failed: %s (code %d)", method, resp.Error.Message, resp.Error.Code)
+		}
+		if result == nil || len(resp.Result) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
+		return nil
+	}
+}
+
+// readMessage reads the body of the next Content-Length framed message
+func (c *kaiClient) readMessage() ([]byte, error) {
+	header, err := textproto.NewReader(c.reader).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -3,8 +3,18 @@ package targets
 import (
 	"context"
 	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+	"go.lsp.dev/uri"
 )
 
 // KaiRPCTarget implements Target for Kai analyzer RPC
@@ -30,8 +40,22 @@ func (k *KaiRPCTarget) Name() string {
 	return "kai-rpc"
 }
 
-// Execute runs analysis via Kai analyzer RPC
+// Version isn't detected for the Kai RPC target
+func (k *KaiRPCTarget) Version(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// Capabilities are unknown for the Kai RPC target, every test is run
+func (k *KaiRPCTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	return nil, nil
+}
+
+// Execute runs analysis via Kai analyzer RPC, or requests fixes for tests with fixes
 func (k *KaiRPCTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	if test.IsFixTest() {
+		return k.requestFixes(ctx, test)
+	}
+
 	// TODO: Implement Kai RPC execution
 	// 1. Connect to Kai RPC server (host:port)
 	// 2. Send analysis request with application path and rulesets
@@ -39,3 +63,137 @@ func (k *KaiRPCTarget) Execute(ctx context.Context, test *config.TestDefinition)
 	// 4. Parse and return RuleSets
 	return nil, fmt.Errorf("kai-rpc target not yet implemented")
 }
+
+// kaiIncident is an incident Kai is asked to fix
+type kaiIncident struct {
+	URI                  string `json:"uri"`
+	Message              string `json:"message"`
+	LineNumber           int    `json:"line_number"`
+	RulesetName          string `json:"ruleset_name"`
+	ViolationName        string `json:"violation_name"`
+	ViolationDescription string `json:"violation_description,omitempty"`
+	ViolationCategory    string `json:"violation_category,omitempty"`
+}
+
+// kaiSolutionParams requests a solution for the incidents of a file
+type kaiSolutionParams struct {
+	FilePath      string        `json:"file_path"`
+	Incidents     []kaiIncident `json:"incidents"`
+	MaxIterations int           `json:"max_iterations,omitempty"`
+}
+
+// kaiSolution is the solution Kai returns for a file
+type kaiSolution struct {
+	Diff              string   `json:"diff"`
+	ModifiedFiles     []string `json:"modified_files"`
+	EncounteredErrors []string `json:"encountered_errors"`
+}
+
+// requestFixes asks Kai to fix the selected incidents of the expected output file by file
+// The returned diffs are written to the result's PatchesDir
+func (k *KaiRPCTarget) requestFixes(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	log.Info("Requesting Kai fixes", "test", test.Name)
+
+	if test.Analysis.ApplicationGitComponents != nil || test.Analysis.ApplicationImageComponents != nil {
+		return nil, fmt.Errorf("kai fixes require a local application")
+	}
+	root, err := filepath.Abs(test.ResolvePath(test.Analysis.Application))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute application path: %w", err)
+	}
+
+	incidents := fixIncidents(test, root)
+	if len(incidents) == 0 {
+		return nil, fmt.Errorf("no incidents to fix in the expected output")
+	}
+
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+	patchesDir := filepath.Join(workDir, "patches")
+	if err := os.MkdirAll(patchesDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create patches directory: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, test.GetTimeout())
+	defer cancel()
+
+	start := time.Now()
+	client, err := dialKai(ctx, net.JoinHostPort(k.host, strconv.Itoa(k.port)))
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	if err := client.call(ctx, "initialize", map[string]string{"root_path": root}, nil); err != nil {
+		return nil, err
+	}
+
+	for i, file := range slices.Sorted(maps.Keys(incidents)) {
+		log.Info("Requesting fix", "file", file, "incidents", len(incidents[file]))
+		var solution kaiSolution
+		params := kaiSolutionParams{
+			FilePath:      file,
+			Incidents:     incidents[file],
+			MaxIterations: test.Fixes.MaxIterations,
+		}
+		if err := client.call(ctx, "getCodeplanAgentSolution", params, &solution); err != nil {
+			return nil, fmt.Errorf("fix for %s: %w", file, err)
+		}
+		for _, e := range solution.EncounteredErrors {
+			log.Info("Kai encountered an error", "file", file, "error", e)
+		}
+		if strings.TrimSpace(solution.Diff) == "" {
+			continue
+		}
+
+		base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		patchFile := filepath.Join(patchesDir, fmt.Sprintf("%03d-%s.diff", i+1, sanitizeName(base)))
+		if err := os.WriteFile(patchFile, []byte(solution.Diff), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write patch: %w", err)
+		}
+	}
+
+	return &ExecutionResult{
+		Duration:   time.Since(start),
+		WorkDir:    workDir,
+		PatchesDir: patchesDir,
+	}, nil
+}
+
+// fixIncidents collects the incidents of the expected output selected by the test's fix rules,
+// keyed by the path of their file in the application at root
+func fixIncidents(test *config.TestDefinition, root string) map[string][]kaiIncident {
+	incidents := make(map[string][]kaiIncident)
+	for _, rs := range test.Expect.Output.Result {
+		for _, ruleID := range slices.Sorted(maps.Keys(rs.Violations)) {
+			if len(test.Fixes.Rules) > 0 && !slices.Contains(test.Fixes.Rules, ruleID) {
+				continue
+			}
+			violation := rs.Violations[ruleID]
+			for _, incident := range violation.Incidents {
+				// Expected outputs hold paths relative to the normalized /source directory
+				rel := strings.TrimPrefix(incident.URI.Filename(), "/source")
+				file := filepath.Join(root, rel)
+
+				ki := kaiIncident{
+					URI:                  string(uri.File(file)),
+					Message:              incident.Message,
+					RulesetName:          rs.Name,
+					ViolationName:        ruleID,
+					ViolationDescription: violation.Description,
+				}
+				if incident.LineNumber != nil {
+					ki.LineNumber = *incident.LineNumber
+				}
+				if violation.Category != nil {
+					ki.ViolationCategory = string(*violation.Category)
+				}
+				incidents[file] = append(incidents[file], ki)
+			}
+		}
+	}
+	return incidents
+}
@@ -0,0 +1,204 @@
+package targets
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/config"
+	"go.lsp.dev/uri"
+)
+
+// fakeKaiServer serves one connection, answering every request with the result of handle
+// A notification is sent before each response to check that clients skip them
+func fakeKaiServer(t *testing.T, handle func(method string, params json.RawMessage) any) (string, int) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			header, err := textproto.NewReader(reader).ReadMIMEHeader()
+			if err != nil {
+				return
+			}
+			length, _ := strconv.Atoi(header.Get("Content-Length"))
+			body := make([]byte, length)
+			if _, err := io.ReadFull(reader, body); err != nil {
+				return
+			}
+
+			var req struct {
+				ID     int             `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := json.Unmarshal(body, &req); err != nil {
+				return
+			}
+			for _, msg := range []any{
+				map[string]any{"jsonrpc": "2.0", "method": "progress", "params": map[string]any{}},
+				map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": handle(req.Method, req.Params)},
+			} {
+				data, _ := json.Marshal(msg)
+				fmt.Fprintf(conn, "Content-Length: %d\r\n\r\n%s", len(data), data)
+			}
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port
+}
+
+func fixTest(dir string, rules []string) *config.TestDefinition {
+	line := 3
+	test := &config.TestDefinition{
+		Name:    "fixes",
+		WorkDir: filepath.Join(dir, "work"),
+		Fixes:   &config.FixConfig{Rules: rules, MaxIterations: 2},
+	}
+	test.Analysis.Application = "app"
+	test.Expect.Patches = "patches"
+	test.Expect.Output.Result = []konveyor.RuleSet{{
+		Name: "eap8/eap7",
+		Violations: map[string]konveyor.Violation{
+			"javax-to-jakarta-00001": {
+				Description: "javax to jakarta",
+				Incidents: []konveyor.Incident{
+					{URI: uri.File("/source/src/Order.java"), Message: "Replace javax", LineNumber: &line},
+					{URI: uri.File("/source/src/Item.java"), Message: "Replace javax"},
+				},
+			},
+			"hibernate-00002": {
+				Incidents: []konveyor.Incident{{URI: uri.File("/source/src/Order.java"), Message: "Update hibernate"}},
+			},
+		},
+	}}
+	test.SetTestFilePath(filepath.Join(dir, "test.yaml"))
+	return test
+}
+
+func TestFixIncidents(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []string
+		expected map[string]int
+	}{
+		{
+			name:     "all rules",
+			expected: map[string]int{"/app/src/Order.java": 2, "/app/src/Item.java": 1},
+		},
+		{
+			name:     "selected rules",
+			rules:    []string{"hibernate-00002"},
+			expected: map[string]int{"/app/src/Order.java": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incidents := fixIncidents(fixTest(t.TempDir(), tt.rules), "/app")
+			if len(incidents) != len(tt.expected) {
+				t.Fatalf("fixIncidents() returned %d files, want %d: %v", len(incidents), len(tt.expected), incidents)
+			}
+			for file, count := range tt.expected {
+				if len(incidents[file]) != count {
+					t.Errorf("%s has %d incidents, want %d", file, len(incidents[file]), count)
+				}
+				for _, incident := range incidents[file] {
+					if incident.URI != string(uri.File(file)) {
+						t.Errorf("incident URI = %s, want %s", incident.URI, uri.File(file))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestKaiRPCTarget_RequestFixes(t *testing.T) {
+	dir := t.TempDir()
+	var rootPath string
+	var requested []kaiSolutionParams
+
+	host, port := fakeKaiServer(t, func(method string, params json.RawMessage) any {
+		switch method {
+		case "initialize":
+			var p map[string]string
+			json.Unmarshal(params, &p)
+			rootPath = p["root_path"]
+			return nil
+		case "getCodeplanAgentSolution":
+			var p kaiSolutionParams
+			json.Unmarshal(params, &p)
+			requested = append(requested, p)
+			// Only Order.java needs changes
+			if filepath.Base(p.FilePath) != "Order.java" {
+				return kaiSolution{}
+			}
+			return kaiSolution{Diff: "--- a/src/Order.java\n+++ b/src/Order.java\n", ModifiedFiles: []string{p.FilePath}}
+		}
+		return nil
+	})
+
+	target, err := NewKaiRPCTarget(&config.KaiRPCConfig{Host: host, Port: port})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := target.Execute(context.Background(), fixTest(dir, nil))
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if rootPath != filepath.Join(dir, "app") {
+		t.Errorf("initialize root_path = %s, want %s", rootPath, filepath.Join(dir, "app"))
+	}
+	if len(requested) != 2 {
+		t.Fatalf("requested %d solutions, want 2", len(requested))
+	}
+	if requested[0].MaxIterations != 2 {
+		t.Errorf("max_iterations = %d, want 2", requested[0].MaxIterations)
+	}
+
+	entries, err := os.ReadDir(result.PatchesDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), "-Order.diff") {
+		t.Errorf("patches = %v, want a single Order.java patch", entries)
+	}
+}
+
+func TestKaiRPCTarget_RequestFixesErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	target := &KaiRPCTarget{host: "127.0.0.1", port: 1}
+	test := fixTest(dir, []string{"unknown-rule"})
+	if _, err := target.Execute(context.Background(), test); err == nil || !strings.Contains(err.Error(), "no incidents") {
+		t.Errorf("Execute() error = %v, want no incidents error", err)
+	}
+
+	test = fixTest(dir, nil)
+	test.Analysis.ApplicationGitComponents = &config.GitURLComponents{URL: "https://github.com/example/app.git"}
+	if _, err := target.Execute(context.Background(), test); err == nil {
+		t.Error("Execute() expected error for a git application")
+	}
+}
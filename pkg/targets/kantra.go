@@ -2,28 +2,43 @@ package targets
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/konveyor/analyzer-lsp/provider"
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/fixtures"
+	"github.com/konveyor/test-harness/pkg/progress"
 	"github.com/konveyor/test-harness/pkg/util"
 )
 
 // KantraTarget implements Target for Kantra
 type KantraTarget struct {
-	binaryPath    string
-	mavenSettings string
+	binaryPath     string
+	mavenSettings  string
+	gitAuth        *config.GitAuthConfig
+	providerImages map[string]string
+	outputDir      string
+	resources      *config.ResourceLimits
+	containerTool  string
+	containerHost  string
+	runLocal       bool
+	pullPolicy     string
+	runnerImage    string
+	offline        bool
+	sourceCache    string
 }
 
 // NewKantraTarget creates a new Kantra target
 func NewKantraTarget(cfg *config.KantraConfig) (*KantraTarget, error) {
 	var binaryPath string
-	var mavenSettings string
 
 	// Use configured path if provided
 	if cfg != nil && cfg.BinaryPath != "" {
@@ -37,15 +52,33 @@ func NewKantraTarget(cfg *config.KantraConfig) (*KantraTarget, error) {
 		}
 	}
 
-	// Get maven settings from config
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get maven settings, git credentials and container settings from config
+	target := &KantraTarget{
+		binaryPath: binaryPath,
+		outputDir:  "output",
+	}
 	if cfg != nil {
-		mavenSettings = cfg.MavenSettings
+		target.mavenSettings = cfg.MavenSettings
+		target.gitAuth = cfg.Git
+		target.providerImages = cfg.ProviderImages
+		target.resources = cfg.Resources
+		target.containerTool = cfg.ContainerTool
+		target.containerHost = cfg.ContainerHost
+		target.runLocal = cfg.RunLocal
+		target.pullPolicy = cfg.PullPolicy
+		target.runnerImage = cfg.RunnerImage
+		target.offline = cfg.Offline
+		target.sourceCache = cfg.SourceCache
+		if cfg.OutputDir != "" {
+			target.outputDir = cfg.OutputDir
+		}
 	}
 
-	return &KantraTarget{
-		binaryPath:    binaryPath,
-		mavenSettings: mavenSettings,
-	}, nil
+	return target, nil
 }
 
 // Name returns the target name
@@ -53,11 +86,124 @@ func (k *KantraTarget) Name() string {
 	return "kantra"
 }
 
+// Version returns the version reported by kantra version
+func (k *KantraTarget) Version(ctx context.Context) (string, error) {
+	return kantraVersion(ctx, k.binaryPath)
+}
+
+// Capabilities returns the features of the installed kantra version
+func (k *KantraTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	version, err := k.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return capabilitiesFor(k.Name(), version), nil
+}
+
+// kantraVersion runs kantra version and returns the version it reports
+// The output looks like "version: v0.6.0" followed by the commit and image
+func kantraVersion(ctx context.Context, binaryPath string) (string, error) {
+	out, err := exec.CommandContext(ctx, binaryPath, "version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get kantra version: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "version:"); ok {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if match := versionPattern.FindString(string(out)); match != "" {
+		return match, nil
+	}
+	return "", fmt.Errorf("no version in kantra output: %s", strings.TrimSpace(string(out)))
+}
+
 // Execute runs kantra analyze
 func (k *KantraTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	if test.IsMultiApplication() {
+		return k.executeApplications(ctx, test)
+	}
+	return k.execute(ctx, test)
+}
+
+// executeApplications runs kantra sequentially for each application of a multi-application test
+// The output of every run is keyed by the application name
+func (k *KantraTarget) executeApplications(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+
+	result := &ExecutionResult{
+		WorkDir:              test.GetWorkDir(),
+		ApplicationArtifacts: make(map[string]OutputArtifacts, len(test.Analysis.Applications)),
+		ApplicationWorkDirs:  make(map[string]string, len(test.Analysis.Applications)),
+	}
+
+	for _, app := range test.Analysis.Applications {
+		log.Info("Analyzing application", "test", test.Name, "application", app.Name)
+		appResult, err := k.execute(ctx, test.ForApplication(app))
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", app.Name, err)
+		}
+
+		result.Duration += appResult.Duration
+		result.PeakMemory = max(result.PeakMemory, appResult.PeakMemory)
+		if appResult.OOMKilled {
+			result.OOMKilled, result.ExitCode = true, appResult.ExitCode
+		}
+		result.Stdout += appResult.Stdout
+		result.Stderr += appResult.Stderr
+		result.ApplicationArtifacts[app.Name] = appResult.Artifacts
+		result.ApplicationWorkDirs[app.Name] = appResult.WorkDir
+	}
+
+	return result, nil
+}
+
+// kantraRun is a kantra analysis of a single application prepared to run
+type kantraRun struct {
+	workDir   string
+	outputDir string
+	args      []string
+	env       []string
+	limits    *config.ResourceLimits
+}
+
+// execute runs kantra analyze for a single application
+func (k *KantraTarget) execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
 	log := util.GetLogger()
 	log.Info("Executing Kantra analysis", "test", test.Name)
 
+	run, err := k.prepareRun(ctx, test)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execute kantra
+	progress.Update(ctx, "analyzing")
+	result, err := ExecuteCommandWithEnv(ctx, k.binaryPath, run.args, run.env, run.workDir, test.GetTimeout())
+	if err != nil {
+		// Running out of memory is a result, so tests can expect it
+		if result == nil || !oomKilled(run.workDir) {
+			return nil, err
+		}
+		log.Info("Analysis container ran out of memory", "test", test.Name, "memory", run.limits.Memory)
+		result.OOMKilled = true
+	}
+
+	// Record the artifacts kantra wrote (the output directory is already absolute) with its command logs
+	artifacts := collectArtifacts(run.outputDir)
+	maps.Copy(artifacts, result.Artifacts)
+	result.Artifacts = artifacts
+	if err := writeArtifactManifest(run.workDir, result.Artifacts); err != nil {
+		return nil, err
+	}
+
+	LogResult(log, result)
+
+	return result, nil
+}
+
+// prepareRun prepares the work directory, input, rules and arguments of a kantra run
+func (k *KantraTarget) prepareRun(ctx context.Context, test *config.TestDefinition) (*kantraRun, error) {
 	// Validate maven settings requirement
 	if test.RequireMavenSettings && k.mavenSettings == "" {
 		return nil, fmt.Errorf("test requires maven settings but none configured in target config")
@@ -76,20 +222,28 @@ func (k *KantraTarget) Execute(ctx context.Context, test *config.TestDefinition)
 	}
 
 	// Handle application input (clone git repo to test-dir/source if needed)
+	progress.Update(ctx, "preparing input")
 	inputPath, err := k.prepareInput(ctx, &test.Analysis, testDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare input: %w", err)
 	}
 
+	// A build missing subprojects would silently analyze less than expected
+	if build := test.Analysis.Build; build != nil && len(build.Subprojects) > 0 {
+		if err := CheckSubprojects(inputPath, build); err != nil {
+			return nil, err
+		}
+	}
+
 	// Handle rules that may be Git URLs
+	progress.Update(ctx, "preparing rules")
 	preparedRules, err := k.prepareRules(ctx, &test.Analysis, workDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare rules: %w", err)
 	}
 
 	// Create output directory with absolute path
-	outputDir := filepath.Join(workDir, "output")
-	absOutputDir, err := filepath.Abs(outputDir)
+	absOutputDir, err := filepath.Abs(k.testOutputDir(workDir))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get absolute output path: %w", err)
 	}
@@ -100,18 +254,75 @@ func (k *KantraTarget) Execute(ctx context.Context, test *config.TestDefinition)
 	// Build kantra command arguments with prepared rules
 	args := k.buildArgsWithPreparedRules(test.Analysis, inputPath, absOutputDir, k.mavenSettings, preparedRules)
 
-	// Execute kantra
-	result, err := ExecuteCommand(ctx, k.binaryPath, args, workDir, test.GetTimeout())
+	// Provider settings overrides are passed to kantra as a settings file
+	if len(test.Analysis.ProviderSettings) > 0 {
+		settingsFile, err := writeProviderSettings(test.Analysis.ProviderConfigs(), workDir)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--override-provider-settings", settingsFile)
+	}
+
+	// Test environment variables take precedence over the provider images
+	env, limits, err := k.containerEnv(test, workDir)
 	if err != nil {
 		return nil, err
 	}
+	return &kantraRun{workDir: workDir, outputDir: absOutputDir, args: args, env: env, limits: limits}, nil
+}
 
-	// Set the output file path (absOutputDir is already absolute)
-	result.OutputFile = filepath.Join(absOutputDir, "output.yaml")
+// containerEnv returns the environment of a kantra run and the resource limits of its containers
+// The test's env takes precedence over the container settings, the wrapper adding flags to container
+// runs wraps the container tool it selects
+func (k *KantraTarget) containerEnv(test *config.TestDefinition, workDir string) ([]string, *config.ResourceLimits, error) {
+	log := util.GetLogger()
 
-	LogResult(log, result)
+	env := k.providerImageEnv()
+	if k.runnerImage != "" {
+		env = append(env, "RUNNER_IMG="+k.runnerImage)
+	}
+	tool := k.containerTool
+	if tool != "" {
+		env = append(env, "CONTAINER_TOOL="+tool)
+	}
+	if k.containerHost != "" {
+		env = append(env, containerHostEnv(tool, k.containerHost))
+	}
+	env = append(env, test.Environ()...)
 
-	return result, nil
+	limits := k.resources.Merge(test.Resources)
+	flags := containerRunFlags(limits, k.pullPolicy)
+	if len(flags) == 0 {
+		return env, limits, nil
+	}
+	if k.runLocal {
+		log.Info("Resource limits and pull policy are ignored when running locally", "test", test.Name)
+		return env, &config.ResourceLimits{}, nil
+	}
+
+	if testTool := test.Env["CONTAINER_TOOL"]; testTool != "" {
+		tool = testTool
+	}
+	if tool == "" {
+		var err error
+		if tool, err = FindContainerTool(); err != nil {
+			return nil, nil, err
+		}
+	}
+	wrapper, err := writeContainerWrapper(tool, flags, limits.Memory != "", workDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(env, "CONTAINER_TOOL="+wrapper), limits, nil
+}
+
+// testOutputDir returns where kantra writes the outputs of a test
+// Relative output directories are inside the test's work directory, absolute ones get a subdirectory per run
+func (k *KantraTarget) testOutputDir(workDir string) string {
+	if filepath.IsAbs(k.outputDir) {
+		return filepath.Join(k.outputDir, filepath.Base(workDir))
+	}
+	return filepath.Join(workDir, k.outputDir)
 }
 
 // buildArgs constructs the kantra analyze command arguments
@@ -154,6 +365,24 @@ func (k *KantraTarget) buildArgs(analysis config.AnalysisConfig, inputPath, outp
 		}
 	}
 
+	// Providers to run instead of the detected ones
+	for _, p := range analysis.Providers {
+		args = append(args, "--provider", p)
+	}
+
+	// Build-less analyses don't resolve dependencies
+	if analysis.Build != nil && analysis.Build.BuildLess {
+		args = append(args, "--disable-maven-search", "--no-dependency-rules")
+	}
+
+	// Paths of the application left out of the analysis and folders holding its dependencies
+	for _, excluded := range analysis.ExcludedPaths {
+		args = append(args, "--excluded-paths", excluded)
+	}
+	for _, folder := range analysis.DependencyFolders {
+		args = append(args, "--dependency-folders", folder)
+	}
+
 	// Analysis mode
 	switch analysis.AnalysisMode {
 	case provider.SourceOnlyAnalysisMode:
@@ -163,8 +392,8 @@ func (k *KantraTarget) buildArgs(analysis config.AnalysisConfig, inputPath, outp
 		args = append(args, "--mode", "full")
 	}
 
-	// Use container mode instead of run-local to avoid dependency issues
-	args = append(args, "--run-local=false")
+	// Containers avoid dependency issues, running locally is for environments without them
+	args = append(args, fmt.Sprintf("--run-local=%t", k.runLocal))
 
 	// Allow overwriting existing output
 	args = append(args, "--overwrite")
@@ -213,6 +442,24 @@ func (k *KantraTarget) buildArgsWithPreparedRules(analysis config.AnalysisConfig
 		}
 	}
 
+	// Providers to run instead of the detected ones
+	for _, p := range analysis.Providers {
+		args = append(args, "--provider", p)
+	}
+
+	// Build-less analyses don't resolve dependencies
+	if analysis.Build != nil && analysis.Build.BuildLess {
+		args = append(args, "--disable-maven-search", "--no-dependency-rules")
+	}
+
+	// Paths of the application left out of the analysis and folders holding its dependencies
+	for _, excluded := range analysis.ExcludedPaths {
+		args = append(args, "--excluded-paths", excluded)
+	}
+	for _, folder := range analysis.DependencyFolders {
+		args = append(args, "--dependency-folders", folder)
+	}
+
 	// Analysis mode
 	switch analysis.AnalysisMode {
 	case provider.SourceOnlyAnalysisMode:
@@ -222,8 +469,8 @@ func (k *KantraTarget) buildArgsWithPreparedRules(analysis config.AnalysisConfig
 		args = append(args, "--mode", "full")
 	}
 
-	// Use container mode instead of run-local to avoid dependency issues
-	args = append(args, "--run-local=false")
+	// Containers avoid dependency issues, running locally is for environments without them
+	args = append(args, fmt.Sprintf("--run-local=%t", k.runLocal))
 
 	// Allow overwriting existing output
 	args = append(args, "--overwrite")
@@ -231,12 +478,63 @@ func (k *KantraTarget) buildArgsWithPreparedRules(analysis config.AnalysisConfig
 	return args
 }
 
-// prepareInput handles git URLs, local paths, and binary files
+// providerImageEnv returns the environment variables kantra reads provider images from,
+// e.g. DOTNET_PROVIDER_IMG for the dotnet provider and GENERIC_PROVIDER_IMG for go, python and nodejs
+func (k *KantraTarget) providerImageEnv() []string {
+	env := make([]string, 0, len(k.providerImages))
+	for _, name := range slices.Sorted(maps.Keys(k.providerImages)) {
+		impl := config.ProviderImplementation(name)
+		env = append(env, fmt.Sprintf("%s_PROVIDER_IMG=%s", strings.ToUpper(impl), k.providerImages[name]))
+	}
+	return env
+}
+
+// writeProviderSettings writes provider settings overrides as JSON into workDir
+// and returns the absolute path of the file
+func writeProviderSettings(configs []provider.Config, workDir string) (string, error) {
+	data, err := json.MarshalIndent(configs, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal provider settings: %w", err)
+	}
+
+	settingsFile, err := filepath.Abs(filepath.Join(workDir, "provider-settings.json"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute provider settings path: %w", err)
+	}
+	if err := os.WriteFile(settingsFile, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write provider settings: %w", err)
+	}
+
+	return settingsFile, nil
+}
+
+// prepareInput handles git and subversion URLs, container images, fixtures, archives, local paths, and binary files
 // Returns the local path to use as input for kantra
 func (k *KantraTarget) prepareInput(ctx context.Context, analysis *config.AnalysisConfig, workDir string) (string, error) {
 	log := util.GetLogger()
 	application := analysis.Application
 
+	// Check if it's a container image, the artifact or source is extracted from it
+	if analysis.ApplicationImageComponents != nil {
+		if k.offline {
+			return "", fmt.Errorf("offline runs can't pull the application image %s", analysis.ApplicationImageComponents.Image)
+		}
+		log.Info("Detected container image input", "image", analysis.ApplicationImageComponents.Image)
+		return ExtractImageApplication(ctx, analysis.ApplicationImageComponents, workDir)
+	}
+
+	// Check if it's an embedded fixture, it is written to the work directory without network access
+	if config.IsFixtureURL(application) {
+		log.Info("Detected fixture input", "fixture", config.FixtureName(application))
+		return fixtures.Materialize(config.FixtureName(application), workDir)
+	}
+
+	// Check if it's a source archive, it is extracted next to the test like cloned repositories
+	if IsArchiveFile(application) {
+		log.Info("Detected archive input", "file", application)
+		return ExtractArchive(application, workDir, workDir)
+	}
+
 	// Check if it's a binary file (.jar, .war, .ear)
 	if IsBinaryFile(application) {
 		log.Info("Detected binary input", "file", application)
@@ -246,7 +544,7 @@ func (k *KantraTarget) prepareInput(ctx context.Context, analysis *config.Analys
 	// Check if we have parsed Git components
 	if analysis.ApplicationGitComponents != nil {
 		// Clone the repository using parsed components
-		return CloneGitRepository(ctx, analysis.ApplicationGitComponents, workDir, "source")
+		return k.checkoutRepository(ctx, analysis.ApplicationGitComponents, workDir, "source")
 	}
 
 	// It's a local path or binary reference
@@ -262,6 +560,15 @@ func (k *KantraTarget) prepareInput(ctx context.Context, analysis *config.Analys
 	return application, nil
 }
 
+// checkoutRepository clones a repository into workDir/cloneName, offline runs use its checkout in the
+// source cache instead
+func (k *KantraTarget) checkoutRepository(ctx context.Context, components *config.GitURLComponents, workDir, cloneName string) (string, error) {
+	if k.offline {
+		return CachedRepository(k.sourceCache, components)
+	}
+	return CloneGitRepository(ctx, components, k.gitAuth, workDir, cloneName)
+}
+
 // prepareRules handles rules that may be Git URLs or local paths
 // Returns a list of prepared rule paths
 func (k *KantraTarget) prepareRules(ctx context.Context, analysis *config.AnalysisConfig, workDir string) ([]string, error) {
@@ -278,7 +585,7 @@ func (k *KantraTarget) prepareRules(ctx context.Context, analysis *config.Analys
 			log.Info("Detected Git URL for rule", "rule", rule)
 			// Clone the repository to a unique directory for this rule
 			cloneName := fmt.Sprintf("rules-%d", i)
-			clonedPath, err := CloneGitRepository(ctx, analysis.RulesGitComponents[i], workDir, cloneName)
+			clonedPath, err := k.checkoutRepository(ctx, analysis.RulesGitComponents[i], workDir, cloneName)
 			if err != nil {
 				return nil, fmt.Errorf("failed to clone rules repository %s: %w", rule, err)
 			}
@@ -53,6 +53,14 @@ func TestNewKantraTarget(t *testing.T) {
 			checkPath:  true,
 			expectPath: "/usr/local/bin/kantra",
 		},
+		{
+			name: "invalid pull policy",
+			cfg: &config.KantraConfig{
+				BinaryPath: "/usr/local/bin/kantra",
+				PullPolicy: "sometimes",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -187,6 +195,56 @@ func TestKantraTarget_BuildArgs(t *testing.T) {
 				"--rules", "/custom/rules2",
 			},
 		},
+		{
+			name: "analysis with providers",
+			analysis: config.AnalysisConfig{
+				AnalysisMode: provider.SourceOnlyAnalysisMode,
+				Providers:    []string{"dotnet"},
+			},
+			inputPath: "/path/to/app",
+			outputDir: "/path/to/output",
+			expectContain: []string{
+				"--provider", "dotnet",
+			},
+		},
+		{
+			name: "build-less gradle analysis",
+			analysis: config.AnalysisConfig{
+				AnalysisMode: provider.FullAnalysisMode,
+				Build:        &config.BuildOptions{Tool: config.BuildToolGradle, BuildLess: true},
+			},
+			inputPath: "/path/to/app",
+			outputDir: "/path/to/output",
+			expectContain: []string{
+				"--disable-maven-search", "--no-dependency-rules",
+			},
+		},
+		{
+			name: "excluded paths and dependency folders",
+			analysis: config.AnalysisConfig{
+				AnalysisMode:      provider.SourceOnlyAnalysisMode,
+				ExcludedPaths:     []string{"generated", "src/test"},
+				DependencyFolders: []string{"lib"},
+			},
+			inputPath: "/path/to/app",
+			outputDir: "/path/to/output",
+			expectContain: []string{
+				"--excluded-paths", "generated", "src/test",
+				"--dependency-folders", "lib",
+			},
+		},
+		{
+			name: "gradle analysis with dependencies",
+			analysis: config.AnalysisConfig{
+				AnalysisMode: provider.FullAnalysisMode,
+				Build:        &config.BuildOptions{Tool: config.BuildToolGradle},
+			},
+			inputPath: "/path/to/app",
+			outputDir: "/path/to/output",
+			expectNotContain: []string{
+				"--disable-maven-search", "--no-dependency-rules",
+			},
+		},
 		{
 			name: "analysis without maven settings",
 			analysis: config.AnalysisConfig{
@@ -839,3 +897,94 @@ func TestKantraTarget_GitURLIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteProviderSettings(t *testing.T) {
+	workDir := t.TempDir()
+	configs := []provider.Config{{
+		Name: "java",
+		InitConfig: []provider.InitConfig{{
+			ProviderSpecificConfig: map[string]interface{}{"excludedPaths": []interface{}{"target"}},
+		}},
+	}}
+
+	settingsFile, err := writeProviderSettings(configs, workDir)
+	if err != nil {
+		t.Fatalf("writeProviderSettings() error = %v", err)
+	}
+	if !filepath.IsAbs(settingsFile) {
+		t.Errorf("settings file path %s is not absolute", settingsFile)
+	}
+
+	data, err := os.ReadFile(settingsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"excludedPaths"`) || !strings.Contains(string(data), `"name": "java"`) {
+		t.Errorf("unexpected provider settings: %s", data)
+	}
+}
+
+func TestKantraTarget_ProviderImageEnv(t *testing.T) {
+	k := &KantraTarget{
+		providerImages: map[string]string{
+			"java":   "quay.io/konveyor/java-external-provider:latest",
+			"dotnet": "quay.io/konveyor/dotnet-external-provider:latest",
+		},
+	}
+
+	got := k.providerImageEnv()
+	want := []string{
+		"DOTNET_PROVIDER_IMG=quay.io/konveyor/dotnet-external-provider:latest",
+		"JAVA_PROVIDER_IMG=quay.io/konveyor/java-external-provider:latest",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("providerImageEnv() = %v, want %v", got, want)
+	}
+}
+
+func TestKantraTarget_ContainerEnv(t *testing.T) {
+	test := &config.TestDefinition{
+		Name:      "limited",
+		Env:       map[string]string{"CONTAINER_TOOL": "/opt/bin/podman"},
+		Resources: &config.ResourceLimits{Memory: "2g"},
+	}
+
+	k := &KantraTarget{
+		containerTool: "/usr/bin/docker",
+		containerHost: "unix:///var/run/docker.sock",
+		runnerImage:   "quay.io/konveyor/kantra:latest",
+		pullPolicy:    "never",
+	}
+	workDir := t.TempDir()
+	env, limits, err := k.containerEnv(test, workDir)
+	if err != nil {
+		t.Fatalf("containerEnv() error = %v", err)
+	}
+	want := []string{
+		"RUNNER_IMG=quay.io/konveyor/kantra:latest",
+		"CONTAINER_TOOL=/usr/bin/docker",
+		"DOCKER_HOST=unix:///var/run/docker.sock",
+		"CONTAINER_TOOL=/opt/bin/podman",
+		"CONTAINER_TOOL=" + filepath.Join(workDir, containerToolWrapper),
+	}
+	if strings.Join(env, ",") != strings.Join(want, ",") {
+		t.Errorf("containerEnv() = %v, want %v", env, want)
+	}
+	if limits.Memory != "2g" {
+		t.Errorf("containerEnv() limits = %+v", limits)
+	}
+	// The wrapper runs the test's container tool
+	if script, _ := os.ReadFile(filepath.Join(workDir, containerToolWrapper)); !strings.Contains(string(script), "exec '/opt/bin/podman'") {
+		t.Errorf("wrapper doesn't run the test's container tool:\n%s", script)
+	}
+
+	// Nothing is wrapped without containers
+	k.runLocal = true
+	env, limits, err = k.containerEnv(test, t.TempDir())
+	if err != nil {
+		t.Fatalf("containerEnv() error = %v", err)
+	}
+	if !limits.IsZero() || strings.Contains(strings.Join(env, ","), containerToolWrapper) {
+		t.Errorf("containerEnv() running locally = %v, %+v", env, limits)
+	}
+}
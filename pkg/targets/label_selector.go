@@ -1,15 +1,20 @@
 package targets
 
 import (
+	"fmt"
 	"strings"
 )
 
 // ParseLabelSelector parses a label selector string into included and excluded labels.
 // The label selector format supports:
 // - OR operations with "||"
+// - AND operations with "&&" and parentheses, see ParseLabelExpression
 // - Negation with "!" prefix for exclusions
 // - Key-value pairs in format "key=value"
 //
+// Labels under an odd number of negations are excluded, the others are included. Selectors that aren't
+// valid expressions are split on "||" as is.
+//
 // Examples:
 //   - "konveyor.io/target=cloud-readiness || konveyor.io/target=linux" -> Included: ["konveyor.io/target=cloud-readiness", "konveyor.io/target=linux"]
 //   - "!konveyor.io/target=windows" -> Excluded: ["konveyor.io/target=windows"]
@@ -20,10 +25,25 @@ func ParseLabelSelector(selector string) Labels {
 		Excluded: []string{},
 	}
 
-	if selector == "" {
+	if strings.TrimSpace(selector) == "" {
 		return labels
 	}
 
+	expr, err := ParseLabelExpression(selector)
+	if err != nil {
+		return splitLabelSelector(selector)
+	}
+	collectLabels(expr, false, &labels)
+	return labels
+}
+
+// splitLabelSelector splits a label selector on "||", labels with a "!" prefix are excluded
+func splitLabelSelector(selector string) Labels {
+	labels := Labels{
+		Included: []string{},
+		Excluded: []string{},
+	}
+
 	// Split by OR operator
 	parts := strings.Split(selector, "||")
 
@@ -49,3 +69,233 @@ func ParseLabelSelector(selector string) Labels {
 
 	return labels
 }
+
+// collectLabels adds the labels of an expression to the included or excluded labels
+func collectLabels(expr LabelExpression, negated bool, labels *Labels) {
+	switch e := expr.(type) {
+	case labelMatch:
+		if negated {
+			labels.Excluded = append(labels.Excluded, string(e))
+		} else {
+			labels.Included = append(labels.Included, string(e))
+		}
+	case notExpression:
+		collectLabels(e.operand, !negated, labels)
+	case binaryExpression:
+		for _, operand := range e.operands {
+			collectLabels(operand, negated, labels)
+		}
+	}
+}
+
+// LabelExpression is a parsed label selector that tells whether the labels of a rule are selected
+type LabelExpression interface {
+	// Evaluate reports whether a rule with the given labels matches the expression
+	Evaluate(labels []string) bool
+	String() string
+}
+
+// ParseLabelExpression parses a label selector into an expression. Operators are, by precedence,
+// "!", "&&" and "||", parentheses group sub-expressions. An empty selector matches every rule.
+//
+// A label "key=value" matches a rule label with the same key and value. A label without a value, or
+// with the value "*", matches the key with any value.
+//
+// Examples:
+//   - "konveyor.io/target=quarkus && !konveyor.io/source=java8"
+//   - "(konveyor.io/target=quarkus || konveyor.io/target=jakarta-ee) && konveyor.io/source"
+func ParseLabelExpression(selector string) (LabelExpression, error) {
+	tokens := tokenizeLabelSelector(selector)
+	if len(tokens) == 0 {
+		return matchAll{}, nil
+	}
+
+	p := &labelParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid label selector %q: unexpected %q", selector, p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// Label selector operators
+const (
+	labelAnd    = "&&"
+	labelOr     = "||"
+	labelNot    = "!"
+	labelOpen   = "("
+	labelClose  = ")"
+	labelAnyVal = "*"
+)
+
+// tokenizeLabelSelector splits a label selector into operators and labels. Labels end at white space,
+// parentheses and binary operators, "!" is only an operator at the start of a label
+func tokenizeLabelSelector(selector string) []string {
+	var tokens []string
+	for i := 0; i < len(selector); {
+		switch rest := selector[i:]; {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			i++
+		case strings.HasPrefix(rest, labelAnd), strings.HasPrefix(rest, labelOr):
+			tokens = append(tokens, rest[:2])
+			i += 2
+		case strings.HasPrefix(rest, labelNot), strings.HasPrefix(rest, labelOpen), strings.HasPrefix(rest, labelClose):
+			tokens = append(tokens, rest[:1])
+			i++
+		default:
+			end := len(rest)
+			for j := 0; j < len(rest); j++ {
+				if strings.ContainsRune(" \t\n\r()", rune(rest[j])) ||
+					strings.HasPrefix(rest[j:], labelAnd) || strings.HasPrefix(rest[j:], labelOr) {
+					end = j
+					break
+				}
+			}
+			tokens = append(tokens, rest[:end])
+			i += end
+		}
+	}
+	return tokens
+}
+
+// labelParser is a recursive descent parser over label selector tokens
+type labelParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *labelParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// parseOr parses operands joined by "||"
+func (p *labelParser) parseOr() (LabelExpression, error) {
+	return p.parseBinary(labelOr, p.parseAnd)
+}
+
+// parseAnd parses operands joined by "&&"
+func (p *labelParser) parseAnd() (LabelExpression, error) {
+	return p.parseBinary(labelAnd, p.parseUnary)
+}
+
+func (p *labelParser) parseBinary(op string, operand func() (LabelExpression, error)) (LabelExpression, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	operands := []LabelExpression{first}
+	for p.peek() == op {
+		p.pos++
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return binaryExpression{op: op, operands: operands}, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression or a label
+func (p *labelParser) parseUnary() (LabelExpression, error) {
+	switch token := p.peek(); token {
+	case "":
+		return nil, fmt.Errorf("invalid label selector: unexpected end of expression")
+	case labelNot:
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpression{operand: operand}, nil
+	case labelOpen:
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != labelClose {
+			return nil, fmt.Errorf("invalid label selector: missing %q", labelClose)
+		}
+		p.pos++
+		return expr, nil
+	case labelAnd, labelOr, labelClose:
+		return nil, fmt.Errorf("invalid label selector: unexpected %q", token)
+	default:
+		p.pos++
+		return labelMatch(token), nil
+	}
+}
+
+// matchAll is the expression of an empty selector
+type matchAll struct{}
+
+func (matchAll) Evaluate([]string) bool { return true }
+func (matchAll) String() string         { return "" }
+
+// labelMatch matches a single label
+type labelMatch string
+
+func (l labelMatch) Evaluate(labels []string) bool {
+	key, value, hasValue := strings.Cut(string(l), "=")
+	for _, label := range labels {
+		labelKey, labelValue, _ := strings.Cut(label, "=")
+		if labelKey != key {
+			continue
+		}
+		if !hasValue || value == labelAnyVal || value == labelValue {
+			return true
+		}
+	}
+	return false
+}
+
+func (l labelMatch) String() string { return string(l) }
+
+// notExpression negates its operand
+type notExpression struct {
+	operand LabelExpression
+}
+
+func (n notExpression) Evaluate(labels []string) bool { return !n.operand.Evaluate(labels) }
+
+func (n notExpression) String() string {
+	if _, ok := n.operand.(binaryExpression); ok {
+		return labelNot + labelOpen + n.operand.String() + labelClose
+	}
+	return labelNot + n.operand.String()
+}
+
+// binaryExpression joins its operands with "&&" or "||"
+type binaryExpression struct {
+	op       string
+	operands []LabelExpression
+}
+
+func (b binaryExpression) Evaluate(labels []string) bool {
+	for _, operand := range b.operands {
+		if operand.Evaluate(labels) == (b.op == labelOr) {
+			return b.op == labelOr
+		}
+	}
+	return b.op == labelAnd
+}
+
+func (b binaryExpression) String() string {
+	parts := make([]string, len(b.operands))
+	for i, operand := range b.operands {
+		parts[i] = operand.String()
+		if inner, ok := operand.(binaryExpression); ok && inner.op != b.op {
+			parts[i] = labelOpen + parts[i] + labelClose
+		}
+	}
+	return strings.Join(parts, " "+b.op+" ")
+}
@@ -131,3 +131,121 @@ func TestParseLabelSelectorIncludedCount(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLabelSelectorExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Labels
+	}{
+		{
+			name:     "AND of included and excluded",
+			selector: "konveyor.io/target=quarkus && !konveyor.io/source=java8",
+			want: Labels{
+				Included: []string{"konveyor.io/target=quarkus"},
+				Excluded: []string{"konveyor.io/source=java8"},
+			},
+		},
+		{
+			name:     "negated group",
+			selector: "konveyor.io/target=quarkus && !(konveyor.io/source=java8 || konveyor.io/source=java11)",
+			want: Labels{
+				Included: []string{"konveyor.io/target=quarkus"},
+				Excluded: []string{"konveyor.io/source=java8", "konveyor.io/source=java11"},
+			},
+		},
+		{
+			name:     "invalid expression is split on OR",
+			selector: "konveyor.io/target=quarkus || (konveyor.io/target=linux",
+			want: Labels{
+				Included: []string{"konveyor.io/target=quarkus", "(konveyor.io/target=linux"},
+				Excluded: []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLabelSelector(tt.selector)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLabelSelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLabelExpression(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     string
+		wantErr  bool
+	}{
+		{name: "empty", selector: "", want: ""},
+		{name: "single label", selector: "konveyor.io/target=quarkus", want: "konveyor.io/target=quarkus"},
+		{name: "AND binds tighter than OR", selector: "a=1 || b=2 && c=3", want: "a=1 || (b=2 && c=3)"},
+		{name: "parentheses", selector: "(a=1 || b=2) && c=3", want: "(a=1 || b=2) && c=3"},
+		{name: "negated group", selector: "!(a=1||b=2)", want: "!(a=1 || b=2)"},
+		{name: "double negation", selector: "!!a=1", want: "!!a=1"},
+		{name: "no spaces", selector: "a=1&&!b=2", want: "a=1 && !b=2"},
+		{name: "plus in value", selector: "konveyor.io/target=jakarta-ee9+", want: "konveyor.io/target=jakarta-ee9+"},
+		{name: "missing operand", selector: "a=1 &&", wantErr: true},
+		{name: "missing closing parenthesis", selector: "(a=1 || b=2", wantErr: true},
+		{name: "unexpected closing parenthesis", selector: "a=1)", wantErr: true},
+		{name: "leading operator", selector: "|| a=1", wantErr: true},
+		{name: "empty group", selector: "()", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLabelExpression(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLabelExpression() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("ParseLabelExpression() = %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelExpressionEvaluate(t *testing.T) {
+	quarkus := []string{"konveyor.io/target=quarkus", "konveyor.io/source=java-ee"}
+	tests := []struct {
+		name     string
+		selector string
+		labels   []string
+		want     bool
+	}{
+		{"empty selector matches any rule", "", quarkus, true},
+		{"empty selector matches unlabeled rule", "", nil, true},
+		{"label", "konveyor.io/target=quarkus", quarkus, true},
+		{"other value", "konveyor.io/target=linux", quarkus, false},
+		{"key without value", "konveyor.io/source", quarkus, true},
+		{"wildcard value", "konveyor.io/source=*", quarkus, true},
+		{"missing key", "konveyor.io/target", []string{"konveyor.io/source=java-ee"}, false},
+		{"key is not a prefix", "konveyor.io/target=quark", quarkus, false},
+		{"OR", "konveyor.io/target=linux || konveyor.io/target=quarkus", quarkus, true},
+		{"AND", "konveyor.io/target=quarkus && konveyor.io/source=java-ee", quarkus, true},
+		{"AND with a missing label", "konveyor.io/target=quarkus && konveyor.io/source=java8", quarkus, false},
+		{"negation", "!konveyor.io/target=quarkus", quarkus, false},
+		{"precedence", "konveyor.io/target=linux && konveyor.io/source=java8 || konveyor.io/target=quarkus", quarkus, true},
+		{"parentheses", "konveyor.io/target=linux && (konveyor.io/source=java8 || konveyor.io/target=quarkus)", quarkus, false},
+		{"negated group", "!(konveyor.io/target=linux || konveyor.io/source=java8)", quarkus, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseLabelExpression(tt.selector)
+			if err != nil {
+				t.Fatalf("ParseLabelExpression() error = %v", err)
+			}
+			if got := expr.Evaluate(tt.labels); got != tt.want {
+				t.Errorf("Evaluate(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
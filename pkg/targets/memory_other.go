@@ -0,0 +1,10 @@
+//go:build !unix
+
+package targets
+
+import "os"
+
+// peakMemory returns 0, the peak memory of processes is only known on Unix systems
+func peakMemory(state *os.ProcessState) uint64 {
+	return 0
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package targets
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+)
+
+// peakMemory returns the peak resident memory in bytes of a finished process, 0 when unknown
+func peakMemory(state *os.ProcessState) uint64 {
+	if state == nil {
+		return 0
+	}
+	usage, ok := state.SysUsage().(*syscall.Rusage)
+	if !ok || usage.Maxrss <= 0 {
+		return 0
+	}
+	// Darwin reports bytes, the other systems kilobytes
+	if runtime.GOOS == "darwin" {
+		return uint64(usage.Maxrss)
+	}
+	return uint64(usage.Maxrss) * 1024
+}
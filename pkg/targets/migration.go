@@ -0,0 +1,223 @@
+package targets
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/jira"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+const (
+	// trackerTimeout is how long the Hub has to connect to a tracker and to create a ticket in it
+	trackerTimeout = 3 * time.Minute
+	// trackerPollInterval is the time between two checks of a tracker or ticket
+	trackerPollInterval = 2 * time.Second
+	// mockJiraUser is the user the Hub authenticates to the mock Jira as
+	mockJiraUser = "koncur"
+)
+
+// trackerMetadata is a project or issue type of a tracker as the Hub lists them
+type trackerMetadata struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Migrate adds the application of a migration test to a migration wave and exports it to the test's Jira
+// tracker, like exporting a wave in the UI, then records the ticket the Hub created. Without a tracker URL
+// the ticket is created in a mock Jira started for the test. The Hub resources are deleted afterwards,
+// tickets created in a real Jira are kept
+func (t *TackleHubTarget) Migrate(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	start := time.Now()
+	tracker := test.Migration.Tracker
+
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var mock *jira.Mock
+	trackerURL := tracker.URL
+	var identity api.Ref
+	if tracker.IsMock() {
+		if t.mockJira == nil {
+			return nil, fmt.Errorf("migration test %s uses the mock Jira, configure 'tackleHub.mockJira' in the target config", test.Name)
+		}
+		password := rand.Text()
+		if mock, err = jira.NewMock(t.mockJira.GetListen(), mockJiraUser, password); err != nil {
+			return nil, err
+		}
+		defer mock.Close()
+		mock.AddProject(tracker.Project, tracker.IssueType)
+		trackerURL = t.mockJira.URL
+
+		created := &api.Identity{Name: "koncur-" + test.Name, Kind: "basic-auth", User: mockJiraUser, Password: password}
+		if err := t.client.Identity.Create(created); err != nil {
+			return nil, fmt.Errorf("failed to create the identity of the mock Jira: %w", err)
+		}
+		defer t.deleteResource("identity", created.ID, t.client.Identity.Delete)
+		identity = api.Ref{ID: created.ID, Name: created.Name}
+	} else {
+		identities, err := t.client.Identity.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list identities: %w", err)
+		}
+		i := slices.IndexFunc(identities, func(id api.Identity) bool { return id.Name == tracker.Identity })
+		if i < 0 {
+			return nil, fmt.Errorf("identity %s not found in the Hub", tracker.Identity)
+		}
+		identity = api.Ref{ID: identities[i].ID, Name: identities[i].Name}
+	}
+
+	progress.Update(ctx, "connecting tracker")
+	created := &api.Tracker{Name: "koncur-" + test.Name, URL: trackerURL, Kind: tracker.GetKind(), Identity: identity}
+	if err := t.client.Tracker.Create(created); err != nil {
+		return nil, fmt.Errorf("failed to create tracker %s: %w", trackerURL, err)
+	}
+	defer t.deleteResource("tracker", created.ID, t.client.Tracker.Delete)
+	if err := t.waitTrackerConnected(ctx, created.ID); err != nil {
+		return nil, err
+	}
+	project, issueType, err := t.trackerIssueType(created.ID, tracker.Project, tracker.IssueType)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.Update(ctx, "creating application")
+	app, err := t.createApplication(ctx, test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create application: %w", err)
+	}
+
+	progress.Update(ctx, "creating migration wave")
+	today := time.Now().Truncate(24 * time.Hour)
+	wave := &api.MigrationWave{
+		Name:         test.Migration.GetWave(test.Name),
+		StartDate:    today,
+		EndDate:      today.AddDate(0, 1, 0),
+		Applications: []api.Ref{{ID: app.ID, Name: app.Name}},
+	}
+	if err := t.client.MigrationWave.Create(wave); err != nil {
+		return nil, fmt.Errorf("failed to create migration wave %s: %w", wave.Name, err)
+	}
+	defer t.deleteResource("migration wave", wave.ID, t.client.MigrationWave.Delete)
+
+	// Exporting a wave creates a ticket for each of its applications
+	progress.Update(ctx, "exporting migration wave")
+	ticket := &api.Ticket{
+		Tracker:     api.Ref{ID: created.ID, Name: created.Name},
+		Application: api.Ref{ID: app.ID, Name: app.Name},
+		Parent:      project,
+		Kind:        issueType,
+	}
+	if err := t.client.Ticket.Create(ticket); err != nil {
+		return nil, fmt.Errorf("failed to create the ticket of application %s: %w", app.Name, err)
+	}
+	defer t.deleteResource("ticket", ticket.ID, t.client.Ticket.Delete)
+	if ticket, err = t.waitTicketCreated(ctx, ticket.ID, test.Expect.Ticket.Status != ""); err != nil {
+		return nil, err
+	}
+	log.Info("Ticket created", "ticketID", ticket.ID, "reference", ticket.Reference, "link", ticket.Link, "status", ticket.Status)
+
+	result := &TicketResult{Reference: ticket.Reference, Link: ticket.Link, Status: ticket.Status}
+	if ticket.Error {
+		result.Error = ticket.Message
+	}
+	if mock != nil {
+		issue, ok := mock.Issue(ticket.Reference)
+		if !ok && result.Error == "" {
+			result.Error = fmt.Sprintf("issue %s not found in the mock Jira", ticket.Reference)
+		}
+		result.Summary, result.IssueType = issue.Summary, issue.IssueType
+	}
+	return &ExecutionResult{WorkDir: workDir, Duration: time.Since(start), Ticket: result}, nil
+}
+
+// waitTrackerConnected polls a tracker until the Hub connected to it
+func (t *TackleHubTarget) waitTrackerConnected(ctx context.Context, id uint) error {
+	deadline := time.Now().Add(trackerTimeout)
+	for {
+		tracker, err := t.client.Tracker.Get(id)
+		if err != nil {
+			return fmt.Errorf("failed to get tracker %d: %w", id, err)
+		}
+		if tracker.Connected {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("hub didn't connect to tracker %s within %v: %s", tracker.URL, trackerTimeout, tracker.Message)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(trackerPollInterval):
+		}
+	}
+}
+
+// waitTicketCreated polls a ticket until the Hub created it in the tracker or failed to, and until its
+// status was refreshed when withStatus is set
+func (t *TackleHubTarget) waitTicketCreated(ctx context.Context, id uint, withStatus bool) (*api.Ticket, error) {
+	deadline := time.Now().Add(trackerTimeout)
+	for {
+		ticket, err := t.client.Ticket.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket %d: %w", id, err)
+		}
+		if ticket.Error || ticket.Reference != "" && (!withStatus || ticket.Status != "") {
+			return ticket, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("hub didn't create ticket %d within %v", id, trackerTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(trackerPollInterval):
+		}
+	}
+}
+
+// trackerIssueType returns the IDs of a project of a tracker and of one of its issue types
+func (t *TackleHubTarget) trackerIssueType(trackerID uint, projectName, issueTypeName string) (string, string, error) {
+	var projects []trackerMetadata
+	if err := t.client.Client.Get(fmt.Sprintf("/trackers/%d/projects", trackerID), &projects); err != nil {
+		return "", "", fmt.Errorf("failed to list the projects of tracker %d: %w", trackerID, err)
+	}
+	project, ok := findMetadata(projects, projectName)
+	if !ok {
+		return "", "", fmt.Errorf("project %s not found in tracker %d", projectName, trackerID)
+	}
+	var issueTypes []trackerMetadata
+	if err := t.client.Client.Get(fmt.Sprintf("/trackers/%d/projects/%s/issuetypes", trackerID, project), &issueTypes); err != nil {
+		return "", "", fmt.Errorf("failed to list the issue types of project %s: %w", projectName, err)
+	}
+	issueType, ok := findMetadata(issueTypes, issueTypeName)
+	if !ok {
+		return "", "", fmt.Errorf("issue type %s not found in project %s", issueTypeName, projectName)
+	}
+	return project, issueType, nil
+}
+
+// findMetadata returns the ID of the project or issue type with a name
+func findMetadata(metadata []trackerMetadata, name string) (string, bool) {
+	i := slices.IndexFunc(metadata, func(m trackerMetadata) bool { return m.Name == name })
+	if i < 0 {
+		return "", false
+	}
+	return metadata[i].ID, true
+}
+
+// deleteResource deletes a Hub resource created by a test, failures are logged only
+func (t *TackleHubTarget) deleteResource(kind string, id uint, del func(uint) error) {
+	if err := del(id); err != nil {
+		util.GetLogger().Info("Failed to delete "+kind, "id", id, "error", err.Error())
+	}
+}
@@ -0,0 +1,25 @@
+package targets
+
+import "testing"
+
+func TestFindMetadata(t *testing.T) {
+	projects := []trackerMetadata{{ID: "10001", Name: "MIG"}, {ID: "10002", Name: "OPS"}}
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{name: "OPS", want: "10002", wantOK: true},
+		{name: "ops"},
+		{name: "DEV"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := findMetadata(projects, tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("findMetadata(%s) = %s, %v, want %s, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
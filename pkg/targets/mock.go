@@ -0,0 +1,234 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// malformedOutput is written instead of the output for the malformed-output failure
+const malformedOutput = "- name: [unterminated\n  violations: {\n"
+
+// MockTarget implements Target by returning canned outputs, for testing the harness itself
+// Outputs are copied from a fixture directory per test, or from the test's expectations
+// when it has no fixtures. Failures can be simulated for some or all tests.
+type MockTarget struct {
+	fixturesDir string
+	failure     string
+	exitCode    int
+	failTests   []string
+	version     string
+}
+
+// NewMockTarget creates a new mock target
+func NewMockTarget(cfg *config.MockConfig) (*MockTarget, error) {
+	if cfg == nil {
+		return &MockTarget{exitCode: 1}, nil
+	}
+
+	switch cfg.Failure {
+	case "", config.MockFailureTimeout, config.MockFailureExitCode, config.MockFailureMalformedOutput:
+	default:
+		return nil, fmt.Errorf("unknown mock failure: %s (expected %s, %s or %s)", cfg.Failure,
+			config.MockFailureTimeout, config.MockFailureExitCode, config.MockFailureMalformedOutput)
+	}
+
+	exitCode := cfg.ExitCode
+	if exitCode == 0 {
+		exitCode = 1
+	}
+
+	return &MockTarget{
+		fixturesDir: cfg.FixturesDir,
+		failure:     cfg.Failure,
+		exitCode:    exitCode,
+		failTests:   cfg.FailTests,
+		version:     cfg.Version,
+	}, nil
+}
+
+// Name returns the target name
+func (m *MockTarget) Name() string {
+	return "mock"
+}
+
+// Version returns the configured kantra version the mock simulates
+func (m *MockTarget) Version(ctx context.Context) (string, error) {
+	return m.version, nil
+}
+
+// Capabilities returns the capabilities of the simulated kantra version, unknown without one
+func (m *MockTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	if m.version == "" {
+		return nil, nil
+	}
+	return capabilitiesFor("kantra", m.version), nil
+}
+
+// Execute writes the canned outputs of a test into a new work directory
+// Outputs use the same layout as the other targets so they can be validated again later
+func (m *MockTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
+	log := util.GetLogger()
+	log.Info("Executing mock target", "test", test.Name)
+
+	failure := m.failureFor(test)
+	start := time.Now()
+	if failure == config.MockFailureTimeout {
+		timer := time.NewTimer(test.GetTimeout())
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to execute command: %w", ctx.Err())
+		case <-timer.C:
+			return nil, fmt.Errorf("failed to execute command: timed out after %s", test.GetTimeout())
+		}
+	}
+
+	workDir, err := PrepareWorkDir(test.GetWorkDir(), test.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtureDir := m.fixtureDir(test)
+	if fixtureDir != "" {
+		log.Info("Using fixtures", "directory", fixtureDir)
+	}
+
+	result := &ExecutionResult{
+		WorkDir:  workDir,
+		ExitCode: test.Expect.ExitCode,
+	}
+
+	switch {
+	case test.IsAssetTest():
+		result.AssetsDir = filepath.Join(workDir, "assets")
+		if err := copyFixtureDir(fixtureDir, "assets", test.ResolvePath(test.Expect.Assets), result.AssetsDir); err != nil {
+			return nil, err
+		}
+	case test.IsFixTest():
+		result.PatchesDir = filepath.Join(workDir, "patches")
+		if err := copyFixtureDir(fixtureDir, "patches", test.ResolvePath(test.Expect.Patches), result.PatchesDir); err != nil {
+			return nil, err
+		}
+	case test.IsMultiApplication():
+		// Applications are written to a single work directory like the Hub does
+		result.ApplicationArtifacts = make(map[string]OutputArtifacts, len(test.Analysis.Applications))
+		for _, app := range test.Analysis.Applications {
+			appFixtureDir := ""
+			if fixtureDir != "" {
+				appFixtureDir = filepath.Join(fixtureDir, sanitizeName(app.Name))
+			}
+			outputDir := filepath.Join(workDir, "output", sanitizeName(app.Name))
+			artifacts, err := writeMockOutput(appFixtureDir, app.Expect.Result, outputDir, failure)
+			if err != nil {
+				return nil, fmt.Errorf("application %s: %w", app.Name, err)
+			}
+			result.ApplicationArtifacts[app.Name] = artifacts
+		}
+	default:
+		result.Artifacts, err = writeMockOutput(fixtureDir, test.Expect.Output.Result, filepath.Join(workDir, "output"), failure)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if failure == config.MockFailureExitCode {
+		result.ExitCode = m.exitCode
+		result.Stderr = fmt.Sprintf("mock failure: exit code %d", m.exitCode)
+	}
+
+	result.Duration = time.Since(start)
+	LogResult(log, result)
+	return result, nil
+}
+
+// failureFor returns the failure to simulate for a test, empty when it should succeed
+func (m *MockTarget) failureFor(test *config.TestDefinition) string {
+	if len(m.failTests) > 0 && !slices.Contains(m.failTests, test.Name) {
+		return ""
+	}
+	return m.failure
+}
+
+// fixtureDir returns the fixture directory of a test, empty when it has none
+func (m *MockTarget) fixtureDir(test *config.TestDefinition) string {
+	if m.fixturesDir == "" {
+		return ""
+	}
+	dir := filepath.Join(m.fixturesDir, sanitizeName(test.Name))
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return ""
+	}
+	return dir
+}
+
+// writeMockOutput writes output.yaml into outputDir from the fixture directory, or from the expected
+// rulesets when there is no fixture, and returns the artifacts. Other artifacts of the fixture, such as
+// dependencies.yaml and analysis.log, are copied too
+func writeMockOutput(fixtureDir string, expected []konveyor.RuleSet, outputDir string, failure string) (OutputArtifacts, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	outputFile := filepath.Join(outputDir, "output.yaml")
+
+	var data []byte
+	var err error
+	switch {
+	case failure == config.MockFailureMalformedOutput:
+		data = []byte(malformedOutput)
+	case fixtureDir != "":
+		data, err = os.ReadFile(filepath.Join(fixtureDir, "output.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture output: %w", err)
+		}
+	default:
+		data, err = yaml.Marshal(expected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal expected output: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	if fixtureDir != "" {
+		for kind, name := range artifactFiles {
+			src := filepath.Join(fixtureDir, name)
+			info, err := os.Stat(src)
+			if kind == ArtifactOutput || err != nil {
+				continue
+			}
+			if info.IsDir() {
+				err = os.CopyFS(filepath.Join(outputDir, name), os.DirFS(src))
+			} else if data, err = os.ReadFile(src); err == nil {
+				err = os.WriteFile(filepath.Join(outputDir, name), data, 0644)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to copy fixture %s: %w", name, err)
+			}
+		}
+	}
+	return collectArtifacts(outputDir), nil
+}
+
+// copyFixtureDir copies the named directory of the fixture, or expectedDir when
+// there is no fixture, to dest
+func copyFixtureDir(fixtureDir, name, expectedDir, dest string) error {
+	src := expectedDir
+	if fixtureDir != "" {
+		src = filepath.Join(fixtureDir, name)
+	}
+	if err := os.CopyFS(dest, os.DirFS(src)); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	return nil
+}
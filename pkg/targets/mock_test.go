@@ -0,0 +1,182 @@
+package targets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/output"
+)
+
+func mockTest(dir, name string) *config.TestDefinition {
+	test := &config.TestDefinition{
+		Name:    name,
+		WorkDir: filepath.Join(dir, "work"),
+	}
+	test.Expect.Output.Result = []konveyor.RuleSet{{Name: "expected-ruleset"}}
+	test.SetTestFilePath(filepath.Join(dir, "tests", "test.yaml"))
+	return test
+}
+
+func writeFixture(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMockTarget_Execute(t *testing.T) {
+	dir := t.TempDir()
+	fixturesDir := filepath.Join(dir, "fixtures")
+	writeFixture(t, filepath.Join(fixturesDir, "With-fixture", "output.yaml"), "- name: fixture-ruleset\n")
+	writeFixture(t, filepath.Join(fixturesDir, "With-fixture", "analysis.log"), "done\n")
+
+	tests := []struct {
+		name         string
+		testName     string
+		cfg          *config.MockConfig
+		wantRuleSet  string
+		wantExitCode int
+		wantErr      string
+	}{
+		{
+			name:        "expected output without fixtures",
+			testName:    "No fixture",
+			cfg:         &config.MockConfig{FixturesDir: fixturesDir},
+			wantRuleSet: "expected-ruleset",
+		},
+		{
+			name:        "fixture output",
+			testName:    "With fixture",
+			cfg:         &config.MockConfig{FixturesDir: fixturesDir},
+			wantRuleSet: "fixture-ruleset",
+		},
+		{
+			name:         "exit code failure",
+			testName:     "Exit code",
+			cfg:          &config.MockConfig{Failure: config.MockFailureExitCode, ExitCode: 3},
+			wantRuleSet:  "expected-ruleset",
+			wantExitCode: 3,
+		},
+		{
+			name:        "failure limited to other tests",
+			testName:    "Not failing",
+			cfg:         &config.MockConfig{Failure: config.MockFailureExitCode, FailTests: []string{"Failing"}},
+			wantRuleSet: "expected-ruleset",
+		},
+		{
+			name:     "timeout failure",
+			testName: "Timeout",
+			cfg:      &config.MockConfig{Failure: config.MockFailureTimeout},
+			wantErr:  "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := NewMockTarget(tt.cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test := mockTest(dir, tt.testName)
+			test.Timeout = &config.Duration{Duration: 10 * time.Millisecond}
+
+			result, err := target.Execute(context.Background(), test)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() unexpected error: %v", err)
+			}
+			if result.ExitCode != tt.wantExitCode {
+				t.Errorf("ExitCode = %d, want %d", result.ExitCode, tt.wantExitCode)
+			}
+
+			rulesets, err := output.Load(result.OutputFile())
+			if err != nil {
+				t.Fatalf("failed to load output: %v", err)
+			}
+			if len(rulesets) != 1 || rulesets[0].Name != tt.wantRuleSet {
+				t.Errorf("output rulesets = %+v, want %s", rulesets, tt.wantRuleSet)
+			}
+
+			// Other artifacts of the fixture are kept
+			if _, ok := result.Artifacts[ArtifactAnalysisLog]; ok != (tt.testName == "With fixture") {
+				t.Errorf("Artifacts = %v", result.Artifacts)
+			}
+
+			// Outputs can be found again like those of real targets
+			latest, err := LatestResult(test)
+			if err != nil || latest.OutputFile() != result.OutputFile() {
+				t.Errorf("LatestResult() = %+v, %v, want output %s", latest, err, result.OutputFile())
+			}
+		})
+	}
+}
+
+func TestMockTarget_MalformedOutput(t *testing.T) {
+	target, err := NewMockTarget(&config.MockConfig{Failure: config.MockFailureMalformedOutput})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := target.Execute(context.Background(), mockTest(t.TempDir(), "malformed"))
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if _, err := output.Load(result.OutputFile()); err == nil {
+		t.Error("expected malformed output to fail parsing")
+	}
+}
+
+func TestMockTarget_MultiApplication(t *testing.T) {
+	dir := t.TempDir()
+	test := mockTest(dir, "apps")
+	test.Analysis.Applications = []config.ApplicationConfig{
+		{Name: "frontend", Expect: config.ExpectedOutput{Result: []konveyor.RuleSet{{Name: "frontend-rules"}}}},
+		{Name: "backend", Expect: config.ExpectedOutput{Result: []konveyor.RuleSet{{Name: "backend-rules"}}}},
+	}
+
+	target, _ := NewMockTarget(nil)
+	result, err := target.Execute(context.Background(), test)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	for _, app := range test.Analysis.Applications {
+		rulesets, err := output.Load(result.ApplicationArtifacts[app.Name].Output())
+		if err != nil {
+			t.Fatalf("application %s: %v", app.Name, err)
+		}
+		if len(rulesets) != 1 || rulesets[0].Name != app.Name+"-rules" {
+			t.Errorf("application %s rulesets = %+v", app.Name, rulesets)
+		}
+	}
+}
+
+func TestMockTarget_Assets(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, filepath.Join(dir, "tests", "expected-assets", "values.yaml"), "replicas: 1\n")
+
+	test := mockTest(dir, "assets")
+	test.Assets = &config.AssetsConfig{}
+	test.Expect.Assets = "expected-assets"
+
+	target, _ := NewMockTarget(nil)
+	result, err := target.Execute(context.Background(), test)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(result.AssetsDir, "values.yaml")); err != nil {
+		t.Errorf("expected asset not copied: %v", err)
+	}
+}
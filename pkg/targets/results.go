@@ -0,0 +1,118 @@
+package targets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+// workDirTimestamp is the layout of the timestamp suffix added by PrepareWorkDir
+const workDirTimestamp = "20060102-150405"
+
+// LatestWorkDir finds the most recent work directory created by PrepareWorkDir for a test
+func LatestWorkDir(baseDir, testName string) (string, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read work directory: %w", err)
+	}
+
+	prefix := sanitizeName(testName) + "-"
+	latest := ""
+	var latestTime time.Time
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+
+		// Only an exact timestamp suffix belongs to this test, other suffixes
+		// belong to tests sharing the prefix such as multi-application runs
+		ts, err := time.Parse(workDirTimestamp, strings.TrimPrefix(entry.Name(), prefix))
+		if err != nil {
+			continue
+		}
+		if latest == "" || ts.After(latestTime) {
+			latest = entry.Name()
+			latestTime = ts
+		}
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("no previous run found for test %s in %s", testName, baseDir)
+	}
+
+	return filepath.Join(baseDir, latest), nil
+}
+
+// LatestResult builds an execution result from the outputs of the most recent run of a test
+// This allows outputs to be validated again without re-running the analysis
+func LatestResult(test *config.TestDefinition) (*ExecutionResult, error) {
+	baseDir := test.GetWorkDir()
+
+	if test.IsAssetTest() {
+		workDir, err := LatestWorkDir(baseDir, test.Name)
+		if err != nil {
+			return nil, err
+		}
+		// Assets rendered from a chart, or the discovery manifest without one
+		assetsDir := filepath.Join(workDir, "assets")
+		if test.Assets.ChartDir == "" {
+			assetsDir = filepath.Join(workDir, "discover")
+		}
+		return &ExecutionResult{
+			WorkDir:   workDir,
+			AssetsDir: assetsDir,
+		}, nil
+	}
+
+	if test.IsFixTest() {
+		workDir, err := LatestWorkDir(baseDir, test.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			WorkDir:    workDir,
+			PatchesDir: filepath.Join(workDir, "patches"),
+		}, nil
+	}
+
+	if !test.IsMultiApplication() {
+		workDir, err := LatestWorkDir(baseDir, test.Name)
+		if err != nil {
+			return nil, err
+		}
+		return &ExecutionResult{
+			WorkDir:   workDir,
+			Artifacts: readArtifactManifest(workDir),
+		}, nil
+	}
+
+	result := &ExecutionResult{
+		WorkDir:              baseDir,
+		ApplicationArtifacts: make(map[string]OutputArtifacts, len(test.Analysis.Applications)),
+	}
+	for _, app := range test.Analysis.Applications {
+		// Kantra runs every application in its own work directory
+		if workDir, err := LatestWorkDir(baseDir, test.ForApplication(app).Name); err == nil {
+			if result.ApplicationWorkDirs == nil {
+				result.ApplicationWorkDirs = make(map[string]string, len(test.Analysis.Applications))
+			}
+			result.ApplicationArtifacts[app.Name] = readArtifactManifest(workDir)
+			result.ApplicationWorkDirs[app.Name] = workDir
+			continue
+		}
+
+		// The Hub writes all applications into a single work directory
+		workDir, err := LatestWorkDir(baseDir, test.Name)
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", app.Name, err)
+		}
+		result.ApplicationArtifacts[app.Name] = collectArtifacts(filepath.Join(workDir, "output", sanitizeName(app.Name)))
+		result.WorkDir = workDir
+	}
+
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konveyor/test-harness/pkg/config"
+)
+
+func TestLatestWorkDir(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, name := range []string{
+		"my-test-20250101-100000",
+		"my-test-20250102-090000",
+		"my-test-app-20250103-090000",
+		"other-20250104-090000",
+	} {
+		if err := os.MkdirAll(filepath.Join(baseDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		testName string
+		expected string
+		wantErr  bool
+	}{
+		{name: "latest run", testName: "my test", expected: "my-test-20250102-090000"},
+		{name: "application run", testName: "my-test-app", expected: "my-test-app-20250103-090000"},
+		{name: "no run", testName: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LatestWorkDir(baseDir, tt.testName)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("LatestWorkDir() expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LatestWorkDir() unexpected error: %v", err)
+			}
+			if want := filepath.Join(baseDir, tt.expected); got != want {
+				t.Errorf("LatestWorkDir() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestLatestResult(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, name := range []string{
+		"single-20250101-100000",
+		"kantra-app1-20250101-100000",
+		"hub-20250101-100000",
+	} {
+		if err := os.MkdirAll(filepath.Join(baseDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("single application", func(t *testing.T) {
+		test := &config.TestDefinition{Name: "single", WorkDir: baseDir}
+		result, err := LatestResult(test)
+		if err != nil {
+			t.Fatalf("LatestResult() unexpected error: %v", err)
+		}
+		want := filepath.Join(baseDir, "single-20250101-100000", "output", "output.yaml")
+		if result.OutputFile() != want {
+			t.Errorf("OutputFile = %s, want %s", result.OutputFile(), want)
+		}
+	})
+
+	t.Run("kantra applications", func(t *testing.T) {
+		test := &config.TestDefinition{Name: "kantra", WorkDir: baseDir}
+		test.Analysis.Applications = []config.ApplicationConfig{{Name: "app1", Application: "/src"}}
+		result, err := LatestResult(test)
+		if err != nil {
+			t.Fatalf("LatestResult() unexpected error: %v", err)
+		}
+		want := filepath.Join(baseDir, "kantra-app1-20250101-100000", "output", "output.yaml")
+		if got := result.ApplicationArtifacts["app1"].Output(); got != want {
+			t.Errorf("ApplicationOutputs[app1] = %s, want %s", got, want)
+		}
+		if dirs := result.ArtifactDirs(); len(dirs) != 1 || dirs[0] != filepath.Join(baseDir, "kantra-app1-20250101-100000") {
+			t.Errorf("ArtifactDirs() = %v, want the application work directory", dirs)
+		}
+	})
+
+	t.Run("hub applications", func(t *testing.T) {
+		test := &config.TestDefinition{Name: "hub", WorkDir: baseDir}
+		test.Analysis.Applications = []config.ApplicationConfig{{Name: "app one", Application: "/src"}}
+		result, err := LatestResult(test)
+		if err != nil {
+			t.Fatalf("LatestResult() unexpected error: %v", err)
+		}
+		want := filepath.Join(baseDir, "hub-20250101-100000", "output", "app-one", "output.yaml")
+		if got := result.ApplicationArtifacts["app one"].Output(); got != want {
+			t.Errorf("ApplicationOutputs[app one] = %s, want %s", got, want)
+		}
+		if dirs := result.ArtifactDirs(); len(dirs) != 1 || dirs[0] != filepath.Join(baseDir, "hub-20250101-100000") {
+			t.Errorf("ArtifactDirs() = %v, want the hub work directory", dirs)
+		}
+	})
+}
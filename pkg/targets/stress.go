@@ -0,0 +1,282 @@
+package targets
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/progress"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// stressPollInterval is how often the tasks of a stress run are polled, it bounds the precision of the
+// recorded queue and run times
+const stressPollInterval = 2 * time.Second
+
+// StressOptions configures a stress run against a Hub
+type StressOptions struct {
+	// Tasks is the number of analysis tasks submitted at once
+	Tasks int
+	// DistinctApplications analyzes one application per task instead of a single shared application
+	DistinctApplications bool
+	// Timeout is how long all tasks may take to finish
+	Timeout time.Duration
+}
+
+// StressTask records how the scheduler handled one task of a stress run
+type StressTask struct {
+	ID          uint      `json:"id"`
+	Application string    `json:"application"`
+	State       string    `json:"state"`
+	Submitted   time.Time `json:"submitted"`
+	// Started is when the task was first seen running, zero when it never ran
+	Started time.Time `json:"started,omitzero"`
+	// Finished is when the task was first seen in a final state, zero when it didn't finish
+	Finished time.Time `json:"finished,omitzero"`
+	// Postponed is set when the scheduler postponed the task at least once
+	Postponed bool   `json:"postponed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// QueueTime returns how long the task waited before it ran, zero when it never ran
+func (s *StressTask) QueueTime() time.Duration {
+	if s.Started.IsZero() {
+		return 0
+	}
+	return s.Started.Sub(s.Submitted)
+}
+
+// RunTime returns how long the task ran, zero when it didn't run to the end
+func (s *StressTask) RunTime() time.Duration {
+	if s.Started.IsZero() || s.Finished.IsZero() {
+		return 0
+	}
+	return s.Finished.Sub(s.Started)
+}
+
+// Succeeded reports whether the task completed successfully
+func (s *StressTask) Succeeded() bool {
+	return s.State == TaskStateSucceeded
+}
+
+// observe records the state of the task seen at a time. Tasks finishing between two polls are
+// considered started when they are first seen finished
+func (s *StressTask) observe(state string, now time.Time) {
+	s.State = state
+	switch state {
+	case TaskStatePostponed:
+		s.Postponed = true
+	case TaskStateRunning:
+		if s.Started.IsZero() {
+			s.Started = now
+		}
+	case TaskStateSucceeded, TaskStateFailed, TaskStateCanceled:
+		if s.Started.IsZero() {
+			s.Started = now
+		}
+		if s.Finished.IsZero() {
+			s.Finished = now
+		}
+	}
+}
+
+// done reports whether the task reached a final state
+func (s *StressTask) done() bool {
+	return !s.Finished.IsZero()
+}
+
+// StressResult holds the tasks of a stress run and the scheduler behavior seen while they ran
+type StressResult struct {
+	Tasks []StressTask `json:"tasks"`
+	// MaxRunning is the largest number of tasks seen running at once
+	MaxRunning int           `json:"maxRunning"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// Failed returns the number of tasks that didn't succeed
+func (r *StressResult) Failed() int {
+	failed := 0
+	for i := range r.Tasks {
+		if !r.Tasks[i].Succeeded() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// FailureRate returns the share of tasks that didn't succeed, between 0 and 1
+func (r *StressResult) FailureRate() float64 {
+	if len(r.Tasks) == 0 {
+		return 0
+	}
+	return float64(r.Failed()) / float64(len(r.Tasks))
+}
+
+// Postponed returns the number of tasks the scheduler postponed
+func (r *StressResult) Postponed() int {
+	postponed := 0
+	for i := range r.Tasks {
+		if r.Tasks[i].Postponed {
+			postponed++
+		}
+	}
+	return postponed
+}
+
+// QueueTimes returns the shortest, average and longest queue time of the tasks that ran
+func (r *StressResult) QueueTimes() (shortest, average, longest time.Duration) {
+	var total time.Duration
+	ran := 0
+	for i := range r.Tasks {
+		if r.Tasks[i].Started.IsZero() {
+			continue
+		}
+		queued := r.Tasks[i].QueueTime()
+		if ran == 0 || queued < shortest {
+			shortest = queued
+		}
+		longest = max(longest, queued)
+		total += queued
+		ran++
+	}
+	if ran == 0 {
+		return 0, 0, 0
+	}
+	return shortest, total / time.Duration(ran), longest
+}
+
+// observeRunning records the number of tasks running at once after a poll
+func (r *StressResult) observeRunning() {
+	running := 0
+	for i := range r.Tasks {
+		if r.Tasks[i].State == TaskStateRunning {
+			running++
+		}
+	}
+	r.MaxRunning = max(r.MaxRunning, running)
+}
+
+// Stress submits several analysis tasks of a test at once and waits for all of them, recording how the
+// scheduler queued them. The tasks analyze the test's application, or one copy of it per task with
+// DistinctApplications
+func (t *TackleHubTarget) Stress(ctx context.Context, test *config.TestDefinition, opts StressOptions) (*StressResult, error) {
+	log := util.GetLogger()
+	start := time.Now()
+
+	if test.IsMultiApplication() {
+		return nil, fmt.Errorf("stress runs analyze a single application, %s has %d", test.Name, len(test.Analysis.Applications))
+	}
+	if err := t.verifyImages(); err != nil {
+		return nil, err
+	}
+
+	// The tasks share the custom target of the test
+	var customTarget *api.Target
+	if test.Analysis.CustomTarget != nil {
+		progress.Update(ctx, "creating custom target")
+		created, err := t.createCustomTarget(test)
+		if err != nil {
+			return nil, err
+		}
+		defer t.deleteCustomTarget(created)
+		customTarget = created
+	}
+
+	// Tasks are created first and submitted together, so they reach the scheduler at once
+	result := &StressResult{Tasks: make([]StressTask, opts.Tasks)}
+	var taskIDs []uint
+	var app *api.Application
+	for i := range opts.Tasks {
+		appTest := test
+		if opts.DistinctApplications {
+			copied := *test
+			copied.Name = fmt.Sprintf("%s-stress-%d", test.Name, i+1)
+			appTest = &copied
+		}
+
+		progress.Update(ctx, fmt.Sprintf("creating task %d of %d", i+1, opts.Tasks))
+		// Tasks sharing the application reuse the one created for the first task
+		if app == nil || opts.DistinctApplications {
+			created, err := t.createApplication(ctx, appTest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create application: %w", err)
+			}
+			app = created
+		}
+		task, err := t.createAnalysisTask(ctx, appTest, app, customTarget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create analysis task: %w", err)
+		}
+		result.Tasks[i] = StressTask{ID: task.ID, Application: app.Name, State: TaskStateCreated}
+		taskIDs = append(taskIDs, task.ID)
+	}
+
+	for i, taskID := range taskIDs {
+		if err := t.submitTask(taskID); err != nil {
+			return nil, fmt.Errorf("failed to submit task %d: %w", taskID, err)
+		}
+		result.Tasks[i].Submitted = time.Now()
+	}
+	log.Info("Submitted stress tasks", "tasks", taskIDs, "distinctApplications", opts.DistinctApplications)
+
+	if err := t.pollStressTasks(ctx, result, opts.Timeout); err != nil {
+		return nil, err
+	}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// pollStressTasks polls the tasks of a stress run until all of them finished. Tasks still unfinished at
+// the timeout keep their last state and are reported as failed
+func (t *TackleHubTarget) pollStressTasks(ctx context.Context, result *StressResult, timeout time.Duration) error {
+	log := util.GetLogger()
+
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(stressPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(deadline)):
+			for i := range result.Tasks {
+				if !result.Tasks[i].done() {
+					result.Tasks[i].Error = fmt.Sprintf("still %s after %v", result.Tasks[i].State, timeout)
+				}
+			}
+			return nil
+		case <-ticker.C:
+		}
+
+		finished := 0
+		for i := range result.Tasks {
+			task := &result.Tasks[i]
+			if task.done() {
+				finished++
+				continue
+			}
+			hubTask, err := t.client.Task.Get(task.ID)
+			if err != nil {
+				return fmt.Errorf("failed to get task status: %w", err)
+			}
+			if hubTask.State != task.State {
+				log.Info("Task state changed", "taskID", task.ID, "from", task.State, "to", hubTask.State)
+			}
+			task.observe(hubTask.State, time.Now())
+			if hubTask.State == TaskStateFailed {
+				task.Error = fmt.Sprintf("%v", hubTask.Errors)
+			}
+			if task.done() {
+				finished++
+			}
+		}
+		result.observeRunning()
+		progress.Update(ctx, fmt.Sprintf("%d of %d tasks finished", finished, len(result.Tasks)))
+		if finished == len(result.Tasks) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package targets
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStressTaskObserve(t *testing.T) {
+	submitted := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return submitted.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name          string
+		states        []string
+		wantState     string
+		wantQueue     time.Duration
+		wantRun       time.Duration
+		wantPostponed bool
+		wantDone      bool
+	}{
+		{name: "queued", states: []string{TaskStateReady, TaskStatePending}, wantState: TaskStatePending},
+		{
+			name:      "succeeded",
+			states:    []string{TaskStatePending, TaskStateRunning, TaskStateRunning, TaskStateSucceeded},
+			wantState: TaskStateSucceeded, wantQueue: 2 * time.Second, wantRun: 2 * time.Second, wantDone: true,
+		},
+		{
+			name:      "postponed",
+			states:    []string{TaskStatePostponed, TaskStateRunning, TaskStateFailed},
+			wantState: TaskStateFailed, wantQueue: 2 * time.Second, wantRun: time.Second, wantPostponed: true, wantDone: true,
+		},
+		{
+			name:      "finished between polls",
+			states:    []string{TaskStatePending, TaskStateSucceeded},
+			wantState: TaskStateSucceeded, wantQueue: 2 * time.Second, wantDone: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := StressTask{ID: 1, State: TaskStateCreated, Submitted: submitted}
+			for i, state := range tt.states {
+				task.observe(state, at(i+1))
+			}
+			if task.State != tt.wantState || task.Postponed != tt.wantPostponed || task.done() != tt.wantDone {
+				t.Errorf("observe() state = %s, postponed = %v, done = %v, want %s, %v and %v",
+					task.State, task.Postponed, task.done(), tt.wantState, tt.wantPostponed, tt.wantDone)
+			}
+			if task.QueueTime() != tt.wantQueue || task.RunTime() != tt.wantRun {
+				t.Errorf("QueueTime() = %v, RunTime() = %v, want %v and %v", task.QueueTime(), task.RunTime(), tt.wantQueue, tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestStressResult(t *testing.T) {
+	submitted := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	result := &StressResult{Tasks: []StressTask{
+		{State: TaskStateRunning, Submitted: submitted, Started: submitted.Add(2 * time.Second)},
+		{State: TaskStateRunning, Submitted: submitted, Started: submitted.Add(4 * time.Second)},
+		{State: TaskStatePostponed, Submitted: submitted, Postponed: true},
+		{State: TaskStateSucceeded, Submitted: submitted, Started: submitted.Add(6 * time.Second), Finished: submitted.Add(9 * time.Second)},
+	}}
+
+	result.observeRunning()
+	result.Tasks[0].State = TaskStateSucceeded
+	result.observeRunning()
+	if result.MaxRunning != 2 {
+		t.Errorf("MaxRunning = %d, want 2", result.MaxRunning)
+	}
+	if result.Failed() != 2 || result.FailureRate() != 0.5 || result.Postponed() != 1 {
+		t.Errorf("Failed() = %d, FailureRate() = %v, Postponed() = %d, want 2, 0.5 and 1", result.Failed(), result.FailureRate(), result.Postponed())
+	}
+	shortest, average, longest := result.QueueTimes()
+	if shortest != 2*time.Second || average != 4*time.Second || longest != 6*time.Second {
+		t.Errorf("QueueTimes() = %v, %v, %v, want 2s, 4s and 6s", shortest, average, longest)
+	}
+	if (&StressResult{}).FailureRate() != 0 {
+		t.Error("FailureRate() of no tasks must be 0")
+	}
+}
@@ -6,16 +6,19 @@ import (
 	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
-	"strings"
 	"time"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/analyzer-lsp/provider"
 	"github.com/konveyor/tackle2-hub/api"
 	"github.com/konveyor/tackle2-hub/binding"
 	"github.com/konveyor/test-harness/pkg/config"
+	"github.com/konveyor/test-harness/pkg/fixtures"
+	"github.com/konveyor/test-harness/pkg/output"
+	"github.com/konveyor/test-harness/pkg/progress"
 	"github.com/konveyor/test-harness/pkg/util"
-	"go.lsp.dev/uri"
 	"gopkg.in/yaml.v2"
 )
 
@@ -34,6 +37,8 @@ const (
 	TaskStateSucceeded = "Succeeded"
 	// TaskStateFailed indicates task failed
 	TaskStateFailed = "Failed"
+	// TaskStateCanceled indicates task was canceled
+	TaskStateCanceled = "Canceled"
 )
 
 type Data struct {
@@ -47,6 +52,10 @@ type Data struct {
 	Rules Rules `json:"rules"`
 	// Tagger options.
 	Tagger Tagger `json:"tagger"`
+	// Env variables for the analyzer.
+	Env map[string]string `json:"env,omitempty"`
+	// Provider settings overrides.
+	ProviderSettings []provider.Config `json:"providerSettings,omitempty"`
 }
 
 type Mode struct {
@@ -87,9 +96,16 @@ type Tagger struct {
 
 // TackleHubTarget implements Target for Tackle Hub API
 type TackleHubTarget struct {
-	url           string
-	client        *binding.RichClient
-	mavenSettings string
+	url             string
+	client          *binding.RichClient
+	mavenSettings   string
+	addon           string
+	addonImage      string
+	extensionImages map[string]string
+	reuseApps       bool
+	task            *config.HubTaskConfig
+	results         string
+	mockJira        *config.MockJiraConfig
 }
 
 // NewTackleHubTarget creates a new Tackle Hub API target
@@ -97,6 +113,9 @@ func NewTackleHubTarget(cfg *config.TackleHubConfig) (*TackleHubTarget, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("tackle hub configuration is required")
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	client := binding.New(cfg.URL)
 
@@ -110,18 +129,52 @@ func NewTackleHubTarget(cfg *config.TackleHubConfig) (*TackleHubTarget, error) {
 	// If no credentials provided, assume auth is disabled on the Tackle instance
 
 	return &TackleHubTarget{
-		url:           cfg.URL,
-		client:        client,
-		mavenSettings: cfg.MavenSettings,
+		url:             cfg.URL,
+		client:          client,
+		mavenSettings:   cfg.MavenSettings,
+		addon:           cfg.GetAddon(),
+		addonImage:      cfg.AddonImage,
+		extensionImages: cfg.ExtensionImages,
+		reuseApps:       cfg.GetReuseApplications(),
+		task:            cfg.Task,
+		results:         cfg.GetResults(),
+		mockJira:        cfg.MockJira,
 	}, nil
 }
 
+// Client returns the Hub client, e.g. to seed the Hub before the tests
+func (t *TackleHubTarget) Client() *binding.RichClient {
+	return t.client
+}
+
 // Name returns the target name
 func (t *TackleHubTarget) Name() string {
 	return "tackle-hub"
 }
 
+// hubBuildSetting holds the build of the Hub that seeded the database, the output of git describe
+const hubBuildSetting = ".hub.db.seed.build"
+
+// Version returns the Hub build, e.g. v0.6.0 or v0.6.0-ea89gcd
+func (t *TackleHubTarget) Version(ctx context.Context) (string, error) {
+	version, err := t.client.Setting.Str(hubBuildSetting)
+	if err != nil {
+		return "", fmt.Errorf("failed to get Hub version: %w", err)
+	}
+	return version, nil
+}
+
+// Capabilities returns the features of the Hub version
+func (t *TackleHubTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	version, err := t.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return capabilitiesFor(t.Name(), version), nil
+}
+
 // Execute runs analysis via Tackle Hub API
+// Multi-application tests create one Application per entry and analyze them in the same run
 func (t *TackleHubTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
 	log := util.GetLogger()
 	start := time.Now()
@@ -139,187 +192,193 @@ func (t *TackleHubTarget) Execute(ctx context.Context, test *config.TestDefiniti
 
 	log.Info("Executing Tackle Hub analysis", "workDir", workDir)
 
-	// Step 1: Create or find application
-	log.Info("Creating application", "name", test.Name)
-	app, err := t.createApplication(test)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create application: %w", err)
+	// Pinned images are checked before every test, the operator may update the addon during a run
+	if err := t.verifyImages(); err != nil {
+		return nil, err
 	}
-	log.Info("Application created", "id", app.ID, "name", app.Name)
 
-	// Step 2: Create analysis task
-	log.Info("Creating analysis task", "applicationID", app.ID)
-	task, err := t.createAnalysisTask(ctx, test, app)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create analysis task: %w", err)
+	// Custom targets are created once for every application of the test
+	var customTarget *api.Target
+	if test.Analysis.CustomTarget != nil {
+		progress.Update(ctx, "creating custom target")
+		if customTarget, err = t.createCustomTarget(test); err != nil {
+			return nil, err
+		}
+		defer t.deleteCustomTarget(customTarget)
 	}
-	log.Info("Analysis task created", "taskID", task.ID)
 
-	// Step 2.5: Submit the task to move it to Ready state
-	log.Info("Submitting task", "taskID", task.ID)
-	err = t.submitTask(task.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to submit task: %w", err)
+	appTests := []*config.TestDefinition{test}
+	if test.IsMultiApplication() {
+		appTests = make([]*config.TestDefinition, 0, len(test.Analysis.Applications))
+		for _, app := range test.Analysis.Applications {
+			appTests = append(appTests, test.ForApplication(app))
+		}
 	}
-	log.Info("Task submitted", "taskID", task.ID)
 
-	// Step 3: Poll for task completion
-	log.Info("Polling for task completion", "taskID", task.ID)
-	err = t.pollTaskCompletion(ctx, task.ID, test.GetTimeout())
-	if err != nil {
-		return nil, fmt.Errorf("task failed or timed out: %w", err)
+	apps := make([]*api.Application, len(appTests))
+	tasks := make([]*api.Task, len(appTests))
+	for i, appTest := range appTests {
+		// Step 1: Create or find application
+		progress.Update(ctx, "creating application")
+		log.Info("Creating application", "name", appTest.Name)
+		app, err := t.createApplication(ctx, appTest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create application: %w", err)
+		}
+		log.Info("Application created", "id", app.ID, "name", app.Name)
+		apps[i] = app
+
+		// Step 2: Create analysis task
+		progress.Update(ctx, "creating task")
+		log.Info("Creating analysis task", "applicationID", app.ID)
+		task, err := t.createAnalysisTask(ctx, appTest, app, customTarget)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create analysis task: %w", err)
+		}
+		log.Info("Analysis task created", "taskID", task.ID)
+		tasks[i] = task
+
+		// Step 2.5: Submit the task to move it to Ready state
+		log.Info("Submitting task", "taskID", task.ID)
+		err = t.submitTask(task.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to submit task: %w", err)
+		}
+		log.Info("Task submitted", "taskID", task.ID)
 	}
-	log.Info("Analysis task completed successfully", "taskID", task.ID)
 
-	var insights []api.Insight
-	err = t.client.Client.Get(
-		api.AnalysesInsightsRoot,
-		&insights,
-		binding.Param{
-			Key:   "application",
-			Value: fmt.Sprintf("%v", app.ID),
-		},
-	)
-
-	rulesetToInsightConverted := map[string]konveyor.RuleSet{}
-	for _, insight := range insights {
-		rs := rulesetToInsightConverted[insight.RuleSet]
-		rs.Name = insight.RuleSet
-		if rs.Insights == nil {
-			rs.Insights = map[string]konveyor.Violation{}
-		}
-		if rs.Violations == nil {
-			rs.Violations = map[string]konveyor.Violation{}
-		}
-		incidents := []konveyor.Incident{}
-		for _, i := range insight.Incidents {
-			// Normalize paths to match expected output format
-			if strings.Contains(i.File, "/cache/m2") {
-				i.File = strings.ReplaceAll(i.File, "/cache/m2/", "/m2/")
-			}
-			// Remove container-specific path prefix
-			if strings.Contains(i.File, "/opt/input/source/") {
-				i.File = strings.ReplaceAll(i.File, "/opt/input/source", "/source")
-			}
-			incidents = append(incidents, konveyor.Incident{
-				URI:        uri.File(i.File),
-				Message:    i.Message,
-				CodeSnip:   i.CodeSnip,
-				LineNumber: &i.Line,
-			})
-		}
-		links := []konveyor.Link{}
-		for _, l := range insight.Links {
-			links = append(links, konveyor.Link{
-				URL:   l.URL,
-				Title: l.Title,
-			})
-		}
-
-		v := konveyor.Violation{
-			Description: insight.Description,
-			Category:    (*konveyor.Category)(&insight.Category),
-			Labels:      insight.Labels,
-			Incidents:   incidents,
-			Links:       links,
-			Effort:      &insight.Effort,
-		}
-
-		if insight.Effort == 0 {
-			rs.Insights[insight.Rule] = v
-		} else {
-			rs.Violations[insight.Rule] = v
+	// Step 3: Poll for task completion
+	for _, task := range tasks {
+		log.Info("Polling for task completion", "taskID", task.ID)
+		err = t.pollTaskCompletion(ctx, task.ID, test.GetTimeout())
+		if err != nil {
+			return nil, fmt.Errorf("task failed or timed out: %w", err)
 		}
-		rulesetToInsightConverted[insight.RuleSet] = rs
+		log.Info("Analysis task completed successfully", "taskID", task.ID)
 	}
-	// Get tags from application
-	appTag := t.client.Application.Tags(app.ID)
-	tags, err := appTag.List()
-	if err != nil {
-		return nil, err
+
+	// Step 4: Convert the results of every application
+	progress.Update(ctx, "downloading results")
+	result := &ExecutionResult{
+		ExitCode: 0,
+		WorkDir:  workDir,
+	}
+	if test.IsMultiApplication() {
+		result.ApplicationArtifacts = make(map[string]OutputArtifacts, len(apps))
 	}
+	for i, app := range apps {
+		outputDir := filepath.Join(workDir, "output")
+		if test.IsMultiApplication() {
+			outputDir = filepath.Join(outputDir, sanitizeName(test.Analysis.Applications[i].Name))
+		}
+
+		outputFile, err := t.writeApplicationOutput(app, outputDir)
+		if err != nil {
+			return nil, err
+		}
 
-	// Ensure discovery-rules and technology-usage rulesets exist
-	if _, exists := rulesetToInsightConverted["discovery-rules"]; !exists {
-		rulesetToInsightConverted["discovery-rules"] = konveyor.RuleSet{
-			Name: "discovery-rules",
-			Tags: []string{},
+		// The Hub only reports insights and tags, which are converted to the analysis output
+		artifacts := OutputArtifacts{ArtifactOutput: outputFile}
+		if test.IsMultiApplication() {
+			result.ApplicationArtifacts[test.Analysis.Applications[i].Name] = artifacts
+			continue
 		}
-	}
-	if _, exists := rulesetToInsightConverted["technology-usage"]; !exists {
-		rulesetToInsightConverted["technology-usage"] = konveyor.RuleSet{
-			Name: "technology-usage",
-			Tags: []string{},
+		result.Artifacts = artifacts
+		if result.Archetypes, err = t.applicationArchetypes(app.ID); err != nil {
+			return nil, err
 		}
 	}
 
-	// Add tags to appropriate rulesets based on source
-	for _, tag := range tags {
-		switch tag.Source {
-		case "language-discovery":
-			rs := rulesetToInsightConverted["discovery-rules"]
-			rs.Tags = append(rs.Tags, tag.Name)
-			rulesetToInsightConverted["discovery-rules"] = rs
-		case "tech-discovery":
-			rs := rulesetToInsightConverted["technology-usage"]
-			rs.Tags = append(rs.Tags, tag.Name)
-			rulesetToInsightConverted["technology-usage"] = rs
-		}
+	result.Duration = time.Since(start)
+	return result, nil
+}
+
+// writeApplicationOutput converts the insights and tags of an analyzed application
+// into rulesets and writes them to output.yaml in outputDir
+func (t *TackleHubTarget) writeApplicationOutput(app *api.Application, outputDir string) (string, error) {
+	log := util.GetLogger()
+
+	var insights []api.Insight
+	var err error
+	if t.results == config.HubResultsAnalysis {
+		insights, err = t.analysisInsights(app.ID)
+	} else {
+		err = t.client.Client.Get(
+			api.AnalysesInsightsRoot,
+			&insights,
+			binding.Param{
+				Key:   "application",
+				Value: fmt.Sprintf("%v", app.ID),
+			},
+		)
 	}
-	output, err := yaml.Marshal(slices.Collect(maps.Values(rulesetToInsightConverted)))
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to get insights: %w", err)
+	}
+
+	// Get tags from application
+	appTag := t.client.Application.Tags(app.ID)
+	tags, err := appTag.List()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := yaml.Marshal(output.FromHubInsights(insights, tags))
+	if err != nil {
+		return "", err
 	}
 
 	// Create output directory
-	outputDir := filepath.Join(workDir, "output")
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create output directory: %w", err)
+		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// Write output to file
 	outputFile := filepath.Join(outputDir, "output.yaml")
-	if err := os.WriteFile(outputFile, output, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write output file: %w", err)
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write output file: %w", err)
 	}
 
 	log.Info("Successfully wrote analysis results", "file", outputFile)
 
-	duration := time.Since(start)
-	result := &ExecutionResult{
-		ExitCode:   0,
-		Duration:   duration,
-		OutputFile: outputFile,
-		WorkDir:    workDir,
-	}
-
-	return result, nil
+	return outputFile, nil
 }
 
-// createApplication creates a new application in Tackle Hub or finds existing one
-func (t *TackleHubTarget) createApplication(test *config.TestDefinition) (*api.Application, error) {
-	log := util.GetLogger()
-
-	// First, try to find an existing application with the same name
-	apps, err := t.client.Application.List()
+// applicationArchetypes returns the names of the archetypes an application belongs to, the Hub associates
+// them when the tags added by the analysis match their criteria
+func (t *TackleHubTarget) applicationArchetypes(appID uint) ([]string, error) {
+	app, err := t.client.Application.Get(appID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list applications: %w", err)
+		return nil, fmt.Errorf("failed to get application %d: %w", appID, err)
+	}
+	archetypes := make([]string, 0, len(app.Archetypes))
+	for _, ref := range app.Archetypes {
+		archetypes = append(archetypes, ref.Name)
 	}
+	return archetypes, nil
+}
 
-	// Look for existing application with matching name
-	for _, existingApp := range apps {
-		if existingApp.Name == test.Name {
-			log.Info("Found existing application", "id", existingApp.ID, "name", existingApp.Name)
+// createApplication creates a new application in Tackle Hub or reuses the existing one
+func (t *TackleHubTarget) createApplication(ctx context.Context, test *config.TestDefinition) (*api.Application, error) {
+	log := util.GetLogger()
 
-			// Update identities if maven settings configured
-			if t.mavenSettings != "" {
-				err = t.attachMavenIdentity(&existingApp)
-				if err != nil {
-					return nil, fmt.Errorf("failed to attach maven identity: %w", err)
-				}
-			}
+	// Archives have no Hub representation, they are neither repositories nor binaries
+	if IsArchiveFile(test.Analysis.Application) {
+		return nil, fmt.Errorf("tackle hub does not support archive applications: %s", test.Analysis.Application)
+	}
+	// Source fixtures aren't in a repository the Hub could clone, binary fixtures are uploaded
+	if config.IsFixtureURL(test.Analysis.Application) && !IsBinaryFile(test.Analysis.Application) {
+		return nil, fmt.Errorf("tackle hub only supports binary fixtures: %s", test.Analysis.Application)
+	}
 
-			return &existingApp, nil
+	if t.reuseApps {
+		app, err := t.findApplication(test.Name)
+		if err != nil {
+			return nil, err
+		}
+		if app != nil {
+			log.Info("Found existing application", "id", app.ID, "name", app.Name)
+			return app, t.reuseApplication(ctx, test, app)
 		}
 	}
 
@@ -327,34 +386,14 @@ func (t *TackleHubTarget) createApplication(test *config.TestDefinition) (*api.A
 	app := &api.Application{
 		Name:        test.Name,
 		Description: test.Description,
+		Repository:  applicationRepository(test),
 	}
 
-	// Check if this is a binary analysis (based on file extension)
-	isBinary := IsBinaryFile(test.Analysis.Application)
-
-	// Only set repository for source code analysis
-	if !isBinary {
-		// Use parsed Git components if available, otherwise parse the URL
-		if test.Analysis.ApplicationGitComponents != nil {
-			app.Repository = &api.Repository{
-				Kind:   "git",
-				URL:    test.Analysis.ApplicationGitComponents.URL,
-				Branch: test.Analysis.ApplicationGitComponents.Ref,
-				Path:   test.Analysis.ApplicationGitComponents.Path,
-			}
-		} else {
-			// Fallback to simple parsing (for backward compatibility)
-			repoURL, branch := parseGitURL(test.Analysis.Application)
-			app.Repository = &api.Repository{
-				Kind:   "git",
-				URL:    repoURL,
-				Branch: branch,
-			}
-		}
-	}
-
-	err = t.client.Application.Create(app)
+	err := t.client.Application.Create(app)
 	if err != nil {
+		if !t.reuseApps {
+			return nil, fmt.Errorf("%w (set reuseApplications to reuse an existing application)", err)
+		}
 		return nil, err
 	}
 
@@ -369,8 +408,126 @@ func (t *TackleHubTarget) createApplication(test *config.TestDefinition) (*api.A
 	return app, nil
 }
 
-// uploadBinary uploads a binary file to the application's bucket
-func (t *TackleHubTarget) uploadBinary(task *api.Task, binaryPath string, testDir string) error {
+// findApplication returns the application with a name, nil when there is none
+func (t *TackleHubTarget) findApplication(name string) (*api.Application, error) {
+	apps, err := t.client.Application.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for i := range apps {
+		if apps[i].Name == name {
+			return &apps[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// reuseApplication waits for the running tasks of an existing application and updates it with the
+// test's repository, so a previous run of the test doesn't decide what is analyzed
+func (t *TackleHubTarget) reuseApplication(ctx context.Context, test *config.TestDefinition, app *api.Application) error {
+	log := util.GetLogger()
+
+	if err := t.waitApplicationTasks(ctx, app.ID, test.GetTimeout()); err != nil {
+		return err
+	}
+
+	repository := applicationRepository(test)
+	if app.Description != test.Description || !reflect.DeepEqual(app.Repository, repository) {
+		app.Description = test.Description
+		app.Repository = repository
+		if err := t.client.Application.Update(app); err != nil {
+			return fmt.Errorf("failed to update application %s: %w", app.Name, err)
+		}
+		log.Info("Updated existing application", "id", app.ID, "name", app.Name)
+	}
+
+	// Update identities if maven settings configured
+	if t.mavenSettings != "" {
+		if err := t.attachMavenIdentity(app); err != nil {
+			return fmt.Errorf("failed to attach maven identity: %w", err)
+		}
+	}
+	return nil
+}
+
+// waitApplicationTasks waits until no task of an application is queued or running, e.g. the tasks of a
+// concurrent or aborted run of the same test
+func (t *TackleHubTarget) waitApplicationTasks(ctx context.Context, appID uint, timeout time.Duration) error {
+	log := util.GetLogger()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		tasks, err := t.client.Task.List()
+		if err != nil {
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		active := activeApplicationTasks(tasks, appID)
+		if len(active) == 0 {
+			return nil
+		}
+		log.Info("Waiting for the tasks of the application", "applicationID", appID, "tasks", active)
+		progress.Update(ctx, fmt.Sprintf("waiting for %d tasks of application %d", len(active), appID))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(deadline)):
+			return fmt.Errorf("tasks %v of application %d still running after %v", active, appID, timeout)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// activeApplicationTasks returns the IDs of the tasks of an application which didn't finish
+func activeApplicationTasks(tasks []api.Task, appID uint) []uint {
+	var active []uint
+	for _, task := range tasks {
+		if task.Application == nil || task.Application.ID != appID {
+			continue
+		}
+		switch task.State {
+		case TaskStateCreated, TaskStateReady, TaskStatePending, TaskStatePostponed, TaskStateRunning:
+			active = append(active, task.ID)
+		}
+	}
+	return active
+}
+
+// applicationRepository returns the repository of a test's application, nil for binaries
+func applicationRepository(test *config.TestDefinition) *api.Repository {
+	// Check if this is a binary analysis (based on file extension)
+	// Applications from container images are uploaded as binaries too
+	if IsBinaryFile(test.Analysis.Application) || test.Analysis.ApplicationImageComponents != nil {
+		return nil
+	}
+
+	// Use parsed Git components if available, otherwise parse the URL
+	if comps := test.Analysis.ApplicationGitComponents; comps != nil {
+		repository := &api.Repository{
+			Kind:   comps.RepositoryKind(),
+			URL:    comps.URL,
+			Branch: comps.Ref,
+			Path:   comps.Path,
+		}
+		// The hub checks out the branch, which may also be a commit SHA
+		if comps.Options != nil && comps.Options.Commit != "" {
+			repository.Branch = comps.Options.Commit
+		}
+		return repository
+	}
+
+	// Fallback to simple parsing (for backward compatibility)
+	repoURL, branch := parseGitURL(test.Analysis.Application)
+	return &api.Repository{
+		Kind:   "git",
+		URL:    repoURL,
+		Branch: branch,
+	}
+}
+
+// uploadBinary uploads a binary file to the bucket of the task or of the application. With verification,
+// the uploaded binary is downloaded and compared with the file, so truncated uploads fail the test
+func (t *TackleHubTarget) uploadBinary(task *api.Task, app *api.Application, binaryPath string, testDir string, scheduling *config.HubTaskConfig) error {
 	log := util.GetLogger()
 
 	// Resolve the binary path (handle both absolute and relative paths)
@@ -389,35 +546,77 @@ func (t *TackleHubTarget) uploadBinary(task *api.Task, binaryPath string, testDi
 		return fmt.Errorf("binary file not found at %s: %w", absPath, err)
 	}
 
-	log.Info("Uploading binary file", "path", absPath, "task", task.ID)
+	log.Info("Uploading binary file", "path", absPath, "task", task.ID, "bucket", scheduling.GetUpload())
 
-	// Get application bucket
+	// The artifact path of the task is the same in both buckets
 	bucket := t.client.Bucket.Content(task.Bucket.ID)
+	if scheduling.GetUpload() == config.HubUploadApplication {
+		bucket = t.client.Application.Bucket(app.ID)
+	}
 
 	// Upload the binary to the bucket
 	// The file will be stored at /binary in the bucket
-	err = bucket.Put(absPath, fmt.Sprintf("/binary/%v", filepath.Base(absPath)))
+	artifact := fmt.Sprintf("/binary/%v", filepath.Base(absPath))
+	err = bucket.Put(absPath, artifact)
 	if err != nil {
 		return fmt.Errorf("failed to upload binary: %w", err)
 	}
 
+	if scheduling.GetVerifyUpload() {
+		downloadDir, err := os.MkdirTemp("", "koncur-upload-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(downloadDir)
+		downloaded := filepath.Join(downloadDir, filepath.Base(absPath))
+		if err := bucket.Get(artifact, downloaded); err != nil {
+			return fmt.Errorf("failed to download uploaded binary %s: %w", artifact, err)
+		}
+		if err := compareUpload(absPath, downloaded); err != nil {
+			return fmt.Errorf("uploaded binary %s doesn't match %s: %w", artifact, absPath, err)
+		}
+		log.Info("Verified uploaded binary", "artifact", artifact)
+	}
+
 	log.Info("Successfully uploaded binary", "path", absPath, "task", task.ID, "bucket_content")
 	return nil
 }
 
-// createAnalysisTask creates an analysis task for the application
-func (t *TackleHubTarget) createAnalysisTask(ctx context.Context, test *config.TestDefinition, app *api.Application) (*api.Task, error) {
+// createAnalysisTask creates an analysis task for the application, selecting the custom target when set
+func (t *TackleHubTarget) createAnalysisTask(ctx context.Context, test *config.TestDefinition, app *api.Application, customTarget *api.Target) (*api.Task, error) {
 	log := util.GetLogger()
 	// Build task data with analysis configuration
 	taskData := Data{}
 
 	// Check if this is a binary analysis
-	isBinary := IsBinaryFile(test.Analysis.Application)
+	binaryPath := test.Analysis.Application
+	artifact := test.Analysis.Application
+	if test.Analysis.ApplicationImageComponents != nil {
+		// Extract the artifact from the image, the Hub analyzes it as an uploaded binary
+		extracted, err := ExtractImageApplication(ctx, test.Analysis.ApplicationImageComponents, test.GetTestDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract application from image: %w", err)
+		}
+		if !IsBinaryFile(extracted) {
+			return nil, fmt.Errorf("tackle hub can only analyze binary artifacts from images, found source at %s", extracted)
+		}
+		binaryPath = extracted
+		artifact = filepath.Base(extracted)
+	} else if config.IsFixtureURL(test.Analysis.Application) {
+		// Binary fixtures are written next to the test like binaries extracted from images
+		materialized, err := fixtures.Materialize(config.FixtureName(test.Analysis.Application), test.GetTestDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed to write fixture: %w", err)
+		}
+		binaryPath = materialized
+		artifact = filepath.Base(materialized)
+	}
+	isBinary := IsBinaryFile(binaryPath)
 
 	if isBinary {
 		// Binary mode
 		taskData.Mode.Binary = true
-		taskData.Mode.Artifact = fmt.Sprintf("/binary/%v", test.Analysis.Application) // Path where binary is stored in bucket
+		taskData.Mode.Artifact = fmt.Sprintf("/binary/%v", artifact) // Path where binary is stored in bucket
 		log.Info("Configuring binary analysis mode", "artifact", taskData.Mode.Artifact)
 	} else {
 		// Source code mode
@@ -441,28 +640,53 @@ func (t *TackleHubTarget) createAnalysisTask(ctx context.Context, test *config.T
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare rules: %w", err)
 	}
+	if customTarget != nil {
+		selectCustomTarget(&taskData.Rules, customTarget, test.Analysis.LabelSelector)
+	}
+
+	// Pass the test environment to the analyzer addon
+	if len(test.Env) > 0 {
+		taskData.Env = test.Env
+	}
+
+	// Pass provider settings overrides to the analyzer addon
+	if len(test.Analysis.ProviderSettings) > 0 {
+		taskData.ProviderSettings = test.Analysis.ProviderConfigs()
+	}
 
 	taskData.Verbosity = 1
 	log.V(1).Info("Using task data", "data", taskData)
 
+	// Tests may schedule their task differently, e.g. to test preemption
+	scheduling := t.task.Merge(test.HubTask)
 	task := &api.Task{
 		Name:        fmt.Sprintf("Analysis: %s", test.Name),
-		Kind:        "analyzer", // analyzer task kind
-		Addon:       "analyzer",
+		Kind:        scheduling.GetKind(),
+		Addon:       t.addon,
 		Application: &api.Ref{ID: app.ID},
 		Data:        taskData,
 		State:       "Created",
 	}
+	if scheduling.Priority != nil {
+		task.Priority = *scheduling.Priority
+	}
+	if scheduling.PreemptEnabled != nil {
+		task.Policy.PreemptEnabled = *scheduling.PreemptEnabled
+	}
+
+	// Providers run as analyzer addon extensions
+	task.Extensions = hubExtensions(test.Analysis.Providers)
 
 	// Debug: log the task before creating
-	log.V(1).Info("Creating task", "name", task.Name, "kind", task.Kind, "addon", task.Addon, "appID", app.ID)
+	log.V(1).Info("Creating task", "name", task.Name, "kind", task.Kind, "addon", task.Addon, "appID", app.ID,
+		"priority", task.Priority, "preemptEnabled", task.Policy.PreemptEnabled)
 
 	err = t.client.Task.Create(task)
 	if err != nil {
 		return nil, err
 	}
 	if isBinary {
-		err = t.uploadBinary(task, test.Analysis.Application, test.GetTestDir())
+		err = t.uploadBinary(task, app, binaryPath, test.GetTestDir(), scheduling)
 		if err != nil {
 			return nil, err
 		}
@@ -476,6 +700,48 @@ func (t *TackleHubTarget) createAnalysisTask(ctx context.Context, test *config.T
 	return task, nil
 }
 
+// verifyImages checks that the addon and its extensions run the configured images
+func (t *TackleHubTarget) verifyImages() error {
+	if t.addonImage == "" && len(t.extensionImages) == 0 {
+		return nil
+	}
+	addon, err := t.client.Addon.Get(t.addon)
+	if err != nil {
+		return fmt.Errorf("failed to get addon %s: %w", t.addon, err)
+	}
+	return checkAddonImages(addon, t.addonImage, t.extensionImages)
+}
+
+// checkAddonImages compares the images of an addon and its extensions with the expected ones
+func checkAddonImages(addon *api.Addon, addonImage string, extensionImages map[string]string) error {
+	if addonImage != "" && addon.Container.Image != addonImage {
+		return fmt.Errorf("addon %s runs image %s, expected %s", addon.Name, addon.Container.Image, addonImage)
+	}
+	for _, name := range slices.Sorted(maps.Keys(extensionImages)) {
+		i := slices.IndexFunc(addon.Extensions, func(ext api.Extension) bool { return ext.Name == name })
+		if i < 0 {
+			return fmt.Errorf("addon %s has no extension %s", addon.Name, name)
+		}
+		if image := addon.Extensions[i].Container.Image; image != extensionImages[name] {
+			return fmt.Errorf("extension %s runs image %s, expected %s", name, image, extensionImages[name])
+		}
+	}
+	return nil
+}
+
+// hubExtensions returns the analyzer addon extensions running the given providers
+// The go, python and nodejs providers share the generic extension
+func hubExtensions(providers []string) []string {
+	var extensions []string
+	for _, p := range providers {
+		ext := config.ProviderImplementation(p)
+		if !slices.Contains(extensions, ext) {
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
 // prepareRulesForHub handles rules that may be Git URLs for Tackle Hub
 // Tackle Hub handles rules differently - it uses repositories rather than file paths
 func (t *TackleHubTarget) prepareRulesForHub(ctx context.Context, test *config.TestDefinition, taskData *Data) error {
@@ -518,6 +784,9 @@ func (t *TackleHubTarget) pollTaskCompletion(ctx context.Context, taskID uint, t
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
+	// State transitions are shown as the progress of the test, with the task's latest activity
+	lastState := ""
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -531,6 +800,15 @@ func (t *TackleHubTarget) pollTaskCompletion(ctx context.Context, taskID uint, t
 			}
 
 			log.V(1).Info("Task status", "taskID", taskID, "state", task.State)
+			if task.State != lastState {
+				log.Info("Task state changed", "taskID", taskID, "from", lastState, "to", task.State)
+				lastState = task.State
+			}
+			status := fmt.Sprintf("task %d %s", taskID, task.State)
+			if len(task.Activity) > 0 {
+				status += ": " + task.Activity[len(task.Activity)-1]
+			}
+			progress.Update(ctx, status)
 
 			switch task.State {
 			case TaskStateSucceeded:
@@ -634,6 +912,17 @@ func (t *TackleHubTarget) submitTask(taskID uint) error {
 	return nil
 }
 
+// cancelTask asks the task manager to cancel a task
+func (t *TackleHubTarget) cancelTask(taskID uint) error {
+	path := fmt.Sprintf("/tasks/%d/cancel", taskID)
+	// Like submit, the cancel endpoint doesn't return a body
+	err := t.client.Client.Put(path, nil)
+	if err != nil && err.Error() != "json: Unmarshal(nil)" {
+		return err
+	}
+	return nil
+}
+
 // attachMavenIdentity creates or finds a maven settings identity and attaches it to the application
 func (t *TackleHubTarget) attachMavenIdentity(app *api.Application) error {
 	log := util.GetLogger()
@@ -0,0 +1,90 @@
+package targets
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/konveyor/tackle2-hub/api"
+	"github.com/konveyor/tackle2-hub/binding"
+	"github.com/konveyor/test-harness/pkg/util"
+)
+
+// hubPageSize is the number of resources requested per page from paginated Hub endpoints
+const hubPageSize = 100
+
+// analysisInsights fetches the issues and insights of the latest analysis of an application from the
+// REST endpoints the UI consumes, page by page. Issues are the insights with effort, Hubs listing them
+// under both endpoints return each insight once. Incidents the list endpoints leave out are fetched per
+// insight
+func (t *TackleHubTarget) analysisInsights(appID uint) ([]api.Insight, error) {
+	log := util.GetLogger()
+
+	var analysis api.Analysis
+	if err := t.client.Client.Get(fmt.Sprintf("/applications/%d/analysis", appID), &analysis); err != nil {
+		return nil, fmt.Errorf("failed to get the analysis of application %d: %w", appID, err)
+	}
+
+	var insights []api.Insight
+	seen := map[uint]bool{}
+	for _, resource := range []string{"issues", "insights"} {
+		path := fmt.Sprintf("/analyses/%d/%s", analysis.ID, resource)
+		page, err := fetchPages(hubPageSize, func(offset, limit int) ([]api.Insight, error) {
+			var items []api.Insight
+			err := t.client.Client.Get(path, &items, pageParams(offset, limit)...)
+			return items, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s of analysis %d: %w", resource, analysis.ID, err)
+		}
+		for _, insight := range page {
+			if seen[insight.ID] {
+				continue
+			}
+			seen[insight.ID] = true
+			insights = append(insights, insight)
+		}
+	}
+
+	for i := range insights {
+		if len(insights[i].Incidents) > 0 {
+			continue
+		}
+		path := fmt.Sprintf("/analyses/insights/%d/incidents", insights[i].ID)
+		incidents, err := fetchPages(hubPageSize, func(offset, limit int) ([]api.Incident, error) {
+			var items []api.Incident
+			err := t.client.Client.Get(path, &items, pageParams(offset, limit)...)
+			return items, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get incidents of insight %d: %w", insights[i].ID, err)
+		}
+		insights[i].Incidents = incidents
+	}
+
+	log.Info("Fetched analysis insights", "analysisID", analysis.ID, "insights", len(insights))
+	return insights, nil
+}
+
+// pageParams returns the query parameters requesting a page of a Hub list endpoint
+func pageParams(offset, limit int) []binding.Param {
+	return []binding.Param{
+		{Key: "offset", Value: strconv.Itoa(offset)},
+		{Key: "limit", Value: strconv.Itoa(limit)},
+	}
+}
+
+// fetchPages calls get with increasing offsets until it returns a page shorter than pageSize, and returns
+// the items of all pages
+func fetchPages[T any](pageSize int, get func(offset, limit int) ([]T, error)) ([]T, error) {
+	var items []T
+	for offset := 0; ; offset += pageSize {
+		page, err := get(offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < pageSize {
+			return items, nil
+		}
+	}
+}
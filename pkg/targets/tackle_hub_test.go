@@ -2,10 +2,13 @@ package targets
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/tackle2-hub/api"
 	"github.com/konveyor/test-harness/pkg/config"
 )
 
@@ -45,6 +48,22 @@ func TestNewTackleHubTarget(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config with analysis results",
+			cfg: &config.TackleHubConfig{
+				URL:     "http://localhost:8080",
+				Results: config.HubResultsAnalysis,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown results",
+			cfg: &config.TackleHubConfig{
+				URL:     "http://localhost:8080",
+				Results: "bucket",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,6 +83,9 @@ func TestNewTackleHubTarget(t *testing.T) {
 				if target.url != tt.cfg.URL {
 					t.Errorf("Expected URL '%s', got '%s'", tt.cfg.URL, target.url)
 				}
+				if target.results != tt.cfg.GetResults() {
+					t.Errorf("Expected results '%s', got '%s'", tt.cfg.GetResults(), target.results)
+				}
 			}
 		})
 	}
@@ -753,3 +775,139 @@ func TestTackleHubTarget_GitURLIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestHubExtensions(t *testing.T) {
+	tests := []struct {
+		name      string
+		providers []string
+		expected  []string
+	}{
+		{name: "no providers", providers: nil, expected: nil},
+		{name: "java and dotnet", providers: []string{"java", "dotnet"}, expected: []string{"java", "dotnet"}},
+		{name: "generic providers share an extension", providers: []string{"go", "python", "nodejs"}, expected: []string{"generic"}},
+		{name: "mixed", providers: []string{"java", "nodejs", "go"}, expected: []string{"java", "generic"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hubExtensions(tt.providers)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("hubExtensions(%v) = %v, want %v", tt.providers, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckAddonImages(t *testing.T) {
+	addon := &api.Addon{Name: "analyzer"}
+	addon.Container.Image = "quay.io/konveyor/tackle2-addon-analyzer:v0.8.0"
+	java := api.Extension{Name: "java"}
+	java.Container.Image = "quay.io/konveyor/java-external-provider:v0.8.0"
+	addon.Extensions = []api.Extension{java}
+
+	tests := []struct {
+		name            string
+		addonImage      string
+		extensionImages map[string]string
+		wantErr         string
+	}{
+		{name: "nothing pinned"},
+		{name: "matching images", addonImage: addon.Container.Image, extensionImages: map[string]string{"java": java.Container.Image}},
+		{name: "other addon image", addonImage: "quay.io/konveyor/tackle2-addon-analyzer:latest", wantErr: "addon analyzer runs image"},
+		{name: "other extension image", extensionImages: map[string]string{"java": "quay.io/konveyor/java-external-provider:latest"}, wantErr: "extension java runs image"},
+		{name: "missing extension", extensionImages: map[string]string{"dotnet": "quay.io/konveyor/dotnet-external-provider:latest"}, wantErr: "has no extension dotnet"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAddonImages(addon, tt.addonImage, tt.extensionImages)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("checkAddonImages() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActiveApplicationTasks(t *testing.T) {
+	tasks := []api.Task{
+		{Resource: api.Resource{ID: 1}, Application: &api.Ref{ID: 7}, State: TaskStateSucceeded},
+		{Resource: api.Resource{ID: 2}, Application: &api.Ref{ID: 7}, State: TaskStateRunning},
+		{Resource: api.Resource{ID: 3}, Application: &api.Ref{ID: 7}, State: TaskStatePending},
+		{Resource: api.Resource{ID: 4}, Application: &api.Ref{ID: 8}, State: TaskStateRunning},
+		{Resource: api.Resource{ID: 5}, State: TaskStateRunning},
+	}
+
+	got := activeApplicationTasks(tasks, 7)
+	if len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("activeApplicationTasks() = %v, want [2 3]", got)
+	}
+	if got := activeApplicationTasks(tasks, 9); len(got) != 0 {
+		t.Errorf("activeApplicationTasks() of an application without tasks = %v", got)
+	}
+}
+
+func TestApplicationRepository(t *testing.T) {
+	source := &config.TestDefinition{Analysis: config.AnalysisConfig{
+		Application: "https://github.com/konveyor/tackle-testapp#main/src",
+	}}
+	source.Analysis.ParseGitURLs()
+	repository := applicationRepository(source)
+	if repository == nil || repository.URL != "https://github.com/konveyor/tackle-testapp" ||
+		repository.Branch != "main" || repository.Path != "src" {
+		t.Errorf("applicationRepository() = %+v", repository)
+	}
+
+	binary := &config.TestDefinition{Analysis: config.AnalysisConfig{Application: "app.war"}}
+	if repository := applicationRepository(binary); repository != nil {
+		t.Errorf("applicationRepository() of a binary = %+v, want nil", repository)
+	}
+}
+
+func TestFetchPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		wantCalls int
+	}{
+		{name: "empty", total: 0, wantCalls: 1},
+		{name: "partial page", total: 2, wantCalls: 1},
+		{name: "full pages", total: 6, wantCalls: 3},
+		{name: "last page partial", total: 7, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			got, err := fetchPages(3, func(offset, limit int) ([]int, error) {
+				calls++
+				var page []int
+				for i := offset; i < offset+limit && i < tt.total; i++ {
+					page = append(page, i)
+				}
+				return page, nil
+			})
+			if err != nil {
+				t.Fatalf("fetchPages() error = %v", err)
+			}
+			if len(got) != tt.total || calls != tt.wantCalls {
+				t.Errorf("fetchPages() = %v in %d calls, want %d items in %d calls", got, calls, tt.total, tt.wantCalls)
+			}
+			for i, item := range got {
+				if item != i {
+					t.Errorf("fetchPages() = %v, want items in order", got)
+					break
+				}
+			}
+		})
+	}
+
+	_, err := fetchPages(3, func(offset, limit int) ([]int, error) {
+		if offset > 0 {
+			return nil, fmt.Errorf("page %d unavailable", offset)
+		}
+		return []int{1, 2, 3}, nil
+	})
+	if err == nil {
+		t.Error("fetchPages() error = nil, want the error of the failed page")
+	}
+}
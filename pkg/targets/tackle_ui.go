@@ -41,6 +41,16 @@ func (t *TackleUITarget) Name() string {
 	return "tackle-ui"
 }
 
+// Version isn't detected for the Tackle UI target
+func (t *TackleUITarget) Version(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// Capabilities are unknown for the Tackle UI target, every test is run
+func (t *TackleUITarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	return nil, nil
+}
+
 // Execute runs analysis via Tackle UI browser automation
 func (t *TackleUITarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
 	// TODO: Implement Tackle UI automation
@@ -2,6 +2,10 @@ package targets
 
 import (
 	"context"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/konveyor/test-harness/pkg/config"
@@ -14,6 +18,56 @@ type Target interface {
 
 	// Execute runs the analysis and returns the result
 	Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error)
+
+	// Version returns the version of the tool behind the target, empty when it isn't detected
+	Version(ctx context.Context) (string, error)
+
+	// Capabilities returns the features the target supports, nil when they are unknown
+	Capabilities(ctx context.Context) (Capabilities, error)
+}
+
+// Canceler is implemented by targets that can cancel a running analysis, cancellation tests run on them
+type Canceler interface {
+	// ExecuteCanceled starts the analysis of a cancellation test, cancels it after the test's delay and
+	// records what the canceled analysis left behind
+	ExecuteCanceled(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error)
+}
+
+// Assessor is implemented by targets that can assess applications with questionnaires, assessment tests
+// run on them
+type Assessor interface {
+	// Assess answers the questionnaire of an assessment test for the test's application and records the
+	// risk and confidence of the assessment
+	Assess(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error)
+}
+
+// AssessmentResult is the outcome of an assessment computed by the target
+type AssessmentResult struct {
+	// Status is the completion of the assessment, e.g. complete when every question was answered
+	Status     string
+	Risk       string
+	Confidence int
+}
+
+// Migrator is implemented by targets that can export applications to issue trackers, migration tests run
+// on them
+type Migrator interface {
+	// Migrate adds the application of a migration test to a migration wave, exports it to the test's
+	// tracker and records the created ticket
+	Migrate(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error)
+}
+
+// TicketResult is a ticket the target created in an issue tracker
+type TicketResult struct {
+	// Reference is the key of the ticket in the tracker, e.g. MIG-1
+	Reference string
+	Link      string
+	Status    string
+	// Summary and IssueType are only known for tickets created in the mock Jira
+	Summary   string
+	IssueType string
+	// Error is the reason the ticket couldn't be created
+	Error string
 }
 
 // ExecutionResult contains the results of executing a target
@@ -24,8 +78,21 @@ type ExecutionResult struct {
 	// Duration of execution
 	Duration time.Duration
 
-	// OutputFile path to the generated output.yaml
-	OutputFile string
+	// Artifacts are the outputs of the analysis, e.g. output.yaml and analysis.log
+	Artifacts OutputArtifacts
+
+	// ApplicationArtifacts maps application names to their artifacts for multi-application tests
+	ApplicationArtifacts map[string]OutputArtifacts
+
+	// ApplicationWorkDirs maps application names to their work directories when
+	// every application of a multi-application test runs separately
+	ApplicationWorkDirs map[string]string
+
+	// AssetsDir holds the generated assets for asset generation tests
+	AssetsDir string
+
+	// PatchesDir holds the patches returned by Kai for fix tests
+	PatchesDir string
 
 	// WorkDir where the execution happened
 	WorkDir string
@@ -38,4 +105,69 @@ type ExecutionResult struct {
 
 	// Error if execution failed
 	Error error
+
+	// OOMKilled is set when an analysis container ran out of memory
+	OOMKilled bool
+
+	// Assessment is the outcome of an assessment test
+	Assessment *AssessmentResult
+
+	// Ticket is the ticket created by a migration test
+	Ticket *TicketResult
+
+	// Archetypes are the names of the archetypes the analyzed application belongs to, nil when the target
+	// has no archetypes
+	Archetypes []string
+
+	// PeakMemory is the peak resident memory in bytes of the executed command, including the processes it
+	// waited for. It is 0 when unknown, e.g. for analyses running remotely
+	PeakMemory uint64
+
+	// Canceled is set when the analysis of a cancellation test was canceled before it finished
+	Canceled bool
+
+	// Leftovers describes what a canceled analysis left behind, e.g. running containers or partial outputs
+	Leftovers []string
+}
+
+// OutputFile returns the analysis output of a single application test
+func (r *ExecutionResult) OutputFile() string {
+	return r.Artifacts.Output()
+}
+
+// ApplicationOutputFile returns the analysis output of an application of a multi-application test
+func (r *ExecutionResult) ApplicationOutputFile(name string) (string, bool) {
+	artifacts, ok := r.ApplicationArtifacts[name]
+	if !ok || artifacts.Output() == "" {
+		return "", false
+	}
+	return artifacts.Output(), true
+}
+
+// ArtifactDirs returns the directories holding the outputs and logs of the execution
+// Output directories configured outside the work directories are included
+func (r *ExecutionResult) ArtifactDirs() []string {
+	dirs := []string{r.WorkDir}
+	if len(r.ApplicationWorkDirs) > 0 {
+		dirs = slices.Sorted(maps.Values(r.ApplicationWorkDirs))
+	}
+
+	all := append([]OutputArtifacts{r.Artifacts}, slices.Collect(maps.Values(r.ApplicationArtifacts))...)
+	for _, artifacts := range all {
+		output := artifacts.Output()
+		if output == "" {
+			continue
+		}
+		outputDir := filepath.Dir(output)
+		if !slices.ContainsFunc(dirs, func(dir string) bool { return isWithin(outputDir, dir) }) {
+			dirs = append(dirs, outputDir)
+		}
+	}
+	return dirs
+}
+
+// isWithin reports whether path is dir or inside it
+func isWithin(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
@@ -0,0 +1,43 @@
+package targets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// fileDigest returns the size and SHA-256 checksum of a file
+func fileDigest(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// compareUpload checks that a downloaded copy of an uploaded file has its size and checksum
+func compareUpload(local, downloaded string) error {
+	size, sum, err := fileDigest(local)
+	if err != nil {
+		return err
+	}
+	downloadedSize, downloadedSum, err := fileDigest(downloaded)
+	if err != nil {
+		return err
+	}
+	if downloadedSize != size {
+		return fmt.Errorf("size is %d bytes, expected %d", downloadedSize, size)
+	}
+	if downloadedSum != sum {
+		return fmt.Errorf("SHA-256 checksum is %s, expected %s", downloadedSum, sum)
+	}
+	return nil
+}
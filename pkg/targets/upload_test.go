@@ -0,0 +1,45 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareUpload(t *testing.T) {
+	dir := t.TempDir()
+	content := strings.Repeat("binary", 1000)
+	local := filepath.Join(dir, "app.war")
+	if err := os.WriteFile(local, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		downloaded string
+		wantErr    string
+	}{
+		{name: "intact", downloaded: content},
+		{name: "truncated", downloaded: content[:4096], wantErr: "size is 4096 bytes, expected 6000"},
+		{name: "corrupted", downloaded: "B" + content[1:], wantErr: "SHA-256 checksum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			downloaded := filepath.Join(dir, tt.name)
+			if err := os.WriteFile(downloaded, []byte(tt.downloaded), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := compareUpload(local, downloaded)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("compareUpload() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("compareUpload() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,15 +2,44 @@ package targets
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/konveyor/test-harness/pkg/config"
 	"github.com/konveyor/test-harness/pkg/util"
 )
 
+func init() {
+	// Serve local repositories in-process as well, so cloning never needs a git binary
+	client.InstallProtocol("file", server.NewServer(localRepositoryLoader{}))
+}
+
+// localRepositoryLoader loads local repositories for the in-process file transport
+// Non-bare repositories are served from their .git directory, like git does
+type localRepositoryLoader struct{}
+
+func (localRepositoryLoader) Load(ep *transport.Endpoint) (storer.Storer, error) {
+	gitDir := filepath.Join(ep.Path, ".git")
+	if info, err := os.Stat(gitDir); err == nil && info.IsDir() {
+		bareEndpoint := *ep
+		bareEndpoint.Path = gitDir
+		return server.DefaultLoader.Load(&bareEndpoint)
+	}
+	return server.DefaultLoader.Load(ep)
+}
+
 // IsBinaryFile returns true if the path appears to be a binary artifact (.jar, .war, or .ear)
 func IsBinaryFile(path string) bool {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -19,7 +48,8 @@ func IsBinaryFile(path string) bool {
 
 // CloneGitRepository clones a Git repository and returns the path to the cloned directory
 // or subdirectory if specified in the GitURLComponents
-func CloneGitRepository(ctx context.Context, components *config.GitURLComponents, workDir string, cloneName string) (string, error) {
+// Credentials from auth are used for private repositories, auth may be nil
+func CloneGitRepository(ctx context.Context, components *config.GitURLComponents, auth *config.GitAuthConfig, workDir string, cloneName string) (string, error) {
 	log := util.GetLogger()
 
 	// Clone the git repository into workDir/cloneName folder
@@ -45,23 +75,34 @@ func CloneGitRepository(ctx context.Context, components *config.GitURLComponents
 		return absInputDir, nil
 	}
 
+	// Subversion repositories are exported rather than cloned
+	if components.RepositoryKind() == config.RepositoryKindSubversion {
+		return exportSVNRepository(ctx, components, absCloneDir, absInputDir)
+	}
+
 	log.Info("Cloning git repository", "url", components.URL, "ref", components.Ref, "path", components.Path, "dest", absCloneDir)
 
-	// Build git clone command
-	var gitArgs []string
-	if components.Ref != "" {
-		gitArgs = []string{"clone", "--depth", "1", "--branch", components.Ref, components.URL, absCloneDir}
-	} else {
-		gitArgs = []string{"clone", "--depth", "1", components.URL, absCloneDir}
+	authMethod, err := gitAuthMethod(components.URL, auth)
+	if err != nil {
+		return "", err
 	}
 
-	// Execute git clone
-	result, err := ExecuteCommand(ctx, "git", gitArgs, ".", 5*60*1000000000) // 5 minute timeout for clone
+	// Bound the clone like other commands, cancelling ctx aborts it
+	cloneCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	repo, err := cloneGitRepository(cloneCtx, components, authMethod, absCloneDir)
 	if err != nil {
-		log.Info("Git clone failed", "error", err.Error(), "exitCode", result.ExitCode, "stderr", result.Stderr)
+		os.RemoveAll(absCloneDir)
 		return "", fmt.Errorf("git clone failed: %w", err)
 	}
 
+	// Limit the checkout, pin the commit and fetch submodules as requested
+	if err := checkoutGitRepository(cloneCtx, repo, components, authMethod); err != nil {
+		os.RemoveAll(absCloneDir)
+		return "", err
+	}
+
 	log.Info("Git clone completed successfully")
 
 	// Remove .git directory to save space and avoid git-related issues
@@ -83,3 +124,213 @@ func CloneGitRepository(ctx context.Context, components *config.GitURLComponents
 
 	return absInputDir, nil
 }
+
+// CachedRepository returns the checkout of a repository in a source cache, or its subdirectory if specified
+// in the GitURLComponents. Checkouts are at <cacheDir>/<host>/<path>/<ref>, HEAD for the default branch
+func CachedRepository(cacheDir string, components *config.GitURLComponents) (string, error) {
+	if cacheDir == "" {
+		return "", fmt.Errorf("offline runs can't clone %s, set sourceCache to a directory of pre-seeded checkouts", components.URL)
+	}
+	dir, err := filepath.Abs(filepath.Join(cacheDir, sourceCacheKey(components), components.Path))
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return "", fmt.Errorf("offline runs can't clone %s, no checkout at %s in the source cache", components.URL, dir)
+	}
+	util.GetLogger().Info("Using repository from the source cache", "url", components.URL, "path", dir)
+	return dir, nil
+}
+
+// sourceCacheKey returns the directory of a repository checkout in a source cache, e.g.
+// github.com/konveyor/tackle-testapp/main for https://github.com/konveyor/tackle-testapp.git#main
+func sourceCacheKey(components *config.GitURLComponents) string {
+	repo, hasScheme := components.URL, strings.Contains(components.URL, "://")
+	if _, rest, ok := strings.Cut(repo, "://"); ok {
+		repo = rest
+	}
+	// Users and credentials aren't part of the key, e.g. user:token@host/org/repo or git@host:org/repo
+	if at := strings.Index(repo, "@"); at >= 0 && !strings.Contains(repo[:at], "/") {
+		repo = repo[at+1:]
+	}
+	if !hasScheme {
+		repo = strings.Replace(repo, ":", "/", 1)
+	}
+	repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+
+	ref := components.Ref
+	if components.Options != nil && components.Options.Commit != "" {
+		ref = components.Options.Commit
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+	return filepath.Join(filepath.FromSlash(repo), ref)
+}
+
+// cloneGitRepository clones the repository into dest with go-git
+// A ref is tried as a branch first and then as a tag, like git clone --branch
+func cloneGitRepository(ctx context.Context, components *config.GitURLComponents, auth transport.AuthMethod, dest string) (*git.Repository, error) {
+	options := components.Options
+
+	cloneOptions := &git.CloneOptions{
+		URL:      components.URL,
+		Auth:     auth,
+		Progress: &gitProgressWriter{},
+		// Sparse and pinned checkouts are done after the clone
+		NoCheckout: options != nil && (len(options.SparsePaths) > 0 || options.Commit != ""),
+	}
+	// The in-process file transport can't serve shallow clones, local clones are cheap anyway
+	if options.IsShallow() && !isLocalRepository(components.URL) {
+		cloneOptions.Depth = 1
+		cloneOptions.SingleBranch = true
+	}
+
+	if components.Ref == "" {
+		return git.PlainCloneContext(ctx, dest, false, cloneOptions)
+	}
+
+	cloneOptions.ReferenceName = plumbing.NewBranchReferenceName(components.Ref)
+	repo, err := git.PlainCloneContext(ctx, dest, false, cloneOptions)
+	if err == nil || !isReferenceNotFound(err) {
+		return repo, err
+	}
+
+	if err := os.RemoveAll(dest); err != nil {
+		return nil, fmt.Errorf("failed to clean clone directory: %w", err)
+	}
+	cloneOptions.ReferenceName = plumbing.NewTagReferenceName(components.Ref)
+	return git.PlainCloneContext(ctx, dest, false, cloneOptions)
+}
+
+// isLocalRepository returns true if the repository URL refers to the local filesystem
+func isLocalRepository(repoURL string) bool {
+	return strings.HasPrefix(repoURL, "file://") ||
+		(!strings.Contains(repoURL, "://") && !strings.HasPrefix(repoURL, "git@"))
+}
+
+// isReferenceNotFound returns true if a clone failed because the ref doesn't exist on the remote
+func isReferenceNotFound(err error) bool {
+	var noMatch git.NoMatchingRefSpecError
+	return errors.As(err, &noMatch) || errors.Is(err, plumbing.ErrReferenceNotFound)
+}
+
+// checkoutGitRepository applies the sparse, commit and submodule options to a clone
+func checkoutGitRepository(ctx context.Context, repo *git.Repository, components *config.GitURLComponents, auth transport.AuthMethod) error {
+	options := components.Options
+	if options == nil {
+		return nil
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	if options.Commit != "" || len(options.SparsePaths) > 0 {
+		revision := "HEAD"
+		if options.Commit != "" {
+			revision = options.Commit
+		}
+		hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", revision, err)
+		}
+		err = worktree.Checkout(&git.CheckoutOptions{
+			Hash:                      *hash,
+			Force:                     true,
+			SparseCheckoutDirectories: options.SparsePaths,
+		})
+		if err != nil {
+			return fmt.Errorf("git checkout of %s failed: %w", revision, err)
+		}
+	}
+
+	if options.Submodules {
+		submodules, err := worktree.Submodules()
+		if err != nil {
+			return fmt.Errorf("failed to read submodules: %w", err)
+		}
+		updateOptions := &git.SubmoduleUpdateOptions{
+			Init:              true,
+			RecurseSubmodules: git.DefaultSubmoduleRecursionDepth,
+			Auth:              auth,
+		}
+		if options.IsShallow() {
+			updateOptions.Depth = 1
+		}
+		if err := submodules.UpdateContext(ctx, updateOptions); err != nil {
+			return fmt.Errorf("git submodule update failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// gitAuthMethod returns the go-git credentials for a repository URL
+// SSH URLs use the configured key, or the SSH agent when no key is set
+func gitAuthMethod(repoURL string, auth *config.GitAuthConfig) (transport.AuthMethod, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(repoURL, "git@") || strings.HasPrefix(repoURL, "ssh://") {
+		if auth.SSHKeyPath == "" {
+			return nil, nil
+		}
+		keys, err := gitssh.NewPublicKeysFromFile("git", auth.SSHKeyPath, auth.SSHKeyPassword)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load SSH key %s: %w", auth.SSHKeyPath, err)
+		}
+		return keys, nil
+	}
+
+	if auth.Token == "" {
+		return nil, nil
+	}
+	username := auth.Username
+	if username == "" {
+		// Hosting services accept any non-empty username with a token
+		username = "git"
+	}
+	return &githttp.BasicAuth{Username: username, Password: auth.Token}, nil
+}
+
+// gitProgressWriter logs clone progress sent by the server in verbose mode
+type gitProgressWriter struct{}
+
+func (w *gitProgressWriter) Write(p []byte) (int, error) {
+	log := util.GetLogger()
+	for _, line := range strings.FieldsFunc(string(p), func(r rune) bool { return r == '\r' || r == '\n' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			log.V(1).Info("Git clone progress", "progress", line)
+		}
+	}
+	return len(p), nil
+}
+
+// exportSVNRepository exports a Subversion repository into absCloneDir
+// An export contains no .svn metadata, matching the cleaned up git clones
+func exportSVNRepository(ctx context.Context, components *config.GitURLComponents, absCloneDir, absInputDir string) (string, error) {
+	log := util.GetLogger()
+
+	checkoutURL := components.CheckoutURL()
+	log.Info("Exporting subversion repository", "url", checkoutURL, "path", components.Path, "dest", absCloneDir)
+
+	svnArgs := []string{"export", "--non-interactive", checkoutURL, absCloneDir}
+	if _, err := ExecuteCommand(ctx, "svn", svnArgs, ".", 5*time.Minute); err != nil {
+		return "", fmt.Errorf("svn export failed: %w", err)
+	}
+
+	log.Info("Subversion export completed successfully")
+
+	// Verify the target path exists if specified
+	if components.Path != "" {
+		if _, err := os.Stat(absInputDir); err != nil {
+			return "", fmt.Errorf("specified path does not exist in repository: %s: %w", components.Path, err)
+		}
+		log.Info("Using subdirectory from repository", "path", components.Path, "fullPath", absInputDir)
+	}
+
+	return absInputDir, nil
+}
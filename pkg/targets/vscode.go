@@ -37,6 +37,16 @@ func (v *VSCodeTarget) Name() string {
 	return "vscode"
 }
 
+// Version isn't detected for the VSCode extension target
+func (v *VSCodeTarget) Version(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// Capabilities are unknown for the VSCode extension target, every test is run
+func (v *VSCodeTarget) Capabilities(ctx context.Context) (Capabilities, error) {
+	return nil, nil
+}
+
 // Execute runs analysis via VSCode extension
 func (v *VSCodeTarget) Execute(ctx context.Context, test *config.TestDefinition) (*ExecutionResult, error) {
 	// TODO: Implement VSCode extension automation
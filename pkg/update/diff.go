@@ -0,0 +1,83 @@
+package update
+
+import "strings"
+
+// diffContext is the number of unchanged lines shown around changed lines
+const diffContext = 2
+
+// Diff returns the lines of the change's YAML prefixed with "-" when removed, "+" when added and a space
+// when unchanged. Only unchanged lines near changes are kept, "..." stands for the others
+func (c Change) Diff() []string {
+	before, after := strings.Split(strings.TrimSuffix(c.Before, "\n"), "\n"), strings.Split(strings.TrimSuffix(c.After, "\n"), "\n")
+	if c.Before == "" {
+		before = nil
+	}
+	if c.After == "" {
+		after = nil
+	}
+	ops := diffLines(before, after)
+
+	// Keep the unchanged lines near a change
+	keep := make([]bool, len(ops))
+	for i, op := range ops {
+		if op[0] == ' ' {
+			continue
+		}
+		for j := max(0, i-diffContext); j <= min(len(ops)-1, i+diffContext); j++ {
+			keep[j] = true
+		}
+	}
+	var lines []string
+	skipped := false
+	for i, op := range ops {
+		if !keep[i] {
+			skipped = true
+			continue
+		}
+		if skipped {
+			lines = append(lines, "  ...")
+			skipped = false
+		}
+		lines = append(lines, op)
+	}
+	if skipped {
+		lines = append(lines, "  ...")
+	}
+	return lines
+}
+
+// diffLines returns the edit script of two line lists from their longest common subsequence
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, "- "+a[i])
+			i++
+		default:
+			ops = append(ops, "+ "+b[j])
+			j++
+		}
+	}
+	return ops
+}
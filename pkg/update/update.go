@@ -0,0 +1,479 @@
+package update
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/expected"
+	yaml2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Fields of a ruleset that changes are made to. Violations and insights change rule by rule, the other
+// fields as a whole
+var (
+	ruleFields  = []string{"violations", "insights"}
+	valueFields = []string{"tags", "errors", "unmatched", "skipped"}
+)
+
+// ErrNotEditable reports an expected output file whose layout can't be edited in place, e.g. JSON or
+// flow style YAML
+var ErrNotEditable = errors.New("expected output can't be edited in place")
+
+// Change is a difference between an expected output and an actual output that can be accepted into
+// the expected output file
+type Change struct {
+	RuleSet string
+	// Field is the ruleset field that changed, empty when the whole ruleset was added or removed
+	Field string
+	// Key is the rule ID of a changed violation or insight
+	Key string
+	// Before and After are the YAML of the expected and actual values, empty when missing
+	Before string
+	After  string
+}
+
+func (c Change) String() string {
+	action := "changed"
+	switch {
+	case c.Before == "":
+		action = "added"
+	case c.After == "":
+		action = "removed"
+	}
+	path := c.RuleSet
+	if c.Field != "" {
+		path += "/" + c.Field
+	}
+	if c.Key != "" {
+		path += "/" + c.Key
+	}
+	return fmt.Sprintf("%s %s", path, action)
+}
+
+// Changes returns the differences between expected and actual rulesets, matched by name. Values are
+// compared as YAML, the way generate writes them, so the changes are what regenerating the expected
+// output would change
+func Changes(expected, actual []konveyor.RuleSet) ([]Change, error) {
+	actualByName := map[string]konveyor.RuleSet{}
+	for _, rs := range actual {
+		if _, ok := actualByName[rs.Name]; !ok {
+			actualByName[rs.Name] = rs
+		}
+	}
+	expectedNames := map[string]bool{}
+
+	var changes []Change
+	for _, ers := range expected {
+		if expectedNames[ers.Name] {
+			continue
+		}
+		expectedNames[ers.Name] = true
+
+		rs, ok := actualByName[ers.Name]
+		if !ok {
+			before, err := marshal([]konveyor.RuleSet{ers})
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, Change{RuleSet: ers.Name, Before: before})
+			continue
+		}
+
+		for _, field := range ruleFields {
+			expectedRules, actualRules := ruleMap(ers, field), ruleMap(rs, field)
+			ids := map[string]bool{}
+			for id := range expectedRules {
+				ids[id] = true
+			}
+			for id := range actualRules {
+				ids[id] = true
+			}
+			for _, id := range slices.Sorted(maps.Keys(ids)) {
+				before, err := marshalRule(id, expectedRules)
+				if err != nil {
+					return nil, err
+				}
+				after, err := marshalRule(id, actualRules)
+				if err != nil {
+					return nil, err
+				}
+				if before != after {
+					changes = append(changes, Change{RuleSet: ers.Name, Field: field, Key: id, Before: before, After: after})
+				}
+			}
+		}
+
+		for _, field := range valueFields {
+			before, err := marshalField(field, ers)
+			if err != nil {
+				return nil, err
+			}
+			after, err := marshalField(field, rs)
+			if err != nil {
+				return nil, err
+			}
+			if before != after {
+				changes = append(changes, Change{RuleSet: ers.Name, Field: field, Before: before, After: after})
+			}
+		}
+	}
+
+	for _, rs := range actual {
+		if expectedNames[rs.Name] {
+			continue
+		}
+		expectedNames[rs.Name] = true
+		after, err := marshal([]konveyor.RuleSet{rs})
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{RuleSet: rs.Name, After: after})
+	}
+	return changes, nil
+}
+
+func ruleMap(rs konveyor.RuleSet, field string) map[string]konveyor.Violation {
+	if field == "insights" {
+		return rs.Insights
+	}
+	return rs.Violations
+}
+
+// marshalRule returns the YAML of a rule's entry, empty when the rule is missing
+func marshalRule(id string, rules map[string]konveyor.Violation) (string, error) {
+	v, ok := rules[id]
+	if !ok {
+		return "", nil
+	}
+	return marshal(yaml2.MapSlice{{Key: id, Value: v}})
+}
+
+// marshalField returns the YAML of a ruleset field's entry, empty when the field is empty
+func marshalField(field string, rs konveyor.RuleSet) (string, error) {
+	var value any
+	empty := true
+	switch field {
+	case "tags":
+		value, empty = rs.Tags, len(rs.Tags) == 0
+	case "errors":
+		value, empty = rs.Errors, len(rs.Errors) == 0
+	case "unmatched":
+		value, empty = rs.Unmatched, len(rs.Unmatched) == 0
+	case "skipped":
+		value, empty = rs.Skipped, len(rs.Skipped) == 0
+	}
+	if empty {
+		return "", nil
+	}
+	return marshal(yaml2.MapSlice{{Key: field, Value: value}})
+}
+
+// marshal writes rules and fields like generate writes expected outputs
+func marshal(v any) (string, error) {
+	data, err := expected.EncodeYAML(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal expected output: %w", err)
+	}
+	return string(data), nil
+}
+
+// edit replaces the lines from start to end, 1-based and inclusive, with text. Insertions before start
+// have end start-1
+type edit struct {
+	start, end int
+	text       string
+	// order keeps insertions at the same line in the order of their changes
+	order int
+}
+
+// Apply edits an expected output file to accept changes. Only the lines of the changed rulesets, rules
+// and fields are rewritten, comments and the layout of the rest of the file are kept
+func Apply(data []byte, changes []Change) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse expected output: %w", err)
+	}
+	lines := splitLines(string(data))
+	var root *yaml.Node
+	end := len(lines)
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+		// Versioned expected outputs hold the rulesets in a field, the fields after it aren't edited
+		if root.Kind == yaml.MappingNode {
+			i := mappingIndex(root, "rulesets")
+			if i < 0 {
+				return nil, ErrNotEditable
+			}
+			if 2*i+2 < len(root.Content) {
+				end = root.Content[2*i+2].Line - 1
+			}
+			root = root.Content[2*i+1]
+		}
+		if root.Kind != yaml.SequenceNode || root.Style&yaml.FlowStyle != 0 {
+			return nil, ErrNotEditable
+		}
+	}
+
+	f := &file{lines: lines, root: root, end: end, emptied: emptiedFields(changes), created: map[[2]string]bool{}, dropped: map[[2]string]bool{}}
+	for i, c := range changes {
+		if err := f.edit(i, c); err != nil {
+			return nil, fmt.Errorf("%s: %w", c, err)
+		}
+	}
+
+	result := f.apply()
+	if _, _, err := expected.Parse(result); err != nil {
+		return nil, fmt.Errorf("edited expected output is invalid: %w", err)
+	}
+	return result, nil
+}
+
+// file is an expected output file being edited
+type file struct {
+	lines []string
+	root  *yaml.Node
+	// end is the last line of the rulesets
+	end   int
+	edits []edit
+	// emptied counts the rules removed from the ruleset fields no rule is added to, fields losing all
+	// their rules are removed
+	emptied map[[2]string]int
+	// created and dropped hold the ruleset fields added and removed by the edits
+	created map[[2]string]bool
+	dropped map[[2]string]bool
+}
+
+// emptiedFields counts the rules removed from each ruleset field, fields rules are added to aren't counted
+func emptiedFields(changes []Change) map[[2]string]int {
+	removed := map[[2]string]int{}
+	added := map[[2]string]bool{}
+	for _, c := range changes {
+		field := [2]string{c.RuleSet, c.Field}
+		switch {
+		case c.Key == "":
+		case c.After == "":
+			removed[field]++
+		case c.Before == "":
+			added[field] = true
+		}
+	}
+	for field := range added {
+		delete(removed, field)
+	}
+	return removed
+}
+
+func (f *file) add(start, end int, text string, order int) {
+	f.edits = append(f.edits, edit{start: start, end: end, text: text, order: order})
+}
+
+// edit adds the edits of a change
+func (f *file) edit(order int, c Change) error {
+	item, itemSpan := f.findRuleSet(c.RuleSet)
+	if c.Field == "" {
+		switch {
+		case item == nil && c.After == "":
+			return fmt.Errorf("ruleset not found")
+		case item == nil:
+			f.add(f.end+1, f.end, indent(c.After, f.itemIndent(), f.itemIndent()), order)
+		case c.After == "":
+			f.remove(itemSpan, order)
+		default:
+			f.add(itemSpan[0], itemSpan[1], indent(c.After, f.itemIndent(), f.itemIndent()), order)
+		}
+		return nil
+	}
+	if item == nil {
+		return fmt.Errorf("ruleset not found")
+	}
+	if item.Kind != yaml.MappingNode || item.Style&yaml.FlowStyle != 0 {
+		return ErrNotEditable
+	}
+
+	keyIndent := strings.Repeat(" ", item.Content[0].Column-1)
+	fieldIndex := mappingIndex(item, c.Field)
+	if fieldIndex < 0 {
+		if c.After == "" {
+			return fmt.Errorf("%s not found", c.Field)
+		}
+		text := indent(c.After, keyIndent, keyIndent)
+		if c.Key != "" {
+			// Rules of a new field share its key
+			field := [2]string{c.RuleSet, c.Field}
+			text = indent(c.After, keyIndent+"  ", keyIndent+"  ")
+			if !f.created[field] {
+				f.created[field] = true
+				text = keyIndent + c.Field + ":\n" + text
+			}
+		}
+		f.add(itemSpan[1]+1, itemSpan[1], text, order)
+		return nil
+	}
+
+	fieldSpan := f.entrySpan(item, fieldIndex, itemSpan[1])
+	key := item.Content[2*fieldIndex]
+	if key.Line == itemSpan[0] && c.Key == "" {
+		// The entry shares its line with the dash of the ruleset
+		return ErrNotEditable
+	}
+	if c.Key == "" {
+		f.add(fieldSpan[0], fieldSpan[1], indent(c.After, keyIndent, keyIndent), order)
+		return nil
+	}
+
+	rules := item.Content[2*fieldIndex+1]
+	if rules.Kind != yaml.MappingNode || rules.Style&yaml.FlowStyle != 0 || len(rules.Content) == 0 {
+		return ErrNotEditable
+	}
+	ruleIndent := strings.Repeat(" ", rules.Content[0].Column-1)
+	ruleIndex := mappingIndex(rules, c.Key)
+	switch {
+	case ruleIndex < 0 && c.After == "":
+		return fmt.Errorf("rule not found")
+	case ruleIndex < 0:
+		f.add(fieldSpan[1]+1, fieldSpan[1], indent(c.After, ruleIndent, ruleIndent), order)
+	case c.After == "" && f.emptied[[2]string{c.RuleSet, c.Field}] == len(rules.Content)/2:
+		// A field losing all its rules is removed, once
+		if key.Line == itemSpan[0] {
+			return ErrNotEditable
+		}
+		if field := [2]string{c.RuleSet, c.Field}; !f.dropped[field] {
+			f.dropped[field] = true
+			f.remove(fieldSpan, order)
+		}
+	case c.After == "":
+		f.remove(f.entrySpan(rules, ruleIndex, fieldSpan[1]), order)
+	default:
+		ruleSpan := f.entrySpan(rules, ruleIndex, fieldSpan[1])
+		f.add(ruleSpan[0], ruleSpan[1], indent(c.After, ruleIndent, ruleIndent), order)
+	}
+	return nil
+}
+
+// remove removes the lines of a span with the comment lines right above it at the same indentation,
+// except for the comments at the top of the file
+func (f *file) remove(span [2]int, order int) {
+	line := f.lines[span[0]-1]
+	prefix := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	start := span[0]
+	for start > 1 && strings.HasPrefix(f.lines[start-2], prefix+"#") {
+		start--
+	}
+	// Comments at the top of the file describe the file
+	if start == 1 {
+		start = span[0]
+	}
+	f.add(start, span[1], "", order)
+}
+
+// findRuleSet returns the first ruleset item with the given name and its lines
+func (f *file) findRuleSet(name string) (*yaml.Node, [2]int) {
+	if f.root == nil {
+		return nil, [2]int{}
+	}
+	for i, item := range f.root.Content {
+		nameIndex := mappingIndex(item, "name")
+		if nameIndex < 0 || item.Content[2*nameIndex+1].Value != name {
+			continue
+		}
+		next := f.end + 1
+		if i+1 < len(f.root.Content) {
+			next = f.root.Content[i+1].Line
+		}
+		return item, [2]int{item.Line, f.trimEnd(item.Line, next-1)}
+	}
+	return nil, [2]int{}
+}
+
+// itemIndent returns the indentation of the ruleset items' dashes
+func (f *file) itemIndent() string {
+	if f.root == nil || len(f.root.Content) == 0 {
+		return ""
+	}
+	line := f.lines[f.root.Content[0].Line-1]
+	return line[:len(line)-len(strings.TrimLeft(line, " "))]
+}
+
+// entrySpan returns the lines of the i-th entry of a mapping ending at parentEnd
+func (f *file) entrySpan(mapping *yaml.Node, i, parentEnd int) [2]int {
+	start := mapping.Content[2*i].Line
+	next := parentEnd + 1
+	if 2*i+2 < len(mapping.Content) {
+		next = mapping.Content[2*i+2].Line
+	}
+	return [2]int{start, f.trimEnd(start, next-1)}
+}
+
+// trimEnd moves the end of a span before trailing blank and comment lines, they stay in place
+func (f *file) trimEnd(start, end int) int {
+	for end > start {
+		line := strings.TrimSpace(f.lines[end-1])
+		if line != "" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		end--
+	}
+	return end
+}
+
+// apply returns the file with its edits, applied from the bottom up so line numbers stay valid
+func (f *file) apply() []byte {
+	slices.SortStableFunc(f.edits, func(a, b edit) int {
+		if a.start != b.start {
+			return b.start - a.start
+		}
+		return b.order - a.order
+	})
+	lines := f.lines
+	for _, e := range f.edits {
+		replacement := splitLines(e.text)
+		lines = slices.Concat(lines[:e.start-1], replacement, lines[e.end:])
+	}
+	return []byte(strings.Join(lines, ""))
+}
+
+// mappingIndex returns the index of a key in a mapping node, -1 when it is missing
+func mappingIndex(mapping *yaml.Node, key string) int {
+	if mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i / 2
+		}
+	}
+	return -1
+}
+
+// indent prefixes the first line of text with first and the other non-empty lines with rest
+func indent(text, first, rest string) string {
+	lines := splitLines(text)
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			lines[i] = first + line
+		case strings.TrimSpace(line) != "":
+			lines[i] = rest + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// splitLines splits text into lines ending with a newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n"
+	}
+	return lines
+}
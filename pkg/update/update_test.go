@@ -0,0 +1,225 @@
+package update
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+	"gopkg.in/yaml.v3"
+)
+
+const expectedYAML = `# Expected output of the daytrader test
+- name: quarkus
+  description: Quarkus rules
+  tags:
+  - Servlet
+  violations:
+    quarkus-00001:
+      description: Use Quarkus
+      category: mandatory
+      incidents:
+      - uri: file:///source/src/Main.java
+        message: Replace with Quarkus
+        lineNumber: 10
+    # Kept on purpose, see the issue
+    quarkus-00002:
+      description: Replace JSF
+      category: mandatory
+      incidents:
+      - uri: file:///source/src/View.java
+        message: Replace JSF
+        lineNumber: 3
+  unmatched:
+  - quarkus-00003
+- name: eap8
+  violations:
+    eap8-00001:
+      description: Update EAP
+      category: optional
+      incidents: []
+`
+
+func loadRuleSets(t *testing.T, data string) []konveyor.RuleSet {
+	t.Helper()
+	var rulesets []konveyor.RuleSet
+	if err := yaml.Unmarshal([]byte(data), &rulesets); err != nil {
+		t.Fatalf("failed to parse rulesets: %v", err)
+	}
+	return rulesets
+}
+
+func incident(path string, line int, message string) konveyor.Incident {
+	return konveyor.Incident{URI: uri.URI("file:///source/" + path), LineNumber: &line, Message: message}
+}
+
+func TestChangesAndApply(t *testing.T) {
+	expected := loadRuleSets(t, expectedYAML)
+
+	// The analysis moved an incident, stopped matching quarkus-00002, matched a new rule and a new ruleset
+	actual := loadRuleSets(t, expectedYAML)
+	quarkus := actual[0]
+	v := quarkus.Violations["quarkus-00001"]
+	v.Incidents = []konveyor.Incident{incident("src/Main.java", 12, "Replace with Quarkus")}
+	quarkus.Violations["quarkus-00001"] = v
+	delete(quarkus.Violations, "quarkus-00002")
+	quarkus.Insights = map[string]konveyor.Violation{"info-00001": {Description: "Informational"}}
+	quarkus.Unmatched = []string{"quarkus-00002", "quarkus-00003"}
+	actual[0] = quarkus
+	actual = append(actual, konveyor.RuleSet{Name: "cloud-readiness", Tags: []string{"Docker"}})
+
+	changes, err := Changes(expected, actual)
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"quarkus/violations/quarkus-00001 changed",
+		"quarkus/violations/quarkus-00002 removed",
+		"quarkus/insights/info-00001 added",
+		"quarkus/unmatched changed",
+		"cloud-readiness added",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Changes() = %q, want %q", got, want)
+	}
+
+	// Every change accepted gives the actual output
+	edited, err := Apply([]byte(expectedYAML), changes)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if remaining, err := Changes(loadRuleSets(t, string(edited)), actual); err != nil || len(remaining) != 0 {
+		t.Errorf("Apply() left changes %v (error %v):\n%s", remaining, err, edited)
+	}
+	if strings.Contains(string(edited), "# Kept on purpose") {
+		t.Errorf("Apply() didn't remove the comment of the removed rule:\n%s", edited)
+	}
+	for _, kept := range []string{"# Expected output of the daytrader test\n", "  description: Quarkus rules\n", "- name: eap8\n  violations:\n    eap8-00001:\n"} {
+		if !strings.Contains(string(edited), kept) {
+			t.Errorf("Apply() didn't keep %q:\n%s", kept, edited)
+		}
+	}
+
+	// Only the accepted change is made, the rest of the file is kept as is
+	edited, err = Apply([]byte(expectedYAML), changes[:1])
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	wantEdited := strings.Replace(expectedYAML, "        lineNumber: 10\n", "        lineNumber: 12\n", 1)
+	if string(edited) != wantEdited {
+		t.Errorf("Apply() =\n%s\nwant\n%s", edited, wantEdited)
+	}
+}
+
+func TestApply_Fields(t *testing.T) {
+	tests := []struct {
+		name    string
+		actual  func([]konveyor.RuleSet) []konveyor.RuleSet
+		want    string
+		wantErr error
+	}{
+		{
+			name: "removing every rule removes the field",
+			actual: func(rs []konveyor.RuleSet) []konveyor.RuleSet {
+				rs[1].Violations = nil
+				return rs
+			},
+			want: strings.TrimSuffix(expectedYAML, "  violations:\n    eap8-00001:\n      description: Update EAP\n      category: optional\n      incidents: []\n"),
+		},
+		{
+			name: "replacing every rule keeps the field",
+			actual: func(rs []konveyor.RuleSet) []konveyor.RuleSet {
+				rs[1].Violations = map[string]konveyor.Violation{"eap8-00002": {Description: "Remove EJB", Incidents: []konveyor.Incident{}}}
+				return rs
+			},
+			want: strings.Replace(expectedYAML, "    eap8-00001:\n      description: Update EAP\n      category: optional\n", "    eap8-00002:\n      description: Remove EJB\n", 1),
+		},
+		{
+			name: "added field",
+			actual: func(rs []konveyor.RuleSet) []konveyor.RuleSet {
+				rs[1].Skipped = []string{"eap8-00003"}
+				return rs
+			},
+			want: expectedYAML + "  skipped:\n  - eap8-00003\n",
+		},
+		{
+			name: "removed ruleset",
+			actual: func(rs []konveyor.RuleSet) []konveyor.RuleSet {
+				return rs[:1]
+			},
+			want: expectedYAML[:strings.Index(expectedYAML, "- name: eap8")],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := Changes(loadRuleSets(t, expectedYAML), tt.actual(loadRuleSets(t, expectedYAML)))
+			if err != nil {
+				t.Fatalf("Changes() error = %v", err)
+			}
+			got, err := Apply([]byte(expectedYAML), changes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Apply() error = %v, want %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Apply() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_NotEditable(t *testing.T) {
+	changes := []Change{{RuleSet: "quarkus", Field: "tags", Before: "tags:\n- a\n", After: "tags:\n- b\n"}}
+	for _, data := range []string{
+		`[{"name": "quarkus", "tags": ["a"]}]`,
+		"- {name: quarkus, tags: [a]}\n",
+		"version: 2\n",
+	} {
+		if _, err := Apply([]byte(data), changes); !errors.Is(err, ErrNotEditable) {
+			t.Errorf("Apply(%q) error = %v, want ErrNotEditable", data, err)
+		}
+	}
+}
+
+func TestApply_VersionedDocument(t *testing.T) {
+	const data = `version: 2
+rulesets:
+- name: quarkus
+  tags:
+  - a
+`
+	changes := []Change{
+		{RuleSet: "quarkus", Field: "tags", Before: "tags:\n- a\n", After: "tags:\n- b\n"},
+		{RuleSet: "eap8", After: "- name: eap8\n"},
+	}
+	got, err := Apply([]byte(data), changes)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	want := "version: 2\nrulesets:\n- name: quarkus\n  tags:\n  - b\n- name: eap8\n"
+	if string(got) != want {
+		t.Errorf("Apply() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestChange_Diff(t *testing.T) {
+	c := Change{
+		Before: "rule:\n  a: 1\n  b: 2\n  c: 3\n  d: 4\n  e: 5\n  f: 6\n",
+		After:  "rule:\n  a: 1\n  b: 2\n  c: 3\n  d: 4\n  e: 50\n  f: 6\n",
+	}
+	want := []string{"  ...", "    c: 3", "    d: 4", "-   e: 5", "+   e: 50", "    f: 6"}
+	if got := c.Diff(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %q, want %q", got, want)
+	}
+
+	added := Change{After: "tags:\n- a\n"}
+	if got := added.Diff(); !reflect.DeepEqual(got, []string{"+ tags:", "+ - a"}) {
+		t.Errorf("Diff() = %q", got)
+	}
+}
@@ -1,20 +1,28 @@
 package util
 
 import (
+	"io"
 	"log/slog"
 	"os"
+	"sync"
 
 	"github.com/go-logr/logr"
 )
 
 var logger logr.Logger
 
-// InitLogger initializes the global logger with the specified log level
-func InitLogger(verbose bool) {
+// logOutput is where log records are written, it can be redirected after the logger is created
+var logOutput = &switchWriter{w: os.Stderr}
+
+// InitLogger initializes the global logger, logging debug messages when verbose and only errors when quiet
+func InitLogger(verbose, quiet bool) {
 	var level slog.Level
-	if verbose {
+	switch {
+	case verbose:
 		level = slog.LevelDebug
-	} else {
+	case quiet:
+		level = slog.LevelError
+	default:
 		level = slog.LevelInfo
 	}
 
@@ -22,7 +30,7 @@ func InitLogger(verbose bool) {
 		Level: level,
 	}
 
-	handler := slog.NewTextHandler(os.Stderr, opts)
+	handler := slog.NewTextHandler(logOutput, opts)
 	slogger := slog.New(handler)
 	logger = logr.FromSlogHandler(handler)
 	slog.SetDefault(slogger)
@@ -32,7 +40,33 @@ func InitLogger(verbose bool) {
 func GetLogger() logr.Logger {
 	if logger.GetSink() == nil {
 		// Initialize with default settings if not already initialized
-		InitLogger(false)
+		InitLogger(false, false)
 	}
 	return logger
 }
+
+// SetLogOutput redirects log records, e.g. through a progress display, nil restores stderr
+func SetLogOutput(w io.Writer) {
+	if w == nil {
+		w = os.Stderr
+	}
+	logOutput.set(w)
+}
+
+// switchWriter writes to a writer that can be replaced while loggers hold it
+type switchWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+func (s *switchWriter) set(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.w = w
+}
@@ -0,0 +1,230 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"gopkg.in/yaml.v3"
+)
+
+// Artifact kinds with a built-in validator
+const (
+	// ArtifactDependencies is the dependencies file of an analysis
+	ArtifactDependencies = "dependencies"
+	// ArtifactTransactions is the transaction report of analyzers that emit one (tech preview)
+	ArtifactTransactions = "transactions"
+)
+
+// ArtifactValidator compares an output artifact of an execution other than the analysis output, e.g. a
+// transaction report, with its expected file
+type ArtifactValidator interface {
+	// Validate returns how the actual artifact differs from the expected one, errors are failures to read them
+	Validate(expectedPath, actualPath string) ([]ValidationError, error)
+}
+
+// ArtifactValidatorFunc adapts a function to an ArtifactValidator
+type ArtifactValidatorFunc func(expectedPath, actualPath string) ([]ValidationError, error)
+
+// Validate calls f
+func (f ArtifactValidatorFunc) Validate(expectedPath, actualPath string) ([]ValidationError, error) {
+	return f(expectedPath, actualPath)
+}
+
+var (
+	artifactValidatorsMu sync.RWMutex
+	artifactValidators   = map[string]ArtifactValidator{}
+)
+
+func init() {
+	RegisterArtifactValidator(ArtifactDependencies, ArtifactValidatorFunc(validateDependencies))
+	RegisterArtifactValidator(ArtifactTransactions, ArtifactValidatorFunc(validateUnordered))
+}
+
+// RegisterArtifactValidator registers the validator of an artifact kind, new artifacts are validated
+// without changes to the core validator. It panics when the kind already has a validator
+func RegisterArtifactValidator(kind string, v ArtifactValidator) {
+	artifactValidatorsMu.Lock()
+	defer artifactValidatorsMu.Unlock()
+	if _, ok := artifactValidators[kind]; ok {
+		panic(fmt.Sprintf("artifact validator already registered for %s", kind))
+	}
+	artifactValidators[kind] = v
+}
+
+// ArtifactValidatorFor returns the validator registered for an artifact kind
+func ArtifactValidatorFor(kind string) (ArtifactValidator, bool) {
+	artifactValidatorsMu.RLock()
+	defer artifactValidatorsMu.RUnlock()
+	v, ok := artifactValidators[kind]
+	return v, ok
+}
+
+// ArtifactKinds returns the artifact kinds with a registered validator, sorted
+func ArtifactKinds() []string {
+	artifactValidatorsMu.RLock()
+	defer artifactValidatorsMu.RUnlock()
+	return slices.Sorted(maps.Keys(artifactValidators))
+}
+
+// validateDependencies compares the dependencies found by an analysis by provider, name and version.
+// The files declaring them are ignored, their paths depend on where the application was analyzed
+func validateDependencies(expectedPath, actualPath string) ([]ValidationError, error) {
+	expected, err := loadDependencies(expectedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected dependencies: %w", err)
+	}
+	actual, err := loadDependencies(actualPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dependencies: %w", err)
+	}
+
+	var errors []ValidationError
+	for _, dep := range slices.Sorted(maps.Keys(expected)) {
+		if !actual[dep] {
+			errors = append(errors, ValidationError{
+				Path:     fmt.Sprintf("/%s", dep),
+				Message:  fmt.Sprintf("Did not find expected dependency: %s", dep),
+				Expected: dep,
+			})
+		}
+	}
+	for _, dep := range slices.Sorted(maps.Keys(actual)) {
+		if !expected[dep] {
+			errors = append(errors, ValidationError{
+				Path:    fmt.Sprintf("/%s", dep),
+				Message: fmt.Sprintf("Unexpected dependency found: %s", dep),
+				Actual:  dep,
+			})
+		}
+	}
+	return errors, nil
+}
+
+// loadDependencies returns the dependencies of a dependencies file as provider/name@version
+func loadDependencies(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []konveyor.DepsFlatItem
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	deps := map[string]bool{}
+	for _, item := range items {
+		for _, dep := range item.Dependencies {
+			deps[fmt.Sprintf("%s/%s@%s", item.Provider, dep.Name, dep.Version)] = true
+		}
+	}
+	return deps, nil
+}
+
+// validateUnordered compares YAML or JSON artifacts whose lists have no meaningful order, such as reports
+// built concurrently. The entries of a top-level list, or the documents of the file, are matched one by one
+func validateUnordered(expectedPath, actualPath string) ([]ValidationError, error) {
+	expected, err := loadUnorderedEntries(expectedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected artifact: %w", err)
+	}
+	actual, err := loadUnorderedEntries(actualPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+
+	var errors []ValidationError
+	for _, entry := range multisetDifference(expected, actual) {
+		errors = append(errors, ValidationError{
+			Path:     "/",
+			Message:  fmt.Sprintf("Did not find expected entry: %s", entry),
+			Expected: entry,
+		})
+	}
+	for _, entry := range multisetDifference(actual, expected) {
+		errors = append(errors, ValidationError{
+			Path:    "/",
+			Message: fmt.Sprintf("Unexpected entry found: %s", entry),
+			Actual:  entry,
+		})
+	}
+	return errors, nil
+}
+
+// loadUnorderedEntries returns the entries of an artifact as canonical JSON, nested lists sorted
+func loadUnorderedEntries(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := decodeYAMLDocuments(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 1 {
+		if list, ok := docs[0].([]any); ok {
+			docs = list
+		}
+	}
+
+	entries := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		entry, err := canonicalJSON(doc)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// canonicalJSON encodes a decoded YAML value with sorted keys and sorted lists
+func canonicalJSON(v any) (string, error) {
+	switch value := v.(type) {
+	case map[string]any:
+		canonical := make(map[string]json.RawMessage, len(value))
+		for k, item := range value {
+			encoded, err := canonicalJSON(item)
+			if err != nil {
+				return "", err
+			}
+			canonical[k] = json.RawMessage(encoded)
+		}
+		data, err := json.Marshal(canonical)
+		return string(data), err
+	case []any:
+		items := make([]json.RawMessage, 0, len(value))
+		for _, item := range value {
+			encoded, err := canonicalJSON(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, json.RawMessage(encoded))
+		}
+		slices.SortFunc(items, func(a, b json.RawMessage) int { return slices.Compare(a, b) })
+		data, err := json.Marshal(items)
+		return string(data), err
+	}
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// multisetDifference returns the entries of a missing from b, counting duplicates
+func multisetDifference(a, b []string) []string {
+	counts := map[string]int{}
+	for _, entry := range b {
+		counts[entry]++
+	}
+	var missing []string
+	for _, entry := range a {
+		if counts[entry] > 0 {
+			counts[entry]--
+			continue
+		}
+		missing = append(missing, entry)
+	}
+	return missing
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestArtifactValidators(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "artifact.yaml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	const dependencies = `- fileURI: file:///tmp/a/pom.xml
+  provider: java
+  dependencies:
+    - name: org.apache.commons.commons-lang3
+      version: 3.12.0
+    - name: junit.junit
+      version: "4.13"
+`
+	const transactions = `- name: checkout
+  calls: [OrderService.place, PaymentService.charge]
+- name: browse
+  calls: [CatalogService.list]
+`
+
+	tests := []struct {
+		name       string
+		kind       string
+		expected   string
+		actual     string
+		wantErrors []string
+	}{
+		{
+			name:     "dependencies from another directory",
+			kind:     ArtifactDependencies,
+			expected: dependencies,
+			actual:   strings.ReplaceAll(dependencies, "/tmp/a", "/work/b"),
+		},
+		{
+			name:     "changed dependency version",
+			kind:     ArtifactDependencies,
+			expected: dependencies,
+			actual:   strings.ReplaceAll(dependencies, "3.12.0", "3.14.0"),
+			wantErrors: []string{
+				"Did not find expected dependency: java/org.apache.commons.commons-lang3@3.12.0",
+				"Unexpected dependency found: java/org.apache.commons.commons-lang3@3.14.0",
+			},
+		},
+		{
+			name:     "transactions in another order",
+			kind:     ArtifactTransactions,
+			expected: transactions,
+			actual:   `[{"calls": ["CatalogService.list"], "name": "browse"}, {"name": "checkout", "calls": ["PaymentService.charge", "OrderService.place"]}]`,
+		},
+		{
+			name:     "missing transaction",
+			kind:     ArtifactTransactions,
+			expected: transactions,
+			actual:   "- name: browse\n  calls: [CatalogService.list]\n",
+			wantErrors: []string{
+				`Did not find expected entry: {"calls":["OrderService.place","PaymentService.charge"],"name":"checkout"}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := ArtifactValidatorFor(tt.kind)
+			if !ok {
+				t.Fatalf("no validator registered for %s", tt.kind)
+			}
+			errors, err := v.Validate(writeFile(t, tt.expected), writeFile(t, tt.actual))
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			var messages []string
+			for _, e := range errors {
+				messages = append(messages, e.Message)
+			}
+			if !slices.Equal(messages, tt.wantErrors) {
+				t.Errorf("Validate() errors = %q, want %q", messages, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestRegisterArtifactValidator(t *testing.T) {
+	RegisterArtifactValidator("call-graph", ArtifactValidatorFunc(validateUnordered))
+	t.Cleanup(func() {
+		artifactValidatorsMu.Lock()
+		delete(artifactValidators, "call-graph")
+		artifactValidatorsMu.Unlock()
+	})
+
+	if kinds := ArtifactKinds(); !slices.Contains(kinds, "call-graph") {
+		t.Errorf("ArtifactKinds() = %v, want call-graph registered", kinds)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a kind twice should panic")
+		}
+	}()
+	RegisterArtifactValidator(ArtifactDependencies, ArtifactValidatorFunc(validateUnordered))
+}
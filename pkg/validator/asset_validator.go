@@ -0,0 +1,159 @@
+package validator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidateAssets compares generated asset files against a directory of expected files
+// YAML files are compared by content so formatting and key order don't matter,
+// other files are compared as text ignoring surrounding whitespace
+func ValidateAssets(expectedDir, actualDir string) (*ValidationResult, error) {
+	expectedFiles, err := listAssetFiles(expectedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected assets: %w", err)
+	}
+	actualFiles, err := listAssetFiles(actualDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated assets: %w", err)
+	}
+
+	errors := []ValidationError{}
+	for _, name := range expectedFiles {
+		if !slices.Contains(actualFiles, name) {
+			errors = append(errors, ValidationError{
+				Path:    fmt.Sprintf("assets/%s", name),
+				Message: "Did not find expected asset",
+			})
+			continue
+		}
+
+		assetErr, err := compareAssetFile(filepath.Join(expectedDir, name), filepath.Join(actualDir, name))
+		if err != nil {
+			return nil, err
+		}
+		if assetErr != nil {
+			assetErr.Path = fmt.Sprintf("assets/%s", name)
+			errors = append(errors, *assetErr)
+		}
+	}
+
+	for _, name := range actualFiles {
+		if !slices.Contains(expectedFiles, name) {
+			errors = append(errors, ValidationError{
+				Path:    fmt.Sprintf("assets/%s", name),
+				Message: fmt.Sprintf("Unexpected asset found: %s", name),
+				Actual:  name,
+			})
+		}
+	}
+
+	return &ValidationResult{
+		Passed: len(errors) == 0,
+		Errors: errors,
+	}, nil
+}
+
+// listAssetFiles returns the sorted paths of all files in dir, relative to dir
+func listAssetFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	slices.Sort(files)
+	return files, err
+}
+
+// compareAssetFile compares a generated file with the expected one
+// A nil validation error means the files match
+func compareAssetFile(expectedPath, actualPath string) (*ValidationError, error) {
+	expected, err := os.ReadFile(expectedPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected asset: %w", err)
+	}
+	actual, err := os.ReadFile(actualPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated asset: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(expectedPath)) {
+	case ".yaml", ".yml":
+		expectedDocs, expErr := decodeYAMLDocuments(expected)
+		actualDocs, actErr := decodeYAMLDocuments(actual)
+		if expErr == nil && actErr == nil {
+			if reflect.DeepEqual(expectedDocs, actualDocs) {
+				return nil, nil
+			}
+			return &ValidationError{
+				Message:  "Generated YAML content does not match expected asset",
+				Expected: string(expected),
+				Actual:   string(actual),
+			}, nil
+		}
+		// Templates that aren't valid YAML are compared as text
+	}
+
+	expectedText := strings.TrimSpace(string(expected))
+	actualText := strings.TrimSpace(string(actual))
+	if expectedText == actualText {
+		return nil, nil
+	}
+
+	return &ValidationError{
+		Message:  fmt.Sprintf("Generated content does not match expected asset (first difference at line %d)", firstDifferentLine(expectedText, actualText)),
+		Expected: expectedText,
+		Actual:   actualText,
+	}, nil
+}
+
+// decodeYAMLDocuments decodes every document of a multi-document YAML file
+func decodeYAMLDocuments(data []byte) ([]any, error) {
+	var docs []any
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc any
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		// Empty documents, e.g. from templates rendering nothing, are ignored
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// firstDifferentLine returns the 1-based number of the first line that differs
+func firstDifferentLine(expected, actual string) int {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := range min(len(expectedLines), len(actualLines)) {
+		if strings.TrimRight(expectedLines[i], " \t\r") != strings.TrimRight(actualLines[i], " \t\r") {
+			return i + 1
+		}
+	}
+	return min(len(expectedLines), len(actualLines)) + 1
+}
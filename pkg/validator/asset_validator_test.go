@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeAssets(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestValidateAssets(t *testing.T) {
+	expected := map[string]string{
+		"templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  labels:\n    app: app\n    tier: web\n",
+		"Dockerfile":                "FROM registry.access.redhat.com/ubi9/openjdk-17\nCOPY app.jar /deployments/\n",
+	}
+
+	tests := []struct {
+		name       string
+		actual     map[string]string
+		passed     bool
+		errorPaths []string
+	}{
+		{
+			name:   "identical",
+			actual: expected,
+			passed: true,
+		},
+		{
+			name: "yaml key order and formatting",
+			actual: map[string]string{
+				"templates/deployment.yaml": "---\nkind: Deployment\napiVersion: apps/v1\nmetadata:\n  labels: {tier: web, app: app}\n  name: app\n",
+				"Dockerfile":                "FROM registry.access.redhat.com/ubi9/openjdk-17\nCOPY app.jar /deployments/",
+			},
+			passed: true,
+		},
+		{
+			name: "different content",
+			actual: map[string]string{
+				"templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: other\n",
+				"Dockerfile":                "FROM ubi9\nCOPY app.jar /deployments/\n",
+			},
+			errorPaths: []string{"assets/Dockerfile", "assets/templates/deployment.yaml"},
+		},
+		{
+			name: "missing and unexpected",
+			actual: map[string]string{
+				"templates/deployment.yaml": expected["templates/deployment.yaml"],
+				"templates/service.yaml":    "kind: Service\n",
+			},
+			errorPaths: []string{"assets/Dockerfile", "assets/templates/service.yaml"},
+		},
+	}
+
+	expectedDir := writeAssets(t, expected)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateAssets(expectedDir, writeAssets(t, tt.actual))
+			if err != nil {
+				t.Fatalf("ValidateAssets() unexpected error: %v", err)
+			}
+			if result.Passed != tt.passed {
+				t.Errorf("Passed = %v, want %v (errors: %+v)", result.Passed, tt.passed, result.Errors)
+			}
+			if len(result.Errors) != len(tt.errorPaths) {
+				t.Fatalf("got %d errors, want %d: %+v", len(result.Errors), len(tt.errorPaths), result.Errors)
+			}
+			for i, path := range tt.errorPaths {
+				if result.Errors[i].Path != path {
+					t.Errorf("Errors[%d].Path = %s, want %s", i, result.Errors[i].Path, path)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAssets_MissingDirectory(t *testing.T) {
+	if _, err := ValidateAssets(t.TempDir(), filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("ValidateAssets() expected error for missing generated assets")
+	}
+}
+
+func TestFirstDifferentLine(t *testing.T) {
+	if got := firstDifferentLine("a\nb\nc", "a\nx\nc"); got != 2 {
+		t.Errorf("firstDifferentLine() = %d, want 2", got)
+	}
+	if got := firstDifferentLine("a\nb", "a\nb\nc"); got != 3 {
+		t.Errorf("firstDifferentLine() = %d, want 3", got)
+	}
+}
@@ -2,14 +2,17 @@ package validator
 
 import (
 	"fmt"
-	"reflect"
+	"slices"
 	"strings"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/output"
+	"go.lsp.dev/uri"
 )
 
 type baseValidator struct {
 	testDir string
+	options Options
 }
 
 func (b *baseValidator) compareTags(expected, actual []string) []ValidationError {
@@ -20,15 +23,17 @@ func (b *baseValidator) compareTags(expected, actual []string) []ValidationError
 				Path:     fmt.Sprintf("/%s", exp),
 				Message:  fmt.Sprintf("Did not find expected tag: %s", exp),
 				Expected: exp,
+				Actual:   listValue(similarStrings(exp, actual)),
 			})
 		}
 	}
 	for _, act := range actual {
 		if !findExpectedString(act, expected) {
 			errors = append(errors, ValidationError{
-				Path:    fmt.Sprintf("/%s", act),
-				Message: fmt.Sprintf("Unexpected tag found: %s", act),
-				Actual:  act,
+				Path:     fmt.Sprintf("/%s", act),
+				Message:  fmt.Sprintf("Unexpected tag found: %s", act),
+				Expected: listValue(similarStrings(act, expected)),
+				Actual:   act,
 			})
 		}
 	}
@@ -49,7 +54,7 @@ func (b *baseValidator) compareViolations(expected, actual map[string]konveyor.V
 			continue
 		}
 
-		detailErrors := b.compareViolationDetails(exp, act)
+		detailErrors := b.compareViolationDetails(k, exp, act)
 		for i := range detailErrors {
 			detailErrors[i].Path = fmt.Sprintf("/%s%s", k, detailErrors[i].Path)
 		}
@@ -68,69 +73,54 @@ func (b *baseValidator) compareViolations(expected, actual map[string]konveyor.V
 	return errors
 }
 
-func (b *baseValidator) compareViolationDetails(expected, actual konveyor.Violation) []ValidationError {
+func (b *baseValidator) compareViolationDetails(ruleID string, expected, actual konveyor.Violation) []ValidationError {
 	var errors []ValidationError
 
-	if actual.Category != nil && expected.Category != nil && *expected.Category != *actual.Category {
+	if actual.Category != nil && expected.Category != nil && !b.options.categoryMatches(*expected.Category, *actual.Category) {
 		errors = append(errors, ValidationError{
-			Message: fmt.Sprintf("Did not find expected category: %v", expected.Category),
+			Message:  fmt.Sprintf("Did not find expected category: %v", *expected.Category),
+			Expected: *expected.Category,
+			Actual:   *actual.Category,
 		})
 	}
-	if (expected.Effort != nil && actual.Effort != nil) && (*expected.Effort != *actual.Effort) {
-		errors = append(errors, ValidationError{
-			Message: fmt.Sprintf("Did not find expected effort: %v", expected.Effort),
-		})
-	}
-	// Handle Links
-	for _, l := range expected.Links {
-		found := false
-		for _, al := range actual.Links {
-			if l.Title == al.Title && l.URL == al.URL {
-				found = true
-				break
-			}
-		}
-		if !found {
+	if expected.Effort != nil && actual.Effort != nil {
+		if ok, want := b.options.effortMatches(ruleID, *expected.Effort, *actual.Effort); !ok {
 			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Did not find expected link: %v", l),
+				Message:  fmt.Sprintf("Did not find expected effort: %s (actual %d)", want, *actual.Effort),
+				Expected: want,
+				Actual:   *actual.Effort,
 			})
 		}
 	}
+	// Handle Links
+	errors = append(errors, compareLinks(expected.Links, actual.Links)...)
 	// Handle Labels
 	for _, l := range expected.Labels {
 		if !findExpectedString(l, actual.Labels) {
 			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Did not find expected label: %v", l),
+				Message:  fmt.Sprintf("Did not find expected label: %v", l),
+				Expected: l,
+				Actual:   listValue(similarStrings(l, actual.Labels)),
 			})
 		}
 	}
-	// Handle Incidents - collect all missing incidents and report as one error
+	// Handle Incidents, reported with the closest incident on the other side
+	expectedIndex := newIncidentIndex(expected.Incidents, b.incidentKey)
+	actualIndex := newIncidentIndex(actual.Incidents, b.incidentKey)
 	for _, i := range expected.Incidents {
-		found := false
-		for _, ai := range actual.Incidents {
-			if b.incidentsMatch(i, ai) {
-				found = true
-				break
-			}
-		}
+		found := slices.ContainsFunc(actualIndex.candidates(i), func(ai konveyor.Incident) bool { return b.incidentsMatch(i, ai) })
 		if !found {
-			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Did not find expected incident: %s:%d", i.URI, lineNumberOrZero(i.LineNumber)),
-			})
+			errors = append(errors, b.missingIncident(i, actual.Incidents, b.sameURI))
 		}
 	}
 
 	for _, ai := range actual.Incidents {
-		found := false
-		for _, i := range expected.Incidents {
-			if b.incidentsMatch(i, ai) {
-				found = true
-				break
-			}
-		}
+		found := slices.ContainsFunc(expectedIndex.candidates(ai), func(i konveyor.Incident) bool { return b.incidentsMatch(i, ai) })
 		if !found {
 			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Unexpected incident found: %s:%d", ai.URI, lineNumberOrZero(ai.LineNumber)),
+				Message:  fmt.Sprintf("Unexpected incident found: %s:%d", ai.URI, lineNumberOrZero(ai.LineNumber)),
+				Expected: incidentValue(closestIncident(ai, expected.Incidents, reversed(b.sameURI))),
+				Actual:   ai,
 			})
 		}
 	}
@@ -138,6 +128,12 @@ func (b *baseValidator) compareViolationDetails(expected, actual konveyor.Violat
 	return errors
 }
 
+// codeSnip returns the code snip of an incident without the whitespace around it and at the end of its
+// lines, expected outputs don't keep it
+func codeSnip(incident konveyor.Incident) string {
+	return strings.TrimSpace(output.TrimCodeSnip(incident.CodeSnip))
+}
+
 func lineNumberOrZero(ln *int) int {
 	if ln != nil {
 		return *ln
@@ -146,10 +142,10 @@ func lineNumberOrZero(ln *int) int {
 }
 
 func (b *baseValidator) incidentsMatch(expected, actual konveyor.Incident) bool {
-	if strings.TrimSpace(expected.CodeSnip) != "" && strings.TrimSpace(expected.CodeSnip) != strings.TrimSpace(actual.CodeSnip) {
+	if snip := codeSnip(expected); snip != "" && snip != codeSnip(actual) {
 		return false
 	}
-	if string(expected.URI) != string(actual.URI) {
+	if !b.sameURI(expected.URI, actual.URI) {
 		return false
 	}
 	if expected.Message != actual.Message {
@@ -161,23 +157,37 @@ func (b *baseValidator) incidentsMatch(expected, actual konveyor.Incident) bool
 		return false
 	}
 
-	if len(expected.Variables) > 0 && !reflect.DeepEqual(expected.Variables, actual.Variables) {
+	if len(expected.Variables) > 0 && !b.options.variablesMatch(expected.Variables, actual.Variables) {
 		return false
 	}
 
 	return true
 }
 
+// incidentKey returns the message and line number of an incident, which must be equal for incidents to match
+func (b *baseValidator) incidentKey(incident konveyor.Incident) string {
+	return fmt.Sprintf("%d\x00%s", lineNumberOrZero(incident.LineNumber), incident.Message)
+}
+
+// sameURI reports whether two incidents are in the same file
+func (b *baseValidator) sameURI(expected, actual uri.URI) bool {
+	return urisMatch(expected, actual, b.options.SourceRoot)
+}
+
 func (b *baseValidator) compareErrors(expected, actual map[string]string) []ValidationError {
 	var errors []ValidationError
 	for k, exp := range expected {
 		act, exists := actual[k]
 		if !exists || exp != act {
-			errors = append(errors, ValidationError{
+			e := ValidationError{
 				Path:     fmt.Sprintf("/%s", k),
 				Message:  fmt.Sprintf("Did not find expected error: %s", exp),
 				Expected: exp,
-			})
+			}
+			if exists {
+				e.Actual = act
+			}
+			errors = append(errors, e)
 		}
 	}
 	for k := range actual {
@@ -201,15 +211,17 @@ func (b *baseValidator) compareUnmatched(expected, actual []string) []Validation
 				Path:     fmt.Sprintf("/%s", exp),
 				Message:  fmt.Sprintf("Did not find expected unmatched rule: %s", exp),
 				Expected: exp,
+				Actual:   listValue(similarStrings(exp, actual)),
 			})
 		}
 	}
 	for _, act := range actual {
 		if !findExpectedString(act, expected) {
 			errors = append(errors, ValidationError{
-				Path:    fmt.Sprintf("/%s", act),
-				Message: fmt.Sprintf("Unexpected unmatched rule found: %s", act),
-				Actual:  act,
+				Path:     fmt.Sprintf("/%s", act),
+				Message:  fmt.Sprintf("Unexpected unmatched rule found: %s", act),
+				Expected: listValue(similarStrings(act, expected)),
+				Actual:   act,
 			})
 		}
 	}
@@ -225,15 +237,17 @@ func (b *baseValidator) compareSkipped(expected, actual []string) []ValidationEr
 				Path:     fmt.Sprintf("/%s", exp),
 				Message:  fmt.Sprintf("Did not find expected skipped rule: %s", exp),
 				Expected: exp,
+				Actual:   listValue(similarStrings(exp, actual)),
 			})
 		}
 	}
 	for _, act := range actual {
 		if !findExpectedString(act, expected) {
 			errors = append(errors, ValidationError{
-				Path:    fmt.Sprintf("/%s", act),
-				Message: fmt.Sprintf("Unexpected skipped rule found: %s", act),
-				Actual:  act,
+				Path:     fmt.Sprintf("/%s", act),
+				Message:  fmt.Sprintf("Unexpected skipped rule found: %s", act),
+				Expected: listValue(similarStrings(act, expected)),
+				Actual:   act,
 			})
 		}
 	}
@@ -0,0 +1,172 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+// closestIncident returns the candidate most similar to an incident, so a failure shows what was produced
+// instead, e.g. the incident in the same file at another line. The URI weighs most, then the edit distance
+// between messages, then the distance between line numbers. Returns nil without candidates
+func closestIncident(incident konveyor.Incident, candidates []konveyor.Incident, sameURI func(incident, candidate uri.URI) bool) *konveyor.Incident {
+	var closest *konveyor.Incident
+	bestScore := -1.0
+	for i, candidate := range candidates {
+		uriScore := 1.0
+		if !sameURI(incident.URI, candidate.URI) {
+			uriScore = pathSimilarity(incident.URI, candidate.URI)
+		}
+		score := 4*uriScore + 2*stringSimilarity(incident.Message, candidate.Message) +
+			1/float64(1+abs(lineNumberOrZero(incident.LineNumber)-lineNumberOrZero(candidate.LineNumber)))
+		if score > bestScore {
+			closest, bestScore = &candidates[i], score
+		}
+	}
+	return closest
+}
+
+// incidentDiff describes field by field how an actual incident differs from the expected one, e.g.
+// "line number changed from 88 to 90"
+func (b *baseValidator) incidentDiff(expected, actual konveyor.Incident, sameURI func(expected, actual uri.URI) bool) []string {
+	var diff []string
+	if !sameURI(expected.URI, actual.URI) {
+		diff = append(diff, fmt.Sprintf("URI changed from %s to %s", expected.URI, actual.URI))
+	}
+	if expectedLN, actualLN := lineNumberOrZero(expected.LineNumber), lineNumberOrZero(actual.LineNumber); expectedLN != actualLN {
+		diff = append(diff, fmt.Sprintf("line number changed from %d to %d", expectedLN, actualLN))
+	}
+	if expected.Message != actual.Message {
+		diff = append(diff, fmt.Sprintf("message changed from %q to %q", expected.Message, actual.Message))
+	}
+	if snip := codeSnip(expected); snip != "" && snip != codeSnip(actual) {
+		diff = append(diff, "code snippet changed")
+	}
+	if len(expected.Variables) > 0 && !b.options.variablesMatch(expected.Variables, actual.Variables) {
+		diff = append(diff, fmt.Sprintf("variables changed from %v to %v", expected.Variables, actual.Variables))
+	}
+	return diff
+}
+
+// missingIncident reports an expected incident that wasn't found, with the closest actual incident and
+// how it differs so the user sees e.g. that the line number changed instead of just a missing incident
+func (b *baseValidator) missingIncident(expected konveyor.Incident, actual []konveyor.Incident, sameURI func(expected, actual uri.URI) bool) ValidationError {
+	e := ValidationError{
+		Message:  fmt.Sprintf("Did not find expected incident: %s:%d", expected.URI, lineNumberOrZero(expected.LineNumber)),
+		Expected: expected,
+	}
+	closest := closestIncident(expected, actual, sameURI)
+	if closest == nil {
+		return e
+	}
+	e.Actual = *closest
+	if diff := b.incidentDiff(expected, *closest, sameURI); len(diff) > 0 {
+		e.Message += fmt.Sprintf(", closest actual incident: %s", strings.Join(diff, ", "))
+	}
+	return e
+}
+
+// pathSimilarity returns how similar the paths of two URIs are, from 0 to 1, as the number of trailing
+// path segments they share over the number of segments of the longer path
+func pathSimilarity(a, b uri.URI) float64 {
+	aParts := strings.Split(strings.Trim(canonicalURI(a, ""), "/"), "/")
+	bParts := strings.Split(strings.Trim(canonicalURI(b, ""), "/"), "/")
+	common := 0
+	for common < len(aParts) && common < len(bParts) && aParts[len(aParts)-1-common] == bParts[len(bParts)-1-common] {
+		common++
+	}
+	return float64(common) / float64(max(len(aParts), len(bParts)))
+}
+
+// stringSimilarity returns how similar two strings are, from 0 to 1, from their edit distance
+func stringSimilarity(a, b string) float64 {
+	ar, br := []rune(a), []rune(b)
+	if len(ar)+len(br) == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ar, br))/float64(max(len(ar), len(br)))
+}
+
+// editDistance returns the Levenshtein distance between two rune sequences
+func editDistance(a, b []rune) int {
+	// prev and curr hold consecutive rows of the distance table
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := range a {
+		curr[0] = i + 1
+		for j := range b {
+			cost := 1
+			if a[i] == b[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// reversed swaps the arguments of a URI comparison, to find the expected incident closest to an actual one
+func reversed(sameURI func(expected, actual uri.URI) bool) func(actual, expected uri.URI) bool {
+	return func(actual, expected uri.URI) bool { return sameURI(expected, actual) }
+}
+
+// similarStrings returns the candidates sharing the key of s, the part before "=" of labels and tags
+// such as konveyor.io/target=quarkus, or containing s or contained in it ignoring case. Returns nil
+// when none are similar
+func similarStrings(s string, candidates []string) []string {
+	key, _, hasKey := strings.Cut(s, "=")
+	var similar []string
+	for _, candidate := range candidates {
+		candidateKey, _, candidateHasKey := strings.Cut(candidate, "=")
+		lower, candidateLower := strings.ToLower(s), strings.ToLower(candidate)
+		switch {
+		case candidate == "":
+			continue
+		case hasKey && candidateHasKey && key == candidateKey:
+		case strings.Contains(candidateLower, lower), strings.Contains(lower, candidateLower):
+		default:
+			continue
+		}
+		similar = append(similar, candidate)
+	}
+	return similar
+}
+
+// listValue returns candidates as the expected or actual value of an error, nil without candidates so
+// reports don't print an empty list
+func listValue[T any](candidates []T) any {
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates
+}
+
+// incidentValue returns an incident as the actual or expected value of an error, nil when there is none
+func incidentValue(incident *konveyor.Incident) any {
+	if incident == nil {
+		return nil
+	}
+	return *incident
+}
+
+// rulesetNames returns the names of rulesets
+func rulesetNames(rulesets []konveyor.RuleSet) []string {
+	names := make([]string, 0, len(rulesets))
+	for _, rs := range rulesets {
+		names = append(names, rs.Name)
+	}
+	return names
+}
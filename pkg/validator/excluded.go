@@ -0,0 +1,50 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// excludedIncidents reports the rules of an actual ruleset with incidents in excluded paths. Excluded
+// paths are relative to the application, they match the same path segments anywhere in an incident path
+func excludedIncidents(rs konveyor.RuleSet, excluded []string, sourceRoot string) []ValidationError {
+	if len(excluded) == 0 {
+		return nil
+	}
+	var errors []ValidationError
+	for _, findings := range []map[string]konveyor.Violation{rs.Violations, rs.Insights} {
+		for _, ruleID := range slices.Sorted(maps.Keys(findings)) {
+			for _, dir := range excluded {
+				var matches []string
+				for _, incident := range findings[ruleID].Incidents {
+					if inExcludedPath(canonicalURI(incident.URI, sourceRoot), dir) {
+						matches = append(matches, string(incident.URI))
+					}
+				}
+				if len(matches) == 0 {
+					continue
+				}
+				errors = append(errors, ValidationError{
+					Path:    fmt.Sprintf("/%s/%s/incidents", rs.Name, ruleID),
+					Message: fmt.Sprintf("Rule %s has %d incident(s) in excluded path %s, e.g. %s", ruleID, len(matches), dir, matches[0]),
+					Actual:  matches,
+				})
+			}
+		}
+	}
+	return errors
+}
+
+// inExcludedPath reports whether a path contains the segments of an excluded path
+func inExcludedPath(p, excluded string) bool {
+	excluded = strings.Trim(path.Clean("/"+strings.ReplaceAll(excluded, `\`, "/")), "/")
+	if excluded == "" {
+		return false
+	}
+	return strings.Contains("/"+strings.Trim(p, "/")+"/", "/"+excluded+"/")
+}
@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"maps"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Ways findings are split between insights and violations before they are compared
+// Analyzer versions disagree on where findings without effort land, normalizing both outputs lets
+// one expected file work across them
+const (
+	// FindingsAsReported compares insights and violations as the analyzer reported them (default)
+	FindingsAsReported = ""
+	// FindingsByEffort makes findings without effort insights and the others violations, like the Hub
+	FindingsByEffort = "by-effort"
+	// FindingsMerged compares insights and violations as one set of violations
+	FindingsMerged = "merged"
+)
+
+// normalizeFindings splits the insights and violations of a ruleset according to mode
+// The ruleset's maps are copied, a rule found in both keeps its violation
+func normalizeFindings(rs konveyor.RuleSet, mode string) konveyor.RuleSet {
+	if mode == FindingsAsReported || (len(rs.Insights) == 0 && len(rs.Violations) == 0) {
+		return rs
+	}
+
+	findings := make(map[string]konveyor.Violation, len(rs.Insights)+len(rs.Violations))
+	maps.Copy(findings, rs.Insights)
+	maps.Copy(findings, rs.Violations)
+
+	rs.Insights, rs.Violations = nil, nil
+	for rule, finding := range findings {
+		if mode == FindingsByEffort && (finding.Effort == nil || *finding.Effort == 0) {
+			if rs.Insights == nil {
+				rs.Insights = map[string]konveyor.Violation{}
+			}
+			rs.Insights[rule] = finding
+			continue
+		}
+		if rs.Violations == nil {
+			rs.Violations = map[string]konveyor.Violation{}
+		}
+		rs.Violations[rule] = finding
+	}
+	return rs
+}
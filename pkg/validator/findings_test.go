@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestNormalizeFindings(t *testing.T) {
+	// An older analyzer reports the zero effort finding as a violation, a newer one as an insight
+	older := konveyor.RuleSet{
+		Name: "ruleset",
+		Violations: map[string]konveyor.Violation{
+			"info-00001":    {Effort: intPtr(0)},
+			"session-00001": {Effort: intPtr(3)},
+		},
+	}
+	newer := konveyor.RuleSet{
+		Name:       "ruleset",
+		Insights:   map[string]konveyor.Violation{"info-00001": {}},
+		Violations: map[string]konveyor.Violation{"session-00001": {Effort: intPtr(3)}},
+	}
+
+	tests := []struct {
+		mode   string
+		passed bool
+	}{
+		{mode: FindingsAsReported, passed: false},
+		{mode: FindingsByEffort, passed: true},
+		{mode: FindingsMerged, passed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			result, err := ValidateWithOptions("", "kantra", []konveyor.RuleSet{newer}, []konveyor.RuleSet{older}, Options{Findings: tt.mode})
+			if err != nil {
+				t.Fatalf("ValidateWithOptions returned error: %v", err)
+			}
+			if result.Passed != tt.passed {
+				t.Errorf("Passed = %v, want %v (errors: %+v)", result.Passed, tt.passed, result.Errors)
+			}
+		})
+	}
+
+	merged := normalizeFindings(newer, FindingsMerged)
+	if len(merged.Insights) != 0 || len(merged.Violations) != 2 {
+		t.Errorf("merged findings = %+v", merged)
+	}
+	if len(newer.Insights) != 1 || len(newer.Violations) != 1 {
+		t.Errorf("normalizeFindings() changed its ruleset: %+v", newer)
+	}
+}
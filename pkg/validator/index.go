@@ -0,0 +1,27 @@
+package validator
+
+import (
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// incidentIndex groups incidents by a key that must be equal for incidents to match, so outputs with
+// thousands of incidents aren't compared incident by incident
+type incidentIndex struct {
+	key       func(konveyor.Incident) string
+	incidents map[string][]konveyor.Incident
+}
+
+// newIncidentIndex indexes incidents by key
+func newIncidentIndex(incidents []konveyor.Incident, key func(konveyor.Incident) string) incidentIndex {
+	idx := incidentIndex{key: key, incidents: make(map[string][]konveyor.Incident, len(incidents))}
+	for _, incident := range incidents {
+		k := key(incident)
+		idx.incidents[k] = append(idx.incidents[k], incident)
+	}
+	return idx
+}
+
+// candidates returns the indexed incidents that may match an incident
+func (idx incidentIndex) candidates(incident konveyor.Incident) []konveyor.Incident {
+	return idx.incidents[idx.key(incident)]
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// Expected link URLs are matched exactly, except for patterns
+const (
+	// linkRegexPrefix marks an expected URL as a regular expression, e.g. regex:^https://access\.redhat\.com/
+	linkRegexPrefix = "regex:"
+	// linkPrefixSuffix marks an expected URL as a prefix, e.g. https://access.redhat.com/articles/*
+	linkPrefixSuffix = "*"
+)
+
+// linkURLMatches reports whether an actual link URL matches an expected URL or URL pattern
+// Invalid regular expressions are compared literally
+func linkURLMatches(expected, actual string) bool {
+	if pattern, ok := strings.CutPrefix(expected, linkRegexPrefix); ok {
+		re, err := regexp.Compile(pattern)
+		if err == nil {
+			return re.MatchString(actual)
+		}
+	}
+	if prefix, ok := strings.CutSuffix(expected, linkPrefixSuffix); ok {
+		return strings.HasPrefix(actual, prefix)
+	}
+	return expected == actual
+}
+
+// compareLinks reports expected links missing from the actual links. A link whose title is found with
+// another URL, or whose URL is found with another title, is reported as a URL or title mismatch
+func compareLinks(expected, actual []konveyor.Link) []ValidationError {
+	var errors []ValidationError
+	for _, l := range expected {
+		var sameTitle, sameURL *konveyor.Link
+		found := false
+		for i, al := range actual {
+			titleMatches, urlMatches := l.Title == al.Title, linkURLMatches(l.URL, al.URL)
+			if titleMatches && urlMatches {
+				found = true
+				break
+			}
+			if titleMatches && sameTitle == nil {
+				sameTitle = &actual[i]
+			}
+			if urlMatches && sameURL == nil {
+				sameURL = &actual[i]
+			}
+		}
+
+		switch {
+		case found:
+		case sameTitle != nil:
+			errors = append(errors, ValidationError{
+				Path:     "/links",
+				Message:  fmt.Sprintf("Link %q has URL %s, expected %s", l.Title, sameTitle.URL, l.URL),
+				Expected: l.URL,
+				Actual:   sameTitle.URL,
+			})
+		case sameURL != nil:
+			errors = append(errors, ValidationError{
+				Path:     "/links",
+				Message:  fmt.Sprintf("Link %s has title %q, expected %q", sameURL.URL, sameURL.Title, l.Title),
+				Expected: l.Title,
+				Actual:   sameURL.Title,
+			})
+		default:
+			errors = append(errors, ValidationError{
+				Path:     "/links",
+				Message:  fmt.Sprintf("Did not find expected link: %s (%s)", l.Title, l.URL),
+				Expected: l,
+				Actual:   actual,
+			})
+		}
+	}
+	return errors
+}
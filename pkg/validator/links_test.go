@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestLinkURLMatches(t *testing.T) {
+	tests := []struct {
+		expected, actual string
+		want             bool
+	}{
+		{expected: "https://example.com/guide", actual: "https://example.com/guide", want: true},
+		{expected: "https://example.com/guide", actual: "https://example.com/guide#java"},
+		{expected: "https://example.com/guides/*", actual: "https://example.com/guides/java-17", want: true},
+		{expected: "https://example.com/guides/*", actual: "https://example.org/guides/java-17"},
+		{expected: `regex:^https://access\.redhat\.com/(articles|solutions)/\d+$`, actual: "https://access.redhat.com/solutions/12345", want: true},
+		{expected: `regex:^https://access\.redhat\.com/articles/\d+$`, actual: "https://access.redhat.com/solutions/12345"},
+		{expected: "regex:[", actual: "regex:[", want: true},
+	}
+
+	for _, tt := range tests {
+		if got := linkURLMatches(tt.expected, tt.actual); got != tt.want {
+			t.Errorf("linkURLMatches(%q, %q) = %v, want %v", tt.expected, tt.actual, got, tt.want)
+		}
+	}
+}
+
+func TestCompareLinks(t *testing.T) {
+	actual := []konveyor.Link{
+		{Title: "Spring Boot Supported Versions", URL: "https://github.com/spring-projects/spring-boot/wiki/Supported-Versions"},
+		{Title: "Camel 3 Migration Guide", URL: "https://camel.apache.org/manual/camel-3-migration-guide.html"},
+	}
+	expected := []konveyor.Link{
+		{Title: "Spring Boot Supported Versions", URL: "https://github.com/spring-projects/spring-boot/wiki/*"},
+		{Title: "Camel 3 Migration Guide", URL: "https://camel.apache.org/manual/latest/camel-3-migration-guide.html"},
+		{Title: "Spring Boot Versions", URL: "https://github.com/spring-projects/spring-boot/wiki/Supported-Versions"},
+		{Title: "Quarkus Guide", URL: "https://quarkus.io/guides"},
+	}
+
+	errors := compareLinks(expected, actual)
+	if len(errors) != 3 {
+		t.Fatalf("compareLinks() = %+v, want a URL mismatch, a title mismatch and a missing link", errors)
+	}
+	if errors[0].Actual != actual[1].URL || errors[0].Expected != expected[1].URL {
+		t.Errorf("URL mismatch = %+v", errors[0])
+	}
+	if errors[1].Actual != actual[0].Title || errors[1].Expected != expected[2].Title {
+		t.Errorf("title mismatch = %+v", errors[1])
+	}
+	if errors[2].Actual == nil || errors[2].Path != "/links" {
+		t.Errorf("missing link = %+v", errors[2])
+	}
+}
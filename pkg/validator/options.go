@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+// AllRules is the effort range key that applies to every rule without its own range
+const AllRules = "*"
+
+// Options relaxes how violation metadata is compared
+// The zero value compares effort and category exactly
+type Options struct {
+	// EffortRanges maps rule IDs to the accepted effort range
+	EffortRanges map[string]EffortRange
+
+	// CategoryAtLeast accepts actual categories at least as severe as the expected one
+	CategoryAtLeast bool
+
+	// ExactVariables requires incident variables to match exactly
+	// By default expected variables must match and extra actual variables are allowed
+	ExactVariables bool
+
+	// SourceRoot is the local directory holding the analyzed sources, symlinks of incident paths inside
+	// it are resolved before comparing them (optional)
+	SourceRoot string
+
+	// Parallelism limits how many rulesets are compared at once, zero for the number of CPUs
+	Parallelism int
+
+	// Findings decides how findings are split between insights and violations before comparing them,
+	// FindingsAsReported, FindingsByEffort or FindingsMerged
+	Findings string
+
+	// RuleSets limits validation to the rulesets of these names, other expected and actual rulesets
+	// are ignored. All rulesets are validated when empty
+	RuleSets []string
+
+	// ExcludedPaths are application paths excluded from the analysis, actual incidents in them are errors
+	ExcludedPaths []string
+}
+
+// EffortRange is an inclusive range of accepted effort values
+type EffortRange struct {
+	Min int
+	Max int
+}
+
+// ParseEffortRange parses an effort range such as "1-3", a single value such as "3" is an exact match
+func ParseEffortRange(s string) (EffortRange, error) {
+	minStr, maxStr, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		maxStr = minStr
+	}
+
+	lo, err := strconv.Atoi(strings.TrimSpace(minStr))
+	if err != nil {
+		return EffortRange{}, fmt.Errorf("invalid effort range %q: %w", s, err)
+	}
+	hi, err := strconv.Atoi(strings.TrimSpace(maxStr))
+	if err != nil {
+		return EffortRange{}, fmt.Errorf("invalid effort range %q: %w", s, err)
+	}
+	if lo < 0 || lo > hi {
+		return EffortRange{}, fmt.Errorf("invalid effort range %q: minimum must be between 0 and the maximum", s)
+	}
+
+	return EffortRange{Min: lo, Max: hi}, nil
+}
+
+// NewOptions builds validation options from effort range expressions keyed by rule ID
+func NewOptions(effortRanges map[string]string, categoryAtLeast bool) (Options, error) {
+	opts := Options{CategoryAtLeast: categoryAtLeast}
+	if len(effortRanges) == 0 {
+		return opts, nil
+	}
+
+	opts.EffortRanges = make(map[string]EffortRange, len(effortRanges))
+	for rule, expr := range effortRanges {
+		r, err := ParseEffortRange(expr)
+		if err != nil {
+			return Options{}, fmt.Errorf("rule %s: %w", rule, err)
+		}
+		opts.EffortRanges[rule] = r
+	}
+	return opts, nil
+}
+
+func (r EffortRange) String() string {
+	if r.Min == r.Max {
+		return strconv.Itoa(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// effortMatches checks the actual effort of a rule against the expected effort or its accepted range
+func (o Options) effortMatches(ruleID string, expected, actual int) (bool, string) {
+	r, ok := o.EffortRanges[ruleID]
+	if !ok {
+		r, ok = o.EffortRanges[AllRules]
+	}
+	if !ok {
+		return expected == actual, strconv.Itoa(expected)
+	}
+	return actual >= r.Min && actual <= r.Max, r.String()
+}
+
+// categoryMatches checks the actual category against the expected one
+func (o Options) categoryMatches(expected, actual konveyor.Category) bool {
+	if !o.CategoryAtLeast {
+		return expected == actual
+	}
+	return categorySeverity(actual) >= categorySeverity(expected)
+}
+
+// categorySeverity orders categories from potential to mandatory, unknown categories are least severe
+func categorySeverity(c konveyor.Category) int {
+	switch c {
+	case konveyor.Mandatory:
+		return 3
+	case konveyor.Optional:
+		return 2
+	case konveyor.Potential:
+		return 1
+	}
+	return 0
+}
+
+// variablesMatch compares expected incident variables against the actual ones
+func (o Options) variablesMatch(expected, actual map[string]interface{}) bool {
+	if o.ExactVariables {
+		return reflect.DeepEqual(expected, actual)
+	}
+	return isSubset(expected, actual)
+}
+
+// isSubset returns true if every expected key is present in actual with a matching value
+// Nested maps are compared as subsets too, other values must be equal
+func isSubset(expected, actual map[string]interface{}) bool {
+	for k, exp := range expected {
+		act, ok := actual[k]
+		if !ok {
+			return false
+		}
+		expMap, expIsMap := exp.(map[string]interface{})
+		actMap, actIsMap := act.(map[string]interface{})
+		if expIsMap && actIsMap {
+			if !isSubset(expMap, actMap) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(exp, act) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,231 @@
+package validator
+
+import (
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+)
+
+func TestParseEffortRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    EffortRange
+		wantErr bool
+	}{
+		{input: "1-3", want: EffortRange{Min: 1, Max: 3}},
+		{input: " 2 - 5 ", want: EffortRange{Min: 2, Max: 5}},
+		{input: "3", want: EffortRange{Min: 3, Max: 3}},
+		{input: "0-0", want: EffortRange{Min: 0, Max: 0}},
+		{input: "3-1", wantErr: true},
+		{input: "a-3", wantErr: true},
+		{input: "1-", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEffortRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseEffortRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEffortRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWithOptions(t *testing.T) {
+	ruleset := func(category string, effort int) []konveyor.RuleSet {
+		return []konveyor.RuleSet{
+			{
+				Name: "test-ruleset",
+				Violations: map[string]konveyor.Violation{
+					"rule1": {
+						Category: categoryPtr(category),
+						Effort:   intPtr(effort),
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		targetType string
+		expected   []konveyor.RuleSet
+		actual     []konveyor.RuleSet
+		effort     map[string]string
+		atLeast    bool
+		wantPassed bool
+	}{
+		{
+			name:       "exact effort mismatch",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("mandatory", 3),
+			wantPassed: false,
+		},
+		{
+			name:       "effort within rule range",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("mandatory", 3),
+			effort:     map[string]string{"rule1": "1-3"},
+			wantPassed: true,
+		},
+		{
+			name:       "effort outside rule range",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("mandatory", 5),
+			effort:     map[string]string{"rule1": "1-3"},
+			wantPassed: false,
+		},
+		{
+			name:       "effort within wildcard range",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("mandatory", 2),
+			effort:     map[string]string{AllRules: "1-3"},
+			wantPassed: true,
+		},
+		{
+			name:       "rule range takes precedence over wildcard",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("mandatory", 2),
+			effort:     map[string]string{AllRules: "1-3", "rule1": "1"},
+			wantPassed: false,
+		},
+		{
+			name:       "more severe category without tolerance",
+			expected:   ruleset("optional", 1),
+			actual:     ruleset("mandatory", 1),
+			wantPassed: false,
+		},
+		{
+			name:       "more severe category accepted",
+			expected:   ruleset("optional", 1),
+			actual:     ruleset("mandatory", 1),
+			atLeast:    true,
+			wantPassed: true,
+		},
+		{
+			name:       "less severe category rejected",
+			expected:   ruleset("mandatory", 1),
+			actual:     ruleset("potential", 1),
+			atLeast:    true,
+			wantPassed: false,
+		},
+		{
+			name:       "hub effort range",
+			targetType: "tackle-hub",
+			expected:   ruleset("optional", 1),
+			actual:     ruleset("mandatory", 2),
+			effort:     map[string]string{"rule1": "1-3"},
+			atLeast:    true,
+			wantPassed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := NewOptions(tt.effort, tt.atLeast)
+			if err != nil {
+				t.Fatalf("NewOptions() error = %v", err)
+			}
+			targetType := tt.targetType
+			if targetType == "" {
+				targetType = "kantra"
+			}
+
+			result, err := ValidateWithOptions("/test", targetType, tt.actual, tt.expected, opts)
+			if err != nil {
+				t.Fatalf("ValidateWithOptions() error = %v", err)
+			}
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v (errors: %+v)", result.Passed, tt.wantPassed, result.Errors)
+			}
+		})
+	}
+}
+
+func TestNewOptions_InvalidRange(t *testing.T) {
+	if _, err := NewOptions(map[string]string{"rule1": "high"}, false); err == nil {
+		t.Error("Expected error for invalid effort range")
+	}
+}
+
+func TestValidateWithOptions_Variables(t *testing.T) {
+	ruleset := func(variables map[string]interface{}) []konveyor.RuleSet {
+		return []konveyor.RuleSet{
+			{
+				Name: "test-ruleset",
+				Violations: map[string]konveyor.Violation{
+					"rule1": {
+						Incidents: []konveyor.Incident{
+							{
+								URI:        "file:///src/App.java",
+								LineNumber: intPtr(10),
+								Variables:  variables,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	expected := map[string]interface{}{
+		"name":    "javax.servlet",
+		"package": map[string]interface{}{"group": "javax"},
+	}
+
+	tests := []struct {
+		name       string
+		actual     map[string]interface{}
+		exact      bool
+		wantPassed bool
+	}{
+		{
+			name:       "equal variables",
+			actual:     map[string]interface{}{"name": "javax.servlet", "package": map[string]interface{}{"group": "javax"}},
+			wantPassed: true,
+		},
+		{
+			name:       "extra actual variables allowed",
+			actual:     map[string]interface{}{"name": "javax.servlet", "package": map[string]interface{}{"group": "javax", "version": "4.0"}, "extra": 1},
+			wantPassed: true,
+		},
+		{
+			name:       "extra actual variables rejected when exact",
+			actual:     map[string]interface{}{"name": "javax.servlet", "package": map[string]interface{}{"group": "javax"}, "extra": 1},
+			exact:      true,
+			wantPassed: false,
+		},
+		{
+			name:       "missing expected variable",
+			actual:     map[string]interface{}{"package": map[string]interface{}{"group": "javax"}},
+			wantPassed: false,
+		},
+		{
+			name:       "different value",
+			actual:     map[string]interface{}{"name": "jakarta.servlet", "package": map[string]interface{}{"group": "javax"}},
+			wantPassed: false,
+		},
+		{
+			name:       "different nested value",
+			actual:     map[string]interface{}{"name": "javax.servlet", "package": map[string]interface{}{"group": "jakarta"}},
+			wantPassed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := Options{ExactVariables: tt.exact}
+			result, err := ValidateWithOptions("/test", "kantra", ruleset(tt.actual), ruleset(expected), opts)
+			if err != nil {
+				t.Fatalf("ValidateWithOptions() error = %v", err)
+			}
+			if result.Passed != tt.wantPassed {
+				t.Errorf("Passed = %v, want %v (errors: %+v)", result.Passed, tt.wantPassed, result.Errors)
+			}
+		})
+	}
+}
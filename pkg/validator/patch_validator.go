@@ -0,0 +1,197 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PatchFileExtensions are the extensions of files holding expected patches
+var PatchFileExtensions = []string{".diff", ".patch"}
+
+// ValidatePatches compares the patches in actualDir against the expected patches in expectedDir
+// Patches are compared per changed file, so they may be split across patch files differently.
+// A file matches when the similarity of its changed lines is at least minSimilarity,
+// ignoring whitespace, context lines and hunk positions
+func ValidatePatches(expectedDir, actualDir string, minSimilarity float64) (*ValidationResult, error) {
+	expected, err := loadPatches(expectedDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expected patches: %w", err)
+	}
+	actual, err := loadPatches(actualDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated patches: %w", err)
+	}
+
+	errors := []ValidationError{}
+	for _, file := range slices.Sorted(maps.Keys(expected)) {
+		actualChanges, ok := actual[file]
+		if !ok {
+			errors = append(errors, ValidationError{
+				Path:    fmt.Sprintf("patches/%s", file),
+				Message: "Did not find expected changes to file",
+			})
+			continue
+		}
+
+		similarity := lineSimilarity(expected[file], actualChanges)
+		if similarity < minSimilarity {
+			errors = append(errors, ValidationError{
+				Path:     fmt.Sprintf("patches/%s", file),
+				Message:  fmt.Sprintf("Changes are %.0f%% similar to expected patch, at least %.0f%% required", similarity*100, minSimilarity*100),
+				Expected: expected[file],
+				Actual:   actualChanges,
+			})
+		}
+	}
+
+	for _, file := range slices.Sorted(maps.Keys(actual)) {
+		if _, ok := expected[file]; !ok {
+			errors = append(errors, ValidationError{
+				Path:    fmt.Sprintf("patches/%s", file),
+				Message: fmt.Sprintf("Unexpected changes to file: %s", file),
+				Actual:  actual[file],
+			})
+		}
+	}
+
+	return &ValidationResult{
+		Passed: len(errors) == 0,
+		Errors: errors,
+	}, nil
+}
+
+// loadPatches parses every patch file in dir into changed lines keyed by file
+func loadPatches(dir string) (map[string][]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !slices.Contains(PatchFileExtensions, strings.ToLower(filepath.Ext(entry.Name()))) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for file, lines := range ParsePatch(string(data)) {
+			changes[file] = append(changes[file], lines...)
+		}
+	}
+	return changes, nil
+}
+
+// ParsePatch extracts the changed lines of a unified diff keyed by file path
+// Removed lines are prefixed with "-" and added lines with "+", with whitespace normalized.
+// Blank changes are dropped and a/ and b/ path prefixes are removed
+func ParsePatch(patch string) map[string][]string {
+	changes := make(map[string][]string)
+	oldFile, file := "", ""
+	// Lines left in the current hunk, so changes starting with --- or +++ aren't taken for headers
+	oldLeft, newLeft := 0, 0
+
+	for _, line := range strings.Split(patch, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+			case strings.HasPrefix(line, "\\"):
+				// "\ No newline at end of file"
+				continue
+			default:
+				oldLeft--
+				newLeft--
+				continue
+			}
+			content := strings.Join(strings.Fields(line[1:]), " ")
+			if file != "" && content != "" {
+				changes[file] = append(changes[file], line[:1]+content)
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			oldFile = patchPath(line[4:])
+		case strings.HasPrefix(line, "+++ "):
+			// Deleted files only have the old path
+			file = patchPath(line[4:])
+			if file == "" {
+				file = oldFile
+			}
+		case strings.HasPrefix(line, "@@"):
+			oldLeft, newLeft = hunkLengths(line)
+		}
+	}
+	return changes
+}
+
+// hunkLengths returns the old and new line counts of a "@@ -a,b +c,d @@" hunk header
+func hunkLengths(header string) (int, int) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0
+	}
+	return rangeLength(fields[1]), rangeLength(fields[2])
+}
+
+// rangeLength returns the line count of a "-a,b" or "+c,d" hunk range, which defaults to 1
+func rangeLength(r string) int {
+	_, count, found := strings.Cut(r, ",")
+	if !found {
+		return 1
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// patchPath returns the file path of a ---/+++ header, empty for /dev/null
+func patchPath(header string) string {
+	// Headers may carry a tab separated timestamp
+	path, _, _ := strings.Cut(header, "\t")
+	path = strings.TrimSpace(path)
+	if path == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(path, "a/") || strings.HasPrefix(path, "b/") {
+		path = path[2:]
+	}
+	return path
+}
+
+// lineSimilarity returns how similar two line sequences are, from 0 to 1,
+// as twice the longest common subsequence over the total number of lines
+func lineSimilarity(a, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 1
+	}
+
+	// prev and curr hold consecutive rows of the LCS table
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return float64(2*prev[len(b)]) / float64(len(a)+len(b))
+}
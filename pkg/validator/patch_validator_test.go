@@ -0,0 +1,157 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const javaxPatch = `diff --git a/src/main/java/com/example/Order.java b/src/main/java/com/example/Order.java
+index 1111111..2222222 100644
+--- a/src/main/java/com/example/Order.java
++++ b/src/main/java/com/example/Order.java
+@@ -1,5 +1,5 @@
+ package com.example;
+ 
+-import javax.persistence.Entity;
+-import javax.persistence.Id;
++import jakarta.persistence.Entity;
++import jakarta.persistence.Id;
+ 
+@@ -10,3 +10,3 @@ public class Order {
+-    --- legacy marker
++    +++ new marker
+     private Long id;
+`
+
+func TestParsePatch(t *testing.T) {
+	changes := ParsePatch(javaxPatch)
+
+	want := []string{
+		"-import javax.persistence.Entity;",
+		"-import javax.persistence.Id;",
+		"+import jakarta.persistence.Entity;",
+		"+import jakarta.persistence.Id;",
+		"---- legacy marker",
+		"++++ new marker",
+	}
+	got := changes["src/main/java/com/example/Order.java"]
+	if !slices.Equal(got, want) {
+		t.Errorf("ParsePatch() = %q, want %q", got, want)
+	}
+	if len(changes) != 1 {
+		t.Errorf("ParsePatch() found %d files, want 1", len(changes))
+	}
+}
+
+func TestParsePatch_NewAndDeletedFiles(t *testing.T) {
+	patch := `--- /dev/null
++++ b/src/new.txt
+@@ -0,0 +1 @@
++hello
+--- a/src/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-goodbye
+`
+	changes := ParsePatch(patch)
+	if !slices.Equal(changes["src/new.txt"], []string{"+hello"}) {
+		t.Errorf("new file changes = %q", changes["src/new.txt"])
+	}
+	if !slices.Equal(changes["src/old.txt"], []string{"-goodbye"}) {
+		t.Errorf("deleted file changes = %q", changes["src/old.txt"])
+	}
+}
+
+func TestLineSimilarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected float64
+	}{
+		{"empty", nil, nil, 1},
+		{"identical", []string{"+a", "-b"}, []string{"+a", "-b"}, 1},
+		{"half", []string{"+a", "+b"}, []string{"+a", "+c"}, 0.5},
+		{"disjoint", []string{"+a"}, []string{"+b"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lineSimilarity(tt.a, tt.b); got != tt.expected {
+				t.Errorf("lineSimilarity() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func writePatches(t *testing.T, patches map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range patches {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestValidatePatches(t *testing.T) {
+	expectedDir := writePatches(t, map[string]string{"order.diff": javaxPatch, "notes.txt": "ignored"})
+
+	reformatted := `--- a/src/main/java/com/example/Order.java
++++ b/src/main/java/com/example/Order.java
+@@ -3,2 +3,2 @@
+-import   javax.persistence.Entity;
+-import javax.persistence.Id;
++import jakarta.persistence.Entity;
++import jakarta.persistence.Id;
+@@ -11 +11 @@
+-    --- legacy marker
++    +++ new marker
+`
+	partial := `--- a/src/main/java/com/example/Order.java
++++ b/src/main/java/com/example/Order.java
+@@ -3,2 +3,2 @@
+-import javax.persistence.Entity;
++import jakarta.persistence.Entity;
+`
+	extraFile := reformatted + `--- a/pom.xml
++++ b/pom.xml
+@@ -1 +1 @@
+-<version>1</version>
++<version>2</version>
+`
+
+	tests := []struct {
+		name          string
+		actual        string
+		minSimilarity float64
+		errorPaths    []string
+	}{
+		{name: "whitespace and hunk positions ignored", actual: reformatted, minSimilarity: 1},
+		{name: "partial fix below threshold", actual: partial, minSimilarity: 0.8, errorPaths: []string{"patches/src/main/java/com/example/Order.java"}},
+		{name: "partial fix within threshold", actual: partial, minSimilarity: 0.5},
+		{name: "unexpected file", actual: extraFile, minSimilarity: 0.8, errorPaths: []string{"patches/pom.xml"}},
+		{name: "no changes", actual: "", minSimilarity: 0.8, errorPaths: []string{"patches/src/main/java/com/example/Order.java"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualDir := writePatches(t, map[string]string{"001-Order.java.diff": tt.actual})
+			result, err := ValidatePatches(expectedDir, actualDir, tt.minSimilarity)
+			if err != nil {
+				t.Fatalf("ValidatePatches() unexpected error: %v", err)
+			}
+			if result.Passed != (len(tt.errorPaths) == 0) {
+				t.Errorf("Passed = %v, errors: %+v", result.Passed, result.Errors)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if !slices.Equal(paths, tt.errorPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.errorPaths)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package validator
+
+import (
+	"maps"
+	"slices"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/output"
+)
+
+// StreamValidator validates actual rulesets one by one as they are read, for outputs too large to load
+// at once. Only the expected rulesets and the names of the actual ones are kept. Its result is the result
+// of ValidateWithOptions for the same rulesets
+type StreamValidator struct {
+	comparer comparer
+	findings string
+	rulesets []string
+	options  Options
+	expected []konveyor.RuleSet
+	// index maps the names of the expected rulesets to their positions
+	index map[string][]int
+	// rulesetErrors holds the errors of each expected ruleset, nil until it is found
+	rulesetErrors [][]ValidationError
+	found         []bool
+	actualNames   []string
+	// unexpected holds the names of the actual rulesets that aren't expected, excluded the errors of
+	// incidents in excluded paths by ruleset. Both are reported by ruleset name, like ValidateWithOptions
+	unexpected []string
+	excluded   map[string][]ValidationError
+}
+
+// NewStreamValidator creates a validator for actual rulesets added one by one
+func NewStreamValidator(testDir, targetType string, expected []konveyor.RuleSet, opts Options) *StreamValidator {
+	expected = output.Canonicalize(SelectRuleSets(expected, opts.RuleSets))
+	v := &StreamValidator{
+		comparer:      getComparer(targetType, testDir, opts),
+		findings:      opts.Findings,
+		rulesets:      opts.RuleSets,
+		options:       opts,
+		expected:      expected,
+		index:         make(map[string][]int, len(expected)),
+		rulesetErrors: make([][]ValidationError, len(expected)),
+		found:         make([]bool, len(expected)),
+		excluded:      map[string][]ValidationError{},
+	}
+	for i, ers := range expected {
+		v.index[ers.Name] = append(v.index[ers.Name], i)
+	}
+	return v
+}
+
+// Add compares an actual ruleset with the expected ruleset of the same name
+// Like ValidateWithOptions, only the first actual ruleset of a name is compared and rulesets not selected
+// by the options are ignored
+func (v *StreamValidator) Add(rs konveyor.RuleSet) {
+	v.actualNames = append(v.actualNames, rs.Name)
+	if len(v.rulesets) > 0 && !slices.Contains(v.rulesets, rs.Name) {
+		return
+	}
+	if errs := excludedIncidents(rs, v.options.ExcludedPaths, v.options.SourceRoot); len(errs) > 0 {
+		v.excluded[rs.Name] = append(v.excluded[rs.Name], errs...)
+	}
+	positions, exists := v.index[rs.Name]
+	if !exists {
+		v.unexpected = append(v.unexpected, rs.Name)
+		return
+	}
+	if v.found[positions[0]] {
+		return
+	}
+	rs = normalizeFindings(output.CanonicalRuleSet(rs), v.findings)
+	for _, i := range positions {
+		v.found[i] = true
+		v.rulesetErrors[i] = compareRuleSet(v.comparer, normalizeFindings(v.expected[i], v.findings), rs)
+	}
+}
+
+// Result returns the result of validating the rulesets added so far
+func (v *StreamValidator) Result() *ValidationResult {
+	result := &ValidationResult{Errors: []ValidationError{}}
+	for i, ers := range v.expected {
+		if !v.found[i] {
+			result.Errors = append(result.Errors, missingRuleset(ers.Name, v.actualNames))
+			continue
+		}
+		result.Matched = append(result.Matched, ers.Name)
+		result.Errors = append(result.Errors, v.rulesetErrors[i]...)
+	}
+	for _, name := range slices.Sorted(slices.Values(v.unexpected)) {
+		result.Errors = append(result.Errors, unexpectedRuleset(name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(v.excluded)) {
+		result.Errors = append(result.Errors, v.excluded[name]...)
+	}
+	result.Passed = len(result.Errors) == 0
+	return result
+}
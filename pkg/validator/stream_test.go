@@ -0,0 +1,56 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
+)
+
+func TestStreamValidator(t *testing.T) {
+	violation := func(line int) map[string]konveyor.Violation {
+		return map[string]konveyor.Violation{
+			"rule1": {Incidents: []konveyor.Incident{{URI: uri.File("/src/Main.java"), Message: "found", LineNumber: intPtr(line)}}},
+		}
+	}
+	expected := []konveyor.RuleSet{
+		{Name: "matching", Violations: violation(1)},
+		{Name: "changed", Violations: violation(2)},
+		{Name: "missing", Violations: violation(3)},
+	}
+	actual := []konveyor.RuleSet{
+		{Name: "extra", Tags: []string{"Java"}},
+		{Name: "changed", Violations: violation(20)},
+		{Name: "matching", Violations: violation(1)},
+		{Name: "changed", Violations: violation(2)},
+	}
+
+	tests := []struct {
+		name       string
+		opts       Options
+		wantPassed bool
+	}{
+		{name: "all rulesets"},
+		{name: "listed rulesets", opts: Options{RuleSets: []string{"matching", "extra"}}},
+		{name: "listed matching ruleset", opts: Options{RuleSets: []string{"matching"}}, wantPassed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewStreamValidator("/src", "kantra", expected, tt.opts)
+			for _, rs := range actual {
+				v.Add(rs)
+			}
+			got := v.Result()
+
+			want, err := ValidateWithOptions("/src", "kantra", actual, expected, tt.opts)
+			if err != nil {
+				t.Fatalf("ValidateWithOptions returned error: %v", err)
+			}
+			if got.Passed != tt.wantPassed || !reflect.DeepEqual(got, want) {
+				t.Errorf("Result() = %+v, want the result of ValidateWithOptions %+v", got, want)
+			}
+		})
+	}
+}
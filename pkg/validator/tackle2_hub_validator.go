@@ -3,9 +3,11 @@ package validator
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"go.lsp.dev/uri"
 )
 
 type tackleHubValidator struct {
@@ -38,7 +40,7 @@ func (t *tackleHubValidator) compareViolations(expected, actual map[string]konve
 			continue
 		}
 
-		detailErrors := t.compareViolationDetails(exp, act)
+		detailErrors := t.compareViolationDetails(k, exp, act)
 		for i := range detailErrors {
 			detailErrors[i].Path = fmt.Sprintf("/%s%s", k, detailErrors[i].Path)
 		}
@@ -57,72 +59,56 @@ func (t *tackleHubValidator) compareViolations(expected, actual map[string]konve
 	return errors
 }
 
-func (t *tackleHubValidator) compareViolationDetails(expected, actual konveyor.Violation) []ValidationError {
+func (t *tackleHubValidator) compareViolationDetails(ruleID string, expected, actual konveyor.Violation) []ValidationError {
 	var errors []ValidationError
 	skipForInsight := expected.Effort == nil
-	if !skipForInsight && (expected.Effort != nil && actual.Effort != nil) && (*expected.Effort != *actual.Effort) {
-		errors = append(errors, ValidationError{
-			Message: fmt.Sprintf("Did not find expected effort: %v", expected.Effort),
-		})
+	if !skipForInsight && actual.Effort != nil {
+		if ok, want := t.options.effortMatches(ruleID, *expected.Effort, *actual.Effort); !ok {
+			errors = append(errors, ValidationError{
+				Message:  fmt.Sprintf("Did not find expected effort: %s (actual %d)", want, *actual.Effort),
+				Expected: want,
+				Actual:   *actual.Effort,
+			})
+		}
 	}
-	if !skipForInsight && actual.Category != nil && expected.Category != nil && *expected.Category != *actual.Category {
+	if !skipForInsight && actual.Category != nil && expected.Category != nil && !t.options.categoryMatches(*expected.Category, *actual.Category) {
 		errors = append(errors, ValidationError{
-			Message: fmt.Sprintf("Did not find expected category: %v", expected.Category),
+			Message:  fmt.Sprintf("Did not find expected category: %v", *expected.Category),
+			Expected: *expected.Category,
+			Actual:   *actual.Category,
 		})
 	}
 
 	// Handle Links
 	if !skipForInsight {
-		for _, l := range expected.Links {
-			found := false
-			for _, al := range actual.Links {
-				if l.Title == al.Title && l.URL == al.URL {
-					found = true
-					break
-				}
-			}
-			if !found {
-				errors = append(errors, ValidationError{
-					Message: fmt.Sprintf("Did not find expected link: %v", l),
-				})
-			}
-		}
+		errors = append(errors, compareLinks(expected.Links, actual.Links)...)
 		// Handle Labels
 		for _, l := range expected.Labels {
 			if !findExpectedString(l, actual.Labels) {
 				errors = append(errors, ValidationError{
-					Message: fmt.Sprintf("Did not find expected label: %v", l),
+					Message:  fmt.Sprintf("Did not find expected label: %v", l),
+					Expected: l,
+					Actual:   listValue(similarStrings(l, actual.Labels)),
 				})
 			}
 		}
 	}
-	// Handle Incidents
+	// Handle Incidents, reported with the closest incident on the other side
+	expectedIndex := newIncidentIndex(expected.Incidents, t.incidentKey)
+	actualIndex := newIncidentIndex(actual.Incidents, t.incidentKey)
 	for _, i := range expected.Incidents {
-		found := false
-		for _, ai := range actual.Incidents {
-			if t.incidentsMatch(i, ai) {
-				found = true
-				break
-			}
-		}
+		found := slices.ContainsFunc(actualIndex.candidates(i), func(ai konveyor.Incident) bool { return t.incidentsMatch(i, ai) })
 		if !found && !skipForInsight {
-			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Did not find expected incident: %s:%d", i.URI, lineNumberOrZero(i.LineNumber)),
-			})
+			errors = append(errors, t.missingIncident(i, actual.Incidents, t.sameURI))
 		}
 	}
 	for _, ai := range actual.Incidents {
-		found := false
-		for _, i := range expected.Incidents {
-			if t.incidentsMatch(i, ai) {
-				found = true
-				break
-			}
-		}
+		found := slices.ContainsFunc(expectedIndex.candidates(ai), func(i konveyor.Incident) bool { return t.incidentsMatch(i, ai) })
 		if !found && !skipForInsight {
 			errors = append(errors, ValidationError{
-				Message: fmt.Sprintf("Unexpected incident found: %s:%d", ai.URI, lineNumberOrZero(ai.LineNumber)),
-				Actual:  ai,
+				Message:  fmt.Sprintf("Unexpected incident found: %s:%d", ai.URI, lineNumberOrZero(ai.LineNumber)),
+				Expected: incidentValue(closestIncident(ai, expected.Incidents, reversed(t.sameURI))),
+				Actual:   ai,
 			})
 		}
 	}
@@ -133,23 +119,36 @@ func (t *tackleHubValidator) compareViolationDetails(expected, actual konveyor.V
 func (t *tackleHubValidator) incidentsMatch(expected, actual konveyor.Incident) bool {
 	// For code snips, there is no way to configure them
 	// So for tackle2Hub we are going to ignore code snips
-	if string(expected.URI) != "" && string(actual.URI) != "" {
-		if expected.URI != actual.URI {
-			pathToTest, err := filepath.Rel("/source", expected.URI.Filename())
-			if err != nil {
-				return false
-			}
-			if !strings.Contains(actual.URI.Filename(), pathToTest) {
-				return false
-			}
-		}
-	}
 	if expected.Message != actual.Message {
 		return false
 	}
 	if expected.LineNumber != nil && actual.LineNumber != nil && *expected.LineNumber != *actual.LineNumber {
 		return false
 	}
+	// URIs are compared last, canonicalizing them is the most expensive check
+	if string(expected.URI) != "" && string(actual.URI) != "" && !t.sameURI(expected.URI, actual.URI) {
+		return false
+	}
 
 	return true
 }
+
+// incidentKey returns the message of an incident, which must be equal for incidents to match. Line numbers
+// aren't part of the key, the Hub matches incidents without a line number at any line
+func (t *tackleHubValidator) incidentKey(incident konveyor.Incident) string {
+	return incident.Message
+}
+
+// sameURI reports whether two incidents are in the same file. The Hub analyzes the sources at another
+// root, so the path of the expected incident below /source must match the end of the actual path
+func (t *tackleHubValidator) sameURI(expected, actual uri.URI) bool {
+	if urisMatch(expected, actual, t.options.SourceRoot) {
+		return true
+	}
+	pathToTest, err := filepath.Rel("/source", canonicalURI(expected, ""))
+	if err != nil || strings.HasPrefix(pathToTest, "..") {
+		return false
+	}
+	actualPath := canonicalURI(actual, t.options.SourceRoot)
+	return actualPath == pathToTest || strings.HasSuffix(actualPath, "/"+filepath.ToSlash(pathToTest))
+}
@@ -0,0 +1,112 @@
+package validator
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"go.lsp.dev/uri"
+)
+
+// drivePattern matches a Windows drive letter at the start of a path, e.g. C: or /C:
+var drivePattern = regexp.MustCompile(`^/?([A-Za-z]):(/|$)`)
+
+// urisMatch compares incident URIs in their canonical form, so file:// URIs match plain paths and
+// escaping, drive letter and host case differences between providers and targets are ignored
+func urisMatch(expected, actual uri.URI, sourceRoot string) bool {
+	if expected == actual {
+		return true
+	}
+	return canonicalURI(expected, sourceRoot) == canonicalURI(actual, sourceRoot)
+}
+
+// canonicalURI normalizes an incident URI for comparison. File URIs and plain paths become clean,
+// percent-decoded paths with forward slashes and lowercase drive letters, other schemes keep their
+// lowercase scheme and host. Paths inside sourceRoot that exist locally have their symlinks resolved,
+// without a source root the file system isn't read
+func canonicalURI(u uri.URI, sourceRoot string) string {
+	raw := strings.TrimSpace(string(u))
+	scheme, rest, hasScheme := strings.Cut(raw, ":")
+	if hasScheme && (len(scheme) == 1 || strings.ContainsAny(scheme, `/\`)) {
+		// A drive letter or a path, not a scheme
+		hasScheme = false
+	}
+
+	if hasScheme && !strings.EqualFold(scheme, "file") {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return raw
+		}
+		parsed.Scheme = strings.ToLower(parsed.Scheme)
+		parsed.Host = strings.ToLower(parsed.Host)
+		return unescape(parsed.String())
+	}
+
+	p := raw
+	host := ""
+	if hasScheme {
+		p = strings.TrimPrefix(rest, "//")
+		if !strings.HasPrefix(rest, "//") {
+			// file:/path
+			p = rest
+		} else if slash := strings.Index(p, "/"); slash > 0 && !drivePattern.MatchString(p) {
+			// file://host/path, localhost is the local machine
+			host, p = strings.ToLower(p[:slash]), p[slash:]
+			if host == "localhost" {
+				host = ""
+			}
+		}
+	}
+	p = unescape(strings.ReplaceAll(p, `\`, "/"))
+	if match := drivePattern.FindStringSubmatch(p); match != nil {
+		p = "/" + strings.ToLower(match[1]) + ":" + p[len(match[0])-len(match[2]):]
+	}
+	if p != "" {
+		p = path.Clean(p)
+	}
+	p = resolveSymlinks(p, sourceRoot)
+	if host != "" {
+		return "//" + host + p
+	}
+	return p
+}
+
+// unescape decodes percent-encoded characters, keeping the string as is if it is malformed
+// Providers escape paths differently, e.g. the nodejs provider encodes @ in scoped packages as %40
+func unescape(s string) string {
+	unescaped, err := url.PathUnescape(s)
+	if err != nil {
+		return s
+	}
+	return unescaped
+}
+
+// resolveSymlinks resolves the symlinks of a path inside sourceRoot that exists locally, e.g. a
+// source directory linked into the work directory. Other paths are returned as is
+func resolveSymlinks(p, sourceRoot string) string {
+	if sourceRoot == "" || p == "" {
+		return p
+	}
+	local := filepath.FromSlash(p)
+	roots := []string{filepath.Clean(sourceRoot)}
+	if resolved, err := filepath.EvalSymlinks(sourceRoot); err == nil {
+		roots = append(roots, resolved)
+	}
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, local)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if _, err := os.Lstat(local); err != nil {
+			return p
+		}
+		if resolved, err := filepath.EvalSymlinks(local); err == nil {
+			return filepath.ToSlash(resolved)
+		}
+		return p
+	}
+	return p
+}
@@ -0,0 +1,62 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.lsp.dev/uri"
+)
+
+func TestURIsMatch(t *testing.T) {
+	tests := []struct {
+		name             string
+		expected, actual string
+		want             bool
+	}{
+		{name: "file URI and plain path", expected: "file:///opt/input/source/src/App.java", actual: "/opt/input/source/src/App.java", want: true},
+		{name: "file URI without authority", expected: "file:/opt/input/source/App.java", actual: "file:///opt/input/source/App.java", want: true},
+		{name: "uppercase scheme", expected: "FILE:///opt/App.java", actual: "file:///opt/App.java", want: true},
+		{name: "percent-encoded", expected: "file:///opt/node_modules/@types/node/index.d.ts", actual: "file:///opt/node_modules/%40types/node/index.d.ts", want: true},
+		{name: "drive letter case", expected: "file:///C:/src/App.java", actual: "file:///c%3A/src/App.java", want: true},
+		{name: "windows path", expected: `C:\src\App.java`, actual: "file:///c:/src/App.java", want: true},
+		{name: "host case", expected: "file://BuildHost/src/App.java", actual: "file://buildhost/src/App.java", want: true},
+		{name: "localhost", expected: "file://localhost/src/App.java", actual: "/src/App.java", want: true},
+		{name: "unclean path", expected: "file:///opt/input/./source//App.java", actual: "/opt/input/source/App.java", want: true},
+		{name: "other scheme", expected: "JAR:file:///m2/lib.jar!/A.class", actual: "jar:file:///m2/lib.jar!/A.class", want: true},
+		{name: "different files", expected: "file:///opt/App.java", actual: "file:///opt/Main.java"},
+		{name: "different hosts", expected: "file://a/src/App.java", actual: "file://b/src/App.java"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := urisMatch(uri.URI(tt.expected), uri.URI(tt.actual), ""); got != tt.want {
+				t.Errorf("urisMatch(%s, %s) = %v, want %v (%s, %s)", tt.expected, tt.actual, got, tt.want,
+					canonicalURI(uri.URI(tt.expected), ""), canonicalURI(uri.URI(tt.actual), ""))
+			}
+		})
+	}
+}
+
+func TestURIsMatch_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "source")
+	if err := os.MkdirAll(filepath.Join(source, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(source, "src", "App.java"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(source, filepath.Join(root, "input")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	linked := uri.File(filepath.Join(root, "input", "src", "App.java"))
+	real := uri.File(filepath.Join(source, "src", "App.java"))
+	if !urisMatch(linked, real, root) {
+		t.Errorf("urisMatch(%s, %s) should resolve symlinks inside the source root", linked, real)
+	}
+	if urisMatch(linked, real, "") {
+		t.Error("urisMatch() without a source root shouldn't read the file system")
+	}
+}
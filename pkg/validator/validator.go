@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"maps"
 	"reflect"
+	"runtime"
+	"slices"
+	"sync"
 
 	"github.com/fatih/color"
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
+	"github.com/konveyor/test-harness/pkg/output"
 )
 
 type tagCompare interface {
@@ -42,8 +46,8 @@ type comparer interface {
 	skippedCompare
 }
 
-func getComparer(targetType, testDir string) comparer {
-	base := &baseValidator{testDir: testDir}
+func getComparer(targetType, testDir string, opts Options) comparer {
+	base := &baseValidator{testDir: testDir, options: opts}
 	switch targetType {
 	case "kantra":
 		return &kantraValidator{baseValidator: *base}
@@ -55,6 +59,10 @@ func getComparer(targetType, testDir string) comparer {
 		return &kantraValidator{baseValidator: *base}
 	case "vscode":
 		return &kantraValidator{baseValidator: *base}
+	case "asset-gen":
+		return &kantraValidator{baseValidator: *base}
+	case "mock":
+		return &kantraValidator{baseValidator: *base}
 	}
 	return nil
 }
@@ -63,9 +71,13 @@ func getComparer(targetType, testDir string) comparer {
 type ValidationResult struct {
 	Passed bool
 	Errors []ValidationError
+	// Matched lists the expected rulesets found in the actual output, whether or not their contents match
+	Matched []string
 }
 
 // ValidationError represents a single validation failure
+// Actual holds what was produced instead, the closest actual candidate when nothing matched, e.g. the
+// incident in the same file at another line. It is nil when nothing similar was produced
 type ValidationError struct {
 	Path     string
 	Message  string
@@ -104,85 +116,69 @@ func Validate(actual, expected []konveyor.RuleSet) (*ValidationResult, error) {
 
 // ValidateFiles performs exact match validation by comparing YAML files directly
 func ValidateFiles(testDir, targetType string, actual, expected []konveyor.RuleSet) (*ValidationResult, error) {
+	return ValidateWithOptions(testDir, targetType, actual, expected, Options{})
+}
+
+// ValidateWithOptions performs validation with relaxed effort and category comparisons
+func ValidateWithOptions(testDir, targetType string, actual, expected []konveyor.RuleSet, opts Options) (*ValidationResult, error) {
 	result := &ValidationResult{
 		Passed: true,
 		Errors: []ValidationError{},
 	}
 
 	errors := []ValidationError{}
-	comparer := getComparer(targetType, testDir)
-
-	for _, ers := range expected {
-		found := false
-		for _, rs := range actual {
-			if rs.Name != ers.Name {
-				continue
-			}
-			found = true
-
-			if !maps.Equal(ers.Errors, rs.Errors) {
-				errs := comparer.compareErrors(ers.Errors, rs.Errors)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/error%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-
-			if !reflect.DeepEqual(rs.Tags, ers.Tags) {
-				errs := comparer.compareTags(ers.Tags, rs.Tags)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/tags%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-			if !reflect.DeepEqual(rs.Insights, ers.Insights) {
-				errs := comparer.compareViolations(ers.Insights, rs.Insights)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/insights%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-			if !reflect.DeepEqual(rs.Violations, ers.Violations) {
-				errs := comparer.compareViolations(ers.Violations, rs.Violations)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/violations%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-			if !reflect.DeepEqual(rs.Unmatched, ers.Unmatched) {
-				errs := comparer.compareUnmatched(ers.Unmatched, rs.Unmatched)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/unmatched%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-			if !reflect.DeepEqual(rs.Skipped, ers.Skipped) {
-				errs := comparer.compareSkipped(ers.Skipped, rs.Skipped)
-				for i := range errs {
-					errs[i].Path = fmt.Sprintf("%s/skipped%s", rs.Name, errs[i].Path)
-				}
-				errors = append(errors, errs...)
-			}
-			break
-		}
-		if !found {
-			errors = append(errors, ValidationError{Path: fmt.Sprintf("ruleset/%s", ers.Name), Message: "Did not find a matching ruleset"})
+	comparer := getComparer(targetType, testDir, opts)
+	// Rulesets that aren't selected are still suggested for missing rulesets, they may have been renamed
+	actualNames := rulesetNames(actual)
+	// Both outputs are compared in canonical order, only differences in content fail
+	actual = output.Canonicalize(SelectRuleSets(actual, opts.RuleSets))
+	expected = output.Canonicalize(SelectRuleSets(expected, opts.RuleSets))
+
+	actualByName := make(map[string]konveyor.RuleSet, len(actual))
+	for _, rs := range actual {
+		if _, exists := actualByName[rs.Name]; !exists {
+			actualByName[rs.Name] = normalizeFindings(rs, opts.Findings)
 		}
 	}
 
+	// Rulesets are compared in parallel, their errors are kept in the order of the expected rulesets
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	sem := make(chan struct{}, parallelism)
+	rulesetErrors := make([][]ValidationError, len(expected))
+	var wg sync.WaitGroup
 	expectedRulesetNames := make(map[string]bool)
-	for _, ers := range expected {
+	for i, ers := range expected {
 		expectedRulesetNames[ers.Name] = true
+		rs, found := actualByName[ers.Name]
+		if !found {
+			rulesetErrors[i] = []ValidationError{missingRuleset(ers.Name, actualNames)}
+			continue
+		}
+		result.Matched = append(result.Matched, ers.Name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			rulesetErrors[i] = compareRuleSet(comparer, normalizeFindings(ers, opts.Findings), rs)
+		}()
 	}
+	wg.Wait()
+	for _, errs := range rulesetErrors {
+		errors = append(errors, errs...)
+	}
+
 	for _, rs := range actual {
 		if !expectedRulesetNames[rs.Name] {
-			errors = append(errors, ValidationError{
-				Path:    fmt.Sprintf("ruleset/%s", rs.Name),
-				Message: fmt.Sprintf("Unexpected ruleset found: %s", rs.Name),
-				Actual:  rs.Name,
-			})
+			errors = append(errors, unexpectedRuleset(rs.Name))
 		}
 	}
+	for _, rs := range actual {
+		errors = append(errors, excludedIncidents(rs, opts.ExcludedPaths, opts.SourceRoot)...)
+	}
 
 	// If not equal, generate detailed diff
 	result.Passed = len(errors) == 0
@@ -190,3 +186,86 @@ func ValidateFiles(testDir, targetType string, actual, expected []konveyor.RuleS
 
 	return result, nil
 }
+
+// SelectRuleSets returns the rulesets of the given names, all rulesets when no names are given
+func SelectRuleSets(rulesets []konveyor.RuleSet, names []string) []konveyor.RuleSet {
+	if len(names) == 0 {
+		return rulesets
+	}
+	var selected []konveyor.RuleSet
+	for _, rs := range rulesets {
+		if slices.Contains(names, rs.Name) {
+			selected = append(selected, rs)
+		}
+	}
+	return selected
+}
+
+// missingRuleset reports an expected ruleset without an actual ruleset of the same name
+func missingRuleset(name string, actualNames []string) ValidationError {
+	return ValidationError{
+		Path:     fmt.Sprintf("ruleset/%s", name),
+		Message:  "Did not find a matching ruleset",
+		Expected: name,
+		Actual:   listValue(similarStrings(name, actualNames)),
+	}
+}
+
+// unexpectedRuleset reports an actual ruleset that isn't expected
+func unexpectedRuleset(name string) ValidationError {
+	return ValidationError{
+		Path:    fmt.Sprintf("ruleset/%s", name),
+		Message: fmt.Sprintf("Unexpected ruleset found: %s", name),
+		Actual:  name,
+	}
+}
+
+// compareRuleSet compares an expected ruleset with the actual ruleset of the same name
+func compareRuleSet(c comparer, ers, rs konveyor.RuleSet) []ValidationError {
+	var errors []ValidationError
+	if !maps.Equal(ers.Errors, rs.Errors) {
+		errs := c.compareErrors(ers.Errors, rs.Errors)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/error%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+
+	if !reflect.DeepEqual(rs.Tags, ers.Tags) {
+		errs := c.compareTags(ers.Tags, rs.Tags)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/tags%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+	if !reflect.DeepEqual(rs.Insights, ers.Insights) {
+		errs := c.compareViolations(ers.Insights, rs.Insights)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/insights%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+	if !reflect.DeepEqual(rs.Violations, ers.Violations) {
+		errs := c.compareViolations(ers.Violations, rs.Violations)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/violations%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+	if !reflect.DeepEqual(rs.Unmatched, ers.Unmatched) {
+		errs := c.compareUnmatched(ers.Unmatched, rs.Unmatched)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/unmatched%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+	if !reflect.DeepEqual(rs.Skipped, ers.Skipped) {
+		errs := c.compareSkipped(ers.Skipped, rs.Skipped)
+		for i := range errs {
+			errs[i].Path = fmt.Sprintf("%s/skipped%s", rs.Name, errs[i].Path)
+		}
+		errors = append(errors, errs...)
+	}
+
+	return errors
+}
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 
 	konveyor "github.com/konveyor/analyzer-lsp/output/v1/konveyor"
@@ -105,6 +107,135 @@ func TestValidate_MissingRuleset(t *testing.T) {
 	if !foundError {
 		t.Error("Expected error for missing ruleset2")
 	}
+	if len(result.Errors) != 1 {
+		t.Errorf("Expected only the missing ruleset error, got %+v", result.Errors)
+	}
+	if !reflect.DeepEqual(result.Matched, []string{"ruleset1"}) {
+		t.Errorf("Matched = %v, want [ruleset1]", result.Matched)
+	}
+}
+
+func TestValidate_RuleSets(t *testing.T) {
+	actual := []konveyor.RuleSet{
+		{Name: "ruleset1", Tags: []string{"Java"}},
+		{Name: "ruleset2", Tags: []string{"Spring"}},
+		{Name: "unlisted", Tags: []string{"Quarkus"}},
+	}
+	expected := []konveyor.RuleSet{
+		{Name: "ruleset1", Tags: []string{"Java"}},
+		{Name: "ruleset2", Tags: []string{"EJB"}},
+		{Name: "stale", Tags: []string{"Java EE"}},
+	}
+
+	tests := []struct {
+		name        string
+		rulesets    []string
+		wantPaths   []string
+		wantMatched []string
+	}{
+		{
+			name:        "all rulesets",
+			wantPaths:   []string{"ruleset2/tags/EJB", "ruleset2/tags/Spring", "ruleset/stale", "ruleset/unlisted"},
+			wantMatched: []string{"ruleset1", "ruleset2"},
+		},
+		{
+			name:        "only listed rulesets",
+			rulesets:    []string{"ruleset1"},
+			wantMatched: []string{"ruleset1"},
+		},
+		{
+			name:        "listed ruleset differs",
+			rulesets:    []string{"ruleset1", "ruleset2"},
+			wantPaths:   []string{"ruleset2/tags/EJB", "ruleset2/tags/Spring"},
+			wantMatched: []string{"ruleset1", "ruleset2"},
+		},
+		{
+			name:      "listed ruleset missing from actual",
+			rulesets:  []string{"stale"},
+			wantPaths: []string{"ruleset/stale"},
+		},
+		{
+			name:      "listed ruleset not expected",
+			rulesets:  []string{"unlisted"},
+			wantPaths: []string{"ruleset/unlisted"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateWithOptions("/test", "kantra", actual, expected, Options{RuleSets: tt.rulesets})
+			if err != nil {
+				t.Fatalf("ValidateWithOptions returned error: %v", err)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+			if !reflect.DeepEqual(result.Matched, tt.wantMatched) {
+				t.Errorf("Matched = %v, want %v", result.Matched, tt.wantMatched)
+			}
+			if result.Passed != (len(tt.wantPaths) == 0) {
+				t.Errorf("Passed = %v with errors %v", result.Passed, paths)
+			}
+		})
+	}
+}
+
+func TestValidate_ExcludedPaths(t *testing.T) {
+	incidents := func(uris ...string) konveyor.Violation {
+		var v konveyor.Violation
+		for _, u := range uris {
+			v.Incidents = append(v.Incidents, konveyor.Incident{URI: uri.URI(u)})
+		}
+		return v
+	}
+	rulesets := []konveyor.RuleSet{{
+		Name: "ruleset1",
+		Violations: map[string]konveyor.Violation{
+			"local-storage": incidents("file:///source/src/main/java/App.java", "file:///source/generated/src/Gen.java"),
+		},
+		Insights: map[string]konveyor.Violation{
+			"logging": incidents("file:///source/lib/vendor/log4j/Logger.java"),
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		excluded  []string
+		wantPaths []string
+	}{
+		{name: "no excluded paths"},
+		{name: "incidents outside excluded paths", excluded: []string{"test", "src/test"}},
+		{name: "violation in excluded path", excluded: []string{"generated/"}, wantPaths: []string{"/ruleset1/local-storage/incidents"}},
+		{name: "insight in nested excluded path", excluded: []string{"lib/vendor"}, wantPaths: []string{"/ruleset1/logging/incidents"}},
+		{name: "partial segment isn't excluded", excluded: []string{"gen"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ValidateWithOptions("/test", "kantra", rulesets, rulesets, Options{ExcludedPaths: tt.excluded})
+			if err != nil {
+				t.Fatalf("ValidateWithOptions returned error: %v", err)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("error paths = %v, want %v", paths, tt.wantPaths)
+			}
+
+			// Streamed outputs report the same errors
+			v := NewStreamValidator("/test", "kantra", rulesets, Options{ExcludedPaths: tt.excluded})
+			v.Add(rulesets[0])
+			if streamed := v.Result(); len(streamed.Errors) != len(tt.wantPaths) {
+				t.Errorf("streamed errors = %v, want %v", streamed.Errors, tt.wantPaths)
+			}
+		})
+	}
 }
 
 func TestValidate_MissingTag(t *testing.T) {
@@ -241,6 +372,220 @@ func TestValidateFiles_WithTargetType(t *testing.T) {
 	}
 }
 
+func TestValidate_ProviderURIs(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected uri.URI
+		actual   uri.URI
+		passed   bool
+	}{
+		{name: "go", expected: "file:///source/pkg/server/main.go", actual: "file:///source/pkg/server/main.go", passed: true},
+		{name: "python", expected: "file:///source/app/main.py", actual: "file:///source/app/main.py", passed: true},
+		{name: "nodejs scoped package", expected: "file:///source/node_modules/@angular/core/index.d.ts", actual: "file:///source/node_modules/%40angular/core/index.d.ts", passed: true},
+		{name: "nodejs escaped space", expected: "file:///source/src/my%20app.ts", actual: "file:///source/src/my app.ts", passed: true},
+		{name: "different file", expected: "file:///source/app/main.py", actual: "file:///source/app/other.py", passed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleset := func(u uri.URI) []konveyor.RuleSet {
+				return []konveyor.RuleSet{{
+					Name: "generic",
+					Violations: map[string]konveyor.Violation{
+						"rule1": {Incidents: []konveyor.Incident{{URI: u, Message: "found", LineNumber: intPtr(3)}}},
+					},
+				}}
+			}
+
+			result, err := ValidateFiles("/source", "kantra", ruleset(tt.actual), ruleset(tt.expected))
+			if err != nil {
+				t.Fatalf("ValidateFiles returned error: %v", err)
+			}
+			if result.Passed != tt.passed {
+				t.Errorf("Passed = %v, want %v (errors: %+v)", result.Passed, tt.passed, result.Errors)
+			}
+		})
+	}
+}
+
+func TestValidate_ClosestActual(t *testing.T) {
+	incident := func(file string, line int) konveyor.Incident {
+		return konveyor.Incident{URI: uri.File(file), Message: "found", LineNumber: intPtr(line)}
+	}
+	actual := []konveyor.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]konveyor.Violation{
+			"rule1": {
+				Category:  categoryPtr("optional"),
+				Effort:    intPtr(1),
+				Labels:    []string{"konveyor.io/target=quarkus"},
+				Incidents: []konveyor.Incident{incident("/src/Other.java", 10), incident("/src/Main.java", 12)},
+			},
+		},
+	}}
+	expected := []konveyor.RuleSet{{
+		Name: "ruleset",
+		Violations: map[string]konveyor.Violation{
+			"rule1": {
+				Category:  categoryPtr("mandatory"),
+				Effort:    intPtr(1),
+				Labels:    []string{"konveyor.io/target=eap8"},
+				Incidents: []konveyor.Incident{incident("/src/Other.java", 10), incident("/src/Main.java", 10)},
+			},
+		},
+	}}
+
+	for _, target := range []string{"kantra", "tackle-hub"} {
+		t.Run(target, func(t *testing.T) {
+			result, err := ValidateFiles("/src", target, actual, expected)
+			if err != nil {
+				t.Fatalf("ValidateFiles returned error: %v", err)
+			}
+			want := map[string]any{
+				"Did not find expected category: mandatory":                                                                            konveyor.Category("optional"),
+				"Did not find expected label: konveyor.io/target=eap8":                                                                 []string{"konveyor.io/target=quarkus"},
+				"Did not find expected incident: file:///src/Main.java:10, closest actual incident: line number changed from 10 to 12": incident("/src/Main.java", 12),
+				"Unexpected incident found: file:///src/Main.java:12":                                                                  incident("/src/Main.java", 12),
+			}
+			for _, e := range result.Errors {
+				wantActual, ok := want[e.Message]
+				if !ok {
+					t.Errorf("unexpected error %q", e.Message)
+					continue
+				}
+				if !reflect.DeepEqual(e.Actual, wantActual) {
+					t.Errorf("%q: Actual = %+v, want %+v", e.Message, e.Actual, wantActual)
+				}
+				delete(want, e.Message)
+			}
+			for message := range want {
+				t.Errorf("missing error %q", message)
+			}
+		})
+	}
+}
+
+func TestClosestIncident(t *testing.T) {
+	candidates := []konveyor.Incident{
+		{URI: "file:///src/A.java", Message: "other", LineNumber: intPtr(3)},
+		{URI: "file:///src/B.java", Message: "found", LineNumber: intPtr(10)},
+		{URI: "file:///src/A.java", Message: "found", LineNumber: intPtr(40)},
+		{URI: "file:///src/A.java", Message: "found", LineNumber: intPtr(12)},
+	}
+	sameURI := func(a, b uri.URI) bool { return a == b }
+
+	closest := closestIncident(konveyor.Incident{URI: "file:///src/A.java", Message: "found", LineNumber: intPtr(10)}, candidates, sameURI)
+	if closest != &candidates[3] {
+		t.Errorf("closestIncident() = %+v, want the incident in the same file with the nearest line", closest)
+	}
+	if closest := closestIncident(konveyor.Incident{URI: "file:///src/A.java"}, nil, sameURI); closest != nil {
+		t.Errorf("closestIncident() without candidates = %+v, want nil", closest)
+	}
+}
+
+func TestMissingIncident(t *testing.T) {
+	b := &baseValidator{}
+	expected := konveyor.Incident{URI: "file:///src/com/example/Bean.java", Message: "Replace javax.ejb with jakarta.ejb", LineNumber: intPtr(88)}
+	tests := []struct {
+		name    string
+		actual  []konveyor.Incident
+		message string
+	}{
+		{
+			name:    "no incidents",
+			message: "Did not find expected incident: file:///src/com/example/Bean.java:88",
+		},
+		{
+			name: "line number changed",
+			actual: []konveyor.Incident{
+				{URI: "file:///src/com/example/Other.java", Message: expected.Message, LineNumber: intPtr(88)},
+				{URI: expected.URI, Message: expected.Message, LineNumber: intPtr(90)},
+			},
+			message: "Did not find expected incident: file:///src/com/example/Bean.java:88, closest actual incident: line number changed from 88 to 90",
+		},
+		{
+			name: "message and file changed",
+			actual: []konveyor.Incident{
+				{URI: "file:///src/com/example/Other.java", Message: "Remove the EJB annotation", LineNumber: intPtr(3)},
+				{URI: "file:///app/src/com/example/Bean.java", Message: "Replace javax.ejb with jakarta.ejb.", LineNumber: intPtr(88)},
+			},
+			message: `Did not find expected incident: file:///src/com/example/Bean.java:88, closest actual incident: URI changed from file:///src/com/example/Bean.java to file:///app/src/com/example/Bean.java, message changed from "Replace javax.ejb with jakarta.ejb" to "Replace javax.ejb with jakarta.ejb."`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := b.missingIncident(expected, tt.actual, b.sameURI)
+			if e.Message != tt.message {
+				t.Errorf("Message = %q, want %q", e.Message, tt.message)
+			}
+			if (e.Actual == nil) != (len(tt.actual) == 0) {
+				t.Errorf("Actual = %+v", e.Actual)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "javax", b: "jakarta", want: 4},
+		{a: "same", b: "same", want: 0},
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_LargeOutput(t *testing.T) {
+	ruleset := func(name string, incidents int) konveyor.RuleSet {
+		violation := konveyor.Violation{Effort: intPtr(1)}
+		for i := range incidents {
+			violation.Incidents = append(violation.Incidents, konveyor.Incident{
+				URI:        uri.File(fmt.Sprintf("/src/File%d.java", i%100)),
+				Message:    fmt.Sprintf("message %d", i%7),
+				LineNumber: intPtr(i),
+			})
+		}
+		return konveyor.RuleSet{Name: name, Violations: map[string]konveyor.Violation{"rule1": violation}}
+	}
+	var actual, expected []konveyor.RuleSet
+	for i := range 8 {
+		actual = append(actual, ruleset(fmt.Sprintf("ruleset%d", i), 5000))
+		expected = append(expected, ruleset(fmt.Sprintf("ruleset%d", i), 5000))
+	}
+	// One incident of each of the last two rulesets moves to another line
+	for _, rs := range actual[6:] {
+		rs.Violations["rule1"].Incidents[42].LineNumber = intPtr(-1)
+	}
+
+	for _, target := range []string{"kantra", "tackle-hub"} {
+		t.Run(target, func(t *testing.T) {
+			result, err := ValidateWithOptions("/src", target, actual, expected, Options{Parallelism: 4})
+			if err != nil {
+				t.Fatalf("ValidateWithOptions returned error: %v", err)
+			}
+			var paths []string
+			for _, e := range result.Errors {
+				paths = append(paths, e.Path)
+			}
+			want := []string{"ruleset6/violations/rule1", "ruleset6/violations/rule1", "ruleset7/violations/rule1", "ruleset7/violations/rule1"}
+			if !reflect.DeepEqual(paths, want) {
+				t.Errorf("error paths = %v, want a missing and an unexpected incident in ruleset6 and ruleset7", paths)
+			}
+			if len(result.Matched) != 8 {
+				t.Errorf("Matched = %v, want all 8 rulesets", result.Matched)
+			}
+		})
+	}
+}
+
 // Helper functions
 func intPtr(i int) *int {
 	return &i
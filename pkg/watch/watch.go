@@ -0,0 +1,147 @@
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Defaults of the polling interval and the quiet period after a change
+const (
+	DefaultInterval = 500 * time.Millisecond
+	DefaultDebounce = time.Second
+)
+
+// Watcher polls YAML files under its paths for changes. Polling needs no platform specific file
+// notifications and works on mounted volumes. Hidden directories, such as .git and the .koncur work
+// directory, aren't watched so outputs written during a run don't trigger another run
+type Watcher struct {
+	Paths []string
+
+	// Interval is the time between polls (default: DefaultInterval)
+	Interval time.Duration
+
+	// Debounce is how long files must stay unchanged before fn is called, so an editor saving several
+	// files or a rule generator writing a directory triggers a single run (default: DefaultDebounce)
+	Debounce time.Duration
+}
+
+// fileState is what a poll compares to detect a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Run calls fn with the files changed, added or removed since the previous call, until ctx is done
+// fn is called synchronously, changes made while it runs are reported by the next call
+func (w *Watcher) Run(ctx context.Context, fn func(changed []string)) error {
+	interval, debounce := w.Interval, w.Debounce
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	previous, err := snapshot(w.Paths)
+	if err != nil {
+		return err
+	}
+	pending := map[string]bool{}
+	var lastChange time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			current, err := snapshot(w.Paths)
+			if err != nil {
+				return err
+			}
+			if changed := changes(previous, current); len(changed) > 0 {
+				for _, path := range changed {
+					pending[path] = true
+				}
+				lastChange = now
+			}
+			previous = current
+
+			if len(pending) > 0 && now.Sub(lastChange) >= debounce {
+				changed := make([]string, 0, len(pending))
+				for path := range pending {
+					changed = append(changed, path)
+				}
+				slices.Sort(changed)
+				clear(pending)
+				fn(changed)
+			}
+		}
+	}
+}
+
+// snapshot records the state of the YAML files under paths, a path may be a file
+func snapshot(paths []string) (map[string]fileState, error) {
+	files := map[string]fileState{}
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// Files removed while walking are reported by the next poll
+				if os.IsNotExist(err) && path != root {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				if path != root && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !isYAMLFile(path) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// changes returns the files whose state differs between two snapshots, sorted
+func changes(previous, current map[string]fileState) []string {
+	var changed []string
+	for path, state := range current {
+		if before, ok := previous[path]; !ok || before != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+func isYAMLFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
@@ -0,0 +1,122 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "rules", "a.yaml"), "- ruleID: a")
+	writeFile(t, filepath.Join(dir, "rules", "b.yml"), "- ruleID: b")
+	writeFile(t, filepath.Join(dir, "rules", "README.md"), "# rules")
+	writeFile(t, filepath.Join(dir, ".koncur", "output", "output.yaml"), "[]")
+	single := filepath.Join(dir, "test.yaml")
+	writeFile(t, single, "name: test")
+
+	files, err := snapshot([]string{filepath.Join(dir, "rules"), single})
+	if err != nil {
+		t.Fatalf("snapshot() error = %v", err)
+	}
+	var got []string
+	for path := range files {
+		got = append(got, path)
+	}
+	want := []string{filepath.Join(dir, "rules", "a.yaml"), filepath.Join(dir, "rules", "b.yml"), single}
+	if len(got) != len(want) {
+		t.Fatalf("snapshot() = %v, want %v", got, want)
+	}
+	for _, path := range want {
+		if _, ok := files[path]; !ok {
+			t.Errorf("snapshot() is missing %s", path)
+		}
+	}
+
+	if _, err := snapshot([]string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("snapshot() of a missing path should fail")
+	}
+}
+
+func TestChanges(t *testing.T) {
+	now := time.Now()
+	previous := map[string]fileState{
+		"same.yaml":     {modTime: now, size: 1},
+		"modified.yaml": {modTime: now, size: 1},
+		"resized.yaml":  {modTime: now, size: 1},
+		"removed.yaml":  {modTime: now, size: 1},
+	}
+	current := map[string]fileState{
+		"same.yaml":     {modTime: now, size: 1},
+		"modified.yaml": {modTime: now.Add(time.Second), size: 1},
+		"resized.yaml":  {modTime: now, size: 2},
+		"added.yaml":    {modTime: now, size: 1},
+	}
+
+	got := changes(previous, current)
+	want := []string{"added.yaml", "modified.yaml", "removed.yaml", "resized.yaml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changes() = %v, want %v", got, want)
+	}
+	if got := changes(current, current); len(got) != 0 {
+		t.Errorf("changes() of the same snapshot = %v, want none", got)
+	}
+}
+
+func TestWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	rule := filepath.Join(dir, "rule.yaml")
+	writeFile(t, rule, "- ruleID: a")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := &Watcher{Paths: []string{dir}, Interval: 10 * time.Millisecond, Debounce: 50 * time.Millisecond}
+	calls := make(chan []string, 10)
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx, func(changed []string) {
+			calls <- changed
+		})
+	}()
+
+	// Several writes in quick succession are debounced into one call
+	time.Sleep(30 * time.Millisecond)
+	other := filepath.Join(dir, "other.yaml")
+	writeFile(t, other, "- ruleID: b")
+	writeFile(t, rule, "- ruleID: a\n  message: changed")
+
+	select {
+	case changed := <-calls:
+		want := []string{other, rule}
+		if !reflect.DeepEqual(changed, want) {
+			t.Errorf("Run() changed = %v, want %v", changed, want)
+		}
+	case <-ctx.Done():
+		t.Fatal("Run() didn't report the changes")
+	}
+
+	select {
+	case changed := <-calls:
+		t.Errorf("Run() reported unexpected changes %v", changed)
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v", err)
+	}
+}